@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE order_status_history (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				from_status VARCHAR(50),
+				to_status VARCHAR(50) NOT NULL,
+				actor VARCHAR(255) NOT NULL,
+				reason TEXT,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id, created_at);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP TABLE IF EXISTS order_status_history;`)
+		return err
+	})
+}
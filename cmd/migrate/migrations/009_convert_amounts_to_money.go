@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// moneyColumns lists the DECIMAL(10,2) amount columns converted to money_amount
+var moneyColumns = []struct{ table, column string }{
+	{"products", "price"},
+	{"orders", "total_amount"},
+	{"order_items", "unit_price"},
+	{"order_items", "total_price"},
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`CREATE TYPE money_amount AS (amount BIGINT, currency CHAR(3));`)
+		if err != nil {
+			return err
+		}
+
+		// Existing amounts were stored in major units of the default currency (USD)
+		for _, c := range moneyColumns {
+			_, err = db.Exec(fmt.Sprintf(
+				`ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE money_amount USING ROW(ROUND(%[2]s * 100)::BIGINT, 'USD')::money_amount;`,
+				c.table, c.column,
+			))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		for _, c := range moneyColumns {
+			_, err := db.Exec(fmt.Sprintf(
+				`ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE DECIMAL(10,2) USING ((%[2]s).amount / 100.0)::DECIMAL(10,2);`,
+				c.table, c.column,
+			))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := db.Exec(`DROP TYPE IF EXISTS money_amount;`)
+		return err
+	})
+}
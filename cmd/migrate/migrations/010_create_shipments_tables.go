@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE shipments (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				carrier VARCHAR(100) NOT NULL,
+				tracking_number VARCHAR(255),
+				status VARCHAR(50) NOT NULL DEFAULT 'pending',
+				shipped_at TIMESTAMP,
+				delivered_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE TABLE shipment_items (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+				order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+				quantity INTEGER NOT NULL CHECK (quantity > 0),
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_shipments_order_id ON shipments(order_id, created_at);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_shipment_items_shipment_id ON shipment_items(shipment_id);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP TABLE IF EXISTS shipment_items;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`DROP TABLE IF EXISTS shipments;`)
+		return err
+	})
+}
@@ -0,0 +1,81 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE return_requests (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				customer_id UUID NOT NULL REFERENCES customers(id),
+				status VARCHAR(50) NOT NULL DEFAULT 'requested',
+				reason TEXT,
+				review_note TEXT,
+				reviewed_by VARCHAR(255),
+				reviewed_at TIMESTAMP,
+				received_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE TABLE return_items (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				return_request_id UUID NOT NULL REFERENCES return_requests(id) ON DELETE CASCADE,
+				order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+				quantity INTEGER NOT NULL CHECK (quantity > 0),
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE TABLE refunds (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				return_request_id UUID NOT NULL UNIQUE REFERENCES return_requests(id) ON DELETE CASCADE,
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				amount money_amount NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_return_requests_order_id ON return_requests(order_id, created_at);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_return_requests_status ON return_requests(status);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_return_items_return_request_id ON return_items(return_request_id);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_refunds_order_id ON refunds(order_id);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		for _, table := range []string{"refunds", "return_items", "return_requests"} {
+			if _, err := db.Exec(`DROP TABLE IF EXISTS ` + table + `;`); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		// order_id is a plain reference: the ledger is append-only and must outlive deleted orders
+		_, err := db.Exec(`
+			CREATE TABLE stock_movements (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+				delta INTEGER NOT NULL CHECK (delta <> 0),
+				reason VARCHAR(50) NOT NULL,
+				order_id UUID,
+				actor VARCHAR(255) NOT NULL,
+				note TEXT,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, created_at);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_stock_movements_order_id ON stock_movements(order_id);`)
+		if err != nil {
+			return err
+		}
+
+		// Open the ledger with each product's current stock so it reconciles from day one
+		_, err = db.Exec(`
+			INSERT INTO stock_movements (product_id, delta, reason, actor, note)
+			SELECT id, stock, 'initial', 'system', 'opening balance'
+			FROM products
+			WHERE stock <> 0;
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP TABLE IF EXISTS stock_movements;`)
+		return err
+	})
+}
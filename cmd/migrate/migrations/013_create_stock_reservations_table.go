@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		// order_id is checked at commit: CreateOrder converts reservations before inserting the order
+		_, err := db.Exec(`
+			CREATE TABLE stock_reservations (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+				customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+				quantity INTEGER NOT NULL CHECK (quantity > 0),
+				status VARCHAR(50) NOT NULL DEFAULT 'active',
+				expires_at TIMESTAMP NOT NULL,
+				order_id UUID REFERENCES orders(id) ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		// Availability checks and the sweeper only look at active holds
+		_, err = db.Exec(`CREATE INDEX idx_stock_reservations_active ON stock_reservations(product_id, expires_at) WHERE status = 'active';`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_stock_reservations_customer_id ON stock_reservations(customer_id);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP TABLE IF EXISTS stock_reservations;`)
+		return err
+	})
+}
@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE warehouses (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				code VARCHAR(50) UNIQUE NOT NULL,
+				name VARCHAR(255) NOT NULL,
+				city VARCHAR(255) NOT NULL,
+				country VARCHAR(255) NOT NULL,
+				is_default BOOLEAN NOT NULL DEFAULT false,
+				is_active BOOLEAN NOT NULL DEFAULT true,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		// At most one warehouse receives stock added without a location
+		_, err = db.Exec(`CREATE UNIQUE INDEX idx_warehouses_default ON warehouses(is_default) WHERE is_default;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE TABLE stock_levels (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+				warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+				quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (product_id, warehouse_id)
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_stock_levels_warehouse_id ON stock_levels(warehouse_id);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`ALTER TABLE stock_movements ADD COLUMN warehouse_id UUID REFERENCES warehouses(id);`)
+		if err != nil {
+			return err
+		}
+
+		// Existing stock and its ledger move into a default warehouse so every product's
+		// stock keeps matching the sum of its stock levels
+		_, err = db.Exec(`
+			INSERT INTO warehouses (code, name, city, country, is_default)
+			VALUES ('NBO', 'Nairobi Warehouse', 'Nairobi', 'Kenya', true);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			INSERT INTO stock_levels (product_id, warehouse_id, quantity)
+			SELECT p.id, w.id, p.stock
+			FROM products AS p, warehouses AS w
+			WHERE w.is_default AND p.stock > 0;
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`UPDATE stock_movements SET warehouse_id = (SELECT id FROM warehouses WHERE is_default);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE stock_movements DROP COLUMN IF EXISTS warehouse_id;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`DROP TABLE IF EXISTS stock_levels;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`DROP TABLE IF EXISTS warehouses;`)
+		return err
+	})
+}
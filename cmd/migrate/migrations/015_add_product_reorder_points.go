@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		// Existing products keep the old fixed low-stock threshold of 10
+		_, err := db.Exec(`
+			ALTER TABLE products
+				ADD COLUMN reorder_point INTEGER NOT NULL DEFAULT 10 CHECK (reorder_point >= 0),
+				ADD COLUMN reorder_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0);
+		`)
+		if err != nil {
+			return err
+		}
+
+		// The reorder report only looks at products below their reorder point
+		_, err = db.Exec(`CREATE INDEX idx_products_low_stock ON products(id) WHERE stock < reorder_point;`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			DROP INDEX IF EXISTS idx_products_low_stock;
+			ALTER TABLE products
+				DROP COLUMN IF EXISTS reorder_quantity,
+				DROP COLUMN IF EXISTS reorder_point;
+		`)
+		return err
+	})
+}
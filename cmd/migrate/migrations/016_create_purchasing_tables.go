@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE suppliers (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				name VARCHAR(255) UNIQUE NOT NULL,
+				email VARCHAR(255),
+				phone VARCHAR(50),
+				address TEXT,
+				is_active BOOLEAN NOT NULL DEFAULT true,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE TABLE purchase_orders (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				supplier_id UUID NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+				warehouse_id UUID REFERENCES warehouses(id) ON DELETE RESTRICT,
+				status VARCHAR(50) NOT NULL DEFAULT 'draft',
+				expected_date TIMESTAMP,
+				notes TEXT,
+				sent_at TIMESTAMP,
+				received_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		// Receipts can never exceed what was ordered
+		_, err = db.Exec(`
+			CREATE TABLE purchase_order_lines (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+				product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+				quantity_ordered INTEGER NOT NULL CHECK (quantity_ordered > 0),
+				quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0 AND quantity_received <= quantity_ordered),
+				unit_cost money_amount NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id, created_at);`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_purchase_order_lines_purchase_order_id ON purchase_order_lines(purchase_order_id);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE IF EXISTS purchase_order_lines;
+			DROP TABLE IF EXISTS purchase_orders;
+			DROP TABLE IF EXISTS suppliers;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		// A variant's stock is part of its product's stock and can never go negative
+		_, err := db.Exec(`
+			CREATE TABLE product_variants (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+				sku VARCHAR(100) UNIQUE NOT NULL,
+				options JSONB NOT NULL DEFAULT '{}',
+				price money_amount,
+				stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+				is_active BOOLEAN NOT NULL DEFAULT true,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`CREATE INDEX idx_product_variants_product_id ON product_variants(product_id);`)
+		if err != nil {
+			return err
+		}
+
+		// Orders keep the variant they sold; the ledger records which variant's stock changed
+		_, err = db.Exec(`
+			ALTER TABLE order_items ADD COLUMN variant_id UUID REFERENCES product_variants(id) ON DELETE RESTRICT;
+			ALTER TABLE stock_movements ADD COLUMN variant_id UUID REFERENCES product_variants(id) ON DELETE SET NULL;
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE stock_movements DROP COLUMN IF EXISTS variant_id;
+			ALTER TABLE order_items DROP COLUMN IF EXISTS variant_id;
+			DROP TABLE IF EXISTS product_variants;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE products
+				ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(attributes) = 'object');
+		`)
+		if err != nil {
+			return err
+		}
+
+		// Attribute filters are containment checks (attributes @> '{"brand": "Acme"}')
+		_, err = db.Exec(`CREATE INDEX idx_products_attributes ON products USING GIN (attributes jsonb_path_ops);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			DROP INDEX IF EXISTS idx_products_attributes;
+			ALTER TABLE products DROP COLUMN IF EXISTS attributes;
+		`)
+		return err
+	})
+}
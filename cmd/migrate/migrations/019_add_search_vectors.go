@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// The search vectors use the 'simple' configuration, which lower-cases words without
+// stemming them: names, emails, phone numbers and SKUs must match as typed, and prefix
+// matching already covers plurals and partially typed words. Phone numbers are also
+// indexed as their digits alone so "+254 712 345678" is found by "254712".
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		// Users were previously only created by the test schema
+		_, err := db.Exec(`
+			CREATE TABLE IF NOT EXISTS users (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				name VARCHAR(255) NOT NULL,
+				email VARCHAR(255) UNIQUE NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(sku, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+			) STORED;
+
+			ALTER TABLE customers ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(email, '')), 'B') ||
+				setweight(to_tsvector('simple', coalesce(phone, '') || ' ' || regexp_replace(coalesce(phone, ''), '\D', '', 'g')), 'B')
+			) STORED;
+
+			ALTER TABLE users ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(email, '')), 'B')
+			) STORED;
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE INDEX idx_products_search ON products USING GIN (search_vector);
+			CREATE INDEX idx_customers_search ON customers USING GIN (search_vector);
+			CREATE INDEX idx_users_search ON users USING GIN (search_vector);
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		// The users table is kept: it may hold users created before this migration
+		_, err := db.Exec(`
+			DROP INDEX IF EXISTS idx_users_search;
+			DROP INDEX IF EXISTS idx_customers_search;
+			DROP INDEX IF EXISTS idx_products_search;
+			ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+			ALTER TABLE customers DROP COLUMN IF EXISTS search_vector;
+			ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// keysetIndexes back the keyset pagination of list queries, which seek and sort on
+// (created_at, id) newest first, after any equality filter of the list. Row comparisons
+// on (created_at, id) use the indexes scanned backwards.
+var keysetIndexes = []struct {
+	name    string
+	table   string
+	columns string
+}{
+	{"idx_users_created_at_id", "users", "created_at, id"},
+	{"idx_customers_created_at_id", "customers", "created_at, id"},
+	{"idx_categories_created_at_id", "categories", "created_at, id"},
+	{"idx_products_created_at_id", "products", "created_at, id"},
+	{"idx_products_category_created_at_id", "products", "category_id, created_at, id"},
+	{"idx_orders_created_at_id", "orders", "created_at, id"},
+	{"idx_orders_customer_created_at_id", "orders", "customer_id, created_at, id"},
+	{"idx_orders_status_created_at_id", "orders", "status, created_at, id"},
+	{"idx_warehouses_created_at_id", "warehouses", "created_at, id"},
+	{"idx_suppliers_created_at_id", "suppliers", "created_at, id"},
+	{"idx_purchase_orders_created_at_id", "purchase_orders", "created_at, id"},
+	{"idx_stock_movements_product_created_at_id", "stock_movements", "product_id, created_at, id"},
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		for _, index := range keysetIndexes {
+			_, err := db.Exec(`CREATE INDEX IF NOT EXISTS ` + index.name + ` ON ` + index.table + `(` + index.columns + `);`)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		for i := len(keysetIndexes) - 1; i >= 0; i-- {
+			_, err := db.Exec(`DROP INDEX IF EXISTS ` + keysetIndexes[i].name + `;`)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Category paths materialize each category's ancestors as "/root-id/.../id/". Existing
+// categories are given theirs by walking down from the roots; any caught in a parent cycle
+// are never reached and keep an empty path until moved out of it.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE categories ADD COLUMN IF NOT EXISTS path TEXT NOT NULL DEFAULT '';`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			WITH RECURSIVE tree AS (
+				SELECT id, '/' || id::text || '/' AS path FROM categories WHERE parent_id IS NULL
+				UNION ALL
+				SELECT c.id, t.path || c.id::text || '/' FROM categories c JOIN tree t ON c.parent_id = t.id
+			)
+			UPDATE categories SET path = tree.path FROM tree WHERE categories.id = tree.id;
+		`)
+		if err != nil {
+			return err
+		}
+
+		// text_pattern_ops lets the prefix match rewriting a moved subtree's paths use the index
+		_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_categories_path ON categories(path text_pattern_ops);`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			DROP INDEX IF EXISTS idx_categories_path;
+			ALTER TABLE categories DROP COLUMN IF EXISTS path;
+		`)
+		return err
+	})
+}
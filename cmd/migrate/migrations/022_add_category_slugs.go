@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Category slugs are unique among siblings. Existing categories are given slugs made from
+// their names; siblings whose names make the same slug are told apart by the start of
+// their IDs. Slugs categories gave up are kept in category_slug_redirects.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE categories
+				ADD COLUMN IF NOT EXISTS slug VARCHAR(100) NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS image_url TEXT NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS meta_title VARCHAR(70) NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS meta_description VARCHAR(320) NOT NULL DEFAULT '';
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			UPDATE categories SET slug = COALESCE(
+				NULLIF(left(trim(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), 90), ''),
+				'category'
+			)
+			WHERE slug = '';
+
+			UPDATE categories SET slug = slug || '-' || left(id::text, 8)
+			WHERE id IN (
+				SELECT id FROM (
+					SELECT id, row_number() OVER (PARTITION BY parent_id, slug ORDER BY created_at, id) AS n
+					FROM categories
+				) numbered WHERE n > 1
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_parent_slug
+				ON categories(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), slug);
+			CREATE INDEX IF NOT EXISTS idx_categories_parent_position ON categories(parent_id, position, name);
+
+			CREATE TABLE IF NOT EXISTS category_slug_redirects (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+				parent_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+				slug VARCHAR(100) NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_category_slug_redirects_parent_slug
+				ON category_slug_redirects(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), slug);
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			DROP TABLE IF EXISTS category_slug_redirects;
+			DROP INDEX IF EXISTS idx_categories_parent_position;
+			DROP INDEX IF EXISTS idx_categories_parent_slug;
+			ALTER TABLE categories
+				DROP COLUMN IF EXISTS meta_description,
+				DROP COLUMN IF EXISTS meta_title,
+				DROP COLUMN IF EXISTS image_url,
+				DROP COLUMN IF EXISTS position,
+				DROP COLUMN IF EXISTS slug;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Product prices schedule what products sell at over time. Each existing product is given
+// an open-ended list price from when it was created, so that its history starts complete.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE IF NOT EXISTS product_prices (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+				price money_amount NOT NULL,
+				compare_at_price money_amount,
+				label VARCHAR(100) NOT NULL DEFAULT '',
+				effective_from TIMESTAMP NOT NULL,
+				effective_to TIMESTAMP,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				CONSTRAINT chk_product_prices_window CHECK (effective_to IS NULL OR effective_to > effective_from),
+				CONSTRAINT chk_product_prices_price CHECK ((price).amount >= 0)
+			);
+			CREATE INDEX IF NOT EXISTS idx_product_prices_product_from ON product_prices(product_id, effective_from);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			INSERT INTO product_prices (product_id, price, label, effective_from, created_at)
+			SELECT p.id, p.price, 'list price', p.created_at, p.created_at
+			FROM products p
+			WHERE NOT EXISTS (SELECT 1 FROM product_prices pp WHERE pp.product_id = p.id);
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`DROP TABLE IF EXISTS product_prices;`)
+		return err
+	})
+}
@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Promotions are discount rules, coupons when they have a code. Orders record the
+// promotions applied to them and each line its share of the discount; existing orders and
+// lines are given a zero discount in their own currency.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE IF NOT EXISTS promotions (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				name VARCHAR(255) NOT NULL,
+				description TEXT,
+				code VARCHAR(32) UNIQUE,
+				type VARCHAR(20) NOT NULL CHECK (type IN ('percentage', 'fixed_amount', 'buy_x_get_y')),
+				percent_off INTEGER NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+				amount_off money_amount,
+				buy_quantity INTEGER NOT NULL DEFAULT 0,
+				get_quantity INTEGER NOT NULL DEFAULT 0,
+				min_subtotal money_amount,
+				product_ids UUID[] NOT NULL DEFAULT '{}',
+				category_ids UUID[] NOT NULL DEFAULT '{}',
+				customer_ids UUID[] NOT NULL DEFAULT '{}',
+				stackable BOOLEAN NOT NULL DEFAULT false,
+				priority INTEGER NOT NULL DEFAULT 0,
+				usage_limit INTEGER,
+				per_customer_limit INTEGER,
+				times_used INTEGER NOT NULL DEFAULT 0,
+				starts_at TIMESTAMP,
+				expires_at TIMESTAMP,
+				is_active BOOLEAN NOT NULL DEFAULT true,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				CONSTRAINT chk_promotions_usage CHECK (usage_limit IS NULL OR times_used <= usage_limit)
+			);
+			CREATE INDEX IF NOT EXISTS idx_promotions_automatic ON promotions(priority DESC) WHERE code IS NULL AND is_active;
+
+			CREATE TABLE IF NOT EXISTS order_discounts (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE RESTRICT,
+				code VARCHAR(32) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL,
+				amount money_amount NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_order_discounts_order_id ON order_discounts(order_id);
+			CREATE INDEX IF NOT EXISTS idx_order_discounts_promotion_id ON order_discounts(promotion_id);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_total money_amount;
+			UPDATE orders SET discount_total = ROW(0, (total_amount).currency)::money_amount WHERE discount_total IS NULL;
+			ALTER TABLE orders ALTER COLUMN discount_total SET NOT NULL;
+
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS discount_amount money_amount;
+			UPDATE order_items SET discount_amount = ROW(0, (unit_price).currency)::money_amount WHERE discount_amount IS NULL;
+			ALTER TABLE order_items ALTER COLUMN discount_amount SET NOT NULL;
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE order_items DROP COLUMN IF EXISTS discount_amount;
+			ALTER TABLE orders DROP COLUMN IF EXISTS discount_total;
+			DROP TABLE IF EXISTS order_discounts;
+			DROP TABLE IF EXISTS promotions;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Tax rates are kept per country, with optional per-category overrides. Order items record
+// the rate they were taxed at and the tax on them, and orders their subtotal, tax and grand
+// totals and the country they ship to. Existing orders are untaxed: their subtotal and
+// grand total are their total amount and their country is their customer's.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE IF NOT EXISTS tax_rates (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				country VARCHAR(100) NOT NULL,
+				category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+				name VARCHAR(255) NOT NULL,
+				rate INTEGER NOT NULL CHECK (rate BETWEEN 0 AND 10000),
+				prices_include_tax BOOLEAN NOT NULL DEFAULT false,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_country_category
+				ON tax_rates(country, COALESCE(category_id, '00000000-0000-0000-0000-000000000000'));
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal money_amount;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_total money_amount;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS grand_total money_amount;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_country VARCHAR(100) NOT NULL DEFAULT '';
+			UPDATE orders SET
+				subtotal = total_amount,
+				tax_total = ROW(0, (total_amount).currency)::money_amount,
+				grand_total = total_amount
+			WHERE subtotal IS NULL;
+			UPDATE orders o SET shipping_country = UPPER(TRIM(c.country))
+			FROM customers c
+			WHERE c.id = o.customer_id AND o.shipping_country = '' AND c.country IS NOT NULL;
+			ALTER TABLE orders ALTER COLUMN subtotal SET NOT NULL;
+			ALTER TABLE orders ALTER COLUMN tax_total SET NOT NULL;
+			ALTER TABLE orders ALTER COLUMN grand_total SET NOT NULL;
+
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_rate INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN NOT NULL DEFAULT false;
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_amount money_amount;
+			UPDATE order_items SET tax_amount = ROW(0, (unit_price).currency)::money_amount WHERE tax_amount IS NULL;
+			ALTER TABLE order_items ALTER COLUMN tax_amount SET NOT NULL;
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE order_items DROP COLUMN IF EXISTS tax_amount;
+			ALTER TABLE order_items DROP COLUMN IF EXISTS tax_inclusive;
+			ALTER TABLE order_items DROP COLUMN IF EXISTS tax_rate;
+			ALTER TABLE orders DROP COLUMN IF EXISTS shipping_country;
+			ALTER TABLE orders DROP COLUMN IF EXISTS grand_total;
+			ALTER TABLE orders DROP COLUMN IF EXISTS tax_total;
+			ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
+			DROP TABLE IF EXISTS tax_rates;
+		`)
+		return err
+	})
+}
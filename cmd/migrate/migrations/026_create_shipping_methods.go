@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Shipping methods charge a flat rate, by weight or nothing over a threshold, optionally
+// only in some countries and cities. Products get a weight and package size, and orders the
+// method they ship by, what it charged and the city they ship to. Existing orders have no
+// method and no shipping charge, and ship to their customer's city.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			CREATE TABLE IF NOT EXISTS shipping_methods (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				name VARCHAR(255) NOT NULL,
+				description TEXT,
+				type VARCHAR(30) NOT NULL CHECK (type IN ('flat_rate', 'weight_based', 'free_over_threshold')),
+				amount money_amount NOT NULL,
+				per_kg_amount money_amount,
+				free_over money_amount,
+				zones JSONB NOT NULL DEFAULT '[]',
+				is_active BOOLEAN NOT NULL DEFAULT true,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_shipping_methods_active ON shipping_methods(is_active);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(`
+			ALTER TABLE products ADD COLUMN IF NOT EXISTS weight_grams INTEGER NOT NULL DEFAULT 0 CHECK (weight_grams >= 0);
+			ALTER TABLE products ADD COLUMN IF NOT EXISTS length_mm INTEGER NOT NULL DEFAULT 0 CHECK (length_mm >= 0);
+			ALTER TABLE products ADD COLUMN IF NOT EXISTS width_mm INTEGER NOT NULL DEFAULT 0 CHECK (width_mm >= 0);
+			ALTER TABLE products ADD COLUMN IF NOT EXISTS height_mm INTEGER NOT NULL DEFAULT 0 CHECK (height_mm >= 0);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_total money_amount;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_city VARCHAR(100) NOT NULL DEFAULT '';
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_method_id UUID REFERENCES shipping_methods(id) ON DELETE SET NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_method_name VARCHAR(255) NOT NULL DEFAULT '';
+			UPDATE orders SET shipping_total = ROW(0, (total_amount).currency)::money_amount WHERE shipping_total IS NULL;
+			UPDATE orders o SET shipping_city = TRIM(c.city)
+			FROM customers c
+			WHERE c.id = o.customer_id AND o.shipping_city = '' AND c.city IS NOT NULL;
+			ALTER TABLE orders ALTER COLUMN shipping_total SET NOT NULL;
+			CREATE INDEX IF NOT EXISTS idx_orders_shipping_method_id ON orders(shipping_method_id);
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE orders DROP COLUMN IF EXISTS shipping_method_name;
+			ALTER TABLE orders DROP COLUMN IF EXISTS shipping_method_id;
+			ALTER TABLE orders DROP COLUMN IF EXISTS shipping_city;
+			ALTER TABLE orders DROP COLUMN IF EXISTS shipping_total;
+			ALTER TABLE products DROP COLUMN IF EXISTS height_mm;
+			ALTER TABLE products DROP COLUMN IF EXISTS width_mm;
+			ALTER TABLE products DROP COLUMN IF EXISTS length_mm;
+			ALTER TABLE products DROP COLUMN IF EXISTS weight_grams;
+			DROP TABLE IF EXISTS shipping_methods;
+		`)
+		return err
+	})
+}
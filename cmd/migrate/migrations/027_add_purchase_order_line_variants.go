@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Products with variants are stocked through them, so a purchase order line names the
+// variant it restocks
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`
+			ALTER TABLE purchase_order_lines ADD COLUMN IF NOT EXISTS variant_id UUID REFERENCES product_variants(id) ON DELETE RESTRICT;
+		`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.Exec(`ALTER TABLE purchase_order_lines DROP COLUMN IF EXISTS variant_id;`)
+		return err
+	})
+}
@@ -0,0 +1,96 @@
+// cmd/reconcile-stock/main.go
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"silbackendassessment/internal/adapters/repositories"
+	"silbackendassessment/internal/config"
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/services"
+)
+
+// reconcileActor attributes any changes made by this command
+const reconcileActor = "reconcile-stock"
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	fix := flag.Bool("fix", false, "Reset each mismatched product's stock to the sum of its ledger")
+	flag.Parse()
+
+	// Load configuration (prefer file, fallback to environment variables)
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Printf("Failed to load configuration file %s: %v. Falling back to environment variables.", *configPath, err)
+		cfg, err = config.LoadFromEnv()
+		if err != nil {
+			log.Fatalf("Failed to load configuration from environment: %v", err)
+		}
+	}
+
+	// Connect to the database
+	db, err := connectDB(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	inventoryService := services.NewInventoryService(
+		repositories.NewProductRepository(db),
+		repositories.NewStockMovementRepository(db),
+		repositories.NewTxManager(db),
+	)
+
+	ctx := domain.ContextWithActor(context.Background(), reconcileActor)
+	discrepancies, err := inventoryService.ReconcileStock(ctx, *fix)
+	if err != nil {
+		log.Fatalf("Failed to reconcile stock: %v", err)
+	}
+
+	if len(discrepancies) == 0 {
+		fmt.Println("Stock matches the ledger for every product")
+		return
+	}
+
+	fmt.Printf("%-20s %10s %10s %10s\n", "SKU", "STOCK", "LEDGER", "DIFF")
+	for _, d := range discrepancies {
+		fmt.Printf("%-20s %10d %10d %+10d\n", d.SKU, d.Stock, d.LedgerStock, d.Difference())
+	}
+
+	if *fix {
+		fmt.Printf("Reset stock of %d product(s) to match the ledger\n", len(discrepancies))
+		return
+	}
+
+	fmt.Printf("%d product(s) out of sync; rerun with -fix to reset their stock to the ledger\n", len(discrepancies))
+	os.Exit(1)
+}
+
+// connectDB establishes a connection to the database
+func connectDB(cfg *config.Config) (*bun.DB, error) {
+	dsn := cfg.GetDSN()
+	sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
+
+	// Set connection limits
+	sqldb.SetMaxOpenConns(2)
+	sqldb.SetMaxIdleConns(1)
+	sqldb.SetConnMaxLifetime(time.Hour)
+
+	// Check connection
+	if err := sqldb.Ping(); err != nil {
+		return nil, err
+	}
+
+	return bun.NewDB(sqldb, pgdialect.New()), nil
+}
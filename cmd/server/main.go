@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"strings"
@@ -17,6 +22,7 @@ import (
 	"github.com/uptrace/bunrouter/extra/reqlog"
 
 	"silbackendassessment/internal/adapters/auth"
+	"silbackendassessment/internal/adapters/cache"
 	"silbackendassessment/internal/adapters/middleware"
 	"silbackendassessment/internal/adapters/notifications"
 	"silbackendassessment/internal/adapters/repositories"
@@ -24,6 +30,7 @@ import (
 	"silbackendassessment/internal/api/rest"
 	"silbackendassessment/internal/api/rest/handlers"
 	"silbackendassessment/internal/config"
+	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/oidc"
 	"silbackendassessment/internal/core/services"
 )
@@ -50,13 +57,39 @@ func main() {
 	}
 	defer db.Close()
 
+	// Shut down on SIGINT or SIGTERM. Background work runs under this context and is waited
+	// for, so that it has stopped before the database is closed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	customerRepo := repositories.NewCustomerRepository(db)
 	categoryRepo := repositories.NewCategoryRepository(db)
 	productRepo := repositories.NewProductRepository(db)
+	productVariantRepo := repositories.NewProductVariantRepository(db)
+	productPriceRepo := repositories.NewProductPriceRepository(db)
+	promotionRepo := repositories.NewPromotionRepository(db)
 	orderRepo := repositories.NewOrderRepository(db)
 	orderItemRepo := repositories.NewOrderItemRepository(db)
+	orderStatusHistoryRepo := repositories.NewOrderStatusHistoryRepository(db)
+	orderDiscountRepo := repositories.NewOrderDiscountRepository(db)
+	taxRateRepo := repositories.NewTaxRateRepository(db)
+	shippingMethodRepo := repositories.NewShippingMethodRepository(db)
+	shipmentRepo := repositories.NewShipmentRepository(db)
+	returnRepo := repositories.NewReturnRepository(db)
+	refundRepo := repositories.NewRefundRepository(db)
+	stockMovementRepo := repositories.NewStockMovementRepository(db)
+	reservationRepo := repositories.NewReservationRepository(db)
+	warehouseRepo := repositories.NewWarehouseRepository(db)
+	stockLevelRepo := repositories.NewStockLevelRepository(db)
+	supplierRepo := repositories.NewSupplierRepository(db)
+	purchaseOrderRepo := repositories.NewPurchaseOrderRepository(db)
+	txManager := repositories.NewTxManager(db)
+	orderNumberGenerator := repositories.NewOrderNumberGenerator(db, domain.OrderNumberFormat{
+		Prefix:     cfg.OrderNumber.Prefix,
+		DateLayout: cfg.OrderNumber.DateLayout,
+	})
 
 	// Initialize JWT manager
 	jwtManager := auth.NewJWTManager(
@@ -104,17 +137,40 @@ func main() {
 	authService := services.NewAuthService(userRepo, customerRepo, jwtManager, oidcProvider)
 	userService := services.NewUserService(userRepo)
 	customerService := services.NewCustomerService(customerRepo)
-	categoryService := services.NewCategoryService(categoryRepo)
-	productService := services.NewProductService(productRepo, categoryRepo)
-	orderService := services.NewOrderService(orderRepo, orderItemRepo, customerRepo, productRepo)
+	categoryService := services.NewCategoryService(categoryRepo, txManager)
+	lowStockNotifier := services.NewLowStockNotifier(notificationService, cfg.Inventory.LowStockRecipients)
+	productService := services.NewProductService(productRepo, categoryRepo, productVariantRepo, productPriceRepo, lowStockNotifier)
+	orderService := services.NewOrderService(orderRepo, orderItemRepo, orderStatusHistoryRepo, customerRepo, productRepo, productPriceRepo, promotionRepo, orderDiscountRepo, taxRateRepo, shippingMethodRepo, reservationRepo, stockLevelRepo, txManager, orderNumberGenerator, lowStockNotifier, domain.AllocationStrategy(cfg.Inventory.AllocationStrategy))
+	shipmentService := services.NewShipmentService(shipmentRepo, orderRepo, orderItemRepo, orderService, txManager)
+	returnService := services.NewReturnService(returnRepo, refundRepo, orderRepo, orderItemRepo, productRepo, customerRepo, notificationService, txManager)
+	inventoryService := services.NewInventoryService(productRepo, stockMovementRepo, txManager)
+	reservationService := services.NewReservationService(reservationRepo, productRepo, customerRepo, cfg.Reservations.TTL, cfg.Reservations.MaxTTL)
+	warehouseService := services.NewWarehouseService(warehouseRepo, stockLevelRepo, productRepo)
+	supplierService := services.NewSupplierService(supplierRepo)
+	purchaseOrderService := services.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, productRepo, warehouseRepo, txManager)
+	promotionService := services.NewPromotionService(promotionRepo)
+	taxService := services.NewTaxService(taxRateRepo, categoryRepo)
+	shippingService := services.NewShippingService(shippingMethodRepo)
+
+	// Release expired checkout holds in the background until shutdown
+	sweeperDone := make(chan struct{})
+	go func() {
+		defer close(sweeperDone)
+		services.NewReservationSweeper(reservationService, cfg.Reservations.SweepInterval).Run(ctx)
+	}()
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(userService)
 	customerHandler := handlers.NewCustomerAuthHandler(customerService, authService)
 	oidcHandler := handlers.NewOIDCHandler(authService)
 
+	// Initialize Redis client
+	redisClient := cache.NewRedisClient(cfg.Redis.Address, cfg.Redis.Password, cfg.Redis.DB)
+	defer redisClient.Close()
+
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(cache.NewIdempotencyStore(redisClient), cfg.Idempotency.TTL)
 
 	// Setup main router
 	router := bunrouter.New(
@@ -124,26 +180,44 @@ func main() {
 
 	// Initialize REST API router
 	restConfig := &rest.RouterConfig{
-		AuthMiddleware:      authMiddleware,
-		UserService:         userService,
-		CustomerService:     customerService,
-		CategoryService:     categoryService,
-		ProductService:      productService,
-		OrderService:        orderService,
-		NotificationService: notificationService,
-		AuthService:         authService,
+		AuthMiddleware:        authMiddleware,
+		IdempotencyMiddleware: idempotencyMiddleware,
+		UserService:           userService,
+		CustomerService:       customerService,
+		CategoryService:       categoryService,
+		ProductService:        productService,
+		OrderService:          orderService,
+		ShipmentService:       shipmentService,
+		ReturnService:         returnService,
+		InventoryService:      inventoryService,
+		ReservationService:    reservationService,
+		WarehouseService:      warehouseService,
+		SupplierService:       supplierService,
+		PurchaseOrderService:  purchaseOrderService,
+		PromotionService:      promotionService,
+		TaxService:            taxService,
+		ShippingService:       shippingService,
+		NotificationService:   notificationService,
+		AuthService:           authService,
 	}
 	restRouter := rest.NewRouter(restConfig)
 
 	// Initialize GraphQL router
 	graphqlConfig := &graphql.RouterConfig{
-		UserService:         userService,
-		CustomerService:     customerService,
-		CategoryService:     categoryService,
-		ProductService:      productService,
-		OrderService:        orderService,
-		NotificationService: notificationService,
-		AuthMiddleware:      authMiddleware,
+		UserService:          userService,
+		CustomerService:      customerService,
+		CategoryService:      categoryService,
+		ProductService:       productService,
+		OrderService:         orderService,
+		ShipmentService:      shipmentService,
+		ReturnService:        returnService,
+		InventoryService:     inventoryService,
+		ReservationService:   reservationService,
+		WarehouseService:     warehouseService,
+		SupplierService:      supplierService,
+		PurchaseOrderService: purchaseOrderService,
+		NotificationService:  notificationService,
+		AuthMiddleware:       authMiddleware,
 	}
 	graphqlRouter := graphql.NewRouter(graphqlConfig)
 
@@ -155,7 +229,23 @@ func main() {
 	log.Printf("REST API available at: http://localhost:%d/api", cfg.Server.RESTPort)
 	log.Printf("GraphQL API available at: http://localhost:%d/graphql", cfg.Server.RESTPort)
 	log.Printf("GraphQL Playground available at: http://localhost:%d/graphql/playground", cfg.Server.RESTPort)
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", cfg.Server.RESTPort), router))
+	server := &http.Server{Addr: fmt.Sprintf(":%d", cfg.Server.RESTPort), Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Printf("Shutting down")
+
+	// Let in-flight requests finish, then wait for the sweeper to stop
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown failed: %v", err)
+	}
+	<-sweeperDone
 }
 
 func connectDB(cfg *config.Config) (*bun.DB, error) {
@@ -220,6 +310,7 @@ func registerRoutes(
 		g.GET("/*path", forward)
 		g.POST("/*path", forward)
 		g.PUT("/*path", forward)
+		g.PATCH("/*path", forward)
 		g.DELETE("/*path", forward)
 		g.OPTIONS("/*path", forward)
 	})
@@ -358,8 +449,8 @@ func corsMiddleware() bunrouter.MiddlewareFunc {
 	return func(next bunrouter.HandlerFunc) bunrouter.HandlerFunc {
 		return func(w http.ResponseWriter, req bunrouter.Request) error {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key")
 
 			if req.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const idempotencyKeyPrefix = "idempotency:"
+
+// redisIdempotencyStore implements ports.IdempotencyStore on top of Redis
+type redisIdempotencyStore struct {
+	client *RedisClient
+}
+
+// NewIdempotencyStore creates a new Redis backed idempotency store
+func NewIdempotencyStore(client *RedisClient) ports.IdempotencyStore {
+	return &redisIdempotencyStore{client: client}
+}
+
+// Reserve claims the key with an in-progress record using SETNX
+func (s *redisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (bool, error) {
+	record := &ports.IdempotencyRecord{Fingerprint: fingerprint}
+	return s.client.SetNX(ctx, idempotencyKeyPrefix+key, record, ttl)
+}
+
+// Get returns the record stored under the key, or nil if there is none
+func (s *redisIdempotencyStore) Get(ctx context.Context, key string) (*ports.IdempotencyRecord, error) {
+	val, err := s.client.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+
+	var record ports.IdempotencyRecord
+	if err := json.Unmarshal(val, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	return &record, nil
+}
+
+// Save stores the completed record under the key
+func (s *redisIdempotencyStore) Save(ctx context.Context, key string, record *ports.IdempotencyRecord, ttl time.Duration) error {
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, record, ttl)
+}
+
+// Release removes the key so the request can be retried
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Delete(ctx, idempotencyKeyPrefix+key)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/ports"
 
 	"github.com/uptrace/bunrouter"
@@ -71,6 +72,7 @@ func (m *AuthMiddleware) RequireAuth(next bunrouter.HandlerFunc) bunrouter.Handl
 		}
 
 		ctx := context.WithValue(req.Context(), UserContextKey{}, userInfo)
+		ctx = domain.ContextWithActor(ctx, "user:"+userInfo.ID)
 		req = req.WithContext(ctx)
 
 		return next(w, req)
@@ -105,6 +107,7 @@ func (m *AuthMiddleware) RequireCustomerAuth(next bunrouter.HandlerFunc) bunrout
 			}
 
 			ctx := context.WithValue(req.Context(), CustomerContextKey{}, customerInfo)
+			ctx = domain.ContextWithActor(ctx, "customer:"+customerInfo.ID)
 			req = req.WithContext(ctx)
 
 			return next(w, req)
@@ -124,6 +127,7 @@ func (m *AuthMiddleware) RequireCustomerAuth(next bunrouter.HandlerFunc) bunrout
 		}
 
 		ctx := context.WithValue(req.Context(), CustomerContextKey{}, customerInfo)
+		ctx = domain.ContextWithActor(ctx, "customer:"+customerInfo.ID)
 		req = req.WithContext(ctx)
 
 		return next(w, req)
@@ -162,6 +166,7 @@ func (m *AuthMiddleware) RequireOIDCAuth(next bunrouter.HandlerFunc) bunrouter.H
 		}
 
 		ctx := context.WithValue(req.Context(), CustomerContextKey{}, customerInfo)
+		ctx = domain.ContextWithActor(ctx, "customer:"+customerInfo.ID)
 		req = req.WithContext(ctx)
 
 		return next(w, req)
@@ -196,6 +201,7 @@ func (m *AuthMiddleware) OptionalAuth(next bunrouter.HandlerFunc) bunrouter.Hand
 			}
 
 			ctx := context.WithValue(req.Context(), UserContextKey{}, userInfo)
+			ctx = domain.ContextWithActor(ctx, "user:"+userInfo.ID)
 			req = req.WithContext(ctx)
 
 			return next(w, req)
@@ -211,6 +217,7 @@ func (m *AuthMiddleware) OptionalAuth(next bunrouter.HandlerFunc) bunrouter.Hand
 			}
 
 			ctx := context.WithValue(req.Context(), CustomerContextKey{}, customerInfo)
+			ctx = domain.ContextWithActor(ctx, "customer:"+customerInfo.ID)
 			req = req.WithContext(ctx)
 		}
 
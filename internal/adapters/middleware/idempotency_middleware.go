@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/uptrace/bunrouter"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client supplied idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed from the idempotency store
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is used when no replay window is configured
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyMiddleware replays stored responses for retried requests carrying the same Idempotency-Key
+type IdempotencyMiddleware struct {
+	store ports.IdempotencyStore
+	ttl   time.Duration
+}
+
+// NewIdempotencyMiddleware creates a new idempotency middleware with the given replay window
+func NewIdempotencyMiddleware(store ports.IdempotencyStore, ttl time.Duration) *IdempotencyMiddleware {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &IdempotencyMiddleware{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Handle middleware that makes requests with an Idempotency-Key header safe to retry
+func (m *IdempotencyMiddleware) Handle(next bunrouter.HandlerFunc) bunrouter.HandlerFunc {
+	if m == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, req bunrouter.Request) error {
+		idempotencyKey := req.Header.Get(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			return next(w, req)
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body: "+err.Error(), http.StatusBadRequest)
+			return err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := req.Context()
+		key := storeKey(ctx, req.Method, req.URL.Path, idempotencyKey)
+		fingerprint := requestFingerprint(req.Method, req.URL.Path, body)
+
+		reserved, err := m.store.Reserve(ctx, key, fingerprint, m.ttl)
+		if err != nil {
+			// Fail open: an unavailable store should not take the endpoint down
+			log.Printf("Idempotency store unavailable, processing request without idempotency: %v", err)
+			return next(w, req)
+		}
+
+		if !reserved {
+			record, err := m.store.Get(ctx, key)
+			if err != nil {
+				log.Printf("Idempotency store unavailable, processing request without idempotency: %v", err)
+				return next(w, req)
+			}
+			if record != nil {
+				return m.replay(w, record, fingerprint)
+			}
+
+			// The record expired between Reserve and Get; claim it again
+			if reserved, err = m.store.Reserve(ctx, key, fingerprint, m.ttl); err != nil || !reserved {
+				http.Error(w, "A request with this Idempotency-Key is already being processed", http.StatusConflict)
+				return nil
+			}
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		handlerErr := next(recorder, req)
+
+		// Server errors are not stored so that the client can retry with the same key
+		if recorder.statusCode >= http.StatusInternalServerError {
+			if err := m.store.Release(ctx, key); err != nil {
+				log.Printf("Failed to release idempotency key: %v", err)
+			}
+			return handlerErr
+		}
+
+		record := &ports.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			StatusCode:  recorder.statusCode,
+			Header:      w.Header().Clone(),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := m.store.Save(ctx, key, record, m.ttl); err != nil {
+			log.Printf("Failed to save idempotent response: %v", err)
+		}
+
+		return handlerErr
+	}
+}
+
+// replay writes the stored response, or a conflict if the key cannot be replayed for this request
+func (m *IdempotencyMiddleware) replay(w http.ResponseWriter, record *ports.IdempotencyRecord, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		http.Error(w, "Idempotency-Key has already been used with a different request body", http.StatusConflict)
+		return nil
+	}
+	if !record.Completed {
+		http.Error(w, "A request with this Idempotency-Key is already being processed", http.StatusConflict)
+		return nil
+	}
+
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, err := w.Write(record.Body)
+	return err
+}
+
+// storeKey scopes an idempotency key to the principal making the request, so that another
+// client sending the same key is never replayed someone else's response
+func storeKey(ctx context.Context, method, path, idempotencyKey string) string {
+	return domain.ActorFromContext(ctx) + " " + method + " " + path + " " + idempotencyKey
+}
+
+// requestFingerprint hashes the parts of a request that must match for a replay
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder captures the status code and body written by a handler
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
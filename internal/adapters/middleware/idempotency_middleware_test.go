@@ -0,0 +1,244 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/uptrace/bunrouter"
+)
+
+// MockIdempotencyStore is an in-memory idempotency store for testing
+type MockIdempotencyStore struct {
+	mu           sync.Mutex
+	Records      map[string]*ports.IdempotencyRecord
+	ReserveError error
+}
+
+func NewMockIdempotencyStore() *MockIdempotencyStore {
+	return &MockIdempotencyStore{Records: make(map[string]*ports.IdempotencyRecord)}
+}
+
+func (m *MockIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ReserveError != nil {
+		return false, m.ReserveError
+	}
+	if _, exists := m.Records[key]; exists {
+		return false, nil
+	}
+	m.Records[key] = &ports.IdempotencyRecord{Fingerprint: fingerprint}
+	return true, nil
+}
+
+func (m *MockIdempotencyStore) Get(ctx context.Context, key string) (*ports.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Records[key], nil
+}
+
+func (m *MockIdempotencyStore) Save(ctx context.Context, key string, record *ports.IdempotencyRecord, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Records[key] = record
+	return nil
+}
+
+func (m *MockIdempotencyStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.Records, key)
+	return nil
+}
+
+func newIdempotentRequest(key, body string) bunrouter.Request {
+	req := httptest.NewRequest("POST", "/api/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return bunrouter.NewRequest(req)
+}
+
+func TestIdempotencyMiddleware_Handle(t *testing.T) {
+	t.Run("Replays stored response for repeated key", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(NewMockIdempotencyStore(), time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			calls++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id":"order-1"}`))
+			return err
+		})
+
+		first := httptest.NewRecorder()
+		if err := handler(first, newIdempotentRequest("key-1", `{"a":1}`)); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		second := httptest.NewRecorder()
+		if err := handler(second, newIdempotentRequest("key-1", `{"a":1}`)); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected handler to be called once, got: %d", calls)
+		}
+		if second.Code != http.StatusCreated {
+			t.Errorf("Expected status %d, got: %d", http.StatusCreated, second.Code)
+		}
+		if second.Body.String() != `{"id":"order-1"}` {
+			t.Errorf("Expected replayed body, got: %s", second.Body.String())
+		}
+		if second.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Expected replayed Content-Type header, got: %s", second.Header().Get("Content-Type"))
+		}
+		if second.Header().Get(IdempotentReplayedHeader) != "true" {
+			t.Errorf("Expected %s header to be set", IdempotentReplayedHeader)
+		}
+	})
+
+	t.Run("Keys are scoped to the principal", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(NewMockIdempotencyStore(), time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(domain.ActorFromContext(req.Context())))
+			return err
+		})
+		asActor := func(actor string) bunrouter.Request {
+			req := newIdempotentRequest("key-1", `{"a":1}`)
+			return req.WithContext(domain.ContextWithActor(req.Context(), actor))
+		}
+
+		_ = handler(httptest.NewRecorder(), asActor("customer:alice"))
+		w := httptest.NewRecorder()
+		_ = handler(w, asActor("customer:bob"))
+
+		if calls != 2 {
+			t.Errorf("Expected each principal's request to be handled, got %d call(s)", calls)
+		}
+		if w.Body.String() != "customer:bob" || w.Header().Get(IdempotentReplayedHeader) != "" {
+			t.Errorf("Expected bob's own response, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("Conflict when key is reused with a different body", func(t *testing.T) {
+		m := NewIdempotencyMiddleware(NewMockIdempotencyStore(), time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			w.WriteHeader(http.StatusCreated)
+			return nil
+		})
+
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"a":1}`))
+
+		w := httptest.NewRecorder()
+		_ = handler(w, newIdempotentRequest("key-1", `{"a":2}`))
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got: %d", http.StatusConflict, w.Code)
+		}
+	})
+
+	t.Run("Conflict while the original request is in progress", func(t *testing.T) {
+		store := NewMockIdempotencyStore()
+		m := NewIdempotencyMiddleware(store, time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			t.Error("Handler should not be called")
+			return nil
+		})
+
+		req := newIdempotentRequest("key-1", `{"a":1}`)
+		key := storeKey(context.Background(), "POST", "/api/orders", "key-1")
+		store.Records[key] = &ports.IdempotencyRecord{Fingerprint: requestFingerprint("POST", "/api/orders", []byte(`{"a":1}`))}
+
+		w := httptest.NewRecorder()
+		_ = handler(w, req)
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got: %d", http.StatusConflict, w.Code)
+		}
+	})
+
+	t.Run("Server errors release the key", func(t *testing.T) {
+		calls := 0
+		store := NewMockIdempotencyStore()
+		m := NewIdempotencyMiddleware(store, time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			calls++
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return errors.New("boom")
+		})
+
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"a":1}`))
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"a":1}`))
+
+		if calls != 2 {
+			t.Errorf("Expected handler to be called twice, got: %d", calls)
+		}
+		if len(store.Records) != 0 {
+			t.Errorf("Expected no stored records, got: %d", len(store.Records))
+		}
+	})
+
+	t.Run("Requests without a key pass through", func(t *testing.T) {
+		calls := 0
+		store := NewMockIdempotencyStore()
+		m := NewIdempotencyMiddleware(store, time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			calls++
+			return nil
+		})
+
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("", `{"a":1}`))
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("", `{"a":1}`))
+
+		if calls != 2 {
+			t.Errorf("Expected handler to be called twice, got: %d", calls)
+		}
+		if len(store.Records) != 0 {
+			t.Errorf("Expected no stored records, got: %d", len(store.Records))
+		}
+	})
+
+	t.Run("Store failure fails open", func(t *testing.T) {
+		calls := 0
+		store := NewMockIdempotencyStore()
+		store.ReserveError = errors.New("redis down")
+		m := NewIdempotencyMiddleware(store, time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			calls++
+			return nil
+		})
+
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"a":1}`))
+		if calls != 1 {
+			t.Errorf("Expected handler to be called once, got: %d", calls)
+		}
+	})
+
+	t.Run("Handler can read the request body", func(t *testing.T) {
+		m := NewIdempotencyMiddleware(NewMockIdempotencyStore(), time.Hour)
+		handler := m.Handle(func(w http.ResponseWriter, req bunrouter.Request) error {
+			buf := new(strings.Builder)
+			if _, err := io.Copy(buf, req.Body); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if buf.String() != `{"a":1}` {
+				t.Errorf("Expected body to be preserved, got: %s", buf.String())
+			}
+			return nil
+		})
+
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"a":1}`))
+	})
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/ports"
@@ -11,6 +13,22 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// categoryPathsSQL rebuilds the paths of a category and its descendants by walking down the
+// parent links from it, given the category's new path
+const categoryPathsSQL = `WITH RECURSIVE tree AS (
+	SELECT id, ?::text AS path FROM categories WHERE id = ?
+	UNION ALL
+	SELECT c.id, t.path || c.id::text || '/' FROM categories c JOIN tree t ON c.parent_id = t.id
+) UPDATE categories SET path = tree.path FROM tree WHERE categories.id = tree.id`
+
+// categorySubtreeSQL selects the IDs of a category and all its descendants. UNION rather
+// than UNION ALL stops the recursion should the parent links ever form a cycle.
+const categorySubtreeSQL = `WITH RECURSIVE subtree AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION
+	SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+) SELECT id FROM subtree`
+
 type categoryRepository struct {
 	db *bun.DB
 }
@@ -22,14 +40,20 @@ func NewCategoryRepository(db *bun.DB) ports.CategoryRepository {
 	}
 }
 
+// Create inserts the category with its path built from its parent's as stored, so that it
+// follows a parent moved since the parent was read
 func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
-	_, err := r.db.NewInsert().Model(category).Exec(ctx)
+	_, err := conn(ctx, r.db).NewInsert().
+		Model(category).
+		Value("path", "coalesce((SELECT path FROM categories WHERE id = ?), '/') || ?::text || '/'", category.ParentID, category.ID).
+		Returning("path").
+		Exec(ctx)
 	return err
 }
 
 func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
 	category := new(domain.Category)
-	err := r.db.NewSelect().Model(category).Where("id = ?", id).Scan(ctx)
+	err := conn(ctx, r.db).NewSelect().Model(category).Where("id = ?", id).Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -41,7 +65,7 @@ func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 
 func (r *categoryRepository) GetByName(ctx context.Context, name string) (*domain.Category, error) {
 	category := new(domain.Category)
-	err := r.db.NewSelect().Model(category).Where("name = ?", name).Scan(ctx)
+	err := conn(ctx, r.db).NewSelect().Model(category).Where("name = ?", name).Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -51,47 +75,189 @@ func (r *categoryRepository) GetByName(ctx context.Context, name string) (*domai
 	return category, nil
 }
 
-func (r *categoryRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Category, error) {
-	var categories []*domain.Category
-	err := r.db.NewSelect().
-		Model(&categories).
-		Order("name ASC").
-		Limit(limit).
-		Offset(offset).
+func (r *categoryRepository) GetBySlug(ctx context.Context, parentID *uuid.UUID, slug string) (*domain.Category, error) {
+	category := new(domain.Category)
+	err := conn(ctx, r.db).NewSelect().
+		Model(category).
+		Where("parent_id IS NOT DISTINCT FROM ?", parentID).
+		Where("slug = ?", slug).
 		Scan(ctx)
-	return categories, err
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+func (r *categoryRepository) GetBySlugRedirect(ctx context.Context, parentID *uuid.UUID, slug string) (*domain.Category, error) {
+	category := new(domain.Category)
+	err := conn(ctx, r.db).NewSelect().
+		Model(category).
+		Where("cat.id = (SELECT category_id FROM category_slug_redirects WHERE parent_id IS NOT DISTINCT FROM ? AND slug = ?)", parentID, slug).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+// AddSlugRedirect replaces any redirect of the slug under the parent, so that it always
+// leads to the category that gave it up last
+func (r *categoryRepository) AddSlugRedirect(ctx context.Context, redirect *domain.CategorySlugRedirect) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		_, err := db.NewDelete().
+			Model((*domain.CategorySlugRedirect)(nil)).
+			Where("parent_id IS NOT DISTINCT FROM ?", redirect.ParentID).
+			Where("slug = ?", redirect.Slug).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = db.NewInsert().Model(redirect).Exec(ctx)
+		return err
+	})
+}
+
+func (r *categoryRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Category], error) {
+	var categories []*domain.Category
+	q := conn(ctx, r.db).NewSelect().
+		Model(&categories)
+	return selectPage(ctx, q, &categories, page, categorySortColumns)
 }
 
 func (r *categoryRepository) GetByParentID(ctx context.Context, parentID uuid.UUID) ([]*domain.Category, error) {
 	var categories []*domain.Category
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(&categories).
 		Where("parent_id = ?", parentID).
-		Order("name ASC").
+		Order("position ASC", "name ASC").
 		Scan(ctx)
 	return categories, err
 }
 
 func (r *categoryRepository) GetRootCategories(ctx context.Context) ([]*domain.Category, error) {
 	var categories []*domain.Category
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(&categories).
 		Where("parent_id IS NULL").
-		Order("name ASC").
+		Order("position ASC", "name ASC").
+		Scan(ctx)
+	return categories, err
+}
+
+// GetAncestors returns the categories whose path prefixes the category's, root first
+func (r *categoryRepository) GetAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	err := conn(ctx, r.db).NewSelect().
+		Model(&categories).
+		Where("(SELECT path FROM categories WHERE id = ?) LIKE cat.path || '%'", id).
+		Where("cat.path <> ''").
+		Where("cat.id <> ?", id).
+		OrderExpr("length(cat.path) ASC").
+		Scan(ctx)
+	return categories, err
+}
+
+// GetDescendants walks the tree below the category with a recursive query, returning the
+// descendants in sibling order
+func (r *categoryRepository) GetDescendants(ctx context.Context, id uuid.UUID) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	err := conn(ctx, r.db).NewSelect().
+		Model(&categories).
+		Where("cat.id IN ("+categorySubtreeSQL+")", id).
+		Where("cat.id <> ?", id).
+		Order("cat.position ASC", "cat.name ASC").
 		Scan(ctx)
 	return categories, err
 }
 
 func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
-	_, err := r.db.NewUpdate().
+	_, err := conn(ctx, r.db).NewUpdate().
 		Model(category).
+		ExcludeColumn("parent_id", "path").
 		WherePK().
 		Exec(ctx)
 	return err
 }
 
+// Move locks the category and its new parent, so that two moves cannot each pass the cycle
+// check against the other's old place, then rewrites the paths of the whole subtree. A
+// category caught in a parent cycle, which has no path, is given one by moving it out.
+func (r *categoryRepository) Move(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		category, err := lockCategory(ctx, db, id)
+		if err != nil {
+			return err
+		}
+
+		var parent *domain.Category
+		if parentID != nil {
+			if parent, err = lockCategory(ctx, db, *parentID); err != nil {
+				return err
+			}
+		}
+		if err := category.CanMoveUnder(parent); err != nil {
+			return err
+		}
+
+		parentPath := ""
+		if parent != nil {
+			parentPath = parent.Path
+		}
+		_, err = db.NewUpdate().
+			Model((*domain.Category)(nil)).
+			Set("parent_id = ?", parentID).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The categories below one caught in a cycle have no paths either, so theirs are
+		// built from the parent links, which no longer loop now that it has moved out
+		if category.Path == "" {
+			_, err = db.NewRaw(categoryPathsSQL, domain.CategoryPath(parentPath, id), id).Exec(ctx)
+			return err
+		}
+
+		_, err = db.NewUpdate().
+			Model((*domain.Category)(nil)).
+			Set("path = ? || substr(path, ?)", domain.CategoryPath(parentPath, id), len(category.Path)+1).
+			Where("path LIKE ?", category.Path+"%").
+			Exec(ctx)
+		return err
+	})
+}
+
+// lockCategory reads a category's place in the tree, locking its row until the surrounding
+// transaction ends
+func lockCategory(ctx context.Context, db bun.IDB, id uuid.UUID) (*domain.Category, error) {
+	category := new(domain.Category)
+	err := db.NewSelect().
+		Model(category).
+		Column("id", "parent_id", "path").
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("category %s not found", id)
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
 func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().
+	_, err := conn(ctx, r.db).NewDelete().
 		Model((*domain.Category)(nil)).
 		Where("id = ?", id).
 		Exec(ctx)
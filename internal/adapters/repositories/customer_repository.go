@@ -51,15 +51,11 @@ func (r *customerRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return customer, nil
 }
 
-func (r *customerRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Customer, error) {
+func (r *customerRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Customer], error) {
 	var customers []*domain.Customer
-	err := r.db.NewSelect().
-		Model(&customers).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Scan(ctx)
-	return customers, err
+	q := r.db.NewSelect().
+		Model(&customers)
+	return selectPage(ctx, q, &customers, page, customerSortColumns)
 }
 
 func (r *customerRepository) Update(ctx context.Context, customer *domain.Customer) error {
@@ -77,3 +73,33 @@ func (r *customerRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		Exec(ctx)
 	return err
 }
+
+// Search returns the customers whose name, email or phone number has words starting with every term,
+// best match first
+func (r *customerRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.CustomerSearchResult, error) {
+	matches, err := searchMatches(ctx, r.db, (*domain.Customer)(nil), "concat_ws(' ', c.first_name, c.last_name, c.email, c.phone)", terms, limit, offset)
+	if err != nil || len(matches) == 0 {
+		return []*domain.CustomerSearchResult{}, err
+	}
+
+	var customers []*domain.Customer
+	err = r.db.NewSelect().
+		Model(&customers).
+		Where("c.id IN (?)", bun.In(matchIDs(matches))).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*domain.Customer, len(customers))
+	for _, customer := range customers {
+		byID[customer.ID] = customer
+	}
+	results := make([]*domain.CustomerSearchResult, 0, len(matches))
+	for _, match := range matches {
+		if customer, ok := byID[match.ID]; ok {
+			results = append(results, &domain.CustomerSearchResult{Customer: customer, Rank: match.Rank, Highlight: match.Highlight})
+		}
+	}
+	return results, nil
+}
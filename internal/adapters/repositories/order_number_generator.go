@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/uptrace/bun"
+)
+
+// orderNumberSequence is the Postgres sequence backing order numbers
+const orderNumberSequence = "order_number_seq"
+
+type orderNumberGenerator struct {
+	db     *bun.DB
+	format domain.OrderNumberFormat
+}
+
+// NewOrderNumberGenerator creates a new order number generator backed by a Postgres sequence
+func NewOrderNumberGenerator(db *bun.DB, format domain.OrderNumberFormat) ports.OrderNumberGenerator {
+	return &orderNumberGenerator{
+		db:     db,
+		format: format,
+	}
+}
+
+func (g *orderNumberGenerator) Next(ctx context.Context) (string, error) {
+	var seq int64
+	if err := conn(ctx, g.db).NewRaw("SELECT nextval(?)", orderNumberSequence).Scan(ctx, &seq); err != nil {
+		return "", fmt.Errorf("failed to get next order number: %w", err)
+	}
+	return g.format.Format(seq, time.Now()), nil
+}
+
+func (g *orderNumberGenerator) Validate(orderNumber string) bool {
+	return g.format.Validate(orderNumber)
+}
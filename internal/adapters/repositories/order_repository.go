@@ -23,17 +23,18 @@ func NewOrderRepository(db *bun.DB) ports.OrderRepository {
 }
 
 func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
-	_, err := r.db.NewInsert().Model(order).Exec(ctx)
+	_, err := conn(ctx, r.db).NewInsert().Model(order).Exec(ctx)
 	return err
 }
 
 func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
 	order := new(domain.Order)
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(order).
 		Relation("Customer").
 		Relation("OrderItems").
 		Relation("OrderItems.Product").
+		Relation("Discounts").
 		Where("id = ?", id).
 		Scan(ctx)
 	if err != nil {
@@ -47,11 +48,12 @@ func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Or
 
 func (r *orderRepository) GetByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
 	order := new(domain.Order)
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(order).
 		Relation("Customer").
 		Relation("OrderItems").
 		Relation("OrderItems.Product").
+		Relation("Discounts").
 		Where("order_number = ?", orderNumber).
 		Scan(ctx)
 	if err != nil {
@@ -63,46 +65,34 @@ func (r *orderRepository) GetByOrderNumber(ctx context.Context, orderNumber stri
 	return order, nil
 }
 
-func (r *orderRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID, limit, offset int) ([]*domain.Order, error) {
+func (r *orderRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	var orders []*domain.Order
-	err := r.db.NewSelect().
+	q := conn(ctx, r.db).NewSelect().
 		Model(&orders).
 		Relation("Customer").
-		Where("customer_id = ?", customerID).
-		Order("order_date DESC").
-		Limit(limit).
-		Offset(offset).
-		Scan(ctx)
-	return orders, err
+		Where("o.customer_id = ?", customerID)
+	return selectPage(ctx, q, &orders, page, orderSortColumns)
 }
 
-func (r *orderRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Order, error) {
+func (r *orderRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	var orders []*domain.Order
-	err := r.db.NewSelect().
+	q := conn(ctx, r.db).NewSelect().
 		Model(&orders).
-		Relation("Customer").
-		Order("order_date DESC").
-		Limit(limit).
-		Offset(offset).
-		Scan(ctx)
-	return orders, err
+		Relation("Customer")
+	return selectPage(ctx, q, &orders, page, orderSortColumns)
 }
 
-func (r *orderRepository) GetByStatus(ctx context.Context, status domain.OrderStatus, limit, offset int) ([]*domain.Order, error) {
+func (r *orderRepository) GetByStatus(ctx context.Context, status domain.OrderStatus, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	var orders []*domain.Order
-	err := r.db.NewSelect().
+	q := conn(ctx, r.db).NewSelect().
 		Model(&orders).
 		Relation("Customer").
-		Where("status = ?", status).
-		Order("order_date DESC").
-		Limit(limit).
-		Offset(offset).
-		Scan(ctx)
-	return orders, err
+		Where("o.status = ?", status)
+	return selectPage(ctx, q, &orders, page, orderSortColumns)
 }
 
 func (r *orderRepository) Update(ctx context.Context, order *domain.Order) error {
-	_, err := r.db.NewUpdate().
+	_, err := conn(ctx, r.db).NewUpdate().
 		Model(order).
 		WherePK().
 		Exec(ctx)
@@ -110,7 +100,7 @@ func (r *orderRepository) Update(ctx context.Context, order *domain.Order) error
 }
 
 func (r *orderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error {
-	_, err := r.db.NewUpdate().
+	_, err := conn(ctx, r.db).NewUpdate().
 		Model((*domain.Order)(nil)).
 		Set("status = ?", status).
 		Set("updated_at = CURRENT_TIMESTAMP").
@@ -120,7 +110,7 @@ func (r *orderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 }
 
 func (r *orderRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().
+	_, err := conn(ctx, r.db).NewDelete().
 		Model((*domain.Order)(nil)).
 		Where("id = ?", id).
 		Exec(ctx)
@@ -139,13 +129,13 @@ func NewOrderItemRepository(db *bun.DB) ports.OrderItemRepository {
 }
 
 func (r *orderItemRepository) Create(ctx context.Context, orderItem *domain.OrderItem) error {
-	_, err := r.db.NewInsert().Model(orderItem).Exec(ctx)
+	_, err := conn(ctx, r.db).NewInsert().Model(orderItem).Exec(ctx)
 	return err
 }
 
 func (r *orderItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.OrderItem, error) {
 	orderItem := new(domain.OrderItem)
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(orderItem).
 		Relation("Order").
 		Relation("Product").
@@ -162,7 +152,7 @@ func (r *orderItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 
 func (r *orderItemRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderItem, error) {
 	var orderItems []*domain.OrderItem
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(&orderItems).
 		Relation("Product").
 		Where("order_id = ?", orderID).
@@ -173,7 +163,7 @@ func (r *orderItemRepository) GetByOrderID(ctx context.Context, orderID uuid.UUI
 
 func (r *orderItemRepository) GetByProductID(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*domain.OrderItem, error) {
 	var orderItems []*domain.OrderItem
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(&orderItems).
 		Relation("Order").
 		Where("product_id = ?", productID).
@@ -185,7 +175,7 @@ func (r *orderItemRepository) GetByProductID(ctx context.Context, productID uuid
 }
 
 func (r *orderItemRepository) Update(ctx context.Context, orderItem *domain.OrderItem) error {
-	_, err := r.db.NewUpdate().
+	_, err := conn(ctx, r.db).NewUpdate().
 		Model(orderItem).
 		WherePK().
 		Exec(ctx)
@@ -193,7 +183,7 @@ func (r *orderItemRepository) Update(ctx context.Context, orderItem *domain.Orde
 }
 
 func (r *orderItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().
+	_, err := conn(ctx, r.db).NewDelete().
 		Model((*domain.OrderItem)(nil)).
 		Where("id = ?", id).
 		Exec(ctx)
@@ -201,9 +191,78 @@ func (r *orderItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *orderItemRepository) DeleteByOrderID(ctx context.Context, orderID uuid.UUID) error {
-	_, err := r.db.NewDelete().
+	_, err := conn(ctx, r.db).NewDelete().
 		Model((*domain.OrderItem)(nil)).
 		Where("order_id = ?", orderID).
 		Exec(ctx)
 	return err
 }
+
+type orderStatusHistoryRepository struct {
+	db *bun.DB
+}
+
+// NewOrderStatusHistoryRepository creates a new order status history repository
+func NewOrderStatusHistoryRepository(db *bun.DB) ports.OrderStatusHistoryRepository {
+	return &orderStatusHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *orderStatusHistoryRepository) Create(ctx context.Context, entry *domain.OrderStatusHistory) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(entry).Exec(ctx)
+	return err
+}
+
+func (r *orderStatusHistoryRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderStatusHistory, error) {
+	var entries []*domain.OrderStatusHistory
+	err := conn(ctx, r.db).NewSelect().
+		Model(&entries).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return entries, err
+}
+
+type orderDiscountRepository struct {
+	db *bun.DB
+}
+
+// NewOrderDiscountRepository creates a new order discount repository
+func NewOrderDiscountRepository(db *bun.DB) ports.OrderDiscountRepository {
+	return &orderDiscountRepository{
+		db: db,
+	}
+}
+
+func (r *orderDiscountRepository) Create(ctx context.Context, discount *domain.OrderDiscount) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(discount).Exec(ctx)
+	return err
+}
+
+func (r *orderDiscountRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderDiscount, error) {
+	var discounts []*domain.OrderDiscount
+	err := conn(ctx, r.db).NewSelect().
+		Model(&discounts).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return discounts, err
+}
+
+func (r *orderDiscountRepository) Update(ctx context.Context, discount *domain.OrderDiscount) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(discount).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+func (r *orderDiscountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewDelete().
+		Model((*domain.OrderDiscount)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
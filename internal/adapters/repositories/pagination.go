@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/uptrace/bun"
+)
+
+// sortColumn is a field a list can be sorted by: the SQL expression it sorts on and the
+// value a record has for it, which cursors taken in the sort carry
+type sortColumn[T any] struct {
+	expr  string
+	value func(T) interface{}
+}
+
+// sortColumns maps the sortable fields of a model to their columns. Only these fixed
+// expressions reach SQL, so a sort cannot inject a column the map does not list. Every
+// map has an "id" column, which breaks ties.
+type sortColumns[T any] map[string]sortColumn[T]
+
+// sortKey is a column of the order a page is read in
+type sortKey[T any] struct {
+	sortColumn[T]
+	desc bool
+}
+
+// orderKeys resolves a sort to its columns, followed by the ID in the direction of the
+// last term so that the order is total
+func (c sortColumns[T]) orderKeys(sort ports.Sort) ([]sortKey[T], error) {
+	keys := make([]sortKey[T], 0, len(sort)+1)
+	for _, term := range sort {
+		column, ok := c[term.Field]
+		if !ok || term.Field == "id" {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ports.ErrInvalidSort, term.Field)
+		}
+		keys = append(keys, sortKey[T]{sortColumn: column, desc: term.Desc})
+	}
+	return append(keys, sortKey[T]{sortColumn: c["id"], desc: keys[len(keys)-1].desc}), nil
+}
+
+// seekAfter restricts q to the records that follow the cursor in the order of keys. When
+// every key runs the same way this is a single row comparison an index can seek on;
+// otherwise each key in turn is compared with the ones before it held equal.
+func seekAfter[T any](q *bun.SelectQuery, keys []sortKey[T], cursor *ports.Cursor) *bun.SelectQuery {
+	sameWay := true
+	exprs := make([]string, len(keys))
+	for i, key := range keys {
+		exprs[i] = key.expr
+		sameWay = sameWay && key.desc == keys[0].desc
+	}
+
+	if sameWay {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+		return q.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(exprs, ", "), seekOp(keys[0].desc), placeholders), cursor.Values...)
+	}
+
+	var branches []string
+	var args []interface{}
+	for i, key := range keys {
+		conds := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conds = append(conds, exprs[j]+" = ?")
+			args = append(args, cursor.Values[j])
+		}
+		conds = append(conds, fmt.Sprintf("%s %s ?", key.expr, seekOp(key.desc)))
+		args = append(args, cursor.Values[i])
+		branches = append(branches, "("+strings.Join(conds, " AND ")+")")
+	}
+	return q.Where("("+strings.Join(branches, " OR ")+")", args...)
+}
+
+// seekOp is the comparison past a cursor on a key sorted ascending or descending
+func seekOp(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+// selectPage scans a page of the query's model into items, in the order of the page's sort
+// with the ID breaking ties. Pages after a cursor seek past it on that key instead of
+// skipping rows, so deep pages stay as fast as the first. The total counts every row the
+// query matches.
+func selectPage[T any](ctx context.Context, q *bun.SelectQuery, items *[]T, page ports.PageRequest, columns sortColumns[T]) (*ports.Page[T], error) {
+	sort := page.Order()
+	keys, err := columns.orderKeys(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := q.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.After != nil {
+		if page.After.Sort != sort.String() || len(page.After.Values) != len(keys) {
+			return nil, ports.ErrInvalidCursor
+		}
+		q = seekAfter(q, keys, page.After)
+	} else {
+		q = q.Offset(page.Offset)
+	}
+	for _, key := range keys {
+		if key.desc {
+			q = q.OrderExpr(key.expr + " DESC")
+		} else {
+			q = q.OrderExpr(key.expr + " ASC")
+		}
+	}
+	size := page.Size()
+	if err := q.Limit(size + 1).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &ports.Page[T]{Items: *items, TotalCount: total}
+	hasMore := len(result.Items) > size
+	if hasMore {
+		result.Items = result.Items[:size]
+	}
+	result.Cursors = make([]ports.Cursor, len(result.Items))
+	for i, item := range result.Items {
+		values := make([]interface{}, len(keys))
+		for j, key := range keys {
+			values[j] = key.value(item)
+		}
+		result.Cursors[i] = ports.NewCursor(sort, values...)
+	}
+	if hasMore {
+		result.NextCursor = &result.Cursors[size-1]
+	}
+	return result, nil
+}
+
+var userSortColumns = sortColumns[*domain.User]{
+	"id":         {"u.id", func(u *domain.User) interface{} { return u.ID }},
+	"name":       {"u.name", func(u *domain.User) interface{} { return u.Name }},
+	"email":      {"u.email", func(u *domain.User) interface{} { return u.Email }},
+	"created_at": {"u.created_at", func(u *domain.User) interface{} { return u.CreatedAt }},
+}
+
+var customerSortColumns = sortColumns[*domain.Customer]{
+	"id":         {"c.id", func(c *domain.Customer) interface{} { return c.ID }},
+	"first_name": {"c.first_name", func(c *domain.Customer) interface{} { return c.FirstName }},
+	"last_name":  {"c.last_name", func(c *domain.Customer) interface{} { return c.LastName }},
+	"email":      {"c.email", func(c *domain.Customer) interface{} { return c.Email }},
+	"created_at": {"c.created_at", func(c *domain.Customer) interface{} { return c.CreatedAt }},
+}
+
+var categorySortColumns = sortColumns[*domain.Category]{
+	"id":         {"cat.id", func(c *domain.Category) interface{} { return c.ID }},
+	"name":       {"cat.name", func(c *domain.Category) interface{} { return c.Name }},
+	"position":   {"cat.position", func(c *domain.Category) interface{} { return c.Position }},
+	"created_at": {"cat.created_at", func(c *domain.Category) interface{} { return c.CreatedAt }},
+}
+
+// Prices sort on their amount in minor units, whatever their currency
+var productSortColumns = sortColumns[*domain.Product]{
+	"id":         {"p.id", func(p *domain.Product) interface{} { return p.ID }},
+	"name":       {"p.name", func(p *domain.Product) interface{} { return p.Name }},
+	"sku":        {"p.sku", func(p *domain.Product) interface{} { return p.SKU }},
+	"price":      {"(p.price).amount", func(p *domain.Product) interface{} { return p.Price.Amount }},
+	"stock":      {"p.stock", func(p *domain.Product) interface{} { return p.Stock }},
+	"created_at": {"p.created_at", func(p *domain.Product) interface{} { return p.CreatedAt }},
+}
+
+var orderSortColumns = sortColumns[*domain.Order]{
+	"id":           {"o.id", func(o *domain.Order) interface{} { return o.ID }},
+	"order_number": {"o.order_number", func(o *domain.Order) interface{} { return o.OrderNumber }},
+	"status":       {"o.status", func(o *domain.Order) interface{} { return o.Status }},
+	"total_amount": {"(o.total_amount).amount", func(o *domain.Order) interface{} { return o.TotalAmount.Amount }},
+	"order_date":   {"o.order_date", func(o *domain.Order) interface{} { return o.OrderDate }},
+	"created_at":   {"o.created_at", func(o *domain.Order) interface{} { return o.CreatedAt }},
+}
+
+var warehouseSortColumns = sortColumns[*domain.Warehouse]{
+	"id":         {"w.id", func(w *domain.Warehouse) interface{} { return w.ID }},
+	"code":       {"w.code", func(w *domain.Warehouse) interface{} { return w.Code }},
+	"name":       {"w.name", func(w *domain.Warehouse) interface{} { return w.Name }},
+	"created_at": {"w.created_at", func(w *domain.Warehouse) interface{} { return w.CreatedAt }},
+}
+
+var supplierSortColumns = sortColumns[*domain.Supplier]{
+	"id":         {"su.id", func(s *domain.Supplier) interface{} { return s.ID }},
+	"name":       {"su.name", func(s *domain.Supplier) interface{} { return s.Name }},
+	"created_at": {"su.created_at", func(s *domain.Supplier) interface{} { return s.CreatedAt }},
+}
+
+var purchaseOrderSortColumns = sortColumns[*domain.PurchaseOrder]{
+	"id":         {"po.id", func(p *domain.PurchaseOrder) interface{} { return p.ID }},
+	"status":     {"po.status", func(p *domain.PurchaseOrder) interface{} { return p.Status }},
+	"created_at": {"po.created_at", func(p *domain.PurchaseOrder) interface{} { return p.CreatedAt }},
+}
+
+var promotionSortColumns = sortColumns[*domain.Promotion]{
+	"id":         {"pm.id", func(p *domain.Promotion) interface{} { return p.ID }},
+	"name":       {"pm.name", func(p *domain.Promotion) interface{} { return p.Name }},
+	"priority":   {"pm.priority", func(p *domain.Promotion) interface{} { return p.Priority }},
+	"created_at": {"pm.created_at", func(p *domain.Promotion) interface{} { return p.CreatedAt }},
+}
+
+var taxRateSortColumns = sortColumns[*domain.TaxRate]{
+	"id":         {"tr.id", func(r *domain.TaxRate) interface{} { return r.ID }},
+	"country":    {"tr.country", func(r *domain.TaxRate) interface{} { return r.Country }},
+	"rate":       {"tr.rate", func(r *domain.TaxRate) interface{} { return r.Rate }},
+	"created_at": {"tr.created_at", func(r *domain.TaxRate) interface{} { return r.CreatedAt }},
+}
+
+var shippingMethodSortColumns = sortColumns[*domain.ShippingMethod]{
+	"id":         {"sm.id", func(m *domain.ShippingMethod) interface{} { return m.ID }},
+	"name":       {"sm.name", func(m *domain.ShippingMethod) interface{} { return m.Name }},
+	"type":       {"sm.type", func(m *domain.ShippingMethod) interface{} { return m.Type }},
+	"created_at": {"sm.created_at", func(m *domain.ShippingMethod) interface{} { return m.CreatedAt }},
+}
+
+// Stock movements are a ledger and only sort by time
+var stockMovementSortColumns = sortColumns[*domain.StockMovement]{
+	"id":         {"sm.id", func(s *domain.StockMovement) interface{} { return s.ID }},
+	"created_at": {"sm.created_at", func(s *domain.StockMovement) interface{} { return s.CreatedAt }},
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUserSortColumns = sortColumns[*TestUser]{
+	"id":    {"u.id", func(u *TestUser) interface{} { return u.ID }},
+	"name":  {"u.name", func(u *TestUser) interface{} { return u.Name }},
+	"email": {"u.email", func(u *TestUser) interface{} { return u.Email }},
+}
+
+func TestSelectPage_SortedByCursor(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	now := time.Now()
+	for _, u := range []struct{ name, email string }{
+		{"bob", "bob1@example.com"},
+		{"alice", "alice@example.com"},
+		{"bob", "bob3@example.com"},
+		{"carol", "carol@example.com"},
+		{"bob", "bob2@example.com"},
+	} {
+		user := &TestUser{ID: uuid.New(), Name: u.name, Email: u.email, CreatedAt: now, UpdatedAt: now}
+		_, err := db.NewInsert().Model(user).Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	cases := []struct {
+		sort ports.Sort
+		want []string
+	}{
+		// keys running one way seek with a row comparison
+		{ports.Sort{{Field: "email", Desc: true}}, []string{
+			"carol@example.com", "bob3@example.com", "bob2@example.com", "bob1@example.com", "alice@example.com",
+		}},
+		// name ascending, then email descending: the keys run both ways
+		{ports.Sort{{Field: "name"}, {Field: "email", Desc: true}}, []string{
+			"alice@example.com", "bob3@example.com", "bob2@example.com", "bob1@example.com", "carol@example.com",
+		}},
+	}
+	for _, c := range cases {
+		var emails []string
+		token := ""
+		for {
+			page, err := ports.NewPageRequest(2, 0, token, c.sort)
+			require.NoError(t, err)
+
+			var users []*TestUser
+			result, err := selectPage(ctx, db.NewSelect().Model(&users), &users, page, testUserSortColumns)
+			require.NoError(t, err)
+			assert.Equal(t, 5, result.TotalCount)
+			assert.Len(t, result.Cursors, len(result.Items))
+			for _, u := range result.Items {
+				emails = append(emails, u.Email)
+			}
+			if !result.HasNextPage() {
+				break
+			}
+			token = result.NextCursor.String()
+		}
+		assert.Equal(t, c.want, emails, c.sort.String())
+	}
+}
+
+func TestSelectPage_RejectsUnknownSort(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	var users []*TestUser
+	page := ports.PageRequest{Sort: ports.Sort{{Field: "password"}}}
+	_, err := selectPage(context.Background(), db.NewSelect().Model(&users), &users, page, testUserSortColumns)
+	assert.True(t, errors.Is(err, ports.ErrInvalidSort))
+}
+
+func TestSortColumns_CoverAllowedFields(t *testing.T) {
+	cases := []struct {
+		allowed ports.SortFields
+		columns map[string]bool
+	}{
+		{ports.UserSortFields, keysOf(userSortColumns)},
+		{ports.CustomerSortFields, keysOf(customerSortColumns)},
+		{ports.CategorySortFields, keysOf(categorySortColumns)},
+		{ports.ProductSortFields, keysOf(productSortColumns)},
+		{ports.OrderSortFields, keysOf(orderSortColumns)},
+		{ports.WarehouseSortFields, keysOf(warehouseSortColumns)},
+		{ports.SupplierSortFields, keysOf(supplierSortColumns)},
+		{ports.PurchaseOrderSortFields, keysOf(purchaseOrderSortColumns)},
+		{ports.StockMovementSortFields, keysOf(stockMovementSortColumns)},
+		{ports.PromotionSortFields, keysOf(promotionSortColumns)},
+		{ports.TaxRateSortFields, keysOf(taxRateSortColumns)},
+		{ports.ShippingMethodSortFields, keysOf(shippingMethodSortColumns)},
+	}
+	for _, c := range cases {
+		assert.True(t, c.columns["id"])
+		for _, field := range c.allowed {
+			assert.True(t, c.columns[field], field)
+		}
+	}
+}
+
+func keysOf[T any](columns sortColumns[T]) map[string]bool {
+	keys := make(map[string]bool, len(columns))
+	for field := range columns {
+		keys[field] = true
+	}
+	return keys
+}
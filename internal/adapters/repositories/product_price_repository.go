@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type productPriceRepository struct {
+	db *bun.DB
+}
+
+// NewProductPriceRepository creates a new product price repository
+func NewProductPriceRepository(db *bun.DB) ports.ProductPriceRepository {
+	return &productPriceRepository{
+		db: db,
+	}
+}
+
+func (r *productPriceRepository) Create(ctx context.Context, price *domain.ProductPrice) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(price).Exec(ctx)
+	return err
+}
+
+func (r *productPriceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductPrice, error) {
+	price := new(domain.ProductPrice)
+	err := conn(ctx, r.db).NewSelect().
+		Model(price).
+		Where("pp.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return price, nil
+}
+
+func (r *productPriceRepository) GetByProductID(ctx context.Context, productID uuid.UUID, from, to *time.Time) ([]*domain.ProductPrice, error) {
+	var prices []*domain.ProductPrice
+	q := conn(ctx, r.db).NewSelect().
+		Model(&prices).
+		Where("pp.product_id = ?", productID)
+	if to != nil {
+		q = q.Where("pp.effective_from < ?", *to)
+	}
+	if from != nil {
+		q = q.Where("(pp.effective_to IS NULL OR pp.effective_to > ?)", *from)
+	}
+	err := q.Order("pp.effective_from ASC", "pp.created_at ASC").Scan(ctx)
+	return prices, err
+}
+
+// GetEffective ranks the prices in effect at the time the way domain.EffectivePriceAt
+// does: prices with an end first, then the latest to start, then the latest created
+func (r *productPriceRepository) GetEffective(ctx context.Context, productID uuid.UUID, at time.Time) (*domain.ProductPrice, error) {
+	price := new(domain.ProductPrice)
+	err := conn(ctx, r.db).NewSelect().
+		Model(price).
+		Where("pp.product_id = ?", productID).
+		Where("pp.effective_from <= ?", at).
+		Where("(pp.effective_to IS NULL OR pp.effective_to > ?)", at).
+		OrderExpr("pp.effective_to IS NULL ASC").
+		Order("pp.effective_from DESC", "pp.created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return price, nil
+}
+
+func (r *productPriceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewDelete().
+		Model((*domain.ProductPrice)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
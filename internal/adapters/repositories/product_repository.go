@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
 
 	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/ports"
@@ -22,15 +25,28 @@ func NewProductRepository(db *bun.DB) ports.ProductRepository {
 	}
 }
 
+// Create inserts the product and records its opening stock, held at the default
+// warehouse, in the ledger
 func (r *productRepository) Create(ctx context.Context, product *domain.Product) error {
-	_, err := r.db.NewInsert().Model(product).Exec(ctx)
-	return err
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		if _, err := conn(ctx, r.db).NewInsert().Model(product).Exec(ctx); err != nil {
+			return err
+		}
+		if product.Stock == 0 {
+			return nil
+		}
+		movement := domain.NewStockMovement(product.ID, product.Stock, domain.StockMovementReasonInitial, nil)
+		if err := adjustStockLevel(ctx, conn(ctx, r.db), movement); err != nil {
+			return err
+		}
+		return recordMovement(ctx, conn(ctx, r.db), movement)
+	})
 }
 
 func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
 	product := new(domain.Product)
-	err := r.db.NewSelect().
-		Model(product).
+	err := withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(product))).
 		Relation("Category").
 		Where("id = ?", id).
 		Scan(ctx)
@@ -43,12 +59,13 @@ func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return product, nil
 }
 
+// GetBySKU returns the product with the SKU, or the product of the variant with the SKU
 func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*domain.Product, error) {
 	product := new(domain.Product)
-	err := r.db.NewSelect().
-		Model(product).
+	err := withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(product))).
 		Relation("Category").
-		Where("sku = ?", sku).
+		Where("p.sku = ? OR EXISTS (SELECT 1 FROM product_variants AS v WHERE v.product_id = p.id AND v.sku = ?)", sku, sku).
 		Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -59,77 +76,373 @@ func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*domain.P
 	return product, nil
 }
 
-func (r *productRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+func (r *productRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	var products []*domain.Product
-	err := r.db.NewSelect().
-		Model(&products).
+	q := withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(&products))).
+		Relation("Category")
+	return selectPage(ctx, q, &products, page, productSortColumns)
+}
+
+func (r *productRepository) GetByCategoryID(ctx context.Context, categoryID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
+	var products []*domain.Product
+	q := withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(&products))).
 		Relation("Category").
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Scan(ctx)
-	return products, err
+		Where("p.category_id IN ("+categorySubtreeSQL+")", categoryID)
+	return selectPage(ctx, q, &products, page, productSortColumns)
 }
 
-func (r *productRepository) GetByCategoryID(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Product, error) {
+func (r *productRepository) GetActiveProducts(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	var products []*domain.Product
-	err := r.db.NewSelect().
-		Model(&products).
+	q := withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(&products))).
 		Relation("Category").
-		Where("category_id = ?", categoryID).
-		Order("name ASC").
-		Limit(limit).
-		Offset(offset).
+		Where("p.is_active = ?", true)
+	return selectPage(ctx, q, &products, page, productSortColumns)
+}
+
+// Search returns the products whose name, SKU or description has words starting with
+// every term, best match first
+func (r *productRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.ProductSearchResult, error) {
+	db := conn(ctx, r.db)
+	matches, err := searchMatches(ctx, db, (*domain.Product)(nil), "concat_ws(' ', p.name, p.sku, p.description)", terms, limit, offset)
+	if err != nil || len(matches) == 0 {
+		return []*domain.ProductSearchResult{}, err
+	}
+
+	var products []*domain.Product
+	err = withVariants(withStockLevels(db.NewSelect().
+		Model(&products))).
+		Relation("Category").
+		Where("p.id IN (?)", bun.In(matchIDs(matches))).
 		Scan(ctx)
-	return products, err
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*domain.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+	results := make([]*domain.ProductSearchResult, 0, len(matches))
+	for _, match := range matches {
+		if product, ok := byID[match.ID]; ok {
+			results = append(results, &domain.ProductSearchResult{Product: product, Rank: match.Rank, Highlight: match.Highlight})
+		}
+	}
+	return results, nil
 }
 
-func (r *productRepository) GetActiveProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+// Query returns the products matching every filter of the query, in the order of the page
+func (r *productRepository) Query(ctx context.Context, query *domain.ProductQuery, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	var products []*domain.Product
-	err := r.db.NewSelect().
-		Model(&products).
+	q := productFilter(withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(&products))).
+		Relation("Category"), query, false, "")
+	return selectPage(ctx, q, &products, page, productSortColumns)
+}
+
+// attributeCount is a row of the attribute facet query
+type attributeCount struct {
+	Name  string `bun:"name"`
+	Value string `bun:"value"`
+	Count int    `bun:"count"`
+}
+
+// GetFacets counts the products matching the query per category and attribute value.
+// Each facet is counted without its own filter, so a filtered attribute is counted in
+// a query of its own.
+func (r *productRepository) GetFacets(ctx context.Context, query *domain.ProductQuery) (*domain.ProductFacets, error) {
+	db := conn(ctx, r.db)
+	facets := &domain.ProductFacets{
+		Categories: []domain.CategoryFacet{},
+		Attributes: []domain.AttributeFacet{},
+	}
+
+	total, err := productFilter(db.NewSelect().Model((*domain.Product)(nil)), query, false, "").Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	facets.Total = total
+
+	err = productFilter(db.NewSelect().
+		Model((*domain.Product)(nil)).
+		ColumnExpr("p.category_id, c.name, COUNT(*) AS count").
+		Join("JOIN categories AS c ON c.id = p.category_id").
+		GroupExpr("p.category_id, c.name").
+		OrderExpr("count DESC, c.name ASC"), query, true, "").
+		Scan(ctx, &facets.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	countAttributes := func(skipAttribute string, keys func(*bun.SelectQuery) *bun.SelectQuery) error {
+		var counts []attributeCount
+		q := db.NewSelect().
+			Model((*domain.Product)(nil)).
+			ColumnExpr("a.key AS name, a.value AS value, COUNT(*) AS count").
+			Join("CROSS JOIN LATERAL jsonb_each_text(p.attributes) AS a").
+			GroupExpr("a.key, a.value").
+			OrderExpr("a.key ASC, count DESC, a.value ASC")
+		if err := keys(productFilter(q, query, false, skipAttribute)).Scan(ctx, &counts); err != nil {
+			return err
+		}
+		for _, count := range counts {
+			n := len(facets.Attributes)
+			if n == 0 || facets.Attributes[n-1].Name != count.Name {
+				facets.Attributes = append(facets.Attributes, domain.AttributeFacet{Name: count.Name})
+				n++
+			}
+			facets.Attributes[n-1].Values = append(facets.Attributes[n-1].Values, domain.FacetValue{Value: count.Value, Count: count.Count})
+		}
+		return nil
+	}
+
+	filtered := query.AttributeNames()
+	err = countAttributes("", func(q *bun.SelectQuery) *bun.SelectQuery {
+		if len(filtered) > 0 {
+			q = q.Where("a.key NOT IN (?)", bun.In(filtered))
+		}
+		return q
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range filtered {
+		err := countAttributes(name, func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("a.key = ?", name)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(facets.Attributes, func(i, j int) bool {
+		return facets.Attributes[i].Name < facets.Attributes[j].Name
+	})
+
+	return facets, nil
+}
+
+// productFilter adds the query's filters to q. The category filter is left out when
+// skipCategory is set and the filter on the skipAttribute attribute is always left out.
+func productFilter(q *bun.SelectQuery, query *domain.ProductQuery, skipCategory bool, skipAttribute string) *bun.SelectQuery {
+	if query.CategoryID != nil && !skipCategory {
+		q = q.Where("p.category_id = ?", *query.CategoryID)
+	}
+	if query.IsActive != nil {
+		q = q.Where("p.is_active = ?", *query.IsActive)
+	}
+	if query.MinPrice != nil {
+		q = q.Where("(p.price).currency = ? AND (p.price).amount >= ?", query.MinPrice.Currency, query.MinPrice.Amount)
+	}
+	if query.MaxPrice != nil {
+		q = q.Where("(p.price).currency = ? AND (p.price).amount <= ?", query.MaxPrice.Currency, query.MaxPrice.Amount)
+	}
+	if query.MinStock != nil {
+		q = q.Where("p.stock >= ?", *query.MinStock)
+	}
+	if terms := domain.SearchTerms(query.Search); len(terms) > 0 {
+		q = q.Where("p.search_vector @@ to_tsquery(?, ?)", searchConfig, prefixQuery(terms))
+	}
+	for _, name := range query.AttributeNames() {
+		if name == skipAttribute {
+			continue
+		}
+		values := query.Attributes[name]
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, value := range values {
+				// Containment lets the GIN index on attributes serve the filter
+				contains, _ := json.Marshal(map[string]string{name: value})
+				q = q.WhereOr("p.attributes @> ?::jsonb", string(contains))
+			}
+			return q
+		})
+	}
+	return q
+}
+
+// GetLowStock returns active products below their reorder point, furthest below first
+func (r *productRepository) GetLowStock(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+	var products []*domain.Product
+	err := withVariants(withStockLevels(conn(ctx, r.db).NewSelect().
+		Model(&products))).
 		Relation("Category").
-		Where("is_active = ?", true).
-		Order("name ASC").
+		Where("p.is_active = ?", true).
+		Where("p.stock < p.reorder_point").
+		OrderExpr("p.reorder_point - p.stock DESC").
+		Order("p.sku ASC").
 		Limit(limit).
 		Offset(offset).
 		Scan(ctx)
 	return products, err
 }
 
-func (r *productRepository) SearchByName(ctx context.Context, name string, limit, offset int) ([]*domain.Product, error) {
+// ListAfterSKU returns up to limit products with a SKU after afterSKU, in SKU order
+func (r *productRepository) ListAfterSKU(ctx context.Context, afterSKU string, limit int) ([]*domain.Product, error) {
 	var products []*domain.Product
-	err := r.db.NewSelect().
+	err := conn(ctx, r.db).NewSelect().
 		Model(&products).
 		Relation("Category").
-		Where("name ILIKE ?", "%"+name+"%").
-		Order("name ASC").
+		Where("p.sku > ?", afterSKU).
+		Order("p.sku ASC").
 		Limit(limit).
-		Offset(offset).
 		Scan(ctx)
-	return products, err
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
 }
 
+// Update saves the product's attributes. Stock is left untouched; it only
+// changes through UpdateStock and AdjustStock so the ledger stays complete.
 func (r *productRepository) Update(ctx context.Context, product *domain.Product) error {
-	_, err := r.db.NewUpdate().
+	_, err := conn(ctx, r.db).NewUpdate().
 		Model(product).
+		ExcludeColumn("stock").
 		WherePK().
 		Exec(ctx)
 	return err
 }
 
+// UpdateStock sets the product stock to an absolute value, recording the
+// difference as an adjustment at the default warehouse
 func (r *productRepository) UpdateStock(ctx context.Context, id uuid.UUID, stock int) error {
-	_, err := r.db.NewUpdate().
-		Model((*domain.Product)(nil)).
-		Set("stock = ?", stock).
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		var current int
+		err := conn(ctx, r.db).NewSelect().
+			Model((*domain.Product)(nil)).
+			Column("stock").
+			Where("id = ?", id).
+			For("UPDATE").
+			Scan(ctx, &current)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		if stock == current {
+			return nil
+		}
+		return applyStockMovement(ctx, r.db, domain.NewStockMovement(id, stock-current, domain.StockMovementReasonAdjustment, nil))
+	})
+}
+
+// AdjustStock atomically applies the movement's delta to the product stock and
+// its warehouse's stock level, and appends the movement to the ledger
+func (r *productRepository) AdjustStock(ctx context.Context, movement *domain.StockMovement) error {
+	return applyStockMovement(ctx, r.db, movement)
+}
+
+// applyStockMovement changes the product stock, the stock level at the movement's
+// warehouse (the default warehouse when none is set) and the stock of its variant, if
+// any, and records the movement.
+// Decrements only succeed while enough stock remains beyond what active reservations
+// hold and the warehouse has the quantity, so concurrent orders cannot oversell.
+func applyStockMovement(ctx context.Context, db *bun.DB, movement *domain.StockMovement) error {
+	return withinTx(ctx, db, func(ctx context.Context) error {
+		idb := conn(ctx, db)
+		q := idb.NewUpdate().
+			Model((*domain.Product)(nil)).
+			Set("stock = stock + ?", movement.Delta).
+			Set("updated_at = CURRENT_TIMESTAMP").
+			Where("p.id = ?", movement.ProductID)
+		if movement.Delta < 0 {
+			if err := lockProduct(ctx, idb, movement.ProductID); err != nil {
+				return err
+			}
+			q = q.Where("p.stock + ? >= "+heldQuantitySQL, movement.Delta, time.Now())
+		}
+
+		res, err := q.Exec(ctx)
+		if err != nil {
+			return err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return domain.ErrInsufficientStock
+		}
+
+		if err := adjustStockLevel(ctx, idb, movement); err != nil {
+			return err
+		}
+		if err := adjustVariantStock(ctx, idb, movement); err != nil {
+			return err
+		}
+		return recordMovement(ctx, idb, movement)
+	})
+}
+
+// adjustStockLevel applies the movement's delta to the stock level at its warehouse,
+// resolving a missing warehouse to the default one
+func adjustStockLevel(ctx context.Context, db bun.IDB, movement *domain.StockMovement) error {
+	if movement.WarehouseID == nil {
+		var id uuid.UUID
+		err := db.NewSelect().
+			Model((*domain.Warehouse)(nil)).
+			Column("id").
+			Where("is_default").
+			Scan(ctx, &id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return domain.ErrNoDefaultWarehouse
+			}
+			return err
+		}
+		movement.WarehouseID = &id
+	}
+
+	if movement.Delta > 0 {
+		level := &domain.StockLevel{
+			ProductID:   movement.ProductID,
+			WarehouseID: *movement.WarehouseID,
+			Quantity:    movement.Delta,
+		}
+		_, err := db.NewInsert().
+			Model(level).
+			On("CONFLICT (product_id, warehouse_id) DO UPDATE").
+			Set("quantity = sl.quantity + EXCLUDED.quantity").
+			Set("updated_at = CURRENT_TIMESTAMP").
+			Exec(ctx)
+		return err
+	}
+
+	res, err := db.NewUpdate().
+		Model((*domain.StockLevel)(nil)).
+		Set("quantity = quantity + ?", movement.Delta).
 		Set("updated_at = CURRENT_TIMESTAMP").
-		Where("id = ?", id).
+		Where("product_id = ?", movement.ProductID).
+		Where("warehouse_id = ?", *movement.WarehouseID).
+		Where("quantity + ? >= 0", movement.Delta).
 		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrInsufficientStock
+	}
+	return nil
+}
+
+// recordMovement appends movement to the ledger, attributing it to the actor in ctx
+func recordMovement(ctx context.Context, db bun.IDB, movement *domain.StockMovement) error {
+	if movement.Actor == "" {
+		movement.Actor = domain.ActorFromContext(ctx)
+	}
+	_, err := db.NewInsert().Model(movement).Exec(ctx)
 	return err
 }
 
 func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().
+	_, err := conn(ctx, r.db).NewDelete().
 		Model((*domain.Product)(nil)).
 		Where("id = ?", id).
 		Exec(ctx)
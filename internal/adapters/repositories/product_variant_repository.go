@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// withVariants loads the product's variants ordered by SKU
+func withVariants(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Relation("Variants", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Order("pv.sku ASC")
+	})
+}
+
+type productVariantRepository struct {
+	db *bun.DB
+}
+
+// NewProductVariantRepository creates a new product variant repository
+func NewProductVariantRepository(db *bun.DB) ports.ProductVariantRepository {
+	return &productVariantRepository{
+		db: db,
+	}
+}
+
+// Create inserts the variant and records its opening stock, which is added to the
+// product's stock at the default warehouse, in the ledger. The first variant of a product
+// also takes over the stock the product held before it had variants.
+func (r *productVariantRepository) Create(ctx context.Context, variant *domain.ProductVariant) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		// Lock the product so that two first variants cannot both take over its stock
+		if err := lockProduct(ctx, db, variant.ProductID); err != nil {
+			return err
+		}
+		others, err := db.NewSelect().
+			Model((*domain.ProductVariant)(nil)).
+			Where("product_id = ?", variant.ProductID).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		stock := variant.Stock
+		variant.Stock = 0
+		if _, err := db.NewInsert().Model(variant).Exec(ctx); err != nil {
+			return err
+		}
+		if others == 0 {
+			if err := r.takeOverProductStock(ctx, variant); err != nil {
+				return err
+			}
+		}
+		if stock == 0 {
+			return nil
+		}
+		movement := domain.NewStockMovement(variant.ProductID, stock, domain.StockMovementReasonInitial, nil).ForVariant(&variant.ID)
+		if err := applyStockMovement(ctx, r.db, movement); err != nil {
+			return err
+		}
+		variant.Stock += stock
+		return nil
+	})
+}
+
+// takeOverProductStock moves the stock a product held before it had variants into its
+// first variant, since a product with variants only sells its variants' stock. Each
+// warehouse's stock is taken off the product and put back through the variant, so the
+// product's and the warehouses' quantities are unchanged and the ledger adds up.
+func (r *productVariantRepository) takeOverProductStock(ctx context.Context, variant *domain.ProductVariant) error {
+	var levels []*domain.StockLevel
+	err := conn(ctx, r.db).NewSelect().
+		Model(&levels).
+		Where("sl.product_id = ?", variant.ProductID).
+		Where("sl.quantity > 0").
+		Scan(ctx)
+	if err != nil {
+		return err
+	}
+
+	note := fmt.Sprintf("stock moved into variant %s", variant.SKU)
+	for _, level := range levels {
+		// Put the stock on the variant before taking it off the product, so that the
+		// product never drops below what is reserved
+		in := domain.NewStockMovement(variant.ProductID, level.Quantity, domain.StockMovementReasonAdjustment, nil).InWarehouse(level.WarehouseID).ForVariant(&variant.ID)
+		in.Note = note
+		if err := applyStockMovement(ctx, r.db, in); err != nil {
+			return err
+		}
+		out := domain.NewStockMovement(variant.ProductID, -level.Quantity, domain.StockMovementReasonAdjustment, nil).InWarehouse(level.WarehouseID)
+		out.Note = note
+		if err := applyStockMovement(ctx, r.db, out); err != nil {
+			return err
+		}
+		variant.Stock += level.Quantity
+	}
+	return nil
+}
+
+func (r *productVariantRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductVariant, error) {
+	variant := new(domain.ProductVariant)
+	err := conn(ctx, r.db).NewSelect().
+		Model(variant).
+		Where("pv.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (r *productVariantRepository) GetBySKU(ctx context.Context, sku string) (*domain.ProductVariant, error) {
+	variant := new(domain.ProductVariant)
+	err := conn(ctx, r.db).NewSelect().
+		Model(variant).
+		Where("pv.sku = ?", sku).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (r *productVariantRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*domain.ProductVariant, error) {
+	var variants []*domain.ProductVariant
+	err := conn(ctx, r.db).NewSelect().
+		Model(&variants).
+		Where("pv.product_id = ?", productID).
+		Order("pv.sku ASC").
+		Scan(ctx)
+	return variants, err
+}
+
+// Update saves the variant's attributes. Stock is left untouched; it only
+// changes through UpdateStock and stock movements so the ledger stays complete.
+func (r *productVariantRepository) Update(ctx context.Context, variant *domain.ProductVariant) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(variant).
+		ExcludeColumn("stock", "created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// UpdateStock sets the variant stock to an absolute value, recording the difference
+// as an adjustment of its product's stock at the default warehouse
+func (r *productVariantRepository) UpdateStock(ctx context.Context, id uuid.UUID, stock int) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		variant := new(domain.ProductVariant)
+		err := conn(ctx, r.db).NewSelect().
+			Model(variant).
+			Column("product_id", "stock").
+			Where("pv.id = ?", id).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		if stock == variant.Stock {
+			return nil
+		}
+		movement := domain.NewStockMovement(variant.ProductID, stock-variant.Stock, domain.StockMovementReasonAdjustment, nil)
+		return applyStockMovement(ctx, r.db, movement.ForVariant(&id))
+	})
+}
+
+func (r *productVariantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewDelete().
+		Model((*domain.ProductVariant)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// adjustVariantStock applies the movement's delta to its variant's stock, failing
+// with ErrInsufficientStock when the variant does not hold the quantity
+func adjustVariantStock(ctx context.Context, db bun.IDB, movement *domain.StockMovement) error {
+	if movement.VariantID == nil {
+		return nil
+	}
+	res, err := db.NewUpdate().
+		Model((*domain.ProductVariant)(nil)).
+		Set("stock = stock + ?", movement.Delta).
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Where("id = ?", *movement.VariantID).
+		Where("product_id = ?", movement.ProductID).
+		Where("stock + ? >= 0", movement.Delta).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrInsufficientStock
+	}
+	return nil
+}
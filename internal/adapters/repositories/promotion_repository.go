@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type promotionRepository struct {
+	db *bun.DB
+}
+
+// NewPromotionRepository creates a new promotion repository
+func NewPromotionRepository(db *bun.DB) ports.PromotionRepository {
+	return &promotionRepository{
+		db: db,
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *domain.Promotion) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(promotion).Exec(ctx)
+	return err
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	promotion := new(domain.Promotion)
+	err := conn(ctx, r.db).NewSelect().
+		Model(promotion).
+		Where("pm.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) GetByCode(ctx context.Context, code string) (*domain.Promotion, error) {
+	promotion := new(domain.Promotion)
+	err := conn(ctx, r.db).NewSelect().
+		Model(promotion).
+		Where("pm.code = ?", code).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Promotion], error) {
+	var promotions []*domain.Promotion
+	q := conn(ctx, r.db).NewSelect().
+		Model(&promotions)
+	return selectPage(ctx, q, &promotions, page, promotionSortColumns)
+}
+
+func (r *promotionRepository) GetAutomatic(ctx context.Context, at time.Time) ([]*domain.Promotion, error) {
+	var promotions []*domain.Promotion
+	err := conn(ctx, r.db).NewSelect().
+		Model(&promotions).
+		Where("pm.code IS NULL").
+		Where("pm.is_active").
+		Where("pm.starts_at IS NULL OR pm.starts_at <= ?", at).
+		Where("pm.expires_at IS NULL OR pm.expires_at > ?", at).
+		Where("pm.usage_limit IS NULL OR pm.times_used < pm.usage_limit").
+		Order("pm.priority DESC", "pm.created_at ASC").
+		Scan(ctx)
+	return promotions, err
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *domain.Promotion) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(promotion).
+		ExcludeColumn("created_at", "times_used").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+func (r *promotionRepository) Redeem(ctx context.Context, id uuid.UUID) error {
+	// Count the use conditionally so concurrent orders cannot overrun the limit
+	res, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.Promotion)(nil)).
+		Set("times_used = times_used + 1").
+		Where("id = ?", id).
+		Where("usage_limit IS NULL OR times_used < usage_limit").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrPromotionUnavailable
+	}
+	return nil
+}
+
+func (r *promotionRepository) Release(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.Promotion)(nil)).
+		Set("times_used = times_used - 1").
+		Where("id = ?", id).
+		Where("times_used > 0").
+		Exec(ctx)
+	return err
+}
+
+func (r *promotionRepository) CountCustomerUses(ctx context.Context, id, customerID uuid.UUID) (int, error) {
+	return conn(ctx, r.db).NewSelect().
+		Model((*domain.OrderDiscount)(nil)).
+		Join("JOIN orders AS o ON o.id = od.order_id").
+		Where("od.promotion_id = ?", id).
+		Where("o.customer_id = ?", customerID).
+		Where("o.status != ?", domain.OrderStatusCancelled).
+		Count(ctx)
+}
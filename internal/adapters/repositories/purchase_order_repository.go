@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type purchaseOrderRepository struct {
+	db *bun.DB
+}
+
+// NewPurchaseOrderRepository creates a new purchase order repository
+func NewPurchaseOrderRepository(db *bun.DB) ports.PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		db: db,
+	}
+}
+
+// withLines loads the purchase order's supplier and lines
+func withLines(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.
+		Relation("Supplier").
+		Relation("Lines", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("pol.created_at ASC", "pol.id ASC")
+		})
+}
+
+// Create inserts the purchase order together with its lines
+func (r *purchaseOrderRepository) Create(ctx context.Context, po *domain.PurchaseOrder) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		if _, err := db.NewInsert().Model(po).Exec(ctx); err != nil {
+			return err
+		}
+		if len(po.Lines) == 0 {
+			return nil
+		}
+		_, err := db.NewInsert().Model(&po.Lines).Exec(ctx)
+		return err
+	})
+}
+
+func (r *purchaseOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	po := new(domain.PurchaseOrder)
+	err := withLines(conn(ctx, r.db).NewSelect().
+		Model(po)).
+		Where("po.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return po, nil
+}
+
+func (r *purchaseOrderRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.PurchaseOrder], error) {
+	var pos []*domain.PurchaseOrder
+	q := withLines(conn(ctx, r.db).NewSelect().
+		Model(&pos))
+	return selectPage(ctx, q, &pos, page, purchaseOrderSortColumns)
+}
+
+func (r *purchaseOrderRepository) GetBySupplierID(ctx context.Context, supplierID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.PurchaseOrder], error) {
+	var pos []*domain.PurchaseOrder
+	q := withLines(conn(ctx, r.db).NewSelect().
+		Model(&pos)).
+		Where("po.supplier_id = ?", supplierID)
+	return selectPage(ctx, q, &pos, page, purchaseOrderSortColumns)
+}
+
+func (r *purchaseOrderRepository) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(po).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+func (r *purchaseOrderRepository) ReplaceLines(ctx context.Context, po *domain.PurchaseOrder) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		_, err := db.NewDelete().
+			Model((*domain.PurchaseOrderLine)(nil)).
+			Where("purchase_order_id = ?", po.ID).
+			Exec(ctx)
+		if err != nil || len(po.Lines) == 0 {
+			return err
+		}
+		_, err = db.NewInsert().Model(&po.Lines).Exec(ctx)
+		return err
+	})
+}
+
+// ReceiveLine only updates the line while the receipt fits, so concurrent receipts cannot over-receive
+func (r *purchaseOrderRepository) ReceiveLine(ctx context.Context, lineID uuid.UUID, quantity int) error {
+	res, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.PurchaseOrderLine)(nil)).
+		Set("quantity_received = quantity_received + ?", quantity).
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Where("id = ?", lineID).
+		Where("quantity_received + ? <= quantity_ordered", quantity).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrInvalidPurchaseOrder
+	}
+	return nil
+}
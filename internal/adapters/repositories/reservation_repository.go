@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// heldQuantitySQL sums the quantity held by active reservations of product p that
+// expire after the time bound to its placeholder
+const heldQuantitySQL = `(SELECT COALESCE(SUM(sr.quantity), 0) FROM stock_reservations AS sr
+	WHERE sr.product_id = p.id AND sr.status = 'active' AND sr.expires_at > ?)`
+
+// withStockLevels adds the reserved and available stock of product p and its
+// per-warehouse stock levels to a product select
+func withStockLevels(q *bun.SelectQuery) *bun.SelectQuery {
+	now := time.Now()
+	return q.
+		Relation("StockLevels", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("sl.quantity DESC")
+		}).
+		Relation("StockLevels.Warehouse").
+		ColumnExpr("?TableColumns").
+		ColumnExpr(heldQuantitySQL+" AS reserved_stock", now).
+		ColumnExpr("GREATEST(p.stock - "+heldQuantitySQL+", 0) AS available_stock", now)
+}
+
+// lockProduct locks the product row until the surrounding transaction ends, so stock
+// checks that follow see every reservation and sale committed before it
+func lockProduct(ctx context.Context, db bun.IDB, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := db.NewSelect().
+		Model((*domain.Product)(nil)).
+		Column("id").
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx, &locked)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	return nil
+}
+
+type reservationRepository struct {
+	db *bun.DB
+}
+
+// NewReservationRepository creates a new stock reservation repository
+func NewReservationRepository(db *bun.DB) ports.ReservationRepository {
+	return &reservationRepository{
+		db: db,
+	}
+}
+
+// Create holds stock for the reservation when enough of the product is available
+func (r *reservationRepository) Create(ctx context.Context, reservation *domain.StockReservation) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		if err := lockProduct(ctx, db, reservation.ProductID); err != nil {
+			return err
+		}
+
+		var available int
+		err := db.NewSelect().
+			Model((*domain.Product)(nil)).
+			ColumnExpr("p.stock - "+heldQuantitySQL, time.Now()).
+			Where("p.id = ?", reservation.ProductID).
+			Scan(ctx, &available)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return domain.ErrInsufficientStock
+			}
+			return err
+		}
+		if available < reservation.Quantity {
+			return domain.ErrInsufficientStock
+		}
+
+		_, err = db.NewInsert().Model(reservation).Exec(ctx)
+		return err
+	})
+}
+
+func (r *reservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error) {
+	reservation := new(domain.StockReservation)
+	err := conn(ctx, r.db).NewSelect().
+		Model(reservation).
+		Where("sr.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (r *reservationRepository) Convert(ctx context.Context, id, orderID uuid.UUID) error {
+	res, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.StockReservation)(nil)).
+		Set("status = ?", domain.ReservationStatusConverted).
+		Set("order_id = ?", orderID).
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Where("id = ?", id).
+		Where("status = ?", domain.ReservationStatusActive).
+		Where("expires_at > ?", time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrReservationUnavailable
+	}
+	return nil
+}
+
+func (r *reservationRepository) Release(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.StockReservation)(nil)).
+		Set("status = ?", domain.ReservationStatusReleased).
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Where("id = ?", id).
+		Where("status = ?", domain.ReservationStatusActive).
+		Exec(ctx)
+	return err
+}
+
+func (r *reservationRepository) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	res, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.StockReservation)(nil)).
+		Set("status = ?", domain.ReservationStatusExpired).
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Where("status = ?", domain.ReservationStatusActive).
+		Where("expires_at <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
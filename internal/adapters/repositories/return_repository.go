@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type returnRepository struct {
+	db *bun.DB
+}
+
+// NewReturnRepository creates a new return request repository
+func NewReturnRepository(db *bun.DB) ports.ReturnRepository {
+	return &returnRepository{
+		db: db,
+	}
+}
+
+// Create inserts the return request together with its items
+func (r *returnRepository) Create(ctx context.Context, ret *domain.ReturnRequest) error {
+	db := conn(ctx, r.db)
+	if _, err := db.NewInsert().Model(ret).Exec(ctx); err != nil {
+		return err
+	}
+	if len(ret.Items) == 0 {
+		return nil
+	}
+	_, err := db.NewInsert().Model(&ret.Items).Exec(ctx)
+	return err
+}
+
+func (r *returnRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error) {
+	ret := new(domain.ReturnRequest)
+	err := conn(ctx, r.db).NewSelect().
+		Model(ret).
+		Relation("Items").
+		Relation("Refund").
+		Where("rr.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.ReturnRequest, error) {
+	var returns []*domain.ReturnRequest
+	err := conn(ctx, r.db).NewSelect().
+		Model(&returns).
+		Relation("Items").
+		Relation("Refund").
+		Where("rr.order_id = ?", orderID).
+		Order("rr.created_at ASC").
+		Scan(ctx)
+	return returns, err
+}
+
+func (r *returnRepository) Update(ctx context.Context, ret *domain.ReturnRequest) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(ret).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+type refundRepository struct {
+	db *bun.DB
+}
+
+// NewRefundRepository creates a new refund repository
+func NewRefundRepository(db *bun.DB) ports.RefundRepository {
+	return &refundRepository{
+		db: db,
+	}
+}
+
+func (r *refundRepository) Create(ctx context.Context, refund *domain.Refund) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(refund).Exec(ctx)
+	return err
+}
+
+func (r *refundRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.Refund, error) {
+	var refunds []*domain.Refund
+	err := conn(ctx, r.db).NewSelect().
+		Model(&refunds).
+		Where("rf.order_id = ?", orderID).
+		Order("rf.created_at ASC").
+		Scan(ctx)
+	return refunds, err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// searchConfig is the text search configuration the search_vector columns are built with
+const searchConfig = "simple"
+
+// searchHeadlineOptions shape the highlighted snippet returned with each match
+var searchHeadlineOptions = "StartSel=" + domain.SearchHighlightStart +
+	", StopSel=" + domain.SearchHighlightStop +
+	", MaxWords=20, MinWords=5"
+
+// searchMatch is a row matching a full-text search
+type searchMatch struct {
+	ID        uuid.UUID `bun:"id"`
+	Rank      float64   `bun:"rank"`
+	Highlight string    `bun:"highlight"`
+}
+
+// prefixQuery builds a tsquery matching rows with a word starting with every term.
+// Terms are quoted so characters such as '@' and '-' cannot change the query's meaning.
+func prefixQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = "'" + strings.ReplaceAll(term, "'", "") + "':*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// searchMatches ranks the rows of the model's table whose search_vector matches every
+// term, best first, highlighting the terms in the text expression
+func searchMatches(ctx context.Context, db bun.IDB, model interface{}, text string, terms []string, limit, offset int) ([]searchMatch, error) {
+	var matches []searchMatch
+	err := db.NewSelect().
+		Model(model).
+		TableExpr("to_tsquery(?, ?) AS q", searchConfig, prefixQuery(terms)).
+		ColumnExpr("?TableAlias.id").
+		ColumnExpr("ts_rank_cd(?TableAlias.search_vector, q) AS rank").
+		ColumnExpr("ts_headline(?, "+text+", q, ?) AS highlight", searchConfig, searchHeadlineOptions).
+		Where("?TableAlias.search_vector @@ q").
+		OrderExpr("rank DESC").
+		OrderExpr("?TableAlias.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &matches)
+	return matches, err
+}
+
+// matchIDs returns the IDs of the matches in rank order
+func matchIDs(matches []searchMatch) []uuid.UUID {
+	ids := make([]uuid.UUID, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+	return ids
+}
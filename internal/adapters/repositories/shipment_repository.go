@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type shipmentRepository struct {
+	db *bun.DB
+}
+
+// NewShipmentRepository creates a new shipment repository
+func NewShipmentRepository(db *bun.DB) ports.ShipmentRepository {
+	return &shipmentRepository{
+		db: db,
+	}
+}
+
+// Create inserts the shipment together with its items
+func (r *shipmentRepository) Create(ctx context.Context, shipment *domain.Shipment) error {
+	db := conn(ctx, r.db)
+	if _, err := db.NewInsert().Model(shipment).Exec(ctx); err != nil {
+		return err
+	}
+	if len(shipment.Items) == 0 {
+		return nil
+	}
+	_, err := db.NewInsert().Model(&shipment.Items).Exec(ctx)
+	return err
+}
+
+func (r *shipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Shipment, error) {
+	shipment := new(domain.Shipment)
+	err := conn(ctx, r.db).NewSelect().
+		Model(shipment).
+		Relation("Items").
+		Where("sh.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return shipment, nil
+}
+
+func (r *shipmentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.Shipment, error) {
+	var shipments []*domain.Shipment
+	err := conn(ctx, r.db).NewSelect().
+		Model(&shipments).
+		Relation("Items").
+		Where("sh.order_id = ?", orderID).
+		Order("sh.created_at ASC").
+		Scan(ctx)
+	return shipments, err
+}
+
+func (r *shipmentRepository) Update(ctx context.Context, shipment *domain.Shipment) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(shipment).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type shippingMethodRepository struct {
+	db *bun.DB
+}
+
+// NewShippingMethodRepository creates a new shipping method repository
+func NewShippingMethodRepository(db *bun.DB) ports.ShippingMethodRepository {
+	return &shippingMethodRepository{
+		db: db,
+	}
+}
+
+func (r *shippingMethodRepository) Create(ctx context.Context, method *domain.ShippingMethod) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(method).Exec(ctx)
+	return err
+}
+
+func (r *shippingMethodRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ShippingMethod, error) {
+	method := new(domain.ShippingMethod)
+	err := conn(ctx, r.db).NewSelect().
+		Model(method).
+		Where("sm.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return method, nil
+}
+
+func (r *shippingMethodRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.ShippingMethod], error) {
+	var methods []*domain.ShippingMethod
+	q := conn(ctx, r.db).NewSelect().
+		Model(&methods)
+	return selectPage(ctx, q, &methods, page, shippingMethodSortColumns)
+}
+
+func (r *shippingMethodRepository) GetActive(ctx context.Context) ([]*domain.ShippingMethod, error) {
+	var methods []*domain.ShippingMethod
+	err := conn(ctx, r.db).NewSelect().
+		Model(&methods).
+		Where("sm.is_active = ?", true).
+		Order("sm.name ASC").
+		Scan(ctx)
+	return methods, err
+}
+
+func (r *shippingMethodRepository) Update(ctx context.Context, method *domain.ShippingMethod) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(method).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+func (r *shippingMethodRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewDelete().
+		Model((*domain.ShippingMethod)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type stockMovementRepository struct {
+	db *bun.DB
+}
+
+// NewStockMovementRepository creates a new stock movement repository
+func NewStockMovementRepository(db *bun.DB) ports.StockMovementRepository {
+	return &stockMovementRepository{
+		db: db,
+	}
+}
+
+func (r *stockMovementRepository) GetByProductID(ctx context.Context, productID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.StockMovement], error) {
+	var movements []*domain.StockMovement
+	q := conn(ctx, r.db).NewSelect().
+		Model(&movements).
+		Where("sm.product_id = ?", productID)
+	return selectPage(ctx, q, &movements, page, stockMovementSortColumns)
+}
+
+// GetDiscrepancies returns every product whose stock, stock level at any warehouse, or
+// stock of any of its variants differs from the sum of its ledger
+func (r *stockMovementRepository) GetDiscrepancies(ctx context.Context) ([]*domain.StockDiscrepancy, error) {
+	var discrepancies []*domain.StockDiscrepancy
+	err := conn(ctx, r.db).NewRaw(`
+		SELECT p.id AS product_id, p.sku, p.stock, COALESCE(l.ledger_stock, 0) AS ledger_stock
+		FROM products AS p
+		LEFT JOIN (
+			SELECT product_id, SUM(delta) AS ledger_stock FROM stock_movements GROUP BY product_id
+		) AS l ON l.product_id = p.id
+		WHERE p.stock <> COALESCE(l.ledger_stock, 0)
+			OR EXISTS (
+				SELECT 1 FROM stock_levels AS sl
+				WHERE sl.product_id = p.id AND sl.quantity <> (
+					SELECT COALESCE(SUM(sm.delta), 0) FROM stock_movements AS sm
+					WHERE sm.product_id = sl.product_id AND sm.warehouse_id = sl.warehouse_id
+				)
+			)
+			OR EXISTS (
+				SELECT 1 FROM product_variants AS pv
+				WHERE pv.product_id = p.id AND pv.stock <> (
+					SELECT COALESCE(SUM(sm.delta), 0) FROM stock_movements AS sm
+					WHERE sm.variant_id = pv.id
+				)
+			)
+		ORDER BY p.sku`).
+		Scan(ctx, &discrepancies)
+	return discrepancies, err
+}
+
+// SyncStock sets the product stock, its stock level at each warehouse and the stock of each
+// of its variants to the sum of its ledger
+func (r *stockMovementRepository) SyncStock(ctx context.Context, productID uuid.UUID) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		_, err := db.NewUpdate().
+			Model((*domain.Product)(nil)).
+			Set("stock = (SELECT COALESCE(SUM(delta), 0) FROM stock_movements WHERE product_id = ?)", productID).
+			Set("updated_at = CURRENT_TIMESTAMP").
+			Where("id = ?", productID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.NewRaw(`
+			INSERT INTO stock_levels (product_id, warehouse_id, quantity)
+			SELECT ?::uuid, w.id, COALESCE(SUM(sm.delta), 0)
+			FROM warehouses AS w
+			LEFT JOIN stock_movements AS sm ON sm.warehouse_id = w.id AND sm.product_id = ?
+			WHERE w.id IN (
+				SELECT warehouse_id FROM stock_movements WHERE product_id = ?
+				UNION SELECT warehouse_id FROM stock_levels WHERE product_id = ?
+			)
+			GROUP BY w.id
+			ON CONFLICT (product_id, warehouse_id) DO UPDATE
+			SET quantity = EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP`,
+			productID, productID, productID, productID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.NewUpdate().
+			Model((*domain.ProductVariant)(nil)).
+			Set("stock = (SELECT COALESCE(SUM(sm.delta), 0) FROM stock_movements AS sm WHERE sm.variant_id = pv.id)").
+			Set("updated_at = CURRENT_TIMESTAMP").
+			Where("pv.product_id = ?", productID).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type supplierRepository struct {
+	db *bun.DB
+}
+
+// NewSupplierRepository creates a new supplier repository
+func NewSupplierRepository(db *bun.DB) ports.SupplierRepository {
+	return &supplierRepository{
+		db: db,
+	}
+}
+
+func (r *supplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(supplier).Exec(ctx)
+	return err
+}
+
+func (r *supplierRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error) {
+	supplier := new(domain.Supplier)
+	err := conn(ctx, r.db).NewSelect().
+		Model(supplier).
+		Where("su.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (r *supplierRepository) GetByName(ctx context.Context, name string) (*domain.Supplier, error) {
+	supplier := new(domain.Supplier)
+	err := conn(ctx, r.db).NewSelect().
+		Model(supplier).
+		Where("su.name = ?", name).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (r *supplierRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Supplier], error) {
+	var suppliers []*domain.Supplier
+	q := conn(ctx, r.db).NewSelect().
+		Model(&suppliers)
+	return selectPage(ctx, q, &suppliers, page, supplierSortColumns)
+}
+
+func (r *supplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(supplier).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type taxRateRepository struct {
+	db *bun.DB
+}
+
+// NewTaxRateRepository creates a new tax rate repository
+func NewTaxRateRepository(db *bun.DB) ports.TaxRateRepository {
+	return &taxRateRepository{
+		db: db,
+	}
+}
+
+func (r *taxRateRepository) Create(ctx context.Context, rate *domain.TaxRate) error {
+	_, err := conn(ctx, r.db).NewInsert().Model(rate).Exec(ctx)
+	return err
+}
+
+func (r *taxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error) {
+	rate := new(domain.TaxRate)
+	err := conn(ctx, r.db).NewSelect().
+		Model(rate).
+		Where("tr.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (r *taxRateRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.TaxRate], error) {
+	var rates []*domain.TaxRate
+	q := conn(ctx, r.db).NewSelect().
+		Model(&rates)
+	return selectPage(ctx, q, &rates, page, taxRateSortColumns)
+}
+
+func (r *taxRateRepository) GetByCountry(ctx context.Context, country string) ([]*domain.TaxRate, error) {
+	var rates []*domain.TaxRate
+	err := conn(ctx, r.db).NewSelect().
+		Model(&rates).
+		Where("tr.country = ?", country).
+		Scan(ctx)
+	return rates, err
+}
+
+func (r *taxRateRepository) Update(ctx context.Context, rate *domain.TaxRate) error {
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model(rate).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+func (r *taxRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).NewDelete().
+		Model((*domain.TaxRate)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
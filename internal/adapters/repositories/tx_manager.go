@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/uptrace/bun"
+)
+
+// txContextKey is the key used to store the active transaction in context
+type txContextKey struct{}
+
+type txManager struct {
+	db *bun.DB
+}
+
+// NewTxManager creates a new transaction manager backed by bun
+func NewTxManager(db *bun.DB) ports.TxManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithinTx runs fn inside a transaction, committing when fn returns nil and
+// rolling back otherwise. Nested calls reuse the outer transaction.
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, m.db, fn)
+}
+
+// withinTx runs fn in the transaction bound to ctx, starting one on db if there is none.
+// Repositories use it for writes spanning several statements.
+func withinTx(ctx context.Context, db *bun.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(bun.Tx); ok {
+		return fn(ctx)
+	}
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// conn returns the transaction bound to ctx, falling back to db
+func conn(ctx context.Context, db *bun.DB) bun.IDB {
+	if tx, ok := ctx.Value(txContextKey{}).(bun.Tx); ok {
+		return tx
+	}
+	return db
+}
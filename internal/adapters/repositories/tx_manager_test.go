@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxManager_WithinTx(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	manager := NewTxManager(db)
+	ctx := context.Background()
+
+	insertUser := func(ctx context.Context, email string) error {
+		user := &TestUser{
+			ID:        uuid.New(),
+			Name:      "Tx User",
+			Email:     email,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		_, err := conn(ctx, db).NewInsert().Model(user).Exec(ctx)
+		return err
+	}
+
+	countUsers := func(email string) int {
+		count, err := db.NewSelect().Model((*TestUser)(nil)).Where("email = ?", email).Count(ctx)
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("commits when fn succeeds", func(t *testing.T) {
+		err := manager.WithinTx(ctx, func(ctx context.Context) error {
+			return insertUser(ctx, "commit@example.com")
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, countUsers("commit@example.com"))
+	})
+
+	t.Run("rolls back when fn fails", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		err := manager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := insertUser(ctx, "rollback@example.com"); err != nil {
+				return err
+			}
+			return errBoom
+		})
+
+		assert.ErrorIs(t, err, errBoom)
+		assert.Equal(t, 0, countUsers("rollback@example.com"))
+	})
+
+	t.Run("nested calls join the outer transaction", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		err := manager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := manager.WithinTx(ctx, func(ctx context.Context) error {
+				return insertUser(ctx, "nested@example.com")
+			}); err != nil {
+				return err
+			}
+			return errBoom
+		})
+
+		assert.ErrorIs(t, err, errBoom)
+		assert.Equal(t, 0, countUsers("nested@example.com"))
+	})
+}
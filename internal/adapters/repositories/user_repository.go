@@ -50,15 +50,11 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	return user, nil
 }
 
-func (r *userRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+func (r *userRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.User], error) {
 	var users []*domain.User
-	err := r.db.NewSelect().
-		Model(&users).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Scan(ctx)
-	return users, err
+	q := r.db.NewSelect().
+		Model(&users)
+	return selectPage(ctx, q, &users, page, userSortColumns)
 }
 
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
@@ -75,4 +71,34 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		Where("id = ?", id).
 		Exec(ctx)
 	return err
-}
\ No newline at end of file
+}
+
+// Search returns the users whose name or email has words starting with every term,
+// best match first
+func (r *userRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.UserSearchResult, error) {
+	matches, err := searchMatches(ctx, r.db, (*domain.User)(nil), "concat_ws(' ', u.name, u.email)", terms, limit, offset)
+	if err != nil || len(matches) == 0 {
+		return []*domain.UserSearchResult{}, err
+	}
+
+	var users []*domain.User
+	err = r.db.NewSelect().
+		Model(&users).
+		Where("u.id IN (?)", bun.In(matchIDs(matches))).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*domain.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	results := make([]*domain.UserSearchResult, 0, len(matches))
+	for _, match := range matches {
+		if user, ok := byID[match.ID]; ok {
+			results = append(results, &domain.UserSearchResult{User: user, Rank: match.Rank, Highlight: match.Highlight})
+		}
+	}
+	return results, nil
+}
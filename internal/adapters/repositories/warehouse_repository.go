@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type warehouseRepository struct {
+	db *bun.DB
+}
+
+// NewWarehouseRepository creates a new warehouse repository
+func NewWarehouseRepository(db *bun.DB) ports.WarehouseRepository {
+	return &warehouseRepository{
+		db: db,
+	}
+}
+
+func (r *warehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		if err := r.clearDefault(ctx, warehouse); err != nil {
+			return err
+		}
+		_, err := conn(ctx, r.db).NewInsert().Model(warehouse).Exec(ctx)
+		return err
+	})
+}
+
+func (r *warehouseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error) {
+	warehouse := new(domain.Warehouse)
+	err := conn(ctx, r.db).NewSelect().
+		Model(warehouse).
+		Where("w.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+func (r *warehouseRepository) GetByCode(ctx context.Context, code string) (*domain.Warehouse, error) {
+	warehouse := new(domain.Warehouse)
+	err := conn(ctx, r.db).NewSelect().
+		Model(warehouse).
+		Where("w.code = ?", code).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+func (r *warehouseRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Warehouse], error) {
+	var warehouses []*domain.Warehouse
+	q := conn(ctx, r.db).NewSelect().
+		Model(&warehouses)
+	return selectPage(ctx, q, &warehouses, page, warehouseSortColumns)
+}
+
+func (r *warehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		if err := r.clearDefault(ctx, warehouse); err != nil {
+			return err
+		}
+		_, err := conn(ctx, r.db).NewUpdate().
+			Model(warehouse).
+			WherePK().
+			Exec(ctx)
+		return err
+	})
+}
+
+// clearDefault unmarks the current default warehouse when warehouse is to become the default
+func (r *warehouseRepository) clearDefault(ctx context.Context, warehouse *domain.Warehouse) error {
+	if !warehouse.IsDefault {
+		return nil
+	}
+	_, err := conn(ctx, r.db).NewUpdate().
+		Model((*domain.Warehouse)(nil)).
+		Set("is_default = false").
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Where("is_default").
+		Where("id <> ?", warehouse.ID).
+		Exec(ctx)
+	return err
+}
+
+type stockLevelRepository struct {
+	db *bun.DB
+}
+
+// NewStockLevelRepository creates a new stock level repository
+func NewStockLevelRepository(db *bun.DB) ports.StockLevelRepository {
+	return &stockLevelRepository{
+		db: db,
+	}
+}
+
+func (r *stockLevelRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*domain.StockLevel, error) {
+	var levels []*domain.StockLevel
+	err := conn(ctx, r.db).NewSelect().
+		Model(&levels).
+		Relation("Warehouse").
+		Where("sl.product_id = ?", productID).
+		Order("sl.quantity DESC").
+		Scan(ctx)
+	return levels, err
+}
+
+func (r *stockLevelRepository) GetByWarehouseID(ctx context.Context, warehouseID uuid.UUID, limit, offset int) ([]*domain.StockLevel, error) {
+	var levels []*domain.StockLevel
+	err := conn(ctx, r.db).NewSelect().
+		Model(&levels).
+		Relation("Warehouse").
+		Where("sl.warehouse_id = ?", warehouseID).
+		Order("sl.quantity DESC", "sl.product_id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	return levels, err
+}
+
+func (r *stockLevelRepository) Set(ctx context.Context, productID, warehouseID uuid.UUID, quantity int) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+		if err := lockProduct(ctx, db, productID); err != nil {
+			return err
+		}
+
+		var current int
+		err := db.NewSelect().
+			Model((*domain.StockLevel)(nil)).
+			Column("quantity").
+			Where("product_id = ?", productID).
+			Where("warehouse_id = ?", warehouseID).
+			Scan(ctx, &current)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if quantity == current {
+			return nil
+		}
+
+		movement := domain.NewStockMovement(productID, quantity-current, domain.StockMovementReasonAdjustment, nil)
+		return applyStockMovement(ctx, r.db, movement.InWarehouse(warehouseID))
+	})
+}
+
+func (r *stockLevelRepository) GetOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*domain.StockAllocation, error) {
+	var allocations []*domain.StockAllocation
+	err := conn(ctx, r.db).NewSelect().
+		Model((*domain.StockMovement)(nil)).
+		Column("product_id", "warehouse_id").
+		ColumnExpr("-SUM(delta) AS quantity").
+		Where("order_id = ?", orderID).
+		Where("warehouse_id IS NOT NULL").
+		Where("reason IN (?)", bun.In([]domain.StockMovementReason{
+			domain.StockMovementReasonSale,
+			domain.StockMovementReasonOrderEdit,
+			domain.StockMovementReasonCancel,
+		})).
+		Group("product_id", "warehouse_id").
+		Having("SUM(delta) < 0").
+		Order("product_id", "quantity DESC").
+		Scan(ctx, &allocations)
+	return allocations, err
+}
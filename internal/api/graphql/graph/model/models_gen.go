@@ -11,6 +11,46 @@ import (
 	"time"
 )
 
+// Input for filtering products by an attribute
+type AttributeFilterInput struct {
+	// Attribute name
+	Name string `json:"name"`
+	// Accepted values
+	Values []string `json:"values"`
+}
+
+// A page of categories
+type CategoryConnection struct {
+	Edges    []*CategoryEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+	// Number of categories in the whole list
+	TotalCount int32 `json:"totalCount"`
+}
+
+type CategoryEdge struct {
+	// Opaque cursor of the node, usable as `after`
+	Cursor string           `json:"cursor"`
+	Node   *domain.Category `json:"node"`
+}
+
+// One term of a sort of categories; later terms break the ties of earlier ones
+type CategoryOrderBy struct {
+	// Field to sort by
+	Field CategorySortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
+}
+
+// The category a slug path leads to
+type CategoryPathResult struct {
+	// The category found
+	Category *domain.Category `json:"category"`
+	// Current slug path of the category, e.g. electronics/phones
+	CanonicalPath string `json:"canonicalPath"`
+	// Whether the path used an old slug or casing, so that clients should move to canonicalPath
+	Redirected bool `json:"redirected"`
+}
+
 // Input for creating a new category
 type CreateCategoryInput struct {
 	// Category name
@@ -19,6 +59,16 @@ type CreateCategoryInput struct {
 	Description *string `json:"description,omitempty"`
 	// Parent category ID (optional, null for root categories)
 	ParentID *string `json:"parentId,omitempty"`
+	// URL slug (optional, generated from the name when omitted)
+	Slug *string `json:"slug,omitempty"`
+	// Order among siblings (default: 0)
+	Position *int32 `json:"position,omitempty"`
+	// Image shown for the category on the storefront (absolute http(s) URL)
+	ImageURL *string `json:"imageUrl,omitempty"`
+	// Title for search engines (at most 70 characters)
+	MetaTitle *string `json:"metaTitle,omitempty"`
+	// Description for search engines (at most 320 characters)
+	MetaDescription *string `json:"metaDescription,omitempty"`
 }
 
 // Input for creating a new customer
@@ -49,18 +99,30 @@ type CreateOrderInput struct {
 	CustomerID string `json:"customerId"`
 	// Shipping address
 	ShippingAddress string `json:"shippingAddress"`
+	// Country the order ships to (default: the customer's country)
+	ShippingCountry *string `json:"shippingCountry,omitempty"`
+	// City the order ships to (default: the customer's city when shipping to their country)
+	ShippingCity *string `json:"shippingCity,omitempty"`
+	// Shipping method to ship the order by (optional)
+	ShippingMethodID *string `json:"shippingMethodId,omitempty"`
 	// Billing address
 	BillingAddress string `json:"billingAddress"`
 	// Additional notes (optional)
 	Notes *string `json:"notes,omitempty"`
-	// Items to include in the order
-	OrderItems []*CreateOrderItemInput `json:"orderItems"`
+	// Items to include in the order (optional when converting reservations)
+	OrderItems []*CreateOrderItemInput `json:"orderItems,omitempty"`
+	// Reservations to convert into order items (optional)
+	ReservationIds []string `json:"reservationIds,omitempty"`
+	// Coupon codes to redeem (optional)
+	CouponCodes []string `json:"couponCodes,omitempty"`
 }
 
 // Input for creating an order item
 type CreateOrderItemInput struct {
 	// Product ID for this item
 	ProductID string `json:"productId"`
+	// Variant ID (required for products with variants)
+	VariantID *string `json:"variantId,omitempty"`
 	// Quantity to order
 	Quantity int32 `json:"quantity"`
 }
@@ -74,13 +136,125 @@ type CreateProductInput struct {
 	// Stock Keeping Unit (must be unique)
 	Sku string `json:"sku"`
 	// Product price
-	Price float64 `json:"price"`
+	Price domain.Money `json:"price"`
 	// Initial stock quantity
 	Stock int32 `json:"stock"`
 	// Category ID this product belongs to
 	CategoryID string `json:"categoryId"`
 	// Whether the product is active (defaults to true)
 	IsActive *bool `json:"isActive,omitempty"`
+	// Stock level below which the product should be reordered (defaults to 10)
+	ReorderPoint *int32 `json:"reorderPoint,omitempty"`
+	// Quantity to order when the product is reordered (defaults to 0)
+	ReorderQuantity *int32 `json:"reorderQuantity,omitempty"`
+	// Weight of one packed unit in grams (optional)
+	WeightGrams *int32 `json:"weightGrams,omitempty"`
+	// Length of the package in millimetres (optional)
+	LengthMm *int32 `json:"lengthMm,omitempty"`
+	// Width of the package in millimetres (optional)
+	WidthMm *int32 `json:"widthMm,omitempty"`
+	// Height of the package in millimetres (optional)
+	HeightMm *int32 `json:"heightMm,omitempty"`
+	// Free-form attributes
+	Attributes []*ProductAttributeInput `json:"attributes,omitempty"`
+}
+
+// Input for adding a variant to a product
+type CreateProductVariantInput struct {
+	// Stock Keeping Unit (unique across products and variants)
+	Sku string `json:"sku"`
+	// Option values that set the variant apart
+	Options []*VariantOptionInput `json:"options"`
+	// Price override (defaults to the product price)
+	Price *domain.Money `json:"price,omitempty"`
+	// Initial stock quantity
+	Stock int32 `json:"stock"`
+	// Whether the variant is active (defaults to true)
+	IsActive *bool `json:"isActive,omitempty"`
+}
+
+// Input for creating a draft purchase order
+type CreatePurchaseOrderInput struct {
+	// Supplier to order from
+	SupplierID string `json:"supplierId"`
+	// Warehouse receiving the stock (optional, defaults to the default warehouse)
+	WarehouseID *string `json:"warehouseId,omitempty"`
+	// Date the supplier is expected to deliver (optional)
+	ExpectedDate *time.Time `json:"expectedDate,omitempty"`
+	// Free-form notes (optional)
+	Notes *string `json:"notes,omitempty"`
+	// Products to order
+	Lines []*CreatePurchaseOrderLineInput `json:"lines"`
+}
+
+// Input for a product to order on a purchase order
+type CreatePurchaseOrderLineInput struct {
+	// Product to order
+	ProductID string `json:"productId"`
+	// Variant to order; required for products with variants
+	VariantID *string `json:"variantId,omitempty"`
+	// Quantity to order
+	Quantity int32 `json:"quantity"`
+	// Cost per unit
+	UnitCost domain.Money `json:"unitCost"`
+}
+
+// Input for holding stock during checkout
+type CreateReservationInput struct {
+	// Product to hold
+	ProductID string `json:"productId"`
+	// Customer the stock is held for
+	CustomerID string `json:"customerId"`
+	// Quantity to hold
+	Quantity int32 `json:"quantity"`
+	// Seconds to hold the stock for (optional, defaults to the configured TTL)
+	TTLSeconds *int32 `json:"ttlSeconds,omitempty"`
+}
+
+// Input for requesting a return
+type CreateReturnInput struct {
+	// Reason for the return
+	Reason string `json:"reason"`
+	// Order items and quantities to return
+	Items []*CreateReturnItemInput `json:"items"`
+}
+
+// Input for an order item quantity to return
+type CreateReturnItemInput struct {
+	// Order item ID
+	OrderItemID string `json:"orderItemId"`
+	// Quantity to return
+	Quantity int32 `json:"quantity"`
+}
+
+// Input for creating a shipment. When items is omitted, every unshipped unit of the order is included.
+type CreateShipmentInput struct {
+	// Carrier handling the parcel
+	Carrier string `json:"carrier"`
+	// Carrier tracking number (optional)
+	TrackingNumber *string `json:"trackingNumber,omitempty"`
+	// Order items and quantities to include
+	Items []*CreateShipmentItemInput `json:"items,omitempty"`
+}
+
+// Input for an order item quantity to include in a shipment
+type CreateShipmentItemInput struct {
+	// Order item ID
+	OrderItemID string `json:"orderItemId"`
+	// Quantity to ship
+	Quantity int32 `json:"quantity"`
+}
+
+// Input for creating a supplier
+type CreateSupplierInput struct {
+	// Supplier name
+	Name string `json:"name"`
+	// Contact email address (optional)
+	Email *string `json:"email,omitempty"`
+	// Contact phone number (optional)
+	Phone *string `json:"phone,omitempty"`
+	// Postal address (optional)
+	Address *string `json:"address,omitempty"`
 }
 
 // Input for creating a new user
@@ -91,6 +265,42 @@ type CreateUserInput struct {
 	Email string `json:"email"`
 }
 
+// Input for creating a warehouse
+type CreateWarehouseInput struct {
+	// Short unique code, e.g. NBO
+	Code string `json:"code"`
+	// Warehouse name
+	Name string `json:"name"`
+	// City the warehouse is in
+	City string `json:"city"`
+	// Country the warehouse is in
+	Country string `json:"country"`
+	// Make this the default warehouse (optional)
+	IsDefault *bool `json:"isDefault,omitempty"`
+}
+
+// A page of customers
+type CustomerConnection struct {
+	Edges    []*CustomerEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+	// Number of customers in the whole list
+	TotalCount int32 `json:"totalCount"`
+}
+
+type CustomerEdge struct {
+	// Opaque cursor of the node, usable as `after`
+	Cursor string           `json:"cursor"`
+	Node   *domain.Customer `json:"node"`
+}
+
+// One term of a sort of customers; later terms break the ties of earlier ones
+type CustomerOrderBy struct {
+	// Field to sort by
+	Field CustomerSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
+}
+
 // Customer order summary
 type CustomerOrderSummary struct {
 	// Customer information
@@ -98,7 +308,7 @@ type CustomerOrderSummary struct {
 	// Total orders placed
 	TotalOrders int32 `json:"totalOrders"`
 	// Total amount spent
-	TotalSpent float64 `json:"totalSpent"`
+	TotalSpent domain.Money `json:"totalSpent"`
 	// Last order date
 	LastOrderDate *time.Time `json:"lastOrderDate,omitempty"`
 }
@@ -119,6 +329,20 @@ type CustomerStats struct {
 type Mutation struct {
 }
 
+// A page of orders
+type OrderConnection struct {
+	Edges    []*OrderEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+	// Number of orders in the whole list
+	TotalCount int32 `json:"totalCount"`
+}
+
+type OrderEdge struct {
+	// Opaque cursor of the node, usable as `after`
+	Cursor string        `json:"cursor"`
+	Node   *domain.Order `json:"node"`
+}
+
 // Input for filtering orders
 type OrderFilterInput struct {
 	// Filter by customer ID
@@ -131,20 +355,38 @@ type OrderFilterInput struct {
 	EndDate *time.Time `json:"endDate,omitempty"`
 }
 
+// Desired quantity of a product on an order. New products are added and a quantity of 0 removes the product.
+type OrderItemChangeInput struct {
+	// Product ID
+	ProductID string `json:"productId"`
+	// Variant ID (required for products with variants)
+	VariantID *string `json:"variantId,omitempty"`
+	// Desired quantity (0 removes the item)
+	Quantity int32 `json:"quantity"`
+}
+
+// One term of a sort of orders; later terms break the ties of earlier ones
+type OrderOrderBy struct {
+	// Field to sort by
+	Field OrderSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
+}
+
 // Order statistics
 type OrderStats struct {
 	// Total number of orders
 	TotalOrders int32 `json:"totalOrders"`
 	// Total revenue
-	TotalRevenue float64 `json:"totalRevenue"`
+	TotalRevenue domain.Money `json:"totalRevenue"`
 	// Orders by status
 	OrdersByStatus []*OrderStatusCount `json:"ordersByStatus"`
 	// Average order value
-	AverageOrderValue float64 `json:"averageOrderValue"`
+	AverageOrderValue domain.Money `json:"averageOrderValue"`
 	// Orders today
 	OrdersToday int32 `json:"ordersToday"`
 	// Revenue today
-	RevenueToday float64 `json:"revenueToday"`
+	RevenueToday domain.Money `json:"revenueToday"`
 }
 
 // Order count by status
@@ -155,6 +397,16 @@ type OrderStatusCount struct {
 	Count int32 `json:"count"`
 }
 
+// Paging details of a connection. Lists are ordered newest first; pass endCursor as
+// `after` to fetch the next page.
+type PageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
+	// Always false: connections only page forwards
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 // Input for filtering and pagination
 type PaginationInput struct {
 	// Maximum number of items to return (default: 10, max: 100)
@@ -163,20 +415,52 @@ type PaginationInput struct {
 	Offset *int32 `json:"offset,omitempty"`
 }
 
+// Input for a product attribute
+type ProductAttributeInput struct {
+	// Attribute name
+	Name string `json:"name"`
+	// Attribute value
+	Value string `json:"value"`
+}
+
+// A page of products
+type ProductConnection struct {
+	Edges    []*ProductEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+	// Number of products in the whole list
+	TotalCount int32 `json:"totalCount"`
+}
+
+type ProductEdge struct {
+	// Opaque cursor of the node, usable as `after`
+	Cursor string          `json:"cursor"`
+	Node   *domain.Product `json:"node"`
+}
+
 // Input for filtering products
 type ProductFilterInput struct {
 	// Filter by category ID
 	CategoryID *string `json:"categoryId,omitempty"`
 	// Filter by active status
 	IsActive *bool `json:"isActive,omitempty"`
-	// Search by name or description
+	// Full-text search of name, SKU or description (words may be partial)
 	Search *string `json:"search,omitempty"`
-	// Minimum price
-	MinPrice *float64 `json:"minPrice,omitempty"`
-	// Maximum price
-	MaxPrice *float64 `json:"maxPrice,omitempty"`
-	// Minimum stock
+	// Minimum price (only products priced in its currency match)
+	MinPrice *domain.Money `json:"minPrice,omitempty"`
+	// Maximum price (only products priced in its currency match)
+	MaxPrice *domain.Money `json:"maxPrice,omitempty"`
+	// Minimum on-hand stock
 	MinStock *int32 `json:"minStock,omitempty"`
+	// Attribute filters; every attribute must match one of its values
+	Attributes []*AttributeFilterInput `json:"attributes,omitempty"`
+}
+
+// One term of a sort of products; later terms break the ties of earlier ones
+type ProductOrderBy struct {
+	// Field to sort by
+	Field ProductSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
 }
 
 // Product statistics
@@ -187,26 +471,66 @@ type ProductStats struct {
 	ActiveProducts int32 `json:"activeProducts"`
 	// Inactive products
 	InactiveProducts int32 `json:"inactiveProducts"`
-	// Low stock products (stock < 10)
+	// Low stock products (stock below their reorder point)
 	LowStockProducts int32 `json:"lowStockProducts"`
 	// Out of stock products
 	OutOfStockProducts int32 `json:"outOfStockProducts"`
 	// Total inventory value
-	TotalInventoryValue float64 `json:"totalInventoryValue"`
+	TotalInventoryValue domain.Money `json:"totalInventoryValue"`
+}
+
+// One term of a sort of purchase orders; later terms break the ties of earlier ones
+type PurchaseOrderOrderBy struct {
+	// Field to sort by
+	Field PurchaseOrderSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
 }
 
 // Root query type providing read access to all entities
 type Query struct {
 }
 
+// Input for receiving stock against a purchase order. When lines is omitted, every outstanding unit is received.
+type ReceivePurchaseOrderInput struct {
+	// Lines and quantities received
+	Lines []*ReceivePurchaseOrderLineInput `json:"lines,omitempty"`
+}
+
+// Input for a quantity received against a purchase order line
+type ReceivePurchaseOrderLineInput struct {
+	// Purchase order line received against
+	LineID string `json:"lineId"`
+	// Quantity received
+	Quantity int32 `json:"quantity"`
+}
+
+// One term of a sort of suppliers; later terms break the ties of earlier ones
+type SupplierOrderBy struct {
+	// Field to sort by
+	Field SupplierSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
+}
+
 // Input for updating an existing category
 type UpdateCategoryInput struct {
 	// Category name
 	Name *string `json:"name,omitempty"`
 	// Category description
 	Description *string `json:"description,omitempty"`
-	// Parent category ID (null for root categories)
+	// New parent category ID; the nil UUID moves the category to the root
 	ParentID *string `json:"parentId,omitempty"`
+	// New URL slug; the old slug keeps leading to the category
+	Slug *string `json:"slug,omitempty"`
+	// Order among siblings
+	Position *int32 `json:"position,omitempty"`
+	// Image shown for the category on the storefront (absolute http(s) URL)
+	ImageURL *string `json:"imageUrl,omitempty"`
+	// Title for search engines (at most 70 characters)
+	MetaTitle *string `json:"metaTitle,omitempty"`
+	// Description for search engines (at most 320 characters)
+	MetaDescription *string `json:"metaDescription,omitempty"`
 }
 
 // Input for updating an existing customer
@@ -233,6 +557,8 @@ type UpdateCustomerInput struct {
 type UpdateOrderInput struct {
 	// Order status
 	Status *domain.OrderStatus `json:"status,omitempty"`
+	// Reason for the status change (optional)
+	StatusReason *string `json:"statusReason,omitempty"`
 	// Shipping address
 	ShippingAddress *string `json:"shippingAddress,omitempty"`
 	// Billing address
@@ -245,6 +571,12 @@ type UpdateOrderInput struct {
 	DeliveredDate *time.Time `json:"deliveredDate,omitempty"`
 }
 
+// Input for changing the items of a pending or confirmed order
+type UpdateOrderItemsInput struct {
+	// Desired quantities; products not listed are left unchanged
+	Items []*OrderItemChangeInput `json:"items"`
+}
+
 // Input for updating an existing product
 type UpdateProductInput struct {
 	// Product name
@@ -254,13 +586,77 @@ type UpdateProductInput struct {
 	// Stock Keeping Unit (must be unique)
 	Sku *string `json:"sku,omitempty"`
 	// Product price
-	Price *float64 `json:"price,omitempty"`
+	Price *domain.Money `json:"price,omitempty"`
 	// Stock quantity
 	Stock *int32 `json:"stock,omitempty"`
 	// Category ID this product belongs to
 	CategoryID *string `json:"categoryId,omitempty"`
 	// Whether the product is active
 	IsActive *bool `json:"isActive,omitempty"`
+	// Stock level below which the product should be reordered
+	ReorderPoint *int32 `json:"reorderPoint,omitempty"`
+	// Quantity to order when the product is reordered
+	ReorderQuantity *int32 `json:"reorderQuantity,omitempty"`
+	// Weight of one packed unit in grams
+	WeightGrams *int32 `json:"weightGrams,omitempty"`
+	// Length of the package in millimetres
+	LengthMm *int32 `json:"lengthMm,omitempty"`
+	// Width of the package in millimetres
+	WidthMm *int32 `json:"widthMm,omitempty"`
+	// Height of the package in millimetres
+	HeightMm *int32 `json:"heightMm,omitempty"`
+	// Free-form attributes; replace the existing attributes when given
+	Attributes []*ProductAttributeInput `json:"attributes,omitempty"`
+}
+
+// Input for updating a variant
+type UpdateProductVariantInput struct {
+	// Stock Keeping Unit (unique across products and variants)
+	Sku *string `json:"sku,omitempty"`
+	// Option values that set the variant apart
+	Options []*VariantOptionInput `json:"options,omitempty"`
+	// Price override
+	Price *domain.Money `json:"price,omitempty"`
+	// Stock quantity
+	Stock *int32 `json:"stock,omitempty"`
+	// Whether the variant is active
+	IsActive *bool `json:"isActive,omitempty"`
+}
+
+// Input for updating a purchase order. Lines can only be replaced while it is a draft.
+type UpdatePurchaseOrderInput struct {
+	// Warehouse receiving the stock
+	WarehouseID *string `json:"warehouseId,omitempty"`
+	// Date the supplier is expected to deliver
+	ExpectedDate *time.Time `json:"expectedDate,omitempty"`
+	// Free-form notes
+	Notes *string `json:"notes,omitempty"`
+	// Replacement lines
+	Lines []*CreatePurchaseOrderLineInput `json:"lines,omitempty"`
+}
+
+// Input for updating an existing shipment
+type UpdateShipmentInput struct {
+	// Carrier handling the parcel
+	Carrier *string `json:"carrier,omitempty"`
+	// Carrier tracking number
+	TrackingNumber *string `json:"trackingNumber,omitempty"`
+	// Shipment status
+	Status *domain.ShipmentStatus `json:"status,omitempty"`
+}
+
+// Input for updating a supplier
+type UpdateSupplierInput struct {
+	// Supplier name
+	Name *string `json:"name,omitempty"`
+	// Contact email address
+	Email *string `json:"email,omitempty"`
+	// Contact phone number
+	Phone *string `json:"phone,omitempty"`
+	// Postal address
+	Address *string `json:"address,omitempty"`
+	// Whether purchase orders can be raised against the supplier
+	IsActive *bool `json:"isActive,omitempty"`
 }
 
 // Input for updating an existing user
@@ -271,6 +667,58 @@ type UpdateUserInput struct {
 	Email *string `json:"email,omitempty"`
 }
 
+// Input for updating a warehouse
+type UpdateWarehouseInput struct {
+	// Warehouse name
+	Name *string `json:"name,omitempty"`
+	// City the warehouse is in
+	City *string `json:"city,omitempty"`
+	// Country the warehouse is in
+	Country *string `json:"country,omitempty"`
+	// Make this the default warehouse
+	IsDefault *bool `json:"isDefault,omitempty"`
+	// Whether orders can be allocated from this warehouse
+	IsActive *bool `json:"isActive,omitempty"`
+}
+
+// A page of users
+type UserConnection struct {
+	Edges    []*UserEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+	// Number of users in the whole list
+	TotalCount int32 `json:"totalCount"`
+}
+
+type UserEdge struct {
+	// Opaque cursor of the node, usable as `after`
+	Cursor string       `json:"cursor"`
+	Node   *domain.User `json:"node"`
+}
+
+// One term of a sort of users; later terms break the ties of earlier ones
+type UserOrderBy struct {
+	// Field to sort by
+	Field UserSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
+}
+
+// Input for an option value of a variant
+type VariantOptionInput struct {
+	// Option name
+	Name string `json:"name"`
+	// Option value
+	Value string `json:"value"`
+}
+
+// One term of a sort of warehouses; later terms break the ties of earlier ones
+type WarehouseOrderBy struct {
+	// Field to sort by
+	Field WarehouseSortField `json:"field"`
+	// Direction of the sort (default: ASC)
+	Direction SortDirection `json:"direction"`
+}
+
 // Authentication scope
 type AuthScope string
 
@@ -328,3 +776,531 @@ func (e AuthScope) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+// Fields categories can be sorted by
+type CategorySortField string
+
+const (
+	CategorySortFieldName      CategorySortField = "NAME"
+	CategorySortFieldPosition  CategorySortField = "POSITION"
+	CategorySortFieldCreatedAt CategorySortField = "CREATED_AT"
+)
+
+var AllCategorySortField = []CategorySortField{
+	CategorySortFieldName,
+	CategorySortFieldPosition,
+	CategorySortFieldCreatedAt,
+}
+
+func (e CategorySortField) IsValid() bool {
+	switch e {
+	case CategorySortFieldName, CategorySortFieldPosition, CategorySortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e CategorySortField) String() string {
+	return string(e)
+}
+
+func (e *CategorySortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CategorySortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CategorySortField", str)
+	}
+	return nil
+}
+
+func (e CategorySortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CategorySortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CategorySortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields customers can be sorted by
+type CustomerSortField string
+
+const (
+	CustomerSortFieldFirstName CustomerSortField = "FIRST_NAME"
+	CustomerSortFieldLastName  CustomerSortField = "LAST_NAME"
+	CustomerSortFieldEmail     CustomerSortField = "EMAIL"
+	CustomerSortFieldCreatedAt CustomerSortField = "CREATED_AT"
+)
+
+var AllCustomerSortField = []CustomerSortField{
+	CustomerSortFieldFirstName,
+	CustomerSortFieldLastName,
+	CustomerSortFieldEmail,
+	CustomerSortFieldCreatedAt,
+}
+
+func (e CustomerSortField) IsValid() bool {
+	switch e {
+	case CustomerSortFieldFirstName, CustomerSortFieldLastName, CustomerSortFieldEmail, CustomerSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e CustomerSortField) String() string {
+	return string(e)
+}
+
+func (e *CustomerSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomerSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomerSortField", str)
+	}
+	return nil
+}
+
+func (e CustomerSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CustomerSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CustomerSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields orders can be sorted by
+type OrderSortField string
+
+const (
+	OrderSortFieldOrderNumber OrderSortField = "ORDER_NUMBER"
+	OrderSortFieldStatus      OrderSortField = "STATUS"
+	OrderSortFieldTotalAmount OrderSortField = "TOTAL_AMOUNT"
+	OrderSortFieldOrderDate   OrderSortField = "ORDER_DATE"
+	OrderSortFieldCreatedAt   OrderSortField = "CREATED_AT"
+)
+
+var AllOrderSortField = []OrderSortField{
+	OrderSortFieldOrderNumber,
+	OrderSortFieldStatus,
+	OrderSortFieldTotalAmount,
+	OrderSortFieldOrderDate,
+	OrderSortFieldCreatedAt,
+}
+
+func (e OrderSortField) IsValid() bool {
+	switch e {
+	case OrderSortFieldOrderNumber, OrderSortFieldStatus, OrderSortFieldTotalAmount, OrderSortFieldOrderDate, OrderSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e OrderSortField) String() string {
+	return string(e)
+}
+
+func (e *OrderSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderSortField", str)
+	}
+	return nil
+}
+
+func (e OrderSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields products can be sorted by
+type ProductSortField string
+
+const (
+	ProductSortFieldName      ProductSortField = "NAME"
+	ProductSortFieldSku       ProductSortField = "SKU"
+	ProductSortFieldPrice     ProductSortField = "PRICE"
+	ProductSortFieldStock     ProductSortField = "STOCK"
+	ProductSortFieldCreatedAt ProductSortField = "CREATED_AT"
+)
+
+var AllProductSortField = []ProductSortField{
+	ProductSortFieldName,
+	ProductSortFieldSku,
+	ProductSortFieldPrice,
+	ProductSortFieldStock,
+	ProductSortFieldCreatedAt,
+}
+
+func (e ProductSortField) IsValid() bool {
+	switch e {
+	case ProductSortFieldName, ProductSortFieldSku, ProductSortFieldPrice, ProductSortFieldStock, ProductSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e ProductSortField) String() string {
+	return string(e)
+}
+
+func (e *ProductSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductSortField", str)
+	}
+	return nil
+}
+
+func (e ProductSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProductSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProductSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields purchase orders can be sorted by
+type PurchaseOrderSortField string
+
+const (
+	PurchaseOrderSortFieldStatus    PurchaseOrderSortField = "STATUS"
+	PurchaseOrderSortFieldCreatedAt PurchaseOrderSortField = "CREATED_AT"
+)
+
+var AllPurchaseOrderSortField = []PurchaseOrderSortField{
+	PurchaseOrderSortFieldStatus,
+	PurchaseOrderSortFieldCreatedAt,
+}
+
+func (e PurchaseOrderSortField) IsValid() bool {
+	switch e {
+	case PurchaseOrderSortFieldStatus, PurchaseOrderSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e PurchaseOrderSortField) String() string {
+	return string(e)
+}
+
+func (e *PurchaseOrderSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PurchaseOrderSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PurchaseOrderSortField", str)
+	}
+	return nil
+}
+
+func (e PurchaseOrderSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PurchaseOrderSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PurchaseOrderSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Direction of a sort
+type SortDirection string
+
+const (
+	// Smallest first
+	SortDirectionAsc SortDirection = "ASC"
+	// Largest first
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SortDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SortDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields suppliers can be sorted by
+type SupplierSortField string
+
+const (
+	SupplierSortFieldName      SupplierSortField = "NAME"
+	SupplierSortFieldCreatedAt SupplierSortField = "CREATED_AT"
+)
+
+var AllSupplierSortField = []SupplierSortField{
+	SupplierSortFieldName,
+	SupplierSortFieldCreatedAt,
+}
+
+func (e SupplierSortField) IsValid() bool {
+	switch e {
+	case SupplierSortFieldName, SupplierSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e SupplierSortField) String() string {
+	return string(e)
+}
+
+func (e *SupplierSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SupplierSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SupplierSortField", str)
+	}
+	return nil
+}
+
+func (e SupplierSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SupplierSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SupplierSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields users can be sorted by
+type UserSortField string
+
+const (
+	UserSortFieldName      UserSortField = "NAME"
+	UserSortFieldEmail     UserSortField = "EMAIL"
+	UserSortFieldCreatedAt UserSortField = "CREATED_AT"
+)
+
+var AllUserSortField = []UserSortField{
+	UserSortFieldName,
+	UserSortFieldEmail,
+	UserSortFieldCreatedAt,
+}
+
+func (e UserSortField) IsValid() bool {
+	switch e {
+	case UserSortFieldName, UserSortFieldEmail, UserSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e UserSortField) String() string {
+	return string(e)
+}
+
+func (e *UserSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserSortField", str)
+	}
+	return nil
+}
+
+func (e UserSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UserSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UserSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Fields warehouses can be sorted by
+type WarehouseSortField string
+
+const (
+	WarehouseSortFieldCode      WarehouseSortField = "CODE"
+	WarehouseSortFieldName      WarehouseSortField = "NAME"
+	WarehouseSortFieldCreatedAt WarehouseSortField = "CREATED_AT"
+)
+
+var AllWarehouseSortField = []WarehouseSortField{
+	WarehouseSortFieldCode,
+	WarehouseSortFieldName,
+	WarehouseSortFieldCreatedAt,
+}
+
+func (e WarehouseSortField) IsValid() bool {
+	switch e {
+	case WarehouseSortFieldCode, WarehouseSortFieldName, WarehouseSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e WarehouseSortField) String() string {
+	return string(e)
+}
+
+func (e *WarehouseSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WarehouseSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WarehouseSortField", str)
+	}
+	return nil
+}
+
+func (e WarehouseSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WarehouseSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WarehouseSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,76 @@
+package resolvers
+
+import (
+	"context"
+
+	models "silbackendassessment/internal/api/graphql/graph/model"
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+// ordersPage returns a page of the orders matching the GraphQL filter. A customer filter
+// takes precedence over a status filter.
+func (r *queryResolver) ordersPage(ctx context.Context, filter *models.OrderFilterInput, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
+	if filter != nil && filter.CustomerID != nil {
+		cid, err := uuid.Parse(*filter.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		return r.orderService.GetOrdersByCustomer(ctx, cid, page)
+	}
+	if filter != nil && filter.Status != nil {
+		return r.orderService.GetOrdersByStatus(ctx, *filter.Status, page)
+	}
+	return r.orderService.GetOrders(ctx, page)
+}
+
+// createOrderRequest converts the GraphQL order input, shared by createOrder and quoteOrder
+func createOrderRequest(input models.CreateOrderInput) (*domain.CreateOrderRequest, error) {
+	cid, err := uuid.Parse(input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.CreateOrderItemRequest, 0, len(input.OrderItems))
+	for _, it := range input.OrderItems {
+		pid, err := uuid.Parse(it.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		item := domain.CreateOrderItemRequest{ProductID: pid, Quantity: int(it.Quantity)}
+		if it.VariantID != nil {
+			vid, err := uuid.Parse(*it.VariantID)
+			if err != nil {
+				return nil, err
+			}
+			item.VariantID = &vid
+		}
+		items = append(items, item)
+	}
+	req := &domain.CreateOrderRequest{CustomerID: cid, ShippingAddress: input.ShippingAddress, BillingAddress: input.BillingAddress, OrderItems: items, CouponCodes: input.CouponCodes}
+	if input.ShippingCountry != nil {
+		req.ShippingCountry = *input.ShippingCountry
+	}
+	if input.ShippingCity != nil {
+		req.ShippingCity = *input.ShippingCity
+	}
+	if input.ShippingMethodID != nil {
+		mid, err := uuid.Parse(*input.ShippingMethodID)
+		if err != nil {
+			return nil, err
+		}
+		req.ShippingMethodID = &mid
+	}
+	if input.Notes != nil {
+		req.Notes = *input.Notes
+	}
+	for _, id := range input.ReservationIds {
+		rid, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		req.ReservationIDs = append(req.ReservationIDs, rid)
+	}
+	return req, nil
+}
@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	models "silbackendassessment/internal/api/graphql/graph/model"
+	"silbackendassessment/internal/core/ports"
+)
+
+// offsetPage builds a page request from the limit and offset of the pagination input
+func offsetPage(pagination *models.PaginationInput, sort ports.Sort) ports.PageRequest {
+	page := ports.PageRequest{Limit: ports.DefaultPageSize, Sort: sort}
+	if pagination != nil {
+		if pagination.Limit != nil {
+			page.Limit = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			page.Offset = int(*pagination.Offset)
+		}
+	}
+	return page
+}
+
+// pageItems returns the items of a page, for list fields that page by offset
+func pageItems[T any](page *ports.Page[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// cursorPage builds a page request from the first and after arguments of a connection
+func cursorPage(first *int32, after *string, sort ports.Sort) (ports.PageRequest, error) {
+	limit, token := ports.DefaultPageSize, ""
+	if first != nil {
+		limit = int(*first)
+	}
+	if after != nil {
+		token = *after
+	}
+	return ports.NewPageRequest(limit, 0, token, sort)
+}
+
+// connection builds the edges of a page with edge, and the page info describing them
+func connection[T, E any](page *ports.Page[T], edge func(cursor string, node T) E) ([]E, *models.PageInfo) {
+	edges := make([]E, len(page.Items))
+	info := &models.PageInfo{HasNextPage: page.HasNextPage()}
+	for i, item := range page.Items {
+		cursor := page.Cursors[i].String()
+		edges[i] = edge(cursor, item)
+		if i == 0 {
+			info.StartCursor = &cursor
+		}
+		info.EndCursor = &cursor
+	}
+	return edges, info
+}
@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	models "silbackendassessment/internal/api/graphql/graph/model"
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// productQuery builds a product query from the GraphQL filter
+func productQuery(filter *models.ProductFilterInput) (*domain.ProductQuery, error) {
+	query := &domain.ProductQuery{}
+	if filter == nil {
+		return query, nil
+	}
+
+	if filter.CategoryID != nil {
+		cid, err := uuid.Parse(*filter.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		query.CategoryID = &cid
+	}
+	query.IsActive = filter.IsActive
+	if filter.Search != nil {
+		query.Search = *filter.Search
+	}
+	query.MinPrice = filter.MinPrice
+	query.MaxPrice = filter.MaxPrice
+	if filter.MinStock != nil {
+		v := int(*filter.MinStock)
+		query.MinStock = &v
+	}
+	for _, attribute := range filter.Attributes {
+		if query.Attributes == nil {
+			query.Attributes = make(map[string][]string)
+		}
+		query.Attributes[attribute.Name] = append(query.Attributes[attribute.Name], attribute.Values...)
+	}
+	return query, nil
+}
+
+// productAttributes converts attribute inputs into product attributes
+func productAttributes(inputs []*models.ProductAttributeInput) domain.ProductAttributes {
+	if inputs == nil {
+		return nil
+	}
+	attributes := make(domain.ProductAttributes, len(inputs))
+	for _, input := range inputs {
+		attributes[input.Name] = input.Value
+	}
+	return attributes
+}
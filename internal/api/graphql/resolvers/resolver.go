@@ -9,12 +9,19 @@ import (
 )
 
 type Resolver struct {
-	userService         ports.UserService
-	customerService     ports.CustomerService
-	categoryService     ports.CategoryService
-	productService      ports.ProductService
-	orderService        ports.OrderService
-	notificationService ports.NotificationService
+	userService          ports.UserService
+	customerService      ports.CustomerService
+	categoryService      ports.CategoryService
+	productService       ports.ProductService
+	orderService         ports.OrderService
+	shipmentService      ports.ShipmentService
+	returnService        ports.ReturnService
+	inventoryService     ports.InventoryService
+	reservationService   ports.ReservationService
+	warehouseService     ports.WarehouseService
+	supplierService      ports.SupplierService
+	purchaseOrderService ports.PurchaseOrderService
+	notificationService  ports.NotificationService
 }
 
 func NewResolver(
@@ -23,14 +30,28 @@ func NewResolver(
 	categoryService ports.CategoryService,
 	productService ports.ProductService,
 	orderService ports.OrderService,
+	shipmentService ports.ShipmentService,
+	returnService ports.ReturnService,
+	inventoryService ports.InventoryService,
+	reservationService ports.ReservationService,
+	warehouseService ports.WarehouseService,
+	supplierService ports.SupplierService,
+	purchaseOrderService ports.PurchaseOrderService,
 	notificationService ports.NotificationService,
 ) *Resolver {
 	return &Resolver{
-		userService:         userService,
-		customerService:     customerService,
-		categoryService:     categoryService,
-		productService:      productService,
-		orderService:        orderService,
-		notificationService: notificationService,
+		userService:          userService,
+		customerService:      customerService,
+		categoryService:      categoryService,
+		productService:       productService,
+		orderService:         orderService,
+		shipmentService:      shipmentService,
+		returnService:        returnService,
+		inventoryService:     inventoryService,
+		reservationService:   reservationService,
+		warehouseService:     warehouseService,
+		supplierService:      supplierService,
+		purchaseOrderService: purchaseOrderService,
+		notificationService:  notificationService,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"silbackendassessment/internal/api/graphql/graph"
 	models "silbackendassessment/internal/api/graphql/graph/model"
 	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,11 +29,41 @@ func (r *categoryResolver) ParentID(ctx context.Context, obj *domain.Category) (
 	return &s, nil
 }
 
+// Depth is the resolver for the depth field.
+func (r *categoryResolver) Depth(ctx context.Context, obj *domain.Category) (int32, error) {
+	return int32(obj.Depth()), nil
+}
+
+// Ancestors is the resolver for the ancestors field.
+func (r *categoryResolver) Ancestors(ctx context.Context, obj *domain.Category) ([]*domain.Category, error) {
+	return r.categoryService.GetCategoryAncestors(ctx, obj.ID)
+}
+
+// Position is the resolver for the position field.
+func (r *categoryResolver) Position(ctx context.Context, obj *domain.Category) (int32, error) {
+	return int32(obj.Position), nil
+}
+
+// CategoryID is the resolver for the categoryId field.
+func (r *categoryFacetResolver) CategoryID(ctx context.Context, obj *domain.CategoryFacet) (string, error) {
+	return obj.CategoryID.String(), nil
+}
+
+// Count is the resolver for the count field.
+func (r *categoryFacetResolver) Count(ctx context.Context, obj *domain.CategoryFacet) (int32, error) {
+	return int32(obj.Count), nil
+}
+
 // ID is the resolver for the id field.
 func (r *customerResolver) ID(ctx context.Context, obj *domain.Customer) (string, error) {
 	return obj.ID.String(), nil
 }
 
+// Count is the resolver for the count field.
+func (r *facetValueResolver) Count(ctx context.Context, obj *domain.FacetValue) (int32, error) {
+	return int32(obj.Count), nil
+}
+
 // CreateUser is the resolver for the createUser field.
 func (r *mutationResolver) CreateUser(ctx context.Context, input models.CreateUserInput) (*domain.User, error) {
 	req := &domain.CreateUserRequest{Name: input.Name, Email: input.Email}
@@ -154,6 +185,21 @@ func (r *mutationResolver) CreateCategory(ctx context.Context, input models.Crea
 		}
 		req.ParentID = &pid
 	}
+	if input.Slug != nil {
+		req.Slug = *input.Slug
+	}
+	if input.Position != nil {
+		req.Position = int(*input.Position)
+	}
+	if input.ImageURL != nil {
+		req.ImageURL = *input.ImageURL
+	}
+	if input.MetaTitle != nil {
+		req.MetaTitle = *input.MetaTitle
+	}
+	if input.MetaDescription != nil {
+		req.MetaDescription = *input.MetaDescription
+	}
 	return r.categoryService.CreateCategory(ctx, req)
 }
 
@@ -177,9 +223,34 @@ func (r *mutationResolver) UpdateCategory(ctx context.Context, id string, input
 		}
 		req.ParentID = &pid
 	}
+	if input.Position != nil {
+		position := int(*input.Position)
+		req.Position = &position
+	}
+	req.Slug = input.Slug
+	req.ImageURL = input.ImageURL
+	req.MetaTitle = input.MetaTitle
+	req.MetaDescription = input.MetaDescription
 	return r.categoryService.UpdateCategory(ctx, uid, req)
 }
 
+// MoveCategory is the resolver for the moveCategory field.
+func (r *mutationResolver) MoveCategory(ctx context.Context, id string, parentID *string) (*domain.Category, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	req := &domain.MoveCategoryRequest{}
+	if parentID != nil {
+		pid, err := uuid.Parse(*parentID)
+		if err != nil {
+			return nil, err
+		}
+		req.ParentID = &pid
+	}
+	return r.categoryService.MoveCategory(ctx, uid, req)
+}
+
 // DeleteCategory is the resolver for the deleteCategory field.
 func (r *mutationResolver) DeleteCategory(ctx context.Context, id string) (bool, error) {
 	uid, err := uuid.Parse(id)
@@ -212,6 +283,26 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input models.Creat
 	if input.IsActive != nil {
 		req.IsActive = *input.IsActive
 	}
+	if input.ReorderPoint != nil {
+		v := int(*input.ReorderPoint)
+		req.ReorderPoint = &v
+	}
+	if input.ReorderQuantity != nil {
+		req.ReorderQuantity = int(*input.ReorderQuantity)
+	}
+	if input.WeightGrams != nil {
+		req.WeightGrams = int(*input.WeightGrams)
+	}
+	if input.LengthMm != nil {
+		req.LengthMM = int(*input.LengthMm)
+	}
+	if input.WidthMm != nil {
+		req.WidthMM = int(*input.WidthMm)
+	}
+	if input.HeightMm != nil {
+		req.HeightMM = int(*input.HeightMm)
+	}
+	req.Attributes = productAttributes(input.Attributes)
 	return r.productService.CreateProduct(ctx, req)
 }
 
@@ -248,6 +339,31 @@ func (r *mutationResolver) UpdateProduct(ctx context.Context, id string, input m
 	if input.IsActive != nil {
 		req.IsActive = input.IsActive
 	}
+	if input.ReorderPoint != nil {
+		v := int(*input.ReorderPoint)
+		req.ReorderPoint = &v
+	}
+	if input.ReorderQuantity != nil {
+		v := int(*input.ReorderQuantity)
+		req.ReorderQuantity = &v
+	}
+	if input.WeightGrams != nil {
+		v := int(*input.WeightGrams)
+		req.WeightGrams = &v
+	}
+	if input.LengthMm != nil {
+		v := int(*input.LengthMm)
+		req.LengthMM = &v
+	}
+	if input.WidthMm != nil {
+		v := int(*input.WidthMm)
+		req.WidthMM = &v
+	}
+	if input.HeightMm != nil {
+		v := int(*input.HeightMm)
+		req.HeightMM = &v
+	}
+	req.Attributes = productAttributes(input.Attributes)
 	return r.productService.UpdateProduct(ctx, uid, req)
 }
 
@@ -275,23 +391,65 @@ func (r *mutationResolver) UpdateProductStock(ctx context.Context, id string, st
 	return r.productService.GetProduct(ctx, uid)
 }
 
-// CreateOrder is the resolver for the createOrder field.
-func (r *mutationResolver) CreateOrder(ctx context.Context, input models.CreateOrderInput) (*domain.Order, error) {
-	cid, err := uuid.Parse(input.CustomerID)
+// CreateProductVariant is the resolver for the createProductVariant field.
+func (r *mutationResolver) CreateProductVariant(ctx context.Context, productID string, input models.CreateProductVariantInput) (*domain.ProductVariant, error) {
+	pid, err := uuid.Parse(productID)
 	if err != nil {
 		return nil, err
 	}
-	items := make([]domain.CreateOrderItemRequest, 0, len(input.OrderItems))
-	for _, it := range input.OrderItems {
-		pid, err := uuid.Parse(it.ProductID)
-		if err != nil {
-			return nil, err
+	options := make(domain.VariantOptions, len(input.Options))
+	for _, option := range input.Options {
+		options[option.Name] = option.Value
+	}
+	req := &domain.CreateProductVariantRequest{SKU: input.Sku, Options: options, Price: input.Price, Stock: int(input.Stock), IsActive: input.IsActive}
+	return r.productService.CreateVariant(ctx, pid, req)
+}
+
+// UpdateProductVariant is the resolver for the updateProductVariant field.
+func (r *mutationResolver) UpdateProductVariant(ctx context.Context, productID string, id string, input models.UpdateProductVariantInput) (*domain.ProductVariant, error) {
+	pid, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, err
+	}
+	vid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	req := &domain.UpdateProductVariantRequest{SKU: input.Sku, Price: input.Price, IsActive: input.IsActive}
+	if input.Options != nil {
+		req.Options = make(domain.VariantOptions, len(input.Options))
+		for _, option := range input.Options {
+			req.Options[option.Name] = option.Value
 		}
-		items = append(items, domain.CreateOrderItemRequest{ProductID: pid, Quantity: int(it.Quantity)})
 	}
-	req := &domain.CreateOrderRequest{CustomerID: cid, ShippingAddress: input.ShippingAddress, BillingAddress: input.BillingAddress, OrderItems: items}
-	if input.Notes != nil {
-		req.Notes = *input.Notes
+	if input.Stock != nil {
+		v := int(*input.Stock)
+		req.Stock = &v
+	}
+	return r.productService.UpdateVariant(ctx, pid, vid, req)
+}
+
+// DeleteProductVariant is the resolver for the deleteProductVariant field.
+func (r *mutationResolver) DeleteProductVariant(ctx context.Context, productID string, id string) (bool, error) {
+	pid, err := uuid.Parse(productID)
+	if err != nil {
+		return false, err
+	}
+	vid, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+	if err := r.productService.DeleteVariant(ctx, pid, vid); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateOrder is the resolver for the createOrder field.
+func (r *mutationResolver) CreateOrder(ctx context.Context, input models.CreateOrderInput) (*domain.Order, error) {
+	req, err := createOrderRequest(input)
+	if err != nil {
+		return nil, err
 	}
 	return r.orderService.CreateOrder(ctx, req)
 }
@@ -306,6 +464,9 @@ func (r *mutationResolver) UpdateOrder(ctx context.Context, id string, input mod
 	if input.Status != nil {
 		req.Status = input.Status
 	}
+	if input.StatusReason != nil {
+		req.StatusReason = input.StatusReason
+	}
 	if input.ShippingAddress != nil {
 		req.ShippingAddress = input.ShippingAddress
 	}
@@ -324,6 +485,31 @@ func (r *mutationResolver) UpdateOrder(ctx context.Context, id string, input mod
 	return r.orderService.UpdateOrder(ctx, uid, req)
 }
 
+// UpdateOrderItems is the resolver for the updateOrderItems field.
+func (r *mutationResolver) UpdateOrderItems(ctx context.Context, id string, input models.UpdateOrderItemsInput) (*domain.Order, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]domain.OrderItemChange, 0, len(input.Items))
+	for _, it := range input.Items {
+		pid, err := uuid.Parse(it.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		change := domain.OrderItemChange{ProductID: pid, Quantity: int(it.Quantity)}
+		if it.VariantID != nil {
+			vid, err := uuid.Parse(*it.VariantID)
+			if err != nil {
+				return nil, err
+			}
+			change.VariantID = &vid
+		}
+		changes = append(changes, change)
+	}
+	return r.orderService.UpdateOrderItems(ctx, uid, &domain.UpdateOrderItemsRequest{Items: changes})
+}
+
 // DeleteOrder is the resolver for the deleteOrder field.
 func (r *mutationResolver) DeleteOrder(ctx context.Context, id string) (bool, error) {
 	uid, err := uuid.Parse(id)
@@ -354,12 +540,10 @@ func (r *mutationResolver) ShipOrder(ctx context.Context, id string) (*domain.Or
 	if err != nil {
 		return nil, err
 	}
-	if err := r.orderService.UpdateOrderStatus(ctx, uid, domain.OrderStatusShipped); err != nil {
+	// the service stamps the shipped date as part of the transition
+	if err := r.orderService.UpdateOrderStatus(ctx, uid, domain.OrderStatusShipped, ""); err != nil {
 		return nil, err
 	}
-	// set shipped date
-	t := time.Now()
-	_, _ = r.orderService.UpdateOrder(ctx, uid, &domain.UpdateOrderRequest{ShippedDate: &t})
 	return r.orderService.GetOrder(ctx, uid)
 }
 
@@ -369,207 +553,852 @@ func (r *mutationResolver) DeliverOrder(ctx context.Context, id string) (*domain
 	if err != nil {
 		return nil, err
 	}
-	if err := r.orderService.UpdateOrderStatus(ctx, uid, domain.OrderStatusDelivered); err != nil {
+	// the service stamps the delivered date as part of the transition
+	if err := r.orderService.UpdateOrderStatus(ctx, uid, domain.OrderStatusDelivered, ""); err != nil {
 		return nil, err
 	}
-	// set delivered date
-	t := time.Now()
-	_, _ = r.orderService.UpdateOrder(ctx, uid, &domain.UpdateOrderRequest{DeliveredDate: &t})
 	return r.orderService.GetOrder(ctx, uid)
 }
 
-// ID is the resolver for the id field.
-func (r *orderResolver) ID(ctx context.Context, obj *domain.Order) (string, error) {
-	return obj.ID.String(), nil
-}
-
-// CustomerID is the resolver for the customerId field.
-func (r *orderResolver) CustomerID(ctx context.Context, obj *domain.Order) (string, error) {
-	return obj.CustomerID.String(), nil
-}
-
-// ID is the resolver for the id field.
-func (r *orderItemResolver) ID(ctx context.Context, obj *domain.OrderItem) (string, error) {
-	return obj.ID.String(), nil
-}
-
-// OrderID is the resolver for the orderId field.
-func (r *orderItemResolver) OrderID(ctx context.Context, obj *domain.OrderItem) (string, error) {
-	return obj.OrderID.String(), nil
-}
-
-// ProductID is the resolver for the productId field.
-func (r *orderItemResolver) ProductID(ctx context.Context, obj *domain.OrderItem) (string, error) {
-	return obj.ProductID.String(), nil
-}
-
-// Quantity is the resolver for the quantity field.
-func (r *orderItemResolver) Quantity(ctx context.Context, obj *domain.OrderItem) (int32, error) {
-	return int32(obj.Quantity), nil
-}
-
-// ID is the resolver for the id field.
-func (r *productResolver) ID(ctx context.Context, obj *domain.Product) (string, error) {
-	return obj.ID.String(), nil
-}
-
-// Stock is the resolver for the stock field.
-func (r *productResolver) Stock(ctx context.Context, obj *domain.Product) (int32, error) {
-	return int32(obj.Stock), nil
-}
-
-// CategoryID is the resolver for the categoryId field.
-func (r *productResolver) CategoryID(ctx context.Context, obj *domain.Product) (string, error) {
-	return obj.CategoryID.String(), nil
-}
-
-// Users is the resolver for the users field.
-func (r *queryResolver) Users(ctx context.Context, pagination *models.PaginationInput) ([]*domain.User, error) {
-	l, o := 10, 0
-	if pagination != nil {
-		if pagination.Limit != nil {
-			l = int(*pagination.Limit)
-		}
-		if pagination.Offset != nil {
-			o = int(*pagination.Offset)
+// CreateShipment is the resolver for the createShipment field.
+func (r *mutationResolver) CreateShipment(ctx context.Context, orderID string, input models.CreateShipmentInput) (*domain.Shipment, error) {
+	oid, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.CreateShipmentItemRequest, 0, len(input.Items))
+	for _, it := range input.Items {
+		iid, err := uuid.Parse(it.OrderItemID)
+		if err != nil {
+			return nil, err
 		}
+		items = append(items, domain.CreateShipmentItemRequest{OrderItemID: iid, Quantity: int(it.Quantity)})
 	}
-	return r.userService.GetUsers(ctx, l, o)
+	req := &domain.CreateShipmentRequest{Carrier: input.Carrier, Items: items}
+	if input.TrackingNumber != nil {
+		req.TrackingNumber = *input.TrackingNumber
+	}
+	return r.shipmentService.CreateShipment(ctx, oid, req)
 }
 
-// User is the resolver for the user field.
-func (r *queryResolver) User(ctx context.Context, id string) (*domain.User, error) {
+// UpdateShipment is the resolver for the updateShipment field.
+func (r *mutationResolver) UpdateShipment(ctx context.Context, id string, input models.UpdateShipmentInput) (*domain.Shipment, error) {
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
-	return r.userService.GetUser(ctx, uid)
+	req := &domain.UpdateShipmentRequest{
+		Carrier:        input.Carrier,
+		TrackingNumber: input.TrackingNumber,
+		Status:         input.Status,
+	}
+	return r.shipmentService.UpdateShipment(ctx, uid, req)
 }
 
-// SearchUsers is the resolver for the searchUsers field.
-func (r *queryResolver) SearchUsers(ctx context.Context, query string, pagination *models.PaginationInput) ([]*domain.User, error) {
-	// No search implementation available. Return first page as fallback.
-	return r.Users(ctx, pagination)
+// RequestReturn is the resolver for the requestReturn field.
+func (r *mutationResolver) RequestReturn(ctx context.Context, orderID string, input models.CreateReturnInput) (*domain.ReturnRequest, error) {
+	oid, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.CreateReturnItemRequest, 0, len(input.Items))
+	for _, it := range input.Items {
+		iid, err := uuid.Parse(it.OrderItemID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, domain.CreateReturnItemRequest{OrderItemID: iid, Quantity: int(it.Quantity)})
+	}
+	return r.returnService.RequestReturn(ctx, oid, &domain.CreateReturnRequest{Reason: input.Reason, Items: items})
 }
 
-// Customers is the resolver for the customers field.
-func (r *queryResolver) Customers(ctx context.Context, pagination *models.PaginationInput) ([]*domain.Customer, error) {
-	l, o := 10, 0
-	if pagination != nil {
-		if pagination.Limit != nil {
-			l = int(*pagination.Limit)
-		}
-		if pagination.Offset != nil {
-			o = int(*pagination.Offset)
-		}
+// ApproveReturn is the resolver for the approveReturn field.
+func (r *mutationResolver) ApproveReturn(ctx context.Context, id string, note *string) (*domain.ReturnRequest, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	req := &domain.ReviewReturnRequest{}
+	if note != nil {
+		req.Note = *note
 	}
-	return r.customerService.GetCustomers(ctx, l, o)
+	return r.returnService.ApproveReturn(ctx, uid, req)
 }
 
-// Customer is the resolver for the customer field.
-func (r *queryResolver) Customer(ctx context.Context, id string) (*domain.Customer, error) {
+// RejectReturn is the resolver for the rejectReturn field.
+func (r *mutationResolver) RejectReturn(ctx context.Context, id string, note *string) (*domain.ReturnRequest, error) {
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
-	return r.customerService.GetCustomer(ctx, uid)
+	req := &domain.ReviewReturnRequest{}
+	if note != nil {
+		req.Note = *note
+	}
+	return r.returnService.RejectReturn(ctx, uid, req)
 }
 
-// SearchCustomers is the resolver for the searchCustomers field.
-func (r *queryResolver) SearchCustomers(ctx context.Context, query string, pagination *models.PaginationInput) ([]*domain.Customer, error) {
-	// No search implementation available. Return first page as fallback.
-	return r.Customers(ctx, pagination)
+// ReceiveReturn is the resolver for the receiveReturn field.
+func (r *mutationResolver) ReceiveReturn(ctx context.Context, id string) (*domain.ReturnRequest, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.returnService.ReceiveReturn(ctx, uid)
 }
 
-// Categories is the resolver for the categories field.
-func (r *queryResolver) Categories(ctx context.Context, pagination *models.PaginationInput) ([]*domain.Category, error) {
-	l, o := 10, 0
-	if pagination != nil {
-		if pagination.Limit != nil {
-			l = int(*pagination.Limit)
-		}
-		if pagination.Offset != nil {
-			o = int(*pagination.Offset)
-		}
+// CreateReservation is the resolver for the createReservation field.
+func (r *mutationResolver) CreateReservation(ctx context.Context, input models.CreateReservationInput) (*domain.StockReservation, error) {
+	pid, err := uuid.Parse(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	cid, err := uuid.Parse(input.CustomerID)
+	if err != nil {
+		return nil, err
 	}
-	return r.categoryService.GetCategories(ctx, l, o)
+	req := &domain.CreateReservationRequest{ProductID: pid, CustomerID: cid, Quantity: int(input.Quantity)}
+	if input.TTLSeconds != nil {
+		req.TTLSeconds = int(*input.TTLSeconds)
+	}
+	return r.reservationService.CreateReservation(ctx, req)
 }
 
-// Category is the resolver for the category field.
-func (r *queryResolver) Category(ctx context.Context, id string) (*domain.Category, error) {
+// ReleaseReservation is the resolver for the releaseReservation field.
+func (r *mutationResolver) ReleaseReservation(ctx context.Context, id string) (*domain.StockReservation, error) {
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
-	return r.categoryService.GetCategory(ctx, uid)
+	return r.reservationService.ReleaseReservation(ctx, uid)
 }
 
-// RootCategories is the resolver for the rootCategories field.
-func (r *queryResolver) RootCategories(ctx context.Context, pagination *models.PaginationInput) ([]*domain.Category, error) {
-	return r.categoryService.GetRootCategories(ctx)
+// CreateWarehouse is the resolver for the createWarehouse field.
+func (r *mutationResolver) CreateWarehouse(ctx context.Context, input models.CreateWarehouseInput) (*domain.Warehouse, error) {
+	req := &domain.CreateWarehouseRequest{Code: input.Code, Name: input.Name, City: input.City, Country: input.Country}
+	if input.IsDefault != nil {
+		req.IsDefault = *input.IsDefault
+	}
+	return r.warehouseService.CreateWarehouse(ctx, req)
 }
 
-// Subcategories is the resolver for the subcategories field.
-func (r *queryResolver) Subcategories(ctx context.Context, parentID string, pagination *models.PaginationInput) ([]*domain.Category, error) {
-	pid, err := uuid.Parse(parentID)
+// UpdateWarehouse is the resolver for the updateWarehouse field.
+func (r *mutationResolver) UpdateWarehouse(ctx context.Context, id string, input models.UpdateWarehouseInput) (*domain.Warehouse, error) {
+	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
-	return r.categoryService.GetSubCategories(ctx, pid)
+	return r.warehouseService.UpdateWarehouse(ctx, uid, &domain.UpdateWarehouseRequest{
+		Name:      input.Name,
+		City:      input.City,
+		Country:   input.Country,
+		IsDefault: input.IsDefault,
+		IsActive:  input.IsActive,
+	})
 }
 
-// Products is the resolver for the products field.
-func (r *queryResolver) Products(ctx context.Context, filter *models.ProductFilterInput, pagination *models.PaginationInput) ([]*domain.Product, error) {
-	l, o := 10, 0
-	if pagination != nil {
-		if pagination.Limit != nil {
-			l = int(*pagination.Limit)
-		}
-		if pagination.Offset != nil {
-			o = int(*pagination.Offset)
-		}
+// SetStockLevel is the resolver for the setStockLevel field.
+func (r *mutationResolver) SetStockLevel(ctx context.Context, productID string, warehouseID string, quantity int32) ([]*domain.StockLevel, error) {
+	pid, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, err
 	}
-	if filter != nil && filter.CategoryID != nil {
-		cid, err := uuid.Parse(*filter.CategoryID)
-		if err != nil {
-			return nil, err
-		}
-		return r.productService.GetProductsByCategory(ctx, cid, l, o)
+	wid, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	return r.warehouseService.SetStockLevel(ctx, pid, wid, &domain.SetStockLevelRequest{Quantity: int(quantity)})
+}
+
+// CreateSupplier is the resolver for the createSupplier field.
+func (r *mutationResolver) CreateSupplier(ctx context.Context, input models.CreateSupplierInput) (*domain.Supplier, error) {
+	req := &domain.CreateSupplierRequest{Name: input.Name}
+	if input.Email != nil {
+		req.Email = *input.Email
 	}
-	if filter != nil && filter.IsActive != nil && *filter.IsActive {
-		return r.productService.GetActiveProducts(ctx, l, o)
+	if input.Phone != nil {
+		req.Phone = *input.Phone
 	}
-	if filter != nil && filter.Search != nil && *filter.Search != "" {
-		return r.productService.SearchProducts(ctx, *filter.Search, l, o)
+	if input.Address != nil {
+		req.Address = *input.Address
 	}
-	return r.productService.GetProducts(ctx, l, o)
+	return r.supplierService.CreateSupplier(ctx, req)
 }
 
-// Product is the resolver for the product field.
-func (r *queryResolver) Product(ctx context.Context, id string) (*domain.Product, error) {
+// UpdateSupplier is the resolver for the updateSupplier field.
+func (r *mutationResolver) UpdateSupplier(ctx context.Context, id string, input models.UpdateSupplierInput) (*domain.Supplier, error) {
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
-	return r.productService.GetProduct(ctx, uid)
+	return r.supplierService.UpdateSupplier(ctx, uid, &domain.UpdateSupplierRequest{
+		Name:     input.Name,
+		Email:    input.Email,
+		Phone:    input.Phone,
+		Address:  input.Address,
+		IsActive: input.IsActive,
+	})
 }
 
-// ProductsByCategory is the resolver for the productsByCategory field.
-func (r *queryResolver) ProductsByCategory(ctx context.Context, categoryID string, pagination *models.PaginationInput) ([]*domain.Product, error) {
-	cid, err := uuid.Parse(categoryID)
+// CreatePurchaseOrder is the resolver for the createPurchaseOrder field.
+func (r *mutationResolver) CreatePurchaseOrder(ctx context.Context, input models.CreatePurchaseOrderInput) (*domain.PurchaseOrder, error) {
+	sid, err := uuid.Parse(input.SupplierID)
 	if err != nil {
 		return nil, err
 	}
-	l, o := 10, 0
-	if pagination != nil {
-		if pagination.Limit != nil {
-			l = int(*pagination.Limit)
+	var warehouseID *uuid.UUID
+	if input.WarehouseID != nil {
+		wid, err := uuid.Parse(*input.WarehouseID)
+		if err != nil {
+			return nil, err
 		}
-		if pagination.Offset != nil {
-			o = int(*pagination.Offset)
+		warehouseID = &wid
+	}
+	var lines []domain.CreatePurchaseOrderLineRequest
+	if input.Lines != nil {
+		lines = make([]domain.CreatePurchaseOrderLineRequest, 0, len(input.Lines))
+		for _, it := range input.Lines {
+			pid, err := uuid.Parse(it.ProductID)
+			if err != nil {
+				return nil, err
+			}
+			line := domain.CreatePurchaseOrderLineRequest{ProductID: pid, Quantity: int(it.Quantity), UnitCost: it.UnitCost}
+			if it.VariantID != nil {
+				vid, err := uuid.Parse(*it.VariantID)
+				if err != nil {
+					return nil, err
+				}
+				line.VariantID = &vid
+			}
+			lines = append(lines, line)
 		}
 	}
-	return r.productService.GetProductsByCategory(ctx, cid, l, o)
+	req := &domain.CreatePurchaseOrderRequest{SupplierID: sid, WarehouseID: warehouseID, ExpectedDate: input.ExpectedDate, Lines: lines}
+	if input.Notes != nil {
+		req.Notes = *input.Notes
+	}
+	return r.purchaseOrderService.CreatePurchaseOrder(ctx, req)
+}
+
+// UpdatePurchaseOrder is the resolver for the updatePurchaseOrder field.
+func (r *mutationResolver) UpdatePurchaseOrder(ctx context.Context, id string, input models.UpdatePurchaseOrderInput) (*domain.PurchaseOrder, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	var warehouseID *uuid.UUID
+	if input.WarehouseID != nil {
+		wid, err := uuid.Parse(*input.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		warehouseID = &wid
+	}
+	var lines []domain.CreatePurchaseOrderLineRequest
+	if input.Lines != nil {
+		lines = make([]domain.CreatePurchaseOrderLineRequest, 0, len(input.Lines))
+		for _, it := range input.Lines {
+			pid, err := uuid.Parse(it.ProductID)
+			if err != nil {
+				return nil, err
+			}
+			line := domain.CreatePurchaseOrderLineRequest{ProductID: pid, Quantity: int(it.Quantity), UnitCost: it.UnitCost}
+			if it.VariantID != nil {
+				vid, err := uuid.Parse(*it.VariantID)
+				if err != nil {
+					return nil, err
+				}
+				line.VariantID = &vid
+			}
+			lines = append(lines, line)
+		}
+	}
+	return r.purchaseOrderService.UpdatePurchaseOrder(ctx, uid, &domain.UpdatePurchaseOrderRequest{
+		WarehouseID:  warehouseID,
+		ExpectedDate: input.ExpectedDate,
+		Notes:        input.Notes,
+		Lines:        lines,
+	})
+}
+
+// SendPurchaseOrder is the resolver for the sendPurchaseOrder field.
+func (r *mutationResolver) SendPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.purchaseOrderService.SendPurchaseOrder(ctx, uid)
+}
+
+// ReceivePurchaseOrder is the resolver for the receivePurchaseOrder field.
+func (r *mutationResolver) ReceivePurchaseOrder(ctx context.Context, id string, input *models.ReceivePurchaseOrderInput) (*domain.PurchaseOrder, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	req := &domain.ReceivePurchaseOrderRequest{}
+	if input != nil {
+		for _, it := range input.Lines {
+			lid, err := uuid.Parse(it.LineID)
+			if err != nil {
+				return nil, err
+			}
+			req.Lines = append(req.Lines, domain.ReceivePurchaseOrderLineRequest{LineID: lid, Quantity: int(it.Quantity)})
+		}
+	}
+	return r.purchaseOrderService.ReceivePurchaseOrder(ctx, uid, req)
+}
+
+// ID is the resolver for the id field.
+func (r *orderResolver) ID(ctx context.Context, obj *domain.Order) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CustomerID is the resolver for the customerId field.
+func (r *orderResolver) CustomerID(ctx context.Context, obj *domain.Order) (string, error) {
+	return obj.CustomerID.String(), nil
+}
+
+// ShippingMethodID is the resolver for the shippingMethodId field.
+func (r *orderResolver) ShippingMethodID(ctx context.Context, obj *domain.Order) (*string, error) {
+	if obj.ShippingMethodID == nil {
+		return nil, nil
+	}
+	id := obj.ShippingMethodID.String()
+	return &id, nil
+}
+
+// StatusHistory is the resolver for the statusHistory field.
+func (r *orderResolver) StatusHistory(ctx context.Context, obj *domain.Order) ([]*domain.OrderStatusHistory, error) {
+	return r.orderService.GetOrderStatusHistory(ctx, obj.ID)
+}
+
+// Shipments is the resolver for the shipments field.
+func (r *orderResolver) Shipments(ctx context.Context, obj *domain.Order) ([]*domain.Shipment, error) {
+	return r.shipmentService.GetShipmentsByOrder(ctx, obj.ID)
+}
+
+// Returns is the resolver for the returns field.
+func (r *orderResolver) Returns(ctx context.Context, obj *domain.Order) ([]*domain.ReturnRequest, error) {
+	return r.returnService.GetReturnsByOrder(ctx, obj.ID)
+}
+
+// ID is the resolver for the id field.
+func (r *orderDiscountResolver) ID(ctx context.Context, obj *domain.OrderDiscount) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// PromotionID is the resolver for the promotionId field.
+func (r *orderDiscountResolver) PromotionID(ctx context.Context, obj *domain.OrderDiscount) (string, error) {
+	return obj.PromotionID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *orderItemResolver) ID(ctx context.Context, obj *domain.OrderItem) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *orderItemResolver) OrderID(ctx context.Context, obj *domain.OrderItem) (string, error) {
+	return obj.OrderID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *orderItemResolver) ProductID(ctx context.Context, obj *domain.OrderItem) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// VariantID is the resolver for the variantId field.
+func (r *orderItemResolver) VariantID(ctx context.Context, obj *domain.OrderItem) (*string, error) {
+	if obj.VariantID == nil {
+		return nil, nil
+	}
+	id := obj.VariantID.String()
+	return &id, nil
+}
+
+// Quantity is the resolver for the quantity field.
+func (r *orderItemResolver) Quantity(ctx context.Context, obj *domain.OrderItem) (int32, error) {
+	return int32(obj.Quantity), nil
+}
+
+// TaxRate is the resolver for the taxRate field.
+func (r *orderItemResolver) TaxRate(ctx context.Context, obj *domain.OrderItem) (int32, error) {
+	return int32(obj.TaxRate), nil
+}
+
+// CustomerID is the resolver for the customerId field.
+func (r *orderQuoteResolver) CustomerID(ctx context.Context, obj *domain.OrderQuote) (string, error) {
+	return obj.CustomerID.String(), nil
+}
+
+// ShippingMethodID is the resolver for the shippingMethodId field.
+func (r *orderQuoteResolver) ShippingMethodID(ctx context.Context, obj *domain.OrderQuote) (*string, error) {
+	if obj.ShippingMethodID == nil {
+		return nil, nil
+	}
+	id := obj.ShippingMethodID.String()
+	return &id, nil
+}
+
+// PromotionID is the resolver for the promotionId field.
+func (r *orderQuoteDiscountResolver) PromotionID(ctx context.Context, obj *domain.OrderQuoteDiscount) (string, error) {
+	return obj.PromotionID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *orderQuoteLineResolver) ProductID(ctx context.Context, obj *domain.OrderQuoteLine) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// VariantID is the resolver for the variantId field.
+func (r *orderQuoteLineResolver) VariantID(ctx context.Context, obj *domain.OrderQuoteLine) (*string, error) {
+	if obj.VariantID == nil {
+		return nil, nil
+	}
+	id := obj.VariantID.String()
+	return &id, nil
+}
+
+// Quantity is the resolver for the quantity field.
+func (r *orderQuoteLineResolver) Quantity(ctx context.Context, obj *domain.OrderQuoteLine) (int32, error) {
+	return int32(obj.Quantity), nil
+}
+
+// TaxRate is the resolver for the taxRate field.
+func (r *orderQuoteLineResolver) TaxRate(ctx context.Context, obj *domain.OrderQuoteLine) (int32, error) {
+	return int32(obj.TaxRate), nil
+}
+
+// ID is the resolver for the id field.
+func (r *orderStatusHistoryResolver) ID(ctx context.Context, obj *domain.OrderStatusHistory) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *orderStatusHistoryResolver) OrderID(ctx context.Context, obj *domain.OrderStatusHistory) (string, error) {
+	return obj.OrderID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *productResolver) ID(ctx context.Context, obj *domain.Product) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Stock is the resolver for the stock field.
+func (r *productResolver) Stock(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.Stock), nil
+}
+
+// ReservedStock is the resolver for the reservedStock field.
+func (r *productResolver) ReservedStock(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.ReservedStock), nil
+}
+
+// AvailableStock is the resolver for the availableStock field.
+func (r *productResolver) AvailableStock(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.AvailableStock), nil
+}
+
+// ReorderPoint is the resolver for the reorderPoint field.
+func (r *productResolver) ReorderPoint(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.ReorderPoint), nil
+}
+
+// ReorderQuantity is the resolver for the reorderQuantity field.
+func (r *productResolver) ReorderQuantity(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.ReorderQuantity), nil
+}
+
+// WeightGrams is the resolver for the weightGrams field.
+func (r *productResolver) WeightGrams(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.WeightGrams), nil
+}
+
+// LengthMm is the resolver for the lengthMm field.
+func (r *productResolver) LengthMm(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.LengthMM), nil
+}
+
+// WidthMm is the resolver for the widthMm field.
+func (r *productResolver) WidthMm(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.WidthMM), nil
+}
+
+// HeightMm is the resolver for the heightMm field.
+func (r *productResolver) HeightMm(ctx context.Context, obj *domain.Product) (int32, error) {
+	return int32(obj.HeightMM), nil
+}
+
+// CategoryID is the resolver for the categoryId field.
+func (r *productResolver) CategoryID(ctx context.Context, obj *domain.Product) (string, error) {
+	return obj.CategoryID.String(), nil
+}
+
+// Attributes is the resolver for the attributes field.
+func (r *productResolver) Attributes(ctx context.Context, obj *domain.Product) ([]*domain.ProductAttribute, error) {
+	list := obj.Attributes.List()
+	attributes := make([]*domain.ProductAttribute, len(list))
+	for i := range list {
+		attributes[i] = &list[i]
+	}
+	return attributes, nil
+}
+
+// Total is the resolver for the total field.
+func (r *productFacetsResolver) Total(ctx context.Context, obj *domain.ProductFacets) (int32, error) {
+	return int32(obj.Total), nil
+}
+
+// ID is the resolver for the id field.
+func (r *productVariantResolver) ID(ctx context.Context, obj *domain.ProductVariant) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *productVariantResolver) ProductID(ctx context.Context, obj *domain.ProductVariant) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// Options is the resolver for the options field.
+func (r *productVariantResolver) Options(ctx context.Context, obj *domain.ProductVariant) ([]*domain.VariantOption, error) {
+	list := obj.Options.List()
+	options := make([]*domain.VariantOption, len(list))
+	for i := range list {
+		options[i] = &list[i]
+	}
+	return options, nil
+}
+
+// Stock is the resolver for the stock field.
+func (r *productVariantResolver) Stock(ctx context.Context, obj *domain.ProductVariant) (int32, error) {
+	return int32(obj.Stock), nil
+}
+
+// ID is the resolver for the id field.
+func (r *purchaseOrderResolver) ID(ctx context.Context, obj *domain.PurchaseOrder) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// WarehouseID is the resolver for the warehouseId field.
+func (r *purchaseOrderResolver) WarehouseID(ctx context.Context, obj *domain.PurchaseOrder) (*string, error) {
+	if obj.WarehouseID == nil {
+		return nil, nil
+	}
+	id := obj.WarehouseID.String()
+	return &id, nil
+}
+
+// ID is the resolver for the id field.
+func (r *purchaseOrderLineResolver) ID(ctx context.Context, obj *domain.PurchaseOrderLine) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *purchaseOrderLineResolver) ProductID(ctx context.Context, obj *domain.PurchaseOrderLine) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// VariantID is the resolver for the variantId field.
+func (r *purchaseOrderLineResolver) VariantID(ctx context.Context, obj *domain.PurchaseOrderLine) (*string, error) {
+	if obj.VariantID == nil {
+		return nil, nil
+	}
+	id := obj.VariantID.String()
+	return &id, nil
+}
+
+// QuantityOrdered is the resolver for the quantityOrdered field.
+func (r *purchaseOrderLineResolver) QuantityOrdered(ctx context.Context, obj *domain.PurchaseOrderLine) (int32, error) {
+	return int32(obj.QuantityOrdered), nil
+}
+
+// QuantityReceived is the resolver for the quantityReceived field.
+func (r *purchaseOrderLineResolver) QuantityReceived(ctx context.Context, obj *domain.PurchaseOrderLine) (int32, error) {
+	return int32(obj.QuantityReceived), nil
+}
+
+// Outstanding is the resolver for the outstanding field.
+func (r *purchaseOrderLineResolver) Outstanding(ctx context.Context, obj *domain.PurchaseOrderLine) (int32, error) {
+	return int32(obj.Outstanding()), nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, pagination *models.PaginationInput, orderBy []*models.UserOrderBy) ([]*domain.User, error) {
+	sort, err := userSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.userService.GetUsers(ctx, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
+}
+
+// UsersConnection is the resolver for the usersConnection field.
+func (r *queryResolver) UsersConnection(ctx context.Context, first *int32, after *string, orderBy []*models.UserOrderBy) (*models.UserConnection, error) {
+	sort, err := userSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	page, err := cursorPage(first, after, sort)
+	if err != nil {
+		return nil, err
+	}
+	users, err := r.userService.GetUsers(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	edges, info := connection(users, func(cursor string, node *domain.User) *models.UserEdge {
+		return &models.UserEdge{Cursor: cursor, Node: node}
+	})
+	return &models.UserConnection{Edges: edges, PageInfo: info, TotalCount: int32(users.TotalCount)}, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id string) (*domain.User, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.userService.GetUser(ctx, uid)
+}
+
+// SearchUsers is the resolver for the searchUsers field.
+func (r *queryResolver) SearchUsers(ctx context.Context, query string, pagination *models.PaginationInput) ([]*domain.UserSearchResult, error) {
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return r.userService.SearchUsers(ctx, query, l, o)
+}
+
+// Customers is the resolver for the customers field.
+func (r *queryResolver) Customers(ctx context.Context, pagination *models.PaginationInput, orderBy []*models.CustomerOrderBy) ([]*domain.Customer, error) {
+	sort, err := customerSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.customerService.GetCustomers(ctx, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
+}
+
+// CustomersConnection is the resolver for the customersConnection field.
+func (r *queryResolver) CustomersConnection(ctx context.Context, first *int32, after *string, orderBy []*models.CustomerOrderBy) (*models.CustomerConnection, error) {
+	sort, err := customerSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	page, err := cursorPage(first, after, sort)
+	if err != nil {
+		return nil, err
+	}
+	customers, err := r.customerService.GetCustomers(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	edges, info := connection(customers, func(cursor string, node *domain.Customer) *models.CustomerEdge {
+		return &models.CustomerEdge{Cursor: cursor, Node: node}
+	})
+	return &models.CustomerConnection{Edges: edges, PageInfo: info, TotalCount: int32(customers.TotalCount)}, nil
+}
+
+// Customer is the resolver for the customer field.
+func (r *queryResolver) Customer(ctx context.Context, id string) (*domain.Customer, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.customerService.GetCustomer(ctx, uid)
+}
+
+// SearchCustomers is the resolver for the searchCustomers field.
+func (r *queryResolver) SearchCustomers(ctx context.Context, query string, pagination *models.PaginationInput) ([]*domain.CustomerSearchResult, error) {
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return r.customerService.SearchCustomers(ctx, query, l, o)
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context, pagination *models.PaginationInput, orderBy []*models.CategoryOrderBy) ([]*domain.Category, error) {
+	sort, err := categorySort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.categoryService.GetCategories(ctx, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
+}
+
+// CategoriesConnection is the resolver for the categoriesConnection field.
+func (r *queryResolver) CategoriesConnection(ctx context.Context, first *int32, after *string, orderBy []*models.CategoryOrderBy) (*models.CategoryConnection, error) {
+	sort, err := categorySort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	page, err := cursorPage(first, after, sort)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := r.categoryService.GetCategories(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	edges, info := connection(categories, func(cursor string, node *domain.Category) *models.CategoryEdge {
+		return &models.CategoryEdge{Cursor: cursor, Node: node}
+	})
+	return &models.CategoryConnection{Edges: edges, PageInfo: info, TotalCount: int32(categories.TotalCount)}, nil
+}
+
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, id string) (*domain.Category, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.categoryService.GetCategory(ctx, uid)
+}
+
+// CategoryByPath is the resolver for the categoryByPath field.
+func (r *queryResolver) CategoryByPath(ctx context.Context, path string) (*models.CategoryPathResult, error) {
+	lookup, err := r.categoryService.GetCategoryByPath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CategoryPathResult{
+		Category:      lookup.Category,
+		CanonicalPath: lookup.CanonicalPath,
+		Redirected:    lookup.Redirected,
+	}, nil
+}
+
+// CategoryTree is the resolver for the categoryTree field.
+func (r *queryResolver) CategoryTree(ctx context.Context, id string) (*domain.Category, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.categoryService.GetCategoryTree(ctx, uid)
+}
+
+// RootCategories is the resolver for the rootCategories field.
+func (r *queryResolver) RootCategories(ctx context.Context, pagination *models.PaginationInput) ([]*domain.Category, error) {
+	return r.categoryService.GetRootCategories(ctx)
+}
+
+// Subcategories is the resolver for the subcategories field.
+func (r *queryResolver) Subcategories(ctx context.Context, parentID string, pagination *models.PaginationInput) ([]*domain.Category, error) {
+	pid, err := uuid.Parse(parentID)
+	if err != nil {
+		return nil, err
+	}
+	return r.categoryService.GetSubCategories(ctx, pid)
+}
+
+// Products is the resolver for the products field.
+func (r *queryResolver) Products(ctx context.Context, filter *models.ProductFilterInput, pagination *models.PaginationInput, orderBy []*models.ProductOrderBy) ([]*domain.Product, error) {
+	sort, err := productSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	query, err := productQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	return pageItems(r.productService.QueryProducts(ctx, query, offsetPage(pagination, sort)))
+}
+
+// ProductsConnection is the resolver for the productsConnection field.
+func (r *queryResolver) ProductsConnection(ctx context.Context, filter *models.ProductFilterInput, first *int32, after *string, orderBy []*models.ProductOrderBy) (*models.ProductConnection, error) {
+	sort, err := productSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	query, err := productQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	page, err := cursorPage(first, after, sort)
+	if err != nil {
+		return nil, err
+	}
+	products, err := r.productService.QueryProducts(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+	edges, info := connection(products, func(cursor string, node *domain.Product) *models.ProductEdge {
+		return &models.ProductEdge{Cursor: cursor, Node: node}
+	})
+	return &models.ProductConnection{Edges: edges, PageInfo: info, TotalCount: int32(products.TotalCount)}, nil
+}
+
+// ProductFacets is the resolver for the productFacets field.
+func (r *queryResolver) ProductFacets(ctx context.Context, filter *models.ProductFilterInput) (*domain.ProductFacets, error) {
+	query, err := productQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.productService.GetProductFacets(ctx, query)
+}
+
+// Product is the resolver for the product field.
+func (r *queryResolver) Product(ctx context.Context, id string) (*domain.Product, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.productService.GetProduct(ctx, uid)
+}
+
+// ProductsByCategory is the resolver for the productsByCategory field.
+func (r *queryResolver) ProductsByCategory(ctx context.Context, categoryID string, pagination *models.PaginationInput) ([]*domain.Product, error) {
+	cid, err := uuid.Parse(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.productService.GetProductsByCategory(ctx, cid, ports.PageRequest{Limit: l, Offset: o}))
 }
 
 // ActiveProducts is the resolver for the activeProducts field.
@@ -583,12 +1412,185 @@ func (r *queryResolver) ActiveProducts(ctx context.Context, pagination *models.P
 			o = int(*pagination.Offset)
 		}
 	}
-	return r.productService.GetActiveProducts(ctx, l, o)
+	return pageItems(r.productService.GetActiveProducts(ctx, ports.PageRequest{Limit: l, Offset: o}))
+}
+
+// SearchProducts is the resolver for the searchProducts field.
+func (r *queryResolver) SearchProducts(ctx context.Context, query string, pagination *models.PaginationInput) ([]*domain.ProductSearchResult, error) {
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return r.productService.SearchProducts(ctx, query, l, o)
+}
+
+// Orders is the resolver for the orders field.
+func (r *queryResolver) Orders(ctx context.Context, filter *models.OrderFilterInput, pagination *models.PaginationInput, orderBy []*models.OrderOrderBy) ([]*domain.Order, error) {
+	sort, err := orderSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.ordersPage(ctx, filter, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
+}
+
+// OrdersConnection is the resolver for the ordersConnection field.
+func (r *queryResolver) OrdersConnection(ctx context.Context, filter *models.OrderFilterInput, first *int32, after *string, orderBy []*models.OrderOrderBy) (*models.OrderConnection, error) {
+	sort, err := orderSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	page, err := cursorPage(first, after, sort)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := r.ordersPage(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	edges, info := connection(orders, func(cursor string, node *domain.Order) *models.OrderEdge {
+		return &models.OrderEdge{Cursor: cursor, Node: node}
+	})
+	return &models.OrderConnection{Edges: edges, PageInfo: info, TotalCount: int32(orders.TotalCount)}, nil
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, id string) (*domain.Order, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.orderService.GetOrder(ctx, uid)
+}
+
+// OrdersByCustomer is the resolver for the ordersByCustomer field.
+func (r *queryResolver) OrdersByCustomer(ctx context.Context, customerID string, pagination *models.PaginationInput) ([]*domain.Order, error) {
+	cid, err := uuid.Parse(customerID)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.orderService.GetOrdersByCustomer(ctx, cid, ports.PageRequest{Limit: l, Offset: o}))
+}
+
+// OrdersByStatus is the resolver for the ordersByStatus field.
+func (r *queryResolver) OrdersByStatus(ctx context.Context, status domain.OrderStatus, pagination *models.PaginationInput) ([]*domain.Order, error) {
+	l, o := 10, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.orderService.GetOrdersByStatus(ctx, status, ports.PageRequest{Limit: l, Offset: o}))
+}
+
+// OrderByNumber is the resolver for the orderByNumber field.
+func (r *queryResolver) OrderByNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	return r.orderService.GetOrderByNumber(ctx, orderNumber)
+}
+
+// QuoteOrder is the resolver for the quoteOrder field.
+func (r *queryResolver) QuoteOrder(ctx context.Context, input models.CreateOrderInput) (*domain.OrderQuote, error) {
+	req, err := createOrderRequest(input)
+	if err != nil {
+		return nil, err
+	}
+	return r.orderService.QuoteOrder(ctx, req)
+}
+
+// ShippingRates is the resolver for the shippingRates field.
+func (r *queryResolver) ShippingRates(ctx context.Context, input models.CreateOrderInput) ([]*domain.ShippingRate, error) {
+	req, err := createOrderRequest(input)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := r.orderService.QuoteShipping(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.ShippingRate, len(rates))
+	for i := range rates {
+		result[i] = &rates[i]
+	}
+	return result, nil
+}
+
+// Shipment is the resolver for the shipment field.
+func (r *queryResolver) Shipment(ctx context.Context, id string) (*domain.Shipment, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.shipmentService.GetShipment(ctx, uid)
+}
+
+// ReturnRequest is the resolver for the returnRequest field.
+func (r *queryResolver) ReturnRequest(ctx context.Context, id string) (*domain.ReturnRequest, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.returnService.GetReturn(ctx, uid)
+}
+
+// Reservation is the resolver for the reservation field.
+func (r *queryResolver) Reservation(ctx context.Context, id string) (*domain.StockReservation, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.reservationService.GetReservation(ctx, uid)
+}
+
+// StockMovements is the resolver for the stockMovements field.
+func (r *queryResolver) StockMovements(ctx context.Context, productID string, pagination *models.PaginationInput) ([]*domain.StockMovement, error) {
+	pid, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 50, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
+	}
+	return pageItems(r.inventoryService.GetStockMovements(ctx, pid, ports.PageRequest{Limit: l, Offset: o}))
 }
 
-// SearchProducts is the resolver for the searchProducts field.
-func (r *queryResolver) SearchProducts(ctx context.Context, query string, pagination *models.PaginationInput) ([]*domain.Product, error) {
-	l, o := 10, 0
+// Warehouses is the resolver for the warehouses field.
+func (r *queryResolver) Warehouses(ctx context.Context, pagination *models.PaginationInput, orderBy []*models.WarehouseOrderBy) ([]*domain.Warehouse, error) {
+	sort, err := warehouseSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 50, 0
 	if pagination != nil {
 		if pagination.Limit != nil {
 			l = int(*pagination.Limit)
@@ -597,12 +1599,25 @@ func (r *queryResolver) SearchProducts(ctx context.Context, query string, pagina
 			o = int(*pagination.Offset)
 		}
 	}
-	return r.productService.SearchProducts(ctx, query, l, o)
+	return pageItems(r.warehouseService.GetWarehouses(ctx, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
 }
 
-// Orders is the resolver for the orders field.
-func (r *queryResolver) Orders(ctx context.Context, filter *models.OrderFilterInput, pagination *models.PaginationInput) ([]*domain.Order, error) {
-	l, o := 10, 0
+// Warehouse is the resolver for the warehouse field.
+func (r *queryResolver) Warehouse(ctx context.Context, id string) (*domain.Warehouse, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.warehouseService.GetWarehouse(ctx, uid)
+}
+
+// WarehouseStock is the resolver for the warehouseStock field.
+func (r *queryResolver) WarehouseStock(ctx context.Context, warehouseID string, pagination *models.PaginationInput) ([]*domain.StockLevel, error) {
+	wid, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 50, 0
 	if pagination != nil {
 		if pagination.Limit != nil {
 			l = int(*pagination.Limit)
@@ -611,35 +1626,57 @@ func (r *queryResolver) Orders(ctx context.Context, filter *models.OrderFilterIn
 			o = int(*pagination.Offset)
 		}
 	}
-	if filter != nil && filter.CustomerID != nil {
-		cid, err := uuid.Parse(*filter.CustomerID)
-		if err != nil {
-			return nil, err
+	return r.warehouseService.GetWarehouseStock(ctx, wid, l, o)
+}
+
+// ReorderSuggestions is the resolver for the reorderSuggestions field.
+func (r *queryResolver) ReorderSuggestions(ctx context.Context, pagination *models.PaginationInput) ([]*domain.ReorderSuggestion, error) {
+	l, o := 50, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
 		}
-		return r.orderService.GetOrdersByCustomer(ctx, cid, l, o)
 	}
-	if filter != nil && filter.Status != nil {
-		return r.orderService.GetOrdersByStatus(ctx, *filter.Status, l, o)
+	return r.inventoryService.GetReorderSuggestions(ctx, l, o)
+}
+
+// Suppliers is the resolver for the suppliers field.
+func (r *queryResolver) Suppliers(ctx context.Context, pagination *models.PaginationInput, orderBy []*models.SupplierOrderBy) ([]*domain.Supplier, error) {
+	sort, err := supplierSort(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 50, 0
+	if pagination != nil {
+		if pagination.Limit != nil {
+			l = int(*pagination.Limit)
+		}
+		if pagination.Offset != nil {
+			o = int(*pagination.Offset)
+		}
 	}
-	return r.orderService.GetOrders(ctx, l, o)
+	return pageItems(r.supplierService.GetSuppliers(ctx, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
 }
 
-// Order is the resolver for the order field.
-func (r *queryResolver) Order(ctx context.Context, id string) (*domain.Order, error) {
+// Supplier is the resolver for the supplier field.
+func (r *queryResolver) Supplier(ctx context.Context, id string) (*domain.Supplier, error) {
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
-	return r.orderService.GetOrder(ctx, uid)
+	return r.supplierService.GetSupplier(ctx, uid)
 }
 
-// OrdersByCustomer is the resolver for the ordersByCustomer field.
-func (r *queryResolver) OrdersByCustomer(ctx context.Context, customerID string, pagination *models.PaginationInput) ([]*domain.Order, error) {
-	cid, err := uuid.Parse(customerID)
+// PurchaseOrders is the resolver for the purchaseOrders field.
+func (r *queryResolver) PurchaseOrders(ctx context.Context, pagination *models.PaginationInput, orderBy []*models.PurchaseOrderOrderBy) ([]*domain.PurchaseOrder, error) {
+	sort, err := purchaseOrderSort(orderBy)
 	if err != nil {
 		return nil, err
 	}
-	l, o := 10, 0
+	l, o := 50, 0
 	if pagination != nil {
 		if pagination.Limit != nil {
 			l = int(*pagination.Limit)
@@ -648,12 +1685,25 @@ func (r *queryResolver) OrdersByCustomer(ctx context.Context, customerID string,
 			o = int(*pagination.Offset)
 		}
 	}
-	return r.orderService.GetOrdersByCustomer(ctx, cid, l, o)
+	return pageItems(r.purchaseOrderService.GetPurchaseOrders(ctx, ports.PageRequest{Limit: l, Offset: o, Sort: sort}))
 }
 
-// OrdersByStatus is the resolver for the ordersByStatus field.
-func (r *queryResolver) OrdersByStatus(ctx context.Context, status domain.OrderStatus, pagination *models.PaginationInput) ([]*domain.Order, error) {
-	l, o := 10, 0
+// PurchaseOrder is the resolver for the purchaseOrder field.
+func (r *queryResolver) PurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.purchaseOrderService.GetPurchaseOrder(ctx, uid)
+}
+
+// SupplierPurchaseOrders is the resolver for the supplierPurchaseOrders field.
+func (r *queryResolver) SupplierPurchaseOrders(ctx context.Context, supplierID string, pagination *models.PaginationInput) ([]*domain.PurchaseOrder, error) {
+	sid, err := uuid.Parse(supplierID)
+	if err != nil {
+		return nil, err
+	}
+	l, o := 50, 0
 	if pagination != nil {
 		if pagination.Limit != nil {
 			l = int(*pagination.Limit)
@@ -662,41 +1712,37 @@ func (r *queryResolver) OrdersByStatus(ctx context.Context, status domain.OrderS
 			o = int(*pagination.Offset)
 		}
 	}
-	return r.orderService.GetOrdersByStatus(ctx, status, l, o)
-}
-
-// OrderByNumber is the resolver for the orderByNumber field.
-func (r *queryResolver) OrderByNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
-	return r.orderService.GetOrderByNumber(ctx, orderNumber)
+	return pageItems(r.purchaseOrderService.GetPurchaseOrdersBySupplier(ctx, sid, ports.PageRequest{Limit: l, Offset: o}))
 }
 
 // OrderStats is the resolver for the orderStats field.
 func (r *queryResolver) OrderStats(ctx context.Context) (*models.OrderStats, error) {
-	orders, err := r.orderService.GetOrders(ctx, 10000, 0)
+	orders, err := pageItems(r.orderService.GetOrders(ctx, ports.PageRequest{Limit: 10000}))
 	if err != nil {
 		return nil, err
 	}
-	var totalRevenue float64
+	var totalRevenue domain.Money
 	counts := map[domain.OrderStatus]int{}
 	var ordersToday int
-	var revenueToday float64
+	var revenueToday domain.Money
 	today := time.Now().Format("2006-01-02")
 	for _, o := range orders {
-		totalRevenue += o.TotalAmount
+		if totalRevenue, err = totalRevenue.Add(o.TotalAmount); err != nil {
+			return nil, err
+		}
 		counts[o.Status]++
 		if o.OrderDate.Format("2006-01-02") == today {
 			ordersToday++
-			revenueToday += o.TotalAmount
+			if revenueToday, err = revenueToday.Add(o.TotalAmount); err != nil {
+				return nil, err
+			}
 		}
 	}
 	statusCounts := make([]*models.OrderStatusCount, 0, len(counts))
 	for st, c := range counts {
 		statusCounts = append(statusCounts, &models.OrderStatusCount{Status: st, Count: int32(c)})
 	}
-	avg := 0.0
-	if len(orders) > 0 {
-		avg = totalRevenue / float64(len(orders))
-	}
+	avg := totalRevenue.Divide(len(orders))
 	return &models.OrderStats{
 		TotalOrders:       int32(len(orders)),
 		TotalRevenue:      totalRevenue,
@@ -709,12 +1755,12 @@ func (r *queryResolver) OrderStats(ctx context.Context) (*models.OrderStats, err
 
 // ProductStats is the resolver for the productStats field.
 func (r *queryResolver) ProductStats(ctx context.Context) (*models.ProductStats, error) {
-	products, err := r.productService.GetProducts(ctx, 10000, 0)
+	products, err := pageItems(r.productService.GetProducts(ctx, ports.PageRequest{Limit: 10000}))
 	if err != nil {
 		return nil, err
 	}
 	var total, active, inactive, low, out int
-	var totalValue float64
+	var totalValue domain.Money
 	for _, p := range products {
 		total++
 		if p.IsActive {
@@ -725,10 +1771,12 @@ func (r *queryResolver) ProductStats(ctx context.Context) (*models.ProductStats,
 		if p.Stock == 0 {
 			out++
 		}
-		if p.Stock < 10 {
+		if p.IsLowStock() {
 			low++
 		}
-		totalValue += p.Price * float64(p.Stock)
+		if totalValue, err = totalValue.Add(p.Price.Multiply(p.Stock)); err != nil {
+			return nil, err
+		}
 	}
 	return &models.ProductStats{
 		TotalProducts:       int32(total),
@@ -742,23 +1790,25 @@ func (r *queryResolver) ProductStats(ctx context.Context) (*models.ProductStats,
 
 // CustomerStats is the resolver for the customerStats field.
 func (r *queryResolver) CustomerStats(ctx context.Context) (*models.CustomerStats, error) {
-	customers, err := r.customerService.GetCustomers(ctx, 10000, 0)
+	customers, err := pageItems(r.customerService.GetCustomers(ctx, ports.PageRequest{Limit: 10000}))
 	if err != nil {
 		return nil, err
 	}
-	orders, err := r.orderService.GetOrders(ctx, 10000, 0)
+	orders, err := pageItems(r.orderService.GetOrders(ctx, ports.PageRequest{Limit: 10000}))
 	if err != nil {
 		return nil, err
 	}
 	custIdToAgg := map[uuid.UUID]struct {
 		total int
-		spent float64
+		spent domain.Money
 		last  *time.Time
 	}{}
 	for _, o := range orders {
 		a := custIdToAgg[o.CustomerID]
 		a.total++
-		a.spent += o.TotalAmount
+		if a.spent, err = a.spent.Add(o.TotalAmount); err != nil {
+			return nil, err
+		}
 		if a.last == nil || (o.OrderDate.After(*a.last)) {
 			t := o.OrderDate
 			a.last = &t
@@ -790,40 +1840,348 @@ func (r *queryResolver) CustomerStats(ctx context.Context) (*models.CustomerStat
 	}, nil
 }
 
+// ID is the resolver for the id field.
+func (r *refundResolver) ID(ctx context.Context, obj *domain.Refund) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ReturnRequestID is the resolver for the returnRequestId field.
+func (r *refundResolver) ReturnRequestID(ctx context.Context, obj *domain.Refund) (string, error) {
+	return obj.ReturnRequestID.String(), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *refundResolver) OrderID(ctx context.Context, obj *domain.Refund) (string, error) {
+	return obj.OrderID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *reorderSuggestionResolver) ProductID(ctx context.Context, obj *domain.ReorderSuggestion) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// Stock is the resolver for the stock field.
+func (r *reorderSuggestionResolver) Stock(ctx context.Context, obj *domain.ReorderSuggestion) (int32, error) {
+	return int32(obj.Stock), nil
+}
+
+// AvailableStock is the resolver for the availableStock field.
+func (r *reorderSuggestionResolver) AvailableStock(ctx context.Context, obj *domain.ReorderSuggestion) (int32, error) {
+	return int32(obj.AvailableStock), nil
+}
+
+// ReorderPoint is the resolver for the reorderPoint field.
+func (r *reorderSuggestionResolver) ReorderPoint(ctx context.Context, obj *domain.ReorderSuggestion) (int32, error) {
+	return int32(obj.ReorderPoint), nil
+}
+
+// ReorderQuantity is the resolver for the reorderQuantity field.
+func (r *reorderSuggestionResolver) ReorderQuantity(ctx context.Context, obj *domain.ReorderSuggestion) (int32, error) {
+	return int32(obj.ReorderQuantity), nil
+}
+
+// SuggestedQuantity is the resolver for the suggestedQuantity field.
+func (r *reorderSuggestionResolver) SuggestedQuantity(ctx context.Context, obj *domain.ReorderSuggestion) (int32, error) {
+	return int32(obj.SuggestedQuantity), nil
+}
+
+// ID is the resolver for the id field.
+func (r *returnItemResolver) ID(ctx context.Context, obj *domain.ReturnItem) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// OrderItemID is the resolver for the orderItemId field.
+func (r *returnItemResolver) OrderItemID(ctx context.Context, obj *domain.ReturnItem) (string, error) {
+	return obj.OrderItemID.String(), nil
+}
+
+// Quantity is the resolver for the quantity field.
+func (r *returnItemResolver) Quantity(ctx context.Context, obj *domain.ReturnItem) (int32, error) {
+	return int32(obj.Quantity), nil
+}
+
+// ID is the resolver for the id field.
+func (r *returnRequestResolver) ID(ctx context.Context, obj *domain.ReturnRequest) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *returnRequestResolver) OrderID(ctx context.Context, obj *domain.ReturnRequest) (string, error) {
+	return obj.OrderID.String(), nil
+}
+
+// CustomerID is the resolver for the customerId field.
+func (r *returnRequestResolver) CustomerID(ctx context.Context, obj *domain.ReturnRequest) (string, error) {
+	return obj.CustomerID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *shipmentResolver) ID(ctx context.Context, obj *domain.Shipment) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *shipmentResolver) OrderID(ctx context.Context, obj *domain.Shipment) (string, error) {
+	return obj.OrderID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *shipmentItemResolver) ID(ctx context.Context, obj *domain.ShipmentItem) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ShipmentID is the resolver for the shipmentId field.
+func (r *shipmentItemResolver) ShipmentID(ctx context.Context, obj *domain.ShipmentItem) (string, error) {
+	return obj.ShipmentID.String(), nil
+}
+
+// OrderItemID is the resolver for the orderItemId field.
+func (r *shipmentItemResolver) OrderItemID(ctx context.Context, obj *domain.ShipmentItem) (string, error) {
+	return obj.OrderItemID.String(), nil
+}
+
+// Quantity is the resolver for the quantity field.
+func (r *shipmentItemResolver) Quantity(ctx context.Context, obj *domain.ShipmentItem) (int32, error) {
+	return int32(obj.Quantity), nil
+}
+
+// MethodID is the resolver for the methodId field.
+func (r *shippingRateResolver) MethodID(ctx context.Context, obj *domain.ShippingRate) (string, error) {
+	return obj.MethodID.String(), nil
+}
+
+// Type is the resolver for the type field.
+func (r *shippingRateResolver) Type(ctx context.Context, obj *domain.ShippingRate) (string, error) {
+	return string(obj.Type), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *stockLevelResolver) ProductID(ctx context.Context, obj *domain.StockLevel) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// Quantity is the resolver for the quantity field.
+func (r *stockLevelResolver) Quantity(ctx context.Context, obj *domain.StockLevel) (int32, error) {
+	return int32(obj.Quantity), nil
+}
+
+// ID is the resolver for the id field.
+func (r *stockMovementResolver) ID(ctx context.Context, obj *domain.StockMovement) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *stockMovementResolver) ProductID(ctx context.Context, obj *domain.StockMovement) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// Delta is the resolver for the delta field.
+func (r *stockMovementResolver) Delta(ctx context.Context, obj *domain.StockMovement) (int32, error) {
+	return int32(obj.Delta), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *stockMovementResolver) OrderID(ctx context.Context, obj *domain.StockMovement) (*string, error) {
+	if obj.OrderID == nil {
+		return nil, nil
+	}
+	id := obj.OrderID.String()
+	return &id, nil
+}
+
+// WarehouseID is the resolver for the warehouseId field.
+func (r *stockMovementResolver) WarehouseID(ctx context.Context, obj *domain.StockMovement) (*string, error) {
+	if obj.WarehouseID == nil {
+		return nil, nil
+	}
+	id := obj.WarehouseID.String()
+	return &id, nil
+}
+
+// VariantID is the resolver for the variantId field.
+func (r *stockMovementResolver) VariantID(ctx context.Context, obj *domain.StockMovement) (*string, error) {
+	if obj.VariantID == nil {
+		return nil, nil
+	}
+	id := obj.VariantID.String()
+	return &id, nil
+}
+
+// ID is the resolver for the id field.
+func (r *stockReservationResolver) ID(ctx context.Context, obj *domain.StockReservation) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ProductID is the resolver for the productId field.
+func (r *stockReservationResolver) ProductID(ctx context.Context, obj *domain.StockReservation) (string, error) {
+	return obj.ProductID.String(), nil
+}
+
+// CustomerID is the resolver for the customerId field.
+func (r *stockReservationResolver) CustomerID(ctx context.Context, obj *domain.StockReservation) (string, error) {
+	return obj.CustomerID.String(), nil
+}
+
+// Quantity is the resolver for the quantity field.
+func (r *stockReservationResolver) Quantity(ctx context.Context, obj *domain.StockReservation) (int32, error) {
+	return int32(obj.Quantity), nil
+}
+
+// OrderID is the resolver for the orderId field.
+func (r *stockReservationResolver) OrderID(ctx context.Context, obj *domain.StockReservation) (*string, error) {
+	if obj.OrderID == nil {
+		return nil, nil
+	}
+	id := obj.OrderID.String()
+	return &id, nil
+}
+
+// ID is the resolver for the id field.
+func (r *supplierResolver) ID(ctx context.Context, obj *domain.Supplier) (string, error) {
+	return obj.ID.String(), nil
+}
+
 // ID is the resolver for the id field.
 func (r *userResolver) ID(ctx context.Context, obj *domain.User) (string, error) {
 	return obj.ID.String(), nil
 }
 
+// ID is the resolver for the id field.
+func (r *warehouseResolver) ID(ctx context.Context, obj *domain.Warehouse) (string, error) {
+	return obj.ID.String(), nil
+}
+
 // Category returns graph.CategoryResolver implementation.
 func (r *Resolver) Category() graph.CategoryResolver { return &categoryResolver{r} }
 
+// CategoryFacet returns graph.CategoryFacetResolver implementation.
+func (r *Resolver) CategoryFacet() graph.CategoryFacetResolver { return &categoryFacetResolver{r} }
+
 // Customer returns graph.CustomerResolver implementation.
 func (r *Resolver) Customer() graph.CustomerResolver { return &customerResolver{r} }
 
+// FacetValue returns graph.FacetValueResolver implementation.
+func (r *Resolver) FacetValue() graph.FacetValueResolver { return &facetValueResolver{r} }
+
 // Mutation returns graph.MutationResolver implementation.
 func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
 
 // Order returns graph.OrderResolver implementation.
 func (r *Resolver) Order() graph.OrderResolver { return &orderResolver{r} }
 
+// OrderDiscount returns graph.OrderDiscountResolver implementation.
+func (r *Resolver) OrderDiscount() graph.OrderDiscountResolver { return &orderDiscountResolver{r} }
+
 // OrderItem returns graph.OrderItemResolver implementation.
 func (r *Resolver) OrderItem() graph.OrderItemResolver { return &orderItemResolver{r} }
 
+// OrderQuote returns graph.OrderQuoteResolver implementation.
+func (r *Resolver) OrderQuote() graph.OrderQuoteResolver { return &orderQuoteResolver{r} }
+
+// OrderQuoteDiscount returns graph.OrderQuoteDiscountResolver implementation.
+func (r *Resolver) OrderQuoteDiscount() graph.OrderQuoteDiscountResolver {
+	return &orderQuoteDiscountResolver{r}
+}
+
+// OrderQuoteLine returns graph.OrderQuoteLineResolver implementation.
+func (r *Resolver) OrderQuoteLine() graph.OrderQuoteLineResolver { return &orderQuoteLineResolver{r} }
+
+// OrderStatusHistory returns graph.OrderStatusHistoryResolver implementation.
+func (r *Resolver) OrderStatusHistory() graph.OrderStatusHistoryResolver {
+	return &orderStatusHistoryResolver{r}
+}
+
 // Product returns graph.ProductResolver implementation.
 func (r *Resolver) Product() graph.ProductResolver { return &productResolver{r} }
 
+// ProductFacets returns graph.ProductFacetsResolver implementation.
+func (r *Resolver) ProductFacets() graph.ProductFacetsResolver { return &productFacetsResolver{r} }
+
+// ProductVariant returns graph.ProductVariantResolver implementation.
+func (r *Resolver) ProductVariant() graph.ProductVariantResolver { return &productVariantResolver{r} }
+
+// PurchaseOrder returns graph.PurchaseOrderResolver implementation.
+func (r *Resolver) PurchaseOrder() graph.PurchaseOrderResolver { return &purchaseOrderResolver{r} }
+
+// PurchaseOrderLine returns graph.PurchaseOrderLineResolver implementation.
+func (r *Resolver) PurchaseOrderLine() graph.PurchaseOrderLineResolver {
+	return &purchaseOrderLineResolver{r}
+}
+
 // Query returns graph.QueryResolver implementation.
 func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
 
+// Refund returns graph.RefundResolver implementation.
+func (r *Resolver) Refund() graph.RefundResolver { return &refundResolver{r} }
+
+// ReorderSuggestion returns graph.ReorderSuggestionResolver implementation.
+func (r *Resolver) ReorderSuggestion() graph.ReorderSuggestionResolver {
+	return &reorderSuggestionResolver{r}
+}
+
+// ReturnItem returns graph.ReturnItemResolver implementation.
+func (r *Resolver) ReturnItem() graph.ReturnItemResolver { return &returnItemResolver{r} }
+
+// ReturnRequest returns graph.ReturnRequestResolver implementation.
+func (r *Resolver) ReturnRequest() graph.ReturnRequestResolver { return &returnRequestResolver{r} }
+
+// Shipment returns graph.ShipmentResolver implementation.
+func (r *Resolver) Shipment() graph.ShipmentResolver { return &shipmentResolver{r} }
+
+// ShipmentItem returns graph.ShipmentItemResolver implementation.
+func (r *Resolver) ShipmentItem() graph.ShipmentItemResolver { return &shipmentItemResolver{r} }
+
+// ShippingRate returns graph.ShippingRateResolver implementation.
+func (r *Resolver) ShippingRate() graph.ShippingRateResolver { return &shippingRateResolver{r} }
+
+// StockLevel returns graph.StockLevelResolver implementation.
+func (r *Resolver) StockLevel() graph.StockLevelResolver { return &stockLevelResolver{r} }
+
+// StockMovement returns graph.StockMovementResolver implementation.
+func (r *Resolver) StockMovement() graph.StockMovementResolver { return &stockMovementResolver{r} }
+
+// StockReservation returns graph.StockReservationResolver implementation.
+func (r *Resolver) StockReservation() graph.StockReservationResolver {
+	return &stockReservationResolver{r}
+}
+
+// Supplier returns graph.SupplierResolver implementation.
+func (r *Resolver) Supplier() graph.SupplierResolver { return &supplierResolver{r} }
+
 // User returns graph.UserResolver implementation.
 func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
 
+// Warehouse returns graph.WarehouseResolver implementation.
+func (r *Resolver) Warehouse() graph.WarehouseResolver { return &warehouseResolver{r} }
+
 type categoryResolver struct{ *Resolver }
+type categoryFacetResolver struct{ *Resolver }
 type customerResolver struct{ *Resolver }
+type facetValueResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type orderResolver struct{ *Resolver }
+type orderDiscountResolver struct{ *Resolver }
 type orderItemResolver struct{ *Resolver }
+type orderQuoteResolver struct{ *Resolver }
+type orderQuoteDiscountResolver struct{ *Resolver }
+type orderQuoteLineResolver struct{ *Resolver }
+type orderStatusHistoryResolver struct{ *Resolver }
 type productResolver struct{ *Resolver }
+type productFacetsResolver struct{ *Resolver }
+type productVariantResolver struct{ *Resolver }
+type purchaseOrderResolver struct{ *Resolver }
+type purchaseOrderLineResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type refundResolver struct{ *Resolver }
+type reorderSuggestionResolver struct{ *Resolver }
+type returnItemResolver struct{ *Resolver }
+type returnRequestResolver struct{ *Resolver }
+type shipmentResolver struct{ *Resolver }
+type shipmentItemResolver struct{ *Resolver }
+type shippingRateResolver struct{ *Resolver }
+type stockLevelResolver struct{ *Resolver }
+type stockMovementResolver struct{ *Resolver }
+type stockReservationResolver struct{ *Resolver }
+type supplierResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
+type warehouseResolver struct{ *Resolver }
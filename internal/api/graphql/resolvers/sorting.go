@@ -0,0 +1,70 @@
+package resolvers
+
+import (
+	"strings"
+
+	models "silbackendassessment/internal/api/graphql/graph/model"
+	"silbackendassessment/internal/core/ports"
+)
+
+// sortOf converts an orderBy argument to a sort checked against the fields allowed. The
+// field enums name the fields in upper case, so NAME sorts by name and CREATED_AT by created_at.
+func sortOf[T any](allowed ports.SortFields, orderBy []T, term func(T) (string, models.SortDirection)) (ports.Sort, error) {
+	specs := make([]string, len(orderBy))
+	for i, o := range orderBy {
+		field, direction := term(o)
+		specs[i] = strings.ToLower(field)
+		if direction == models.SortDirectionDesc {
+			specs[i] = "-" + specs[i]
+		}
+	}
+	return allowed.ParseSort(strings.Join(specs, ","))
+}
+
+func userSort(orderBy []*models.UserOrderBy) (ports.Sort, error) {
+	return sortOf(ports.UserSortFields, orderBy, func(o *models.UserOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func customerSort(orderBy []*models.CustomerOrderBy) (ports.Sort, error) {
+	return sortOf(ports.CustomerSortFields, orderBy, func(o *models.CustomerOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func categorySort(orderBy []*models.CategoryOrderBy) (ports.Sort, error) {
+	return sortOf(ports.CategorySortFields, orderBy, func(o *models.CategoryOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func productSort(orderBy []*models.ProductOrderBy) (ports.Sort, error) {
+	return sortOf(ports.ProductSortFields, orderBy, func(o *models.ProductOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func orderSort(orderBy []*models.OrderOrderBy) (ports.Sort, error) {
+	return sortOf(ports.OrderSortFields, orderBy, func(o *models.OrderOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func warehouseSort(orderBy []*models.WarehouseOrderBy) (ports.Sort, error) {
+	return sortOf(ports.WarehouseSortFields, orderBy, func(o *models.WarehouseOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func supplierSort(orderBy []*models.SupplierOrderBy) (ports.Sort, error) {
+	return sortOf(ports.SupplierSortFields, orderBy, func(o *models.SupplierOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
+
+func purchaseOrderSort(orderBy []*models.PurchaseOrderOrderBy) (ports.Sort, error) {
+	return sortOf(ports.PurchaseOrderSortFields, orderBy, func(o *models.PurchaseOrderOrderBy) (string, models.SortDirection) {
+		return string(o.Field), o.Direction
+	})
+}
@@ -19,13 +19,20 @@ import (
 
 // RouterConfig holds the configuration for the GraphQL router
 type RouterConfig struct {
-	UserService         ports.UserService
-	CustomerService     ports.CustomerService
-	CategoryService     ports.CategoryService
-	ProductService      ports.ProductService
-	OrderService        ports.OrderService
-	NotificationService ports.NotificationService
-	AuthMiddleware      *middleware.AuthMiddleware
+	UserService          ports.UserService
+	CustomerService      ports.CustomerService
+	CategoryService      ports.CategoryService
+	ProductService       ports.ProductService
+	OrderService         ports.OrderService
+	ShipmentService      ports.ShipmentService
+	ReturnService        ports.ReturnService
+	InventoryService     ports.InventoryService
+	ReservationService   ports.ReservationService
+	WarehouseService     ports.WarehouseService
+	SupplierService      ports.SupplierService
+	PurchaseOrderService ports.PurchaseOrderService
+	NotificationService  ports.NotificationService
+	AuthMiddleware       *middleware.AuthMiddleware
 }
 
 // NewRouter creates a new GraphQL router with all handlers registered
@@ -39,6 +46,13 @@ func NewRouter(config *RouterConfig) *bunrouter.Router {
 		config.CategoryService,
 		config.ProductService,
 		config.OrderService,
+		config.ShipmentService,
+		config.ReturnService,
+		config.InventoryService,
+		config.ReservationService,
+		config.WarehouseService,
+		config.SupplierService,
+		config.PurchaseOrderService,
 		config.NotificationService,
 	)
 
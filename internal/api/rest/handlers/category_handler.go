@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bunrouter"
@@ -33,6 +33,10 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, req bunrouter.Re
 
 	category, err := h.categoryService.CreateCategory(req.Context(), &createReq)
 	if err != nil {
+		if errors.Is(err, domain.ErrSlugTaken) {
+			http.Error(w, "Failed to create category: "+err.Error(), http.StatusConflict)
+			return err
+		}
 		http.Error(w, "Failed to create category: "+err.Error(), http.StatusBadRequest)
 		return err
 	}
@@ -63,38 +67,20 @@ func (h *CategoryHandler) GetCategory(w http.ResponseWriter, req bunrouter.Reque
 
 // GetCategories retrieves all categories with pagination
 func (h *CategoryHandler) GetCategories(w http.ResponseWriter, req bunrouter.Request) error {
-	limitStr := req.URL.Query().Get("limit")
-	offsetStr := req.URL.Query().Get("offset")
-
-	limit := 10
-	offset := 0
-
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
-
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	page, err := pageRequest(req, 10, ports.CategorySortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
 	}
 
-	categories, err := h.categoryService.GetCategories(req.Context(), limit, offset)
+	categories, err := h.categoryService.GetCategories(req.Context(), page)
 	if err != nil {
 		http.Error(w, "Failed to get categories: "+err.Error(), http.StatusInternalServerError)
 		return err
 	}
 
-	response := map[string]interface{}{
-		"categories": categories,
-		"limit":      limit,
-		"offset":     offset,
-	}
-
 	w.Header().Set("Content-Type", "application/json")
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(pageResponse("categories", categories, page))
 }
 
 // UpdateCategory updates an existing category
@@ -114,6 +100,10 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, req bunrouter.Re
 
 	category, err := h.categoryService.UpdateCategory(req.Context(), id, &updateReq)
 	if err != nil {
+		if errors.Is(err, domain.ErrCategoryCycle) || errors.Is(err, domain.ErrSlugTaken) {
+			http.Error(w, "Failed to update category: "+err.Error(), http.StatusConflict)
+			return err
+		}
 		http.Error(w, "Failed to update category: "+err.Error(), http.StatusBadRequest)
 		return err
 	}
@@ -122,6 +112,101 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, req bunrouter.Re
 	return json.NewEncoder(w).Encode(category)
 }
 
+// MoveCategory moves a category and its subtree under a new parent, or to the root
+func (h *CategoryHandler) MoveCategory(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return err
+	}
+
+	var moveReq domain.MoveCategoryRequest
+	if err := json.NewDecoder(req.Body).Decode(&moveReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	category, err := h.categoryService.MoveCategory(req.Context(), id, &moveReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryCycle) || errors.Is(err, domain.ErrSlugTaken) {
+			http.Error(w, "Failed to move category: "+err.Error(), http.StatusConflict)
+			return err
+		}
+		http.Error(w, "Failed to move category: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(category)
+}
+
+// GetCategoryByPath retrieves a category by its slug path, such as
+// /api/categories/by-path/electronics/phones. Paths using old slugs or casing are
+// permanently redirected to the category's canonical path.
+func (h *CategoryHandler) GetCategoryByPath(w http.ResponseWriter, req bunrouter.Request) error {
+	lookup, err := h.categoryService.GetCategoryByPath(req.Context(), req.Param("path"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSlug) {
+			http.Error(w, "Invalid category path: "+err.Error(), http.StatusBadRequest)
+			return err
+		}
+		http.Error(w, "Category not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	if lookup.Redirected {
+		http.Redirect(w, req.Request, "/api/categories/by-path/"+lookup.CanonicalPath, http.StatusMovedPermanently)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(lookup)
+}
+
+// GetCategoryAncestors retrieves the breadcrumb of a category: its ancestors, root first
+func (h *CategoryHandler) GetCategoryAncestors(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return err
+	}
+
+	ancestors, err := h.categoryService.GetCategoryAncestors(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Category not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"category_id": id,
+		"ancestors":   ancestors,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GetCategoryTree retrieves a category with its whole subtree nested under children
+func (h *CategoryHandler) GetCategoryTree(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return err
+	}
+
+	tree, err := h.categoryService.GetCategoryTree(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Category not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(tree)
+}
+
 // DeleteCategory deletes a category
 func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, req bunrouter.Request) error {
 	idStr := req.Param("id")
@@ -149,9 +234,13 @@ func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, req bunrouter.Re
 func (h *CategoryHandler) RegisterRoutes(router *bunrouter.Router) {
 	api := router.NewGroup("/api/categories")
 	api.POST("", h.CreateCategory)
+	api.GET("/by-path/*path", h.GetCategoryByPath)
 	api.GET("/:id", h.GetCategory)
 	api.GET("", h.GetCategories)
 	api.PUT("/:id", h.UpdateCategory)
+	api.POST("/:id/move", h.MoveCategory)
+	api.GET("/:id/ancestors", h.GetCategoryAncestors)
+	api.GET("/:id/tree", h.GetCategoryTree)
 	api.DELETE("/:id", h.DeleteCategory)
 }
 
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// InventoryHandler handles stock ledger operations
+type InventoryHandler struct {
+	inventoryService ports.InventoryService
+}
+
+// NewInventoryHandler creates a new inventory handler
+func NewInventoryHandler(inventoryService ports.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// GetStockMovements retrieves the stock ledger of a product, newest first
+func (h *InventoryHandler) GetStockMovements(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+	page, err := pageRequest(req, 50, ports.StockMovementSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	movements, err := h.inventoryService.GetStockMovements(req.Context(), productID, page)
+	if err != nil {
+		http.Error(w, "Product not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := pageResponse("movements", movements, page)
+	response["product_id"] = productID
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GetReorderSuggestions lists active products below their reorder point, furthest below first
+func (h *InventoryHandler) GetReorderSuggestions(w http.ResponseWriter, req bunrouter.Request) error {
+	limit, offset := warehousePagination(req)
+
+	suggestions, err := h.inventoryService.GetReorderSuggestions(req.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, "Failed to get reorder suggestions: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"suggestions": suggestions,
+		"limit":       limit,
+		"offset":      offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// RegisterRoutes registers inventory routes
+func (h *InventoryHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/products/:id/stock-movements")
+	api.GET("", h.GetStockMovements)
+
+	inventory := router.NewGroup("/api/inventory")
+	inventory.GET("/reorder-suggestions", h.GetReorderSuggestions)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"silbackendassessment/internal/adapters/middleware"
 	"silbackendassessment/internal/core/ports"
 
 	"github.com/uptrace/bunrouter"
@@ -231,8 +232,9 @@ func (h *NotificationHandler) SendNotification(w http.ResponseWriter, req bunrou
 }
 
 // RegisterRoutes registers all notification routes
-func (h *NotificationHandler) RegisterRoutes(router *bunrouter.Router) {
+func (h *NotificationHandler) RegisterRoutes(router *bunrouter.Router, idempotency *middleware.IdempotencyMiddleware) {
 	api := router.NewGroup("/api/notifications")
+	api.Use(idempotency.Handle)
 	api.POST("/email", h.SendEmail)
 	api.POST("/sms", h.SendSMS)
 	api.POST("/bulk/email", h.SendBulkEmail)
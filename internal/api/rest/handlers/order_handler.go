@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
-	"strconv"
 
+	"silbackendassessment/internal/adapters/middleware"
 	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/ports"
 
@@ -34,7 +35,15 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, req bunrouter.Request)
 
 	order, err := h.orderService.CreateOrder(req.Context(), &createReq)
 	if err != nil {
-		http.Error(w, "Failed to create order: "+err.Error(), http.StatusBadRequest)
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, domain.ErrInvalidCoupon):
+			// The coupon cannot be used whatever the reason, which the customer has to fix
+		case errors.Is(err, domain.ErrReservationUnavailable), errors.Is(err, domain.ErrPromotionUnavailable):
+			// Held stock or a promotion's last use was taken by another order
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to create order: "+err.Error(), status)
 		return err
 	}
 
@@ -43,6 +52,42 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, req bunrouter.Request)
 	return json.NewEncoder(w).Encode(order)
 }
 
+// QuoteOrder previews the totals and discounts of an order without placing it
+func (h *OrderHandler) QuoteOrder(w http.ResponseWriter, req bunrouter.Request) error {
+	var quoteReq domain.CreateOrderRequest
+	if err := json.NewDecoder(req.Body).Decode(&quoteReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	quote, err := h.orderService.QuoteOrder(req.Context(), &quoteReq)
+	if err != nil {
+		http.Error(w, "Failed to quote order: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(quote)
+}
+
+// QuoteShipping lists what each shipping method that ships an order would charge for it
+func (h *OrderHandler) QuoteShipping(w http.ResponseWriter, req bunrouter.Request) error {
+	var quoteReq domain.CreateOrderRequest
+	if err := json.NewDecoder(req.Body).Decode(&quoteReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	rates, err := h.orderService.QuoteShipping(req.Context(), &quoteReq)
+	if err != nil {
+		http.Error(w, "Failed to quote shipping: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(map[string]interface{}{"shipping_rates": rates})
+}
+
 // GetOrder retrieves an order by ID
 func (h *OrderHandler) GetOrder(w http.ResponseWriter, req bunrouter.Request) error {
 	idStr := req.Param("id")
@@ -64,26 +109,16 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, req bunrouter.Request) er
 
 // GetOrders retrieves all orders with pagination and filtering
 func (h *OrderHandler) GetOrders(w http.ResponseWriter, req bunrouter.Request) error {
-	limitStr := req.URL.Query().Get("limit")
-	offsetStr := req.URL.Query().Get("offset")
 	customerIDStr := req.URL.Query().Get("customer_id")
 	statusStr := req.URL.Query().Get("status")
 
-	limit := 10
-	offset := 0
 	var customerID *uuid.UUID
 	var status *domain.OrderStatus
 
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
-
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	page, err := pageRequest(req, 10, ports.OrderSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
 	}
 
 	if customerIDStr != "" {
@@ -97,28 +132,23 @@ func (h *OrderHandler) GetOrders(w http.ResponseWriter, req bunrouter.Request) e
 		status = &s
 	}
 
-	var orders []*domain.Order
-	var err error
+	var orders *ports.Page[*domain.Order]
 
 	if customerID != nil {
-		orders, err = h.orderService.GetOrdersByCustomer(req.Context(), *customerID, limit, offset)
+		orders, err = h.orderService.GetOrdersByCustomer(req.Context(), *customerID, page)
 	} else if status != nil {
-		orders, err = h.orderService.GetOrdersByStatus(req.Context(), *status, limit, offset)
+		orders, err = h.orderService.GetOrdersByStatus(req.Context(), *status, page)
 	} else {
-		orders, err = h.orderService.GetOrders(req.Context(), limit, offset)
+		orders, err = h.orderService.GetOrders(req.Context(), page)
 	}
 	if err != nil {
 		http.Error(w, "Failed to get orders: "+err.Error(), http.StatusInternalServerError)
 		return err
 	}
 
-	response := map[string]interface{}{
-		"orders":      orders,
-		"limit":       limit,
-		"offset":      offset,
-		"customer_id": customerID,
-		"status":      status,
-	}
+	response := pageResponse("orders", orders, page)
+	response["customer_id"] = customerID
+	response["status"] = status
 
 	w.Header().Set("Content-Type", "application/json")
 	return json.NewEncoder(w).Encode(response)
@@ -141,7 +171,40 @@ func (h *OrderHandler) UpdateOrder(w http.ResponseWriter, req bunrouter.Request)
 
 	order, err := h.orderService.UpdateOrder(req.Context(), id, &updateReq)
 	if err != nil {
-		http.Error(w, "Failed to update order: "+err.Error(), http.StatusBadRequest)
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to update order: "+err.Error(), status)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(order)
+}
+
+// UpdateOrderItems adds, removes or changes the quantity of items on an order
+func (h *OrderHandler) UpdateOrderItems(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateOrderItemsRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	order, err := h.orderService.UpdateOrderItems(req.Context(), id, &updateReq)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrOrderNotEditable) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to update order items: "+err.Error(), status)
 		return err
 	}
 
@@ -172,12 +235,40 @@ func (h *OrderHandler) DeleteOrder(w http.ResponseWriter, req bunrouter.Request)
 	return json.NewEncoder(w).Encode(response)
 }
 
+// GetOrderHistory retrieves the status history of an order
+func (h *OrderHandler) GetOrderHistory(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return err
+	}
+
+	history, err := h.orderService.GetOrderStatusHistory(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Order not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"order_id": id,
+		"history":  history,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
 // RegisterRoutes registers order routes
-func (h *OrderHandler) RegisterRoutes(router *bunrouter.Router) {
+func (h *OrderHandler) RegisterRoutes(router *bunrouter.Router, idempotency *middleware.IdempotencyMiddleware) {
 	api := router.NewGroup("/api/orders")
-	api.POST("", h.CreateOrder)
+	api.POST("", idempotency.Handle(h.CreateOrder))
+	api.POST("/quote", h.QuoteOrder)
+	api.POST("/shipping-rates", h.QuoteShipping)
 	api.GET("/:id", h.GetOrder)
+	api.GET("/:id/history", h.GetOrderHistory)
 	api.GET("", h.GetOrders)
 	api.PUT("/:id", h.UpdateOrder)
+	api.PATCH("/:id/items", h.UpdateOrderItems)
 	api.DELETE("/:id", h.DeleteOrder)
 }
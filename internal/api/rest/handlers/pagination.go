@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"strconv"
+
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/uptrace/bunrouter"
+)
+
+// pageRequest reads the limit, offset, cursor and sort query parameters, defaulting to the
+// first defaultLimit items. A cursor continues the list where the previous page ended; the
+// sort, such as "-price,name", may only name the fields allowed.
+func pageRequest(req bunrouter.Request, defaultLimit int, allowed ports.SortFields) (ports.PageRequest, error) {
+	limit := defaultLimit
+	offset := 0
+
+	if limitStr := req.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr := req.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	sort, err := allowed.ParseSort(req.URL.Query().Get("sort"))
+	if err != nil {
+		return ports.PageRequest{}, err
+	}
+
+	return ports.NewPageRequest(limit, offset, req.URL.Query().Get("cursor"), sort)
+}
+
+// pageResponse is the envelope of a page of a list: the items under key, the total number
+// of items in the list, the cursor of the next page, null on the last page, and the sort
+func pageResponse[T any](key string, page *ports.Page[T], pageReq ports.PageRequest) map[string]interface{} {
+	return map[string]interface{}{
+		key:           page.Items,
+		"total_count": page.TotalCount,
+		"next_cursor": page.NextCursorString(),
+		"limit":       pageReq.Size(),
+		"offset":      pageReq.Offset,
+		"sort":        pageReq.Order().String(),
+	}
+}
@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
 	"net/http"
 	"strconv"
+	"time"
 
 	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/ports"
@@ -62,17 +66,200 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, req bunrouter.Request
 	return json.NewEncoder(w).Encode(product)
 }
 
-// GetProducts retrieves all products with pagination and filtering
+// GetProducts retrieves products matching any combination of filters, with facet counts
 func (h *ProductHandler) GetProducts(w http.ResponseWriter, req bunrouter.Request) error {
+	query, err := productQuery(req)
+	if err != nil {
+		http.Error(w, "Invalid filter: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+	page, err := pageRequest(req, 10, ports.ProductSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	products, err := h.productService.QueryProducts(req.Context(), query, page)
+	if err != nil {
+		http.Error(w, "Failed to get products: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	facets, err := h.productService.GetProductFacets(req.Context(), query)
+	if err != nil {
+		http.Error(w, "Failed to get product facets: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	response := pageResponse("products", products, page)
+	response["total"] = facets.Total
+	response["facets"] = facets
+	response["category_id"] = query.CategoryID
+	response["is_active"] = query.IsActive
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// UpdateProduct updates an existing product
+func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateProductRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	product, err := h.productService.UpdateProduct(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update product: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(product)
+}
+
+// DeleteProduct deletes a product
+func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, req bunrouter.Request) error {
+	idStr := req.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+
+	err = h.productService.DeleteProduct(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to delete product: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]string{
+		"message": "Product deleted successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// CreateVariant adds a variant to a product
+func (h *ProductHandler) CreateVariant(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+
+	var createReq domain.CreateProductVariantRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	variant, err := h.productService.CreateVariant(req.Context(), productID, &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create variant: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(variant)
+}
+
+// GetVariants retrieves a product's variants
+func (h *ProductHandler) GetVariants(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+
+	variants, err := h.productService.GetVariants(req.Context(), productID)
+	if err != nil {
+		http.Error(w, "Product not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"product_id": productID,
+		"variants":   variants,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// UpdateVariant updates a product's variant
+func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+	variantID, err := uuid.Parse(req.Param("variantId"))
+	if err != nil {
+		http.Error(w, "Invalid variant ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateProductVariantRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	variant, err := h.productService.UpdateVariant(req.Context(), productID, variantID, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update variant: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(variant)
+}
+
+// DeleteVariant deletes a product's variant
+func (h *ProductHandler) DeleteVariant(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+	variantID, err := uuid.Parse(req.Param("variantId"))
+	if err != nil {
+		http.Error(w, "Invalid variant ID", http.StatusBadRequest)
+		return err
+	}
+
+	if err := h.productService.DeleteVariant(req.Context(), productID, variantID); err != nil {
+		http.Error(w, "Failed to delete variant: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	response := map[string]string{
+		"message": "Variant deleted successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// SearchProducts finds products by words, or the start of words, in their name, SKU or description
+func (h *ProductHandler) SearchProducts(w http.ResponseWriter, req bunrouter.Request) error {
+	query := req.URL.Query().Get("q")
 	limitStr := req.URL.Query().Get("limit")
 	offsetStr := req.URL.Query().Get("offset")
-	categoryIDStr := req.URL.Query().Get("category_id")
-	isActiveStr := req.URL.Query().Get("is_active")
 
 	limit := 10
 	offset := 0
-	var categoryID *uuid.UUID
-	var isActive *bool
 
 	if limitStr != "" {
 		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
@@ -86,99 +273,299 @@ func (h *ProductHandler) GetProducts(w http.ResponseWriter, req bunrouter.Reques
 		}
 	}
 
-	if categoryIDStr != "" {
-		if id, err := uuid.Parse(categoryIDStr); err == nil {
-			categoryID = &id
+	results, err := h.productService.SearchProducts(req.Context(), query, limit, offset)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrEmptySearch) {
+			status = http.StatusBadRequest
 		}
+		http.Error(w, "Failed to search products: "+err.Error(), status)
+		return err
 	}
 
-	if isActiveStr != "" {
-		if active, err := strconv.ParseBool(isActiveStr); err == nil {
-			isActive = &active
-		}
+	response := map[string]interface{}{
+		"query":   query,
+		"results": results,
+		"limit":   limit,
+		"offset":  offset,
 	}
 
-	var products []*domain.Product
-	var err error
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
 
-	if categoryID != nil {
-		products, err = h.productService.GetProductsByCategory(req.Context(), *categoryID, limit, offset)
-	} else if isActive != nil && *isActive {
-		products, err = h.productService.GetActiveProducts(req.Context(), limit, offset)
-	} else {
-		products, err = h.productService.GetProducts(req.Context(), limit, offset)
-	}
+// ImportProducts creates or updates products from a CSV or JSON Lines body, by SKU. The
+// format comes from the format parameter or the Content-Type; dry_run only validates.
+func (h *ProductHandler) ImportProducts(w http.ResponseWriter, req bunrouter.Request) error {
+	format, err := productRecordFormat(req, req.Header.Get("Content-Type"))
 	if err != nil {
-		http.Error(w, "Failed to get products: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Invalid format: "+err.Error(), http.StatusBadRequest)
 		return err
 	}
+	dryRun := false
+	if dryRunStr := req.URL.Query().Get("dry_run"); dryRunStr != "" {
+		if dryRun, err = strconv.ParseBool(dryRunStr); err != nil {
+			http.Error(w, "Invalid dry_run: "+err.Error(), http.StatusBadRequest)
+			return err
+		}
+	}
 
-	response := map[string]interface{}{
-		"products":    products,
-		"limit":       limit,
-		"offset":      offset,
-		"category_id": categoryID,
-		"is_active":   isActive,
+	report, err := h.productService.ImportProducts(req.Context(), format, req.Body, dryRun)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidProductRecord) || errors.Is(err, domain.ErrUnsupportedFormat) {
+			http.Error(w, "Invalid import file: "+err.Error(), http.StatusBadRequest)
+			return err
+		}
+		http.Error(w, "Failed to import products: "+err.Error(), http.StatusInternalServerError)
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(report)
 }
 
-// UpdateProduct updates an existing product
-func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, req bunrouter.Request) error {
-	idStr := req.Param("id")
-	id, err := uuid.Parse(idStr)
+// ExportProducts streams every product as CSV or JSON Lines, CSV unless format says otherwise
+func (h *ProductHandler) ExportProducts(w http.ResponseWriter, req bunrouter.Request) error {
+	format, err := productRecordFormat(req, "csv")
+	if err != nil {
+		http.Error(w, "Invalid format: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+	// Once the first batch is written the status is sent, so later errors cut the file short
+	return h.productService.ExportProducts(req.Context(), format, w)
+}
+
+// productRecordFormat reads the format parameter, falling back to a format name or
+// Content-Type
+func productRecordFormat(req bunrouter.Request, fallback string) (domain.ProductRecordFormat, error) {
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = fallback
+		if mediaType, _, err := mime.ParseMediaType(fallback); err == nil {
+			switch mediaType {
+			case "text/csv":
+				format = "csv"
+			case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+				format = "jsonl"
+			}
+		}
+	}
+	return domain.ParseProductRecordFormat(format)
+}
+
+// SchedulePrice schedules a price for a product
+func (h *ProductHandler) SchedulePrice(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
 	if err != nil {
 		http.Error(w, "Invalid product ID", http.StatusBadRequest)
 		return err
 	}
 
-	var updateReq domain.UpdateProductRequest
-	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+	var createReq domain.CreateProductPriceRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
 		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 		return err
 	}
 
-	product, err := h.productService.UpdateProduct(req.Context(), id, &updateReq)
+	price, err := h.productService.SchedulePrice(req.Context(), productID, &createReq)
 	if err != nil {
-		http.Error(w, "Failed to update product: "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "Failed to schedule price: "+err.Error(), http.StatusBadRequest)
 		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	return json.NewEncoder(w).Encode(product)
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(price)
 }
 
-// DeleteProduct deletes a product
-func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, req bunrouter.Request) error {
-	idStr := req.Param("id")
-	id, err := uuid.Parse(idStr)
+// GetPriceHistory lists the prices a product has had or is scheduled to have, optionally
+// limited to those in effect between the from and to query parameters
+func (h *ProductHandler) GetPriceHistory(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
 	if err != nil {
 		http.Error(w, "Invalid product ID", http.StatusBadRequest)
 		return err
 	}
+	from, err := timeParam(req, "from")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+	to, err := timeParam(req, "to")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
 
-	err = h.productService.DeleteProduct(req.Context(), id)
+	prices, err := h.productService.GetPriceHistory(req.Context(), productID, from, to)
 	if err != nil {
-		http.Error(w, "Failed to delete product: "+err.Error(), http.StatusNotFound)
+		status := http.StatusNotFound
+		if errors.Is(err, domain.ErrInvalidPrice) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to get price history: "+err.Error(), status)
 		return err
 	}
 
-	response := map[string]string{
-		"message": "Product deleted successfully",
+	response := map[string]interface{}{
+		"product_id": productID,
+		"prices":     prices,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	return json.NewEncoder(w).Encode(response)
 }
 
+// GetEffectivePrice retrieves what a product sells at now, or at the time in the at query parameter
+func (h *ProductHandler) GetEffectivePrice(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+	at, err := timeParam(req, "at")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+	if at == nil {
+		now := time.Now()
+		at = &now
+	}
+
+	price, err := h.productService.GetEffectivePrice(req.Context(), productID, *at)
+	if err != nil {
+		http.Error(w, "Product not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(price)
+}
+
+// CancelPrice cancels a price that has not taken effect yet
+func (h *ProductHandler) CancelPrice(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+	priceID, err := uuid.Parse(req.Param("priceId"))
+	if err != nil {
+		http.Error(w, "Invalid price ID", http.StatusBadRequest)
+		return err
+	}
+
+	if err := h.productService.CancelPrice(req.Context(), productID, priceID); err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, domain.ErrPriceStarted) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to cancel price: "+err.Error(), status)
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 // RegisterRoutes registers product routes
 func (h *ProductHandler) RegisterRoutes(router *bunrouter.Router) {
 	api := router.NewGroup("/api/products")
 	api.POST("", h.CreateProduct)
+	api.POST("/import", h.ImportProducts)
+	api.GET("/export", h.ExportProducts)
+	api.GET("/search", h.SearchProducts)
 	api.GET("/:id", h.GetProduct)
 	api.GET("", h.GetProducts)
 	api.PUT("/:id", h.UpdateProduct)
 	api.DELETE("/:id", h.DeleteProduct)
+	api.GET("/:id/variants", h.GetVariants)
+	api.POST("/:id/variants", h.CreateVariant)
+	api.PUT("/:id/variants/:variantId", h.UpdateVariant)
+	api.DELETE("/:id/variants/:variantId", h.DeleteVariant)
+	api.GET("/:id/price", h.GetEffectivePrice)
+	api.GET("/:id/prices", h.GetPriceHistory)
+	api.POST("/:id/prices", h.SchedulePrice)
+	api.DELETE("/:id/prices/:priceId", h.CancelPrice)
+}
+
+// productQuery reads product filters from query parameters. Invalid pagination falls back
+// to the defaults; any other invalid filter is an error.
+func productQuery(req bunrouter.Request) (*domain.ProductQuery, error) {
+	params := req.URL.Query()
+	query := &domain.ProductQuery{
+		Search: params.Get("search"),
+	}
+
+	if categoryIDStr := params.Get("category_id"); categoryIDStr != "" {
+		id, err := uuid.Parse(categoryIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category_id: %w", err)
+		}
+		query.CategoryID = &id
+	}
+	if isActiveStr := params.Get("is_active"); isActiveStr != "" {
+		active, err := strconv.ParseBool(isActiveStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid is_active: %w", err)
+		}
+		query.IsActive = &active
+	}
+
+	currency := params.Get("currency")
+	if minPriceStr := params.Get("min_price"); minPriceStr != "" {
+		price, err := domain.ParseMoney(minPriceStr, currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_price: %w", err)
+		}
+		query.MinPrice = &price
+	}
+	if maxPriceStr := params.Get("max_price"); maxPriceStr != "" {
+		price, err := domain.ParseMoney(maxPriceStr, currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_price: %w", err)
+		}
+		query.MaxPrice = &price
+	}
+	if minStockStr := params.Get("min_stock"); minStockStr != "" {
+		minStock, err := strconv.Atoi(minStockStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_stock: %w", err)
+		}
+		query.MinStock = &minStock
+	}
+
+	// Repeated attribute parameters on the same name match any of the values
+	for _, filter := range params["attribute"] {
+		name, value, err := domain.ParseAttributeFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		if query.Attributes == nil {
+			query.Attributes = make(map[string][]string)
+		}
+		query.Attributes[name] = append(query.Attributes[name], value)
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// timeParam reads an RFC 3339 time from a query parameter, returning nil when it is absent
+func timeParam(req bunrouter.Request, name string) (*time.Time, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s, expected an RFC 3339 time: %w", name, err)
+	}
+	return &t, nil
 }
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// PromotionHandler handles promotion and coupon operations
+type PromotionHandler struct {
+	promotionService ports.PromotionService
+}
+
+// NewPromotionHandler creates a new promotion handler
+func NewPromotionHandler(promotionService ports.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+	}
+}
+
+// CreatePromotion creates a new promotion, a coupon when it is given a code
+func (h *PromotionHandler) CreatePromotion(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreatePromotionRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(req.Context(), &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create promotion: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(promotion)
+}
+
+// GetPromotions retrieves promotions with pagination
+func (h *PromotionHandler) GetPromotions(w http.ResponseWriter, req bunrouter.Request) error {
+	page, err := pageRequest(req, 50, ports.PromotionSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	promotions, err := h.promotionService.GetPromotions(req.Context(), page)
+	if err != nil {
+		http.Error(w, "Failed to get promotions: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(pageResponse("promotions", promotions, page))
+}
+
+// GetPromotion retrieves a promotion by ID
+func (h *PromotionHandler) GetPromotion(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid promotion ID", http.StatusBadRequest)
+		return err
+	}
+
+	promotion, err := h.promotionService.GetPromotion(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Promotion not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(promotion)
+}
+
+// UpdatePromotion updates a promotion's limits, eligibility and dates, or deactivates it
+func (h *PromotionHandler) UpdatePromotion(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid promotion ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdatePromotionRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update promotion: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(promotion)
+}
+
+// RegisterRoutes registers promotion routes
+func (h *PromotionHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/promotions")
+	api.GET("", h.GetPromotions)
+	api.POST("", h.CreatePromotion)
+	api.GET("/:id", h.GetPromotion)
+	api.PUT("/:id", h.UpdatePromotion)
+}
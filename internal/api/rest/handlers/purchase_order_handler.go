@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// PurchaseOrderHandler handles purchase order operations
+type PurchaseOrderHandler struct {
+	purchaseOrderService ports.PurchaseOrderService
+}
+
+// NewPurchaseOrderHandler creates a new purchase order handler
+func NewPurchaseOrderHandler(purchaseOrderService ports.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		purchaseOrderService: purchaseOrderService,
+	}
+}
+
+// CreatePurchaseOrder creates a draft purchase order
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreatePurchaseOrderRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	po, err := h.purchaseOrderService.CreatePurchaseOrder(req.Context(), &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create purchase order: "+err.Error(), purchaseOrderErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(po)
+}
+
+// GetPurchaseOrders retrieves purchase orders with pagination, newest first
+func (h *PurchaseOrderHandler) GetPurchaseOrders(w http.ResponseWriter, req bunrouter.Request) error {
+	page, err := pageRequest(req, 50, ports.PurchaseOrderSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	pos, err := h.purchaseOrderService.GetPurchaseOrders(req.Context(), page)
+	if err != nil {
+		http.Error(w, "Failed to get purchase orders: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(pageResponse("purchase_orders", pos, page))
+}
+
+// GetPurchaseOrder retrieves a purchase order by ID
+func (h *PurchaseOrderHandler) GetPurchaseOrder(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return err
+	}
+
+	po, err := h.purchaseOrderService.GetPurchaseOrder(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Purchase order not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(po)
+}
+
+// UpdatePurchaseOrder updates a purchase order that has not been received
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdatePurchaseOrderRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	po, err := h.purchaseOrderService.UpdatePurchaseOrder(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update purchase order: "+err.Error(), purchaseOrderErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(po)
+}
+
+// SendPurchaseOrder marks a draft purchase order as sent to the supplier
+func (h *PurchaseOrderHandler) SendPurchaseOrder(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return err
+	}
+
+	po, err := h.purchaseOrderService.SendPurchaseOrder(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to send purchase order: "+err.Error(), purchaseOrderErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(po)
+}
+
+// ReceivePurchaseOrder records stock arriving against a purchase order. An empty body receives everything outstanding.
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return err
+	}
+
+	var receiveReq domain.ReceivePurchaseOrderRequest
+	if err := json.NewDecoder(req.Body).Decode(&receiveReq); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	po, err := h.purchaseOrderService.ReceivePurchaseOrder(req.Context(), id, &receiveReq)
+	if err != nil {
+		http.Error(w, "Failed to receive purchase order: "+err.Error(), purchaseOrderErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(po)
+}
+
+// purchaseOrderErrorStatus maps purchase order service errors to HTTP status codes
+func purchaseOrderErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// RegisterRoutes registers purchase order routes
+func (h *PurchaseOrderHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/purchase-orders")
+	api.GET("", h.GetPurchaseOrders)
+	api.POST("", h.CreatePurchaseOrder)
+	api.GET("/:id", h.GetPurchaseOrder)
+	api.PUT("/:id", h.UpdatePurchaseOrder)
+	api.POST("/:id/send", h.SendPurchaseOrder)
+	api.POST("/:id/receive", h.ReceivePurchaseOrder)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// ReservationHandler handles stock reservation operations
+type ReservationHandler struct {
+	reservationService ports.ReservationService
+}
+
+// NewReservationHandler creates a new reservation handler
+func NewReservationHandler(reservationService ports.ReservationService) *ReservationHandler {
+	return &ReservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// CreateReservation holds stock for a customer during checkout
+func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreateReservationRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	reservation, err := h.reservationService.CreateReservation(req.Context(), &createReq)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to create reservation: "+err.Error(), status)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(reservation)
+}
+
+// GetReservation retrieves a reservation by ID
+func (h *ReservationHandler) GetReservation(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return err
+	}
+
+	reservation, err := h.reservationService.GetReservation(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Reservation not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(reservation)
+}
+
+// ReleaseReservation gives held stock back before the reservation expires
+func (h *ReservationHandler) ReleaseReservation(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return err
+	}
+
+	reservation, err := h.reservationService.ReleaseReservation(req.Context(), id)
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to release reservation: "+err.Error(), status)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(reservation)
+}
+
+// RegisterRoutes registers reservation routes
+func (h *ReservationHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/reservations")
+	api.POST("", h.CreateReservation)
+	api.GET("/:id", h.GetReservation)
+	api.POST("/:id/release", h.ReleaseReservation)
+}
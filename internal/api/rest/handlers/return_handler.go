@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// ReturnHandler handles return and refund operations
+type ReturnHandler struct {
+	returnService ports.ReturnService
+}
+
+// NewReturnHandler creates a new return handler
+func NewReturnHandler(returnService ports.ReturnService) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returnService,
+	}
+}
+
+// RequestReturn creates a return request for items of a delivered order
+func (h *ReturnHandler) RequestReturn(w http.ResponseWriter, req bunrouter.Request) error {
+	orderID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return err
+	}
+
+	var createReq domain.CreateReturnRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	ret, err := h.returnService.RequestReturn(req.Context(), orderID, &createReq)
+	if err != nil {
+		http.Error(w, "Failed to request return: "+err.Error(), returnErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(ret)
+}
+
+// GetOrderReturns retrieves all return requests of an order
+func (h *ReturnHandler) GetOrderReturns(w http.ResponseWriter, req bunrouter.Request) error {
+	orderID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return err
+	}
+
+	returns, err := h.returnService.GetReturnsByOrder(req.Context(), orderID)
+	if err != nil {
+		http.Error(w, "Order not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"order_id": orderID,
+		"returns":  returns,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GetReturn retrieves a return request by ID
+func (h *ReturnHandler) GetReturn(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid return ID", http.StatusBadRequest)
+		return err
+	}
+
+	ret, err := h.returnService.GetReturn(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Return not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(ret)
+}
+
+// ApproveReturn approves a requested return
+func (h *ReturnHandler) ApproveReturn(w http.ResponseWriter, req bunrouter.Request) error {
+	return h.review(w, req, h.returnService.ApproveReturn)
+}
+
+// RejectReturn rejects a requested return
+func (h *ReturnHandler) RejectReturn(w http.ResponseWriter, req bunrouter.Request) error {
+	return h.review(w, req, h.returnService.RejectReturn)
+}
+
+// ReceiveReturn marks the items of an approved return as received, restocking them and issuing a refund
+func (h *ReturnHandler) ReceiveReturn(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid return ID", http.StatusBadRequest)
+		return err
+	}
+
+	ret, err := h.returnService.ReceiveReturn(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to receive return: "+err.Error(), returnErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(ret)
+}
+
+// review decodes an optional review note and applies the decision
+func (h *ReturnHandler) review(w http.ResponseWriter, req bunrouter.Request, decide func(ctx context.Context, id uuid.UUID, req *domain.ReviewReturnRequest) (*domain.ReturnRequest, error)) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid return ID", http.StatusBadRequest)
+		return err
+	}
+
+	var reviewReq domain.ReviewReturnRequest
+	if err := json.NewDecoder(req.Body).Decode(&reviewReq); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	ret, err := decide(req.Context(), id, &reviewReq)
+	if err != nil {
+		http.Error(w, "Failed to review return: "+err.Error(), returnErrorStatus(err))
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(ret)
+}
+
+// returnErrorStatus maps return service errors to HTTP status codes
+func returnErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// RegisterRoutes registers return routes
+func (h *ReturnHandler) RegisterRoutes(router *bunrouter.Router) {
+	orders := router.NewGroup("/api/orders/:id/returns")
+	orders.POST("", h.RequestReturn)
+	orders.GET("", h.GetOrderReturns)
+
+	api := router.NewGroup("/api/returns")
+	api.GET("/:id", h.GetReturn)
+	api.POST("/:id/approve", h.ApproveReturn)
+	api.POST("/:id/reject", h.RejectReturn)
+	api.POST("/:id/receive", h.ReceiveReturn)
+}
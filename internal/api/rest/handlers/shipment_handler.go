@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// ShipmentHandler handles shipment operations
+type ShipmentHandler struct {
+	shipmentService ports.ShipmentService
+}
+
+// NewShipmentHandler creates a new shipment handler
+func NewShipmentHandler(shipmentService ports.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{
+		shipmentService: shipmentService,
+	}
+}
+
+// CreateShipment creates a shipment for part or all of an order
+func (h *ShipmentHandler) CreateShipment(w http.ResponseWriter, req bunrouter.Request) error {
+	orderID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return err
+	}
+
+	var createReq domain.CreateShipmentRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	shipment, err := h.shipmentService.CreateShipment(req.Context(), orderID, &createReq)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to create shipment: "+err.Error(), status)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(shipment)
+}
+
+// GetShipments retrieves all shipments of an order
+func (h *ShipmentHandler) GetShipments(w http.ResponseWriter, req bunrouter.Request) error {
+	orderID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return err
+	}
+
+	shipments, err := h.shipmentService.GetShipmentsByOrder(req.Context(), orderID)
+	if err != nil {
+		http.Error(w, "Order not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"order_id":  orderID,
+		"shipments": shipments,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GetShipment retrieves a single shipment of an order
+func (h *ShipmentHandler) GetShipment(w http.ResponseWriter, req bunrouter.Request) error {
+	shipment, err := h.findShipment(w, req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(shipment)
+}
+
+// UpdateShipment updates a shipment's tracking details or status
+func (h *ShipmentHandler) UpdateShipment(w http.ResponseWriter, req bunrouter.Request) error {
+	shipment, err := h.findShipment(w, req)
+	if err != nil {
+		return err
+	}
+
+	var updateReq domain.UpdateShipmentRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	shipment, err = h.shipmentService.UpdateShipment(req.Context(), shipment.ID, &updateReq)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to update shipment: "+err.Error(), status)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(shipment)
+}
+
+// findShipment loads the shipment in the path and checks it belongs to the order in the path
+func (h *ShipmentHandler) findShipment(w http.ResponseWriter, req bunrouter.Request) (*domain.Shipment, error) {
+	orderID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return nil, err
+	}
+
+	shipmentID, err := uuid.Parse(req.Param("shipmentId"))
+	if err != nil {
+		http.Error(w, "Invalid shipment ID", http.StatusBadRequest)
+		return nil, err
+	}
+
+	shipment, err := h.shipmentService.GetShipment(req.Context(), shipmentID)
+	if err != nil {
+		http.Error(w, "Shipment not found: "+err.Error(), http.StatusNotFound)
+		return nil, err
+	}
+	if shipment.OrderID != orderID {
+		err := fmt.Errorf("shipment %s does not belong to order %s", shipmentID, orderID)
+		http.Error(w, "Shipment not found: "+err.Error(), http.StatusNotFound)
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// RegisterRoutes registers shipment routes
+func (h *ShipmentHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/orders/:id/shipments")
+	api.POST("", h.CreateShipment)
+	api.GET("", h.GetShipments)
+	api.GET("/:shipmentId", h.GetShipment)
+	api.PUT("/:shipmentId", h.UpdateShipment)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// ShippingHandler handles shipping method operations
+type ShippingHandler struct {
+	shippingService ports.ShippingService
+}
+
+// NewShippingHandler creates a new shipping handler
+func NewShippingHandler(shippingService ports.ShippingService) *ShippingHandler {
+	return &ShippingHandler{
+		shippingService: shippingService,
+	}
+}
+
+// CreateShippingMethod creates a shipping method
+func (h *ShippingHandler) CreateShippingMethod(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreateShippingMethodRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	method, err := h.shippingService.CreateShippingMethod(req.Context(), &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create shipping method: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(method)
+}
+
+// GetShippingMethods retrieves shipping methods with pagination
+func (h *ShippingHandler) GetShippingMethods(w http.ResponseWriter, req bunrouter.Request) error {
+	page, err := pageRequest(req, 50, ports.ShippingMethodSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	methods, err := h.shippingService.GetShippingMethods(req.Context(), page)
+	if err != nil {
+		http.Error(w, "Failed to get shipping methods: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(pageResponse("shipping_methods", methods, page))
+}
+
+// GetShippingMethod retrieves a shipping method by ID
+func (h *ShippingHandler) GetShippingMethod(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid shipping method ID", http.StatusBadRequest)
+		return err
+	}
+
+	method, err := h.shippingService.GetShippingMethod(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Shipping method not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(method)
+}
+
+// UpdateShippingMethod updates a shipping method; orders already placed keep what they were charged
+func (h *ShippingHandler) UpdateShippingMethod(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid shipping method ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateShippingMethodRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	method, err := h.shippingService.UpdateShippingMethod(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update shipping method: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(method)
+}
+
+// DeleteShippingMethod deletes a shipping method
+func (h *ShippingHandler) DeleteShippingMethod(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid shipping method ID", http.StatusBadRequest)
+		return err
+	}
+
+	if err := h.shippingService.DeleteShippingMethod(req.Context(), id); err != nil {
+		http.Error(w, "Failed to delete shipping method: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// RegisterRoutes registers shipping method routes
+func (h *ShippingHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/shipping-methods")
+	api.GET("", h.GetShippingMethods)
+	api.POST("", h.CreateShippingMethod)
+	api.GET("/:id", h.GetShippingMethod)
+	api.PUT("/:id", h.UpdateShippingMethod)
+	api.DELETE("/:id", h.DeleteShippingMethod)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// SupplierHandler handles supplier operations
+type SupplierHandler struct {
+	supplierService      ports.SupplierService
+	purchaseOrderService ports.PurchaseOrderService
+}
+
+// NewSupplierHandler creates a new supplier handler
+func NewSupplierHandler(supplierService ports.SupplierService, purchaseOrderService ports.PurchaseOrderService) *SupplierHandler {
+	return &SupplierHandler{
+		supplierService:      supplierService,
+		purchaseOrderService: purchaseOrderService,
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (h *SupplierHandler) CreateSupplier(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreateSupplierRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	supplier, err := h.supplierService.CreateSupplier(req.Context(), &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create supplier: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(supplier)
+}
+
+// GetSuppliers retrieves suppliers with pagination
+func (h *SupplierHandler) GetSuppliers(w http.ResponseWriter, req bunrouter.Request) error {
+	page, err := pageRequest(req, 50, ports.SupplierSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	suppliers, err := h.supplierService.GetSuppliers(req.Context(), page)
+	if err != nil {
+		http.Error(w, "Failed to get suppliers: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(pageResponse("suppliers", suppliers, page))
+}
+
+// GetSupplier retrieves a supplier by ID
+func (h *SupplierHandler) GetSupplier(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid supplier ID", http.StatusBadRequest)
+		return err
+	}
+
+	supplier, err := h.supplierService.GetSupplier(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Supplier not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(supplier)
+}
+
+// UpdateSupplier updates a supplier
+func (h *SupplierHandler) UpdateSupplier(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid supplier ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateSupplierRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	supplier, err := h.supplierService.UpdateSupplier(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update supplier: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(supplier)
+}
+
+// GetSupplierPurchaseOrders retrieves the purchase orders placed with a supplier, newest first
+func (h *SupplierHandler) GetSupplierPurchaseOrders(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid supplier ID", http.StatusBadRequest)
+		return err
+	}
+	page, err := pageRequest(req, 50, ports.PurchaseOrderSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	pos, err := h.purchaseOrderService.GetPurchaseOrdersBySupplier(req.Context(), id, page)
+	if err != nil {
+		http.Error(w, "Supplier not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := pageResponse("purchase_orders", pos, page)
+	response["supplier_id"] = id
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// RegisterRoutes registers supplier routes
+func (h *SupplierHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/suppliers")
+	api.GET("", h.GetSuppliers)
+	api.POST("", h.CreateSupplier)
+	api.GET("/:id", h.GetSupplier)
+	api.PUT("/:id", h.UpdateSupplier)
+	api.GET("/:id/purchase-orders", h.GetSupplierPurchaseOrders)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// TaxHandler handles tax rate operations
+type TaxHandler struct {
+	taxService ports.TaxService
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxService ports.TaxService) *TaxHandler {
+	return &TaxHandler{
+		taxService: taxService,
+	}
+}
+
+// CreateTaxRate creates a country's standard tax rate or the rate of one of its categories
+func (h *TaxHandler) CreateTaxRate(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreateTaxRateRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	rate, err := h.taxService.CreateTaxRate(req.Context(), &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create tax rate: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(rate)
+}
+
+// GetTaxRates retrieves tax rates with pagination
+func (h *TaxHandler) GetTaxRates(w http.ResponseWriter, req bunrouter.Request) error {
+	page, err := pageRequest(req, 50, ports.TaxRateSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	rates, err := h.taxService.GetTaxRates(req.Context(), page)
+	if err != nil {
+		http.Error(w, "Failed to get tax rates: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(pageResponse("tax_rates", rates, page))
+}
+
+// GetTaxRate retrieves a tax rate by ID
+func (h *TaxHandler) GetTaxRate(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return err
+	}
+
+	rate, err := h.taxService.GetTaxRate(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Tax rate not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(rate)
+}
+
+// UpdateTaxRate updates a tax rate; orders already placed keep the rate they were taxed at
+func (h *TaxHandler) UpdateTaxRate(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateTaxRateRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	rate, err := h.taxService.UpdateTaxRate(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update tax rate: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(rate)
+}
+
+// DeleteTaxRate deletes a tax rate
+func (h *TaxHandler) DeleteTaxRate(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return err
+	}
+
+	if err := h.taxService.DeleteTaxRate(req.Context(), id); err != nil {
+		http.Error(w, "Failed to delete tax rate: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// RegisterRoutes registers tax rate routes
+func (h *TaxHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/tax-rates")
+	api.GET("", h.GetTaxRates)
+	api.POST("", h.CreateTaxRate)
+	api.GET("/:id", h.GetTaxRate)
+	api.PUT("/:id", h.UpdateTaxRate)
+	api.DELETE("/:id", h.DeleteTaxRate)
+}
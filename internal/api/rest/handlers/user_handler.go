@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -60,38 +61,20 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, req bunrouter.Request) erro
 }
 
 func (h *UserHandler) GetUsers(w http.ResponseWriter, req bunrouter.Request) error {
-	limitStr := req.URL.Query().Get("limit")
-	offsetStr := req.URL.Query().Get("offset")
-
-	limit := 10
-	offset := 0
-
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
-
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	page, err := pageRequest(req, 10, ports.UserSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
 	}
 
-	users, err := h.userService.GetUsers(req.Context(), limit, offset)
+	users, err := h.userService.GetUsers(req.Context(), page)
 	if err != nil {
 		http.Error(w, "Failed to get users: "+err.Error(), http.StatusInternalServerError)
 		return err
 	}
 
-	response := map[string]interface{}{
-		"users":  users,
-		"limit":  limit,
-		"offset": offset,
-	}
-
 	w.Header().Set("Content-Type", "application/json")
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(pageResponse("users", users, page))
 }
 
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, req bunrouter.Request) error {
@@ -139,10 +122,53 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, req bunrouter.Request) e
 	return json.NewEncoder(w).Encode(response)
 }
 
+// SearchUsers finds users by words, or the start of words, in their name or email
+func (h *UserHandler) SearchUsers(w http.ResponseWriter, req bunrouter.Request) error {
+	query := req.URL.Query().Get("q")
+	limitStr := req.URL.Query().Get("limit")
+	offsetStr := req.URL.Query().Get("offset")
+
+	limit := 10
+	offset := 0
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	results, err := h.userService.SearchUsers(req.Context(), query, limit, offset)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrEmptySearch) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, "Failed to search users: "+err.Error(), status)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"query":   query,
+		"results": results,
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
 // RegisterRoutes registers user routes
 func (h *UserHandler) RegisterRoutes(router *bunrouter.Router) {
 	api := router.NewGroup("/api/users")
 	api.POST("", h.CreateUser)
+	api.GET("/search", h.SearchUsers)
 	api.GET("/:id", h.GetUser)
 	api.GET("", h.GetUsers)
 	api.PUT("/:id", h.UpdateUser)
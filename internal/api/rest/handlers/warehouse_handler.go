@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bunrouter"
+)
+
+// WarehouseHandler handles warehouse and per-location stock operations
+type WarehouseHandler struct {
+	warehouseService ports.WarehouseService
+}
+
+// NewWarehouseHandler creates a new warehouse handler
+func NewWarehouseHandler(warehouseService ports.WarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{
+		warehouseService: warehouseService,
+	}
+}
+
+// CreateWarehouse creates a new warehouse
+func (h *WarehouseHandler) CreateWarehouse(w http.ResponseWriter, req bunrouter.Request) error {
+	var createReq domain.CreateWarehouseRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	warehouse, err := h.warehouseService.CreateWarehouse(req.Context(), &createReq)
+	if err != nil {
+		http.Error(w, "Failed to create warehouse: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(warehouse)
+}
+
+// GetWarehouses retrieves warehouses with pagination
+func (h *WarehouseHandler) GetWarehouses(w http.ResponseWriter, req bunrouter.Request) error {
+	page, err := pageRequest(req, 50, ports.WarehouseSortFields)
+	if err != nil {
+		http.Error(w, "Invalid page request: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	warehouses, err := h.warehouseService.GetWarehouses(req.Context(), page)
+	if err != nil {
+		http.Error(w, "Failed to get warehouses: "+err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(pageResponse("warehouses", warehouses, page))
+}
+
+// GetWarehouse retrieves a warehouse by ID
+func (h *WarehouseHandler) GetWarehouse(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return err
+	}
+
+	warehouse, err := h.warehouseService.GetWarehouse(req.Context(), id)
+	if err != nil {
+		http.Error(w, "Warehouse not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(warehouse)
+}
+
+// UpdateWarehouse updates a warehouse
+func (h *WarehouseHandler) UpdateWarehouse(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return err
+	}
+
+	var updateReq domain.UpdateWarehouseRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	warehouse, err := h.warehouseService.UpdateWarehouse(req.Context(), id, &updateReq)
+	if err != nil {
+		http.Error(w, "Failed to update warehouse: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(warehouse)
+}
+
+// GetWarehouseStock retrieves the stock levels held at a warehouse
+func (h *WarehouseHandler) GetWarehouseStock(w http.ResponseWriter, req bunrouter.Request) error {
+	id, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return err
+	}
+	limit, offset := warehousePagination(req)
+
+	levels, err := h.warehouseService.GetWarehouseStock(req.Context(), id, limit, offset)
+	if err != nil {
+		http.Error(w, "Warehouse not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	response := map[string]interface{}{
+		"warehouse_id": id,
+		"stock_levels": levels,
+		"limit":        limit,
+		"offset":       offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GetStockLevels retrieves a product's stock per warehouse
+func (h *WarehouseHandler) GetStockLevels(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+
+	levels, err := h.warehouseService.GetStockLevels(req.Context(), productID)
+	if err != nil {
+		http.Error(w, "Product not found: "+err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(stockLevelsResponse(productID, levels))
+}
+
+// SetStockLevel sets a product's quantity at a warehouse
+func (h *WarehouseHandler) SetStockLevel(w http.ResponseWriter, req bunrouter.Request) error {
+	productID, err := uuid.Parse(req.Param("id"))
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return err
+	}
+	warehouseID, err := uuid.Parse(req.Param("warehouseId"))
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return err
+	}
+
+	var setReq domain.SetStockLevelRequest
+	if err := json.NewDecoder(req.Body).Decode(&setReq); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	levels, err := h.warehouseService.SetStockLevel(req.Context(), productID, warehouseID, &setReq)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Failed to set stock level: "+err.Error(), status)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(stockLevelsResponse(productID, levels))
+}
+
+// RegisterRoutes registers warehouse routes
+func (h *WarehouseHandler) RegisterRoutes(router *bunrouter.Router) {
+	api := router.NewGroup("/api/warehouses")
+	api.GET("", h.GetWarehouses)
+	api.POST("", h.CreateWarehouse)
+	api.GET("/:id", h.GetWarehouse)
+	api.PUT("/:id", h.UpdateWarehouse)
+	api.GET("/:id/stock", h.GetWarehouseStock)
+
+	levels := router.NewGroup("/api/products/:id/stock-levels")
+	levels.GET("", h.GetStockLevels)
+	levels.PUT("/:warehouseId", h.SetStockLevel)
+}
+
+// stockLevelsResponse reports a product's stock per warehouse alongside the aggregate
+func stockLevelsResponse(productID uuid.UUID, levels []*domain.StockLevel) map[string]interface{} {
+	total := 0
+	for _, level := range levels {
+		total += level.Quantity
+	}
+	return map[string]interface{}{
+		"product_id":   productID,
+		"stock":        total,
+		"stock_levels": levels,
+	}
+}
+
+// warehousePagination reads limit and offset query parameters, defaulting to the first 50
+func warehousePagination(req bunrouter.Request) (int, int) {
+	limit := 50
+	offset := 0
+
+	if limitStr := req.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr := req.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	return limit, offset
+}
@@ -13,21 +13,36 @@ import (
 
 // RouterConfig holds the configuration for the REST router
 type RouterConfig struct {
-	AuthMiddleware      *middleware.AuthMiddleware
-	UserService         ports.UserService
-	CustomerService     ports.CustomerService
-	CategoryService     ports.CategoryService
-	ProductService      ports.ProductService
-	OrderService        ports.OrderService
-	NotificationService ports.NotificationService
-	AuthService         ports.AuthService
+	AuthMiddleware        *middleware.AuthMiddleware
+	IdempotencyMiddleware *middleware.IdempotencyMiddleware
+	UserService           ports.UserService
+	CustomerService       ports.CustomerService
+	CategoryService       ports.CategoryService
+	ProductService        ports.ProductService
+	OrderService          ports.OrderService
+	ShipmentService       ports.ShipmentService
+	ReturnService         ports.ReturnService
+	InventoryService      ports.InventoryService
+	ReservationService    ports.ReservationService
+	WarehouseService      ports.WarehouseService
+	SupplierService       ports.SupplierService
+	PurchaseOrderService  ports.PurchaseOrderService
+	PromotionService      ports.PromotionService
+	TaxService            ports.TaxService
+	ShippingService       ports.ShippingService
+	NotificationService   ports.NotificationService
+	AuthService           ports.AuthService
 }
 
 // NewRouter creates a new REST router with all handlers registered
 func NewRouter(config *RouterConfig) *bunrouter.Router {
-	router := bunrouter.New(
-		bunrouter.Use(reqlog.NewMiddleware()),
-	)
+	options := []bunrouter.Option{bunrouter.Use(reqlog.NewMiddleware())}
+	// Identify the caller where a token is given, so that idempotency keys and the stock
+	// ledger are attributed to them
+	if config.AuthMiddleware != nil {
+		options = append(options, bunrouter.Use(config.AuthMiddleware.OptionalAuth))
+	}
+	router := bunrouter.New(options...)
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(config.UserService)
@@ -35,6 +50,16 @@ func NewRouter(config *RouterConfig) *bunrouter.Router {
 	categoryHandler := handlers.NewCategoryHandler(config.CategoryService)
 	productHandler := handlers.NewProductHandler(config.ProductService)
 	orderHandler := handlers.NewOrderHandler(config.OrderService)
+	shipmentHandler := handlers.NewShipmentHandler(config.ShipmentService)
+	returnHandler := handlers.NewReturnHandler(config.ReturnService)
+	inventoryHandler := handlers.NewInventoryHandler(config.InventoryService)
+	reservationHandler := handlers.NewReservationHandler(config.ReservationService)
+	warehouseHandler := handlers.NewWarehouseHandler(config.WarehouseService)
+	supplierHandler := handlers.NewSupplierHandler(config.SupplierService, config.PurchaseOrderService)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(config.PurchaseOrderService)
+	promotionHandler := handlers.NewPromotionHandler(config.PromotionService)
+	taxHandler := handlers.NewTaxHandler(config.TaxService)
+	shippingHandler := handlers.NewShippingHandler(config.ShippingService)
 	notificationHandler := handlers.NewNotificationHandler(config.NotificationService)
 
 	// Health check endpoint
@@ -50,8 +75,18 @@ func NewRouter(config *RouterConfig) *bunrouter.Router {
 	customerAuthHandler.RegisterRoutes(router, config.AuthMiddleware)
 	categoryHandler.RegisterRoutes(router)
 	productHandler.RegisterRoutes(router)
-	orderHandler.RegisterRoutes(router)
-	notificationHandler.RegisterRoutes(router)
+	orderHandler.RegisterRoutes(router, config.IdempotencyMiddleware)
+	shipmentHandler.RegisterRoutes(router)
+	returnHandler.RegisterRoutes(router)
+	inventoryHandler.RegisterRoutes(router)
+	reservationHandler.RegisterRoutes(router)
+	warehouseHandler.RegisterRoutes(router)
+	supplierHandler.RegisterRoutes(router)
+	purchaseOrderHandler.RegisterRoutes(router)
+	promotionHandler.RegisterRoutes(router)
+	taxHandler.RegisterRoutes(router)
+	shippingHandler.RegisterRoutes(router)
+	notificationHandler.RegisterRoutes(router, config.IdempotencyMiddleware)
 
 	return router
 }
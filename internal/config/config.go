@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -41,6 +42,26 @@ type Config struct {
 		DB       int    `yaml:"db"`
 	} `yaml:"redis"`
 
+	Idempotency struct {
+		TTL time.Duration `yaml:"ttl"`
+	} `yaml:"idempotency"`
+
+	OrderNumber struct {
+		Prefix     string `yaml:"prefix"`
+		DateLayout string `yaml:"date_layout"`
+	} `yaml:"order_number"`
+
+	Reservations struct {
+		TTL           time.Duration `yaml:"ttl"`
+		MaxTTL        time.Duration `yaml:"max_ttl"`
+		SweepInterval time.Duration `yaml:"sweep_interval"`
+	} `yaml:"reservations"`
+
+	Inventory struct {
+		AllocationStrategy string   `yaml:"allocation_strategy"`
+		LowStockRecipients []string `yaml:"low_stock_recipients"`
+	} `yaml:"inventory"`
+
 	Auth struct {
 		JWTSecret     string        `yaml:"jwt_secret"`
 		JWTExpiry     time.Duration `yaml:"jwt_expiry"`
@@ -170,6 +191,38 @@ func LoadFromEnv() (*Config, error) {
 			DB:       getEnvInt("REDIS_DB", 0),
 		},
 
+		Idempotency: struct {
+			TTL time.Duration `yaml:"ttl"`
+		}{
+			TTL: time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour,
+		},
+
+		OrderNumber: struct {
+			Prefix     string `yaml:"prefix"`
+			DateLayout string `yaml:"date_layout"`
+		}{
+			Prefix:     getEnv("ORDER_NUMBER_PREFIX", "ORD"),
+			DateLayout: getEnv("ORDER_NUMBER_DATE_LAYOUT", "20060102"),
+		},
+
+		Reservations: struct {
+			TTL           time.Duration `yaml:"ttl"`
+			MaxTTL        time.Duration `yaml:"max_ttl"`
+			SweepInterval time.Duration `yaml:"sweep_interval"`
+		}{
+			TTL:           time.Duration(getEnvInt("RESERVATION_TTL_MINUTES", 15)) * time.Minute,
+			MaxTTL:        time.Duration(getEnvInt("RESERVATION_MAX_TTL_MINUTES", 1440)) * time.Minute,
+			SweepInterval: time.Duration(getEnvInt("RESERVATION_SWEEP_INTERVAL_SECONDS", 60)) * time.Second,
+		},
+
+		Inventory: struct {
+			AllocationStrategy string   `yaml:"allocation_strategy"`
+			LowStockRecipients []string `yaml:"low_stock_recipients"`
+		}{
+			AllocationStrategy: getEnv("ALLOCATION_STRATEGY", "nearest"),
+			LowStockRecipients: getEnvList("LOW_STOCK_RECIPIENTS", nil),
+		},
+
 		Auth: struct {
 			JWTSecret     string        `yaml:"jwt_secret"`
 			JWTExpiry     time.Duration `yaml:"jwt_expiry"`
@@ -255,3 +308,16 @@ func getEnvBool(key string, fallback bool) bool {
 	}
 	return fallback
 }
+
+func getEnvList(key string, fallback []string) []string {
+	if value, exists := os.LookupEnv(key); exists {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+	return fallback
+}
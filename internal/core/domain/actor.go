@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// SystemActor identifies changes made without an authenticated principal
+const SystemActor = "system"
+
+// actorContextKey is the key used to store the acting principal in context
+type actorContextKey struct{}
+
+// ContextWithActor returns a copy of ctx carrying the principal performing an action
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the principal stored in ctx, or SystemActor if none is set
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorContextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
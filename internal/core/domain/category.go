@@ -1,13 +1,20 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
 
-// Category represents a product category
+// ErrCategoryCycle is returned when a category would be moved under itself or one of its descendants
+var ErrCategoryCycle = errors.New("category cannot be moved under itself or its descendants")
+
+// Category represents a product category. Path materializes its place in the tree: the IDs
+// of its ancestors, root first, then its own, as "/root-id/.../id/".
 type Category struct {
 	bun.BaseModel `bun:"table:categories,alias:cat"`
 
@@ -15,8 +22,16 @@ type Category struct {
 	Name        string     `bun:"name,unique,notnull" json:"name"`
 	Description string     `bun:"description" json:"description"`
 	ParentID    *uuid.UUID `bun:"parent_id,type:uuid" json:"parent_id"`
-	CreatedAt   time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
-	UpdatedAt   time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+	Path        string     `bun:"path,notnull,default:''" json:"path"`
+	// Slug names the category in URLs, unique among its siblings
+	Slug string `bun:"slug,notnull" json:"slug"`
+	// Position orders the category among its siblings, lowest first, ties by name
+	Position        int       `bun:"position,notnull,default:0" json:"position"`
+	ImageURL        string    `bun:"image_url" json:"image_url"`
+	MetaTitle       string    `bun:"meta_title" json:"meta_title"`
+	MetaDescription string    `bun:"meta_description" json:"meta_description"`
+	CreatedAt       time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt       time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
 
 	// Relations
 	Parent   *Category  `bun:"rel:belongs-to,join:parent_id=id" json:"parent,omitempty"`
@@ -24,16 +39,106 @@ type Category struct {
 	Products []Product  `bun:"rel:has-many,join:id=category_id" json:"products,omitempty"`
 }
 
-// CreateCategoryRequest represents the request to create a category
+// CategoryPath returns the path of the category with the given ID under a parent with
+// parentPath, or at the root when parentPath is empty
+func CategoryPath(parentPath string, id uuid.UUID) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + id.String() + "/"
+}
+
+// AncestorIDs returns the IDs of the category's ancestors, root first
+func (c *Category) AncestorIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+		if id, err := uuid.Parse(part); err == nil && id != c.ID {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Depth returns the number of ancestors of the category, 0 for a root category
+func (c *Category) Depth() int {
+	return len(c.AncestorIDs())
+}
+
+// CanHaveChildren returns ErrCategoryCycle when the category has no path because it is
+// caught in a parent cycle, as a child could not be given a path under it
+func (c *Category) CanHaveChildren() error {
+	if c.Path == "" {
+		return fmt.Errorf("%w: category %s is caught in a parent cycle", ErrCategoryCycle, c.ID)
+	}
+	return nil
+}
+
+// CanMoveUnder returns ErrCategoryCycle when parent is the category itself, one of its
+// descendants or caught in a parent cycle; a nil parent moves the category to the root
+func (c *Category) CanMoveUnder(parent *Category) error {
+	if parent == nil {
+		return nil
+	}
+	if err := parent.CanHaveChildren(); err != nil {
+		return err
+	}
+	if parent.ID == c.ID || strings.Contains(parent.Path, "/"+c.ID.String()+"/") {
+		return ErrCategoryCycle
+	}
+	return nil
+}
+
+// BuildCategoryTree nests the descendants of root under their parents, keeping the order
+// they are given in, and returns root
+func BuildCategoryTree(root *Category, descendants []*Category) *Category {
+	children := make(map[uuid.UUID][]*Category)
+	for _, category := range descendants {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	var attach func(category *Category)
+	attach = func(category *Category) {
+		category.Children = make([]Category, 0, len(children[category.ID]))
+		for _, child := range children[category.ID] {
+			attach(child)
+			category.Children = append(category.Children, *child)
+		}
+	}
+	attach(root)
+	return root
+}
+
+// CreateCategoryRequest represents the request to create a category. The slug is
+// generated from the name when empty.
 type CreateCategoryRequest struct {
-	Name        string     `json:"name" validate:"required"`
-	Description string     `json:"description"`
-	ParentID    *uuid.UUID `json:"parent_id"`
+	Name            string     `json:"name" validate:"required"`
+	Description     string     `json:"description"`
+	ParentID        *uuid.UUID `json:"parent_id"`
+	Slug            string     `json:"slug"`
+	Position        int        `json:"position"`
+	ImageURL        string     `json:"image_url"`
+	MetaTitle       string     `json:"meta_title"`
+	MetaDescription string     `json:"meta_description"`
 }
 
-// UpdateCategoryRequest represents the request to update a category
+// UpdateCategoryRequest represents the request to update a category. A nil ParentID
+// keeps the parent; uuid.Nil moves the category to the root. Renaming keeps the slug;
+// a new slug keeps the old one as a redirect.
 type UpdateCategoryRequest struct {
-	Name        *string    `json:"name,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Name            *string    `json:"name,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	ParentID        *uuid.UUID `json:"parent_id,omitempty"`
+	Slug            *string    `json:"slug,omitempty"`
+	Position        *int       `json:"position,omitempty"`
+	ImageURL        *string    `json:"image_url,omitempty"`
+	MetaTitle       *string    `json:"meta_title,omitempty"`
+	MetaDescription *string    `json:"meta_description,omitempty"`
+}
+
+// MoveCategoryRequest represents the request to move a category under a new parent, or to
+// the root when ParentID is nil
+type MoveCategoryRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
 }
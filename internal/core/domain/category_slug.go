@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var (
+	// ErrInvalidSlug is returned when a slug is not lower-case words of letters and
+	// digits joined by hyphens
+	ErrInvalidSlug = errors.New("invalid slug")
+	// ErrSlugTaken is returned when a sibling category already has the slug
+	ErrSlugTaken = errors.New("slug already used by a sibling category")
+	// ErrInvalidCategoryMetadata is returned when a category's storefront metadata is malformed
+	ErrInvalidCategoryMetadata = errors.New("invalid category metadata")
+)
+
+// MaxSlugLength is the longest slug a category can have
+const MaxSlugLength = 100
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// slugFolds spells accented Latin letters and ampersands the way slugs do
+var slugFolds = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "æ", "ae",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "œ", "oe",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y", "ß", "ss",
+	"&", " and ",
+)
+
+// Slugify turns a category name into a slug: lower-case letters and digits, with every
+// other run of characters a single hyphen. Names with nothing to keep become "category".
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range slugFolds.Replace(strings.ToLower(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	slug := strings.TrimRight(b.String()[:min(b.Len(), MaxSlugLength)], "-")
+	if slug == "" {
+		return "category"
+	}
+	return slug
+}
+
+// NumberedSlug returns the nth candidate for a slug when earlier ones are taken: the slug
+// itself first, then the slug suffixed "-2", "-3" and so on
+func NumberedSlug(slug string, n int) string {
+	if n <= 1 {
+		return slug
+	}
+	suffix := fmt.Sprintf("-%d", n)
+	return strings.TrimRight(slug[:min(len(slug), MaxSlugLength-len(suffix))], "-") + suffix
+}
+
+// ValidateSlug checks that a slug chosen by hand has the form Slugify produces
+func ValidateSlug(slug string) error {
+	if len(slug) > MaxSlugLength || !slugPattern.MatchString(slug) {
+		return fmt.Errorf("%w: %q", ErrInvalidSlug, slug)
+	}
+	return nil
+}
+
+// ValidateMetadata checks the category's storefront metadata: the image must be an
+// absolute http(s) URL and the meta tags must fit search result snippets
+func (c *Category) ValidateMetadata() error {
+	if c.ImageURL != "" {
+		u, err := url.Parse(c.ImageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: image URL must be an absolute http(s) URL", ErrInvalidCategoryMetadata)
+		}
+	}
+	if len([]rune(c.MetaTitle)) > 70 {
+		return fmt.Errorf("%w: meta title is longer than 70 characters", ErrInvalidCategoryMetadata)
+	}
+	if len([]rune(c.MetaDescription)) > 320 {
+		return fmt.Errorf("%w: meta description is longer than 320 characters", ErrInvalidCategoryMetadata)
+	}
+	return nil
+}
+
+// SplitSlugPath splits a slug path such as "electronics/phones" into its slugs, ignoring
+// surrounding and repeated slashes
+func SplitSlugPath(path string) []string {
+	var slugs []string
+	for _, slug := range strings.Split(path, "/") {
+		if slug != "" {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+// CategorySlugRedirect keeps a slug a category no longer answers to under a parent, so
+// that links to it still find the category after it was renamed or moved
+type CategorySlugRedirect struct {
+	bun.BaseModel `bun:"table:category_slug_redirects,alias:csr"`
+
+	ID         uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	CategoryID uuid.UUID  `bun:"category_id,type:uuid,notnull" json:"category_id"`
+	ParentID   *uuid.UUID `bun:"parent_id,type:uuid" json:"parent_id"`
+	Slug       string     `bun:"slug,notnull" json:"slug"`
+	CreatedAt  time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// CategoryPathLookup is the category a slug path leads to. Redirected is set when the
+// path used an old slug or casing, in which case CanonicalPath is where it now lives.
+type CategoryPathLookup struct {
+	Category      *Category `json:"category"`
+	CanonicalPath string    `json:"canonical_path"`
+	Redirected    bool      `json:"redirected"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "electronics", Slugify("Electronics"))
+	assert.Equal(t, "home-and-garden", Slugify("  Home & Garden "))
+	assert.Equal(t, "cafe-creme-2-0", Slugify("Café Crème 2.0"))
+	assert.Equal(t, "category", Slugify("!!!"))
+	assert.LessOrEqual(t, len(Slugify(strings.Repeat("ab ", 60))), MaxSlugLength)
+	assert.NoError(t, ValidateSlug(Slugify(strings.Repeat("ab ", 60))))
+}
+
+func TestNumberedSlug(t *testing.T) {
+	assert.Equal(t, "phones", NumberedSlug("phones", 1))
+	assert.Equal(t, "phones-3", NumberedSlug("phones", 3))
+
+	long := NumberedSlug(strings.Repeat("a", MaxSlugLength), 12)
+	assert.Len(t, long, MaxSlugLength)
+	assert.True(t, strings.HasSuffix(long, "-12"))
+}
+
+func TestValidateSlug(t *testing.T) {
+	assert.NoError(t, ValidateSlug("smart-phones-2"))
+	assert.ErrorIs(t, ValidateSlug("Smart-Phones"), ErrInvalidSlug)
+	assert.ErrorIs(t, ValidateSlug("smart--phones"), ErrInvalidSlug)
+	assert.ErrorIs(t, ValidateSlug("-phones"), ErrInvalidSlug)
+	assert.ErrorIs(t, ValidateSlug(""), ErrInvalidSlug)
+}
+
+func TestCategory_ValidateMetadata(t *testing.T) {
+	assert.NoError(t, (&Category{ImageURL: "https://cdn.example.com/phones.png", MetaTitle: "Phones"}).ValidateMetadata())
+	assert.ErrorIs(t, (&Category{ImageURL: "/phones.png"}).ValidateMetadata(), ErrInvalidCategoryMetadata)
+	assert.ErrorIs(t, (&Category{MetaTitle: strings.Repeat("a", 71)}).ValidateMetadata(), ErrInvalidCategoryMetadata)
+	assert.ErrorIs(t, (&Category{MetaDescription: strings.Repeat("é", 321)}).ValidateMetadata(), ErrInvalidCategoryMetadata)
+}
+
+func TestSplitSlugPath(t *testing.T) {
+	assert.Equal(t, []string{"electronics", "phones"}, SplitSlugPath("/electronics//phones/"))
+	assert.Empty(t, SplitSlugPath("/"))
+}
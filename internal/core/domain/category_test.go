@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// treeCategory returns a category under parent, or at the root when parent is nil
+func treeCategory(name string, parent *Category) *Category {
+	c := &Category{ID: uuid.New(), Name: name}
+	parentPath := ""
+	if parent != nil {
+		c.ParentID = &parent.ID
+		parentPath = parent.Path
+	}
+	c.Path = CategoryPath(parentPath, c.ID)
+	return c
+}
+
+func TestCategory_Path(t *testing.T) {
+	root := treeCategory("Electronics", nil)
+	phones := treeCategory("Phones", root)
+	android := treeCategory("Android", phones)
+
+	assert.Equal(t, "/"+root.ID.String()+"/", root.Path)
+	assert.Equal(t, root.Path+phones.ID.String()+"/"+android.ID.String()+"/", android.Path)
+	assert.Empty(t, root.AncestorIDs())
+	assert.Equal(t, []uuid.UUID{root.ID, phones.ID}, android.AncestorIDs())
+	assert.Equal(t, 0, root.Depth())
+	assert.Equal(t, 2, android.Depth())
+}
+
+func TestCategory_CanMoveUnder(t *testing.T) {
+	root := treeCategory("Electronics", nil)
+	phones := treeCategory("Phones", root)
+	android := treeCategory("Android", phones)
+	books := treeCategory("Books", nil)
+
+	assert.NoError(t, android.CanMoveUnder(books))
+	assert.NoError(t, android.CanMoveUnder(root))
+	assert.NoError(t, phones.CanMoveUnder(nil))
+	assert.ErrorIs(t, phones.CanMoveUnder(phones), ErrCategoryCycle)
+	assert.ErrorIs(t, phones.CanMoveUnder(android), ErrCategoryCycle)
+	assert.ErrorIs(t, root.CanMoveUnder(android), ErrCategoryCycle)
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	root := treeCategory("Electronics", nil)
+	phones := treeCategory("Phones", root)
+	laptops := treeCategory("Laptops", root)
+	android := treeCategory("Android", phones)
+
+	tree := BuildCategoryTree(root, []*Category{android, laptops, phones})
+
+	assert.Len(t, tree.Children, 2)
+	assert.Equal(t, "Laptops", tree.Children[0].Name)
+	assert.Empty(t, tree.Children[0].Children)
+	assert.Equal(t, "Phones", tree.Children[1].Name)
+	assert.Len(t, tree.Children[1].Children, 1)
+	assert.Equal(t, "Android", tree.Children[1].Children[0].Name)
+}
@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for amounts given without a currency
+const DefaultCurrency = "USD"
+
+// ErrCurrencyMismatch is returned when combining amounts in different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrInvalidMoney is returned when a money value cannot be parsed
+var ErrInvalidMoney = errors.New("invalid money value")
+
+// currencyExponents lists ISO-4217 currencies whose minor unit is not 1/100
+var currencyExponents = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// CurrencyExponent returns the number of decimal places of the currency's minor unit
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Money is an amount in integer minor units (e.g. cents) of an ISO-4217 currency.
+// It is stored in Postgres as the composite type money_amount(amount, currency).
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney creates a money value from minor units
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney parses a decimal amount in major units (e.g. "12.34") in the given currency
+func ParseMoney(amount, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if len(currency) != 3 {
+		return Money{}, fmt.Errorf("%w: unknown currency %q", ErrInvalidMoney, currency)
+	}
+
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+
+	whole, frac, _ := strings.Cut(amount, ".")
+	exp := CurrencyExponent(currency)
+	if whole == "" || len(frac) > exp || !isDigits(whole) || (frac != "" && !isDigits(frac)) {
+		return Money{}, fmt.Errorf("%w: %q is not a valid %s amount", ErrInvalidMoney, amount, currency)
+	}
+
+	minor, err := strconv.ParseInt(whole+frac+strings.Repeat("0", exp-len(frac)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add returns the sum of two amounts. A zero value without a currency adopts the other operand's currency,
+// so totals can start from Money{}.
+func (m Money) Add(other Money) (Money, error) {
+	switch {
+	case m.Currency == "":
+		return Money{Amount: m.Amount + other.Amount, Currency: other.Currency}, nil
+	case other.Currency == "" || other.Currency == m.Currency:
+		return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+	default:
+		return Money{}, fmt.Errorf("%w: cannot add %s to %s", ErrCurrencyMismatch, other.Currency, m.Currency)
+	}
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Multiply returns the amount multiplied by a quantity
+func (m Money) Multiply(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Divide returns the amount divided by n, rounded half away from zero to the nearest minor unit
+func (m Money) Divide(n int) Money {
+	if n == 0 {
+		return Money{Currency: m.Currency}
+	}
+	d := int64(n)
+	q, r := m.Amount/d, m.Amount%d
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= abs64(d) {
+		if (m.Amount < 0) != (d < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Money{Amount: q, Currency: m.Currency}
+}
+
+// Decimal returns the amount in major units as a decimal string, e.g. "12.34"
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if exp == 0 {
+		return sign + strconv.FormatInt(amount, 10)
+	}
+
+	digits := fmt.Sprintf("%0*d", exp+1, amount)
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// String returns the amount formatted for display, e.g. "12.34 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Value implements driver.Valuer using the money_amount composite literal
+func (m Money) Value() (driver.Value, error) {
+	return fmt.Sprintf("(%d,%s)", m.Amount, m.Currency), nil
+}
+
+// Scan implements sql.Scanner for the money_amount composite type
+func (m *Money) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+	}
+
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	amount, currency, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	minor, err := strconv.ParseInt(strings.TrimSpace(amount), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	*m = NewMoney(minor, strings.TrimSpace(strings.Trim(currency, `"`)))
+	return nil
+}
+
+// UnmarshalJSON accepts {"amount": <minor units>, "currency": "USD"} or, for backwards compatibility,
+// a bare decimal number in the default currency
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var v struct {
+			Amount   *int64 `json:"amount"`
+			Currency string `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+		}
+		if v.Amount == nil {
+			return fmt.Errorf("%w: amount is required", ErrInvalidMoney)
+		}
+		if v.Currency == "" {
+			v.Currency = DefaultCurrency
+		}
+		*m = NewMoney(*v.Amount, v.Currency)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+	}
+	parsed, err := ParseMoney(n.String(), DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface for the Money scalar
+func (m *Money) UnmarshalGQL(v interface{}) error {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+		}
+		return m.UnmarshalJSON(data)
+	case json.Number:
+		return m.UnmarshalJSON([]byte(value.String()))
+	case string:
+		amount, currency, _ := strings.Cut(strings.TrimSpace(value), " ")
+		parsed, err := ParseMoney(amount, currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case int:
+		*m = NewMoney(int64(value)*pow10(CurrencyExponent(DefaultCurrency)), DefaultCurrency)
+		return nil
+	case int64:
+		*m = NewMoney(value*pow10(CurrencyExponent(DefaultCurrency)), DefaultCurrency)
+		return nil
+	case float64:
+		return m.UnmarshalJSON([]byte(strconv.FormatFloat(value, 'f', -1, 64)))
+	default:
+		return fmt.Errorf("%w: unsupported type %T", ErrInvalidMoney, v)
+	}
+}
+
+// MarshalGQL implements the graphql.Marshaler interface for the Money scalar
+func (m Money) MarshalGQL(w io.Writer) {
+	data, _ := json.Marshal(m)
+	w.Write(data)
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func pow10(exp int) int64 {
+	result := int64(1)
+	for i := 0; i < exp; i++ {
+		result *= 10
+	}
+	return result
+}
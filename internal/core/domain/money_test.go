@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney(t *testing.T) {
+	t.Run("Parse decimal amounts into minor units", func(t *testing.T) {
+		m, err := ParseMoney("12.34", "usd")
+		assert.NoError(t, err)
+		assert.Equal(t, NewMoney(1234, "USD"), m)
+
+		m, err = ParseMoney("12.5", "")
+		assert.NoError(t, err)
+		assert.Equal(t, NewMoney(1250, DefaultCurrency), m)
+
+		m, err = ParseMoney("500", "JPY")
+		assert.NoError(t, err)
+		assert.Equal(t, NewMoney(500, "JPY"), m)
+
+		m, err = ParseMoney("-1.005", "KWD")
+		assert.NoError(t, err)
+		assert.Equal(t, NewMoney(-1005, "KWD"), m)
+	})
+
+	t.Run("Reject invalid amounts", func(t *testing.T) {
+		_, err := ParseMoney("12.345", "USD")
+		assert.ErrorIs(t, err, ErrInvalidMoney)
+		_, err = ParseMoney("abc", "USD")
+		assert.ErrorIs(t, err, ErrInvalidMoney)
+		_, err = ParseMoney("1.00", "DOLLARS")
+		assert.ErrorIs(t, err, ErrInvalidMoney)
+	})
+
+	t.Run("Sums without rounding errors", func(t *testing.T) {
+		var total Money
+		var err error
+		for i := 0; i < 10; i++ {
+			total, err = total.Add(NewMoney(10, "USD"))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, NewMoney(100, "USD"), total)
+		assert.Equal(t, "1.00 USD", total.String())
+	})
+
+	t.Run("Refuse to add different currencies", func(t *testing.T) {
+		_, err := NewMoney(100, "USD").Add(NewMoney(100, "KES"))
+		assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	})
+
+	t.Run("Multiply and divide", func(t *testing.T) {
+		assert.Equal(t, NewMoney(2997, "USD"), NewMoney(999, "USD").Multiply(3))
+		assert.Equal(t, NewMoney(333, "USD"), NewMoney(1000, "USD").Divide(3))
+		assert.Equal(t, NewMoney(167, "USD"), NewMoney(500, "USD").Divide(3))
+		assert.Equal(t, NewMoney(-167, "USD"), NewMoney(-500, "USD").Divide(3))
+		assert.Equal(t, Money{Currency: "USD"}, NewMoney(500, "USD").Divide(0))
+	})
+
+	t.Run("Decimal formatting", func(t *testing.T) {
+		assert.Equal(t, "0.05", NewMoney(5, "USD").Decimal())
+		assert.Equal(t, "-12.34", NewMoney(-1234, "USD").Decimal())
+		assert.Equal(t, "500", NewMoney(500, "JPY").Decimal())
+		assert.Equal(t, "1.005", NewMoney(1005, "KWD").Decimal())
+	})
+
+	t.Run("Database round trip", func(t *testing.T) {
+		value, err := NewMoney(1234, "USD").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "(1234,USD)", value)
+
+		var m Money
+		assert.NoError(t, m.Scan([]byte("(1234,USD)")))
+		assert.Equal(t, NewMoney(1234, "USD"), m)
+		assert.Error(t, m.Scan("1234"))
+		assert.Error(t, m.Scan(12.34))
+	})
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		data, err := json.Marshal(NewMoney(1234, "USD"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"amount":1234,"currency":"USD"}`, string(data))
+
+		var m Money
+		assert.NoError(t, json.Unmarshal(data, &m))
+		assert.Equal(t, NewMoney(1234, "USD"), m)
+
+		assert.NoError(t, json.Unmarshal([]byte(`99.99`), &m))
+		assert.Equal(t, NewMoney(9999, DefaultCurrency), m)
+
+		assert.Error(t, json.Unmarshal([]byte(`{"currency":"USD"}`), &m))
+	})
+
+	t.Run("GraphQL scalar", func(t *testing.T) {
+		var m Money
+		assert.NoError(t, m.UnmarshalGQL(map[string]interface{}{"amount": json.Number("250"), "currency": "KES"}))
+		assert.Equal(t, NewMoney(250, "KES"), m)
+
+		assert.NoError(t, m.UnmarshalGQL("12.34 KES"))
+		assert.Equal(t, NewMoney(1234, "KES"), m)
+
+		assert.NoError(t, m.UnmarshalGQL(19.99))
+		assert.Equal(t, NewMoney(1999, DefaultCurrency), m)
+
+		assert.NoError(t, m.UnmarshalGQL(int64(5)))
+		assert.Equal(t, NewMoney(500, DefaultCurrency), m)
+
+		var buf bytes.Buffer
+		NewMoney(1234, "USD").MarshalGQL(&buf)
+		assert.JSONEq(t, `{"amount":1234,"currency":"USD"}`, buf.String())
+	})
+}
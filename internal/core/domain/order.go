@@ -1,6 +1,11 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,15 +16,90 @@ import (
 type OrderStatus string
 
 const (
-	OrderStatusPending    OrderStatus = "pending"
-	OrderStatusConfirmed  OrderStatus = "confirmed"
-	OrderStatusProcessing OrderStatus = "processing"
-	OrderStatusShipped    OrderStatus = "shipped"
-	OrderStatusDelivered  OrderStatus = "delivered"
-	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusPending          OrderStatus = "pending"
+	OrderStatusConfirmed        OrderStatus = "confirmed"
+	OrderStatusProcessing       OrderStatus = "processing"
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusDelivered        OrderStatus = "delivered"
+	OrderStatusCancelled        OrderStatus = "cancelled"
 )
 
-// Order represents an order in the system
+// ErrInvalidStatusTransition is returned when an order cannot move to the requested status
+var ErrInvalidStatusTransition = errors.New("invalid order status transition")
+
+// ErrOrderNotEditable is returned when changing the items of an order that has started processing
+var ErrOrderNotEditable = errors.New("order items can no longer be changed")
+
+// ErrInvalidOrderItem is returned when an order item asks for a quantity it cannot have
+var ErrInvalidOrderItem = errors.New("invalid order item")
+
+// orderStatusTransitions lists the statuses each status may move to
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:          {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed:        {OrderStatusProcessing, OrderStatusCancelled},
+	OrderStatusProcessing:       {OrderStatusPartiallyShipped, OrderStatusShipped},
+	OrderStatusPartiallyShipped: {OrderStatusShipped},
+	OrderStatusShipped:          {OrderStatusDelivered},
+	OrderStatusDelivered:        {},
+	OrderStatusCancelled:        {},
+}
+
+// IsValid reports whether the status is a known order status
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether an order in this status may move to next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns ErrInvalidStatusTransition when next is not reachable from s
+func (s OrderStatus) ValidateTransition(next OrderStatus) error {
+	if !next.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidStatusTransition, next)
+	}
+	if !s.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, s, next)
+	}
+	return nil
+}
+
+// ItemsEditable reports whether items may still be added, removed or changed in this status
+func (s OrderStatus) ItemsEditable() bool {
+	return s == OrderStatusPending || s == OrderStatusConfirmed
+}
+
+// UnmarshalGQL maps GraphQL enum values (e.g. SHIPPED) to order statuses
+func (s *OrderStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("order status must be a string")
+	}
+	*s = OrderStatus(strings.ToLower(str))
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the order status as a GraphQL enum value
+func (s OrderStatus) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// Order represents an order in the system. Subtotal is the price of its items after
+// discounts and before tax, ShippingTotal what its shipping method charges, and GrandTotal
+// what the customer pays, Subtotal plus ShippingTotal plus TaxTotal; TotalAmount is the same
+// as GrandTotal. ShippingCountry decides the tax rates of its items, and with ShippingCity
+// the shipping methods that ship it.
 type Order struct {
 	bun.BaseModel `bun:"table:orders,alias:o"`
 
@@ -27,8 +107,15 @@ type Order struct {
 	CustomerID      uuid.UUID   `bun:"customer_id,type:uuid,notnull" json:"customer_id"`
 	OrderNumber     string      `bun:"order_number,unique,notnull" json:"order_number"`
 	Status          OrderStatus `bun:"status,notnull,default:'pending'" json:"status"`
-	TotalAmount     float64     `bun:"total_amount,notnull" json:"total_amount"`
+	TotalAmount     Money       `bun:"total_amount,type:money_amount,notnull" json:"total_amount"`
+	DiscountTotal   Money       `bun:"discount_total,type:money_amount,notnull" json:"discount_total"`
+	Subtotal        Money       `bun:"subtotal,type:money_amount,notnull" json:"subtotal"`
+	TaxTotal        Money       `bun:"tax_total,type:money_amount,notnull" json:"tax_total"`
+	ShippingTotal   Money       `bun:"shipping_total,type:money_amount,notnull" json:"shipping_total"`
+	GrandTotal      Money       `bun:"grand_total,type:money_amount,notnull" json:"grand_total"`
 	ShippingAddress string      `bun:"shipping_address,notnull" json:"shipping_address"`
+	ShippingCountry string      `bun:"shipping_country,notnull,default:''" json:"shipping_country"`
+	ShippingCity    string      `bun:"shipping_city,notnull,default:''" json:"shipping_city"`
 	BillingAddress  string      `bun:"billing_address,notnull" json:"billing_address"`
 	Notes           string      `bun:"notes" json:"notes"`
 	OrderDate       time.Time   `bun:"order_date,nullzero,notnull,default:current_timestamp" json:"order_date"`
@@ -37,47 +124,178 @@ type Order struct {
 	CreatedAt       time.Time   `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt       time.Time   `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
 
+	// ShippingMethodID is the method the order ships by, nil for orders placed without one
+	// or whose method has since been deleted; ShippingMethodName keeps its name either way.
+	ShippingMethodID   *uuid.UUID `bun:"shipping_method_id,type:uuid" json:"shipping_method_id,omitempty"`
+	ShippingMethodName string     `bun:"shipping_method_name,notnull,default:''" json:"shipping_method_name,omitempty"`
+
 	// Relations
-	Customer   Customer    `bun:"rel:belongs-to,join:customer_id=id" json:"customer"`
-	OrderItems []OrderItem `bun:"rel:has-many,join:id=order_id" json:"order_items"`
+	Customer      Customer             `bun:"rel:belongs-to,join:customer_id=id" json:"customer"`
+	OrderItems    []OrderItem          `bun:"rel:has-many,join:id=order_id" json:"order_items"`
+	StatusHistory []OrderStatusHistory `bun:"rel:has-many,join:id=order_id" json:"status_history,omitempty"`
+	Shipments     []Shipment           `bun:"rel:has-many,join:id=order_id" json:"shipments,omitempty"`
+	Discounts     []OrderDiscount      `bun:"rel:has-many,join:id=order_id" json:"discounts"`
 }
 
-// OrderItem represents an item within an order
+// OrderItem represents an item within an order. DiscountAmount is the line's share of the
+// order's discounts and TotalPrice the line's price after them. TaxAmount is the tax on the
+// line at TaxRate basis points, already in TotalPrice when TaxInclusive and on top of it
+// otherwise.
 type OrderItem struct {
 	bun.BaseModel `bun:"table:order_items,alias:oi"`
 
-	ID         uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
-	OrderID    uuid.UUID `bun:"order_id,type:uuid,notnull" json:"order_id"`
-	ProductID  uuid.UUID `bun:"product_id,type:uuid,notnull" json:"product_id"`
-	Quantity   int       `bun:"quantity,notnull" json:"quantity"`
-	UnitPrice  float64   `bun:"unit_price,notnull" json:"unit_price"`
-	TotalPrice float64   `bun:"total_price,notnull" json:"total_price"`
-	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
-	UpdatedAt  time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+	ID             uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	OrderID        uuid.UUID  `bun:"order_id,type:uuid,notnull" json:"order_id"`
+	ProductID      uuid.UUID  `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	VariantID      *uuid.UUID `bun:"variant_id,type:uuid" json:"variant_id,omitempty"`
+	Quantity       int        `bun:"quantity,notnull" json:"quantity"`
+	UnitPrice      Money      `bun:"unit_price,type:money_amount,notnull" json:"unit_price"`
+	DiscountAmount Money      `bun:"discount_amount,type:money_amount,notnull" json:"discount_amount"`
+	TotalPrice     Money      `bun:"total_price,type:money_amount,notnull" json:"total_price"`
+	TaxRate        int        `bun:"tax_rate,notnull,default:0" json:"tax_rate"`
+	TaxInclusive   bool       `bun:"tax_inclusive,notnull,default:false" json:"tax_inclusive"`
+	TaxAmount      Money      `bun:"tax_amount,type:money_amount,notnull" json:"tax_amount"`
+	CreatedAt      time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt      time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
 
 	// Relations
 	Order   Order   `bun:"rel:belongs-to,join:order_id=id" json:"order"`
 	Product Product `bun:"rel:belongs-to,join:product_id=id" json:"product"`
 }
 
-// CreateOrderRequest represents the request to create an order
+// SetDiscount sets the line's discount, its total after the discount and the tax on it
+func (i *OrderItem) SetDiscount(amount int64) {
+	i.DiscountAmount = Money{Amount: amount, Currency: i.UnitPrice.Currency}
+	i.TotalPrice = Money{Amount: i.UnitPrice.Amount*int64(i.Quantity) - amount, Currency: i.UnitPrice.Currency}
+	i.TaxAmount = taxOn(i.TotalPrice, i.TaxRate, i.TaxInclusive)
+}
+
+// SetTax taxes the line at rate, which it keeps when its quantity or discount change; a nil
+// rate leaves the line untaxed
+func (i *OrderItem) SetTax(rate *TaxRate) {
+	i.TaxRate, i.TaxInclusive = 0, false
+	if rate != nil {
+		i.TaxRate, i.TaxInclusive = rate.Rate, rate.PricesIncludeTax
+	}
+	i.TaxAmount = taxOn(i.TotalPrice, i.TaxRate, i.TaxInclusive)
+}
+
+// NetPrice returns the line's price after discounts, without tax
+func (i *OrderItem) NetPrice() Money {
+	if i.TaxInclusive {
+		return Money{Amount: i.TotalPrice.Amount - i.TaxAmount.Amount, Currency: i.TotalPrice.Currency}
+	}
+	return i.TotalPrice
+}
+
+// GrossPrice returns what is paid for the line, after discounts and with tax
+func (i *OrderItem) GrossPrice() Money {
+	if i.TaxInclusive {
+		return i.TotalPrice
+	}
+	return Money{Amount: i.TotalPrice.Amount + i.TaxAmount.Amount, Currency: i.TotalPrice.Currency}
+}
+
+// PaidFor returns what was paid for quantity of the line's units, their share of the
+// line's price after discounts and with tax
+func (i *OrderItem) PaidFor(quantity int) Money {
+	if i.Quantity == 0 {
+		return Money{Currency: i.TotalPrice.Currency}
+	}
+	return i.GrossPrice().Multiply(quantity).Divide(i.Quantity)
+}
+
+// Destination returns where the order ships to
+func (o *Order) Destination() ShippingDestination {
+	return ShippingDestination{Country: o.ShippingCountry, City: o.ShippingCity}
+}
+
+// SetShipping ships the order by the method, which charges amount for it
+func (o *Order) SetShipping(method *ShippingMethod, amount Money) {
+	o.ShippingMethodID, o.ShippingMethodName = &method.ID, method.Name
+	o.ShippingTotal = amount
+}
+
+// SetTotals sets the order's subtotal, discount, tax and grand totals from its items, which
+// must share a currency, and its shipping total
+func (o *Order) SetTotals(items []*OrderItem) error {
+	var subtotal, discountTotal, taxTotal Money
+	for _, item := range items {
+		var err error
+		if subtotal, err = subtotal.Add(item.NetPrice()); err != nil {
+			return err
+		}
+		if discountTotal, err = discountTotal.Add(item.DiscountAmount); err != nil {
+			return err
+		}
+		if taxTotal, err = taxTotal.Add(item.TaxAmount); err != nil {
+			return err
+		}
+	}
+	o.Subtotal, o.DiscountTotal, o.TaxTotal = subtotal, discountTotal, taxTotal
+	o.ShippingTotal.Currency = subtotal.Currency
+	o.GrandTotal = Money{Amount: subtotal.Amount + o.ShippingTotal.Amount + taxTotal.Amount, Currency: subtotal.Currency}
+	o.TotalAmount = o.GrandTotal
+	return nil
+}
+
+// OrderStatusHistory records a single status change of an order
+type OrderStatusHistory struct {
+	bun.BaseModel `bun:"table:order_status_history,alias:osh"`
+
+	ID         uuid.UUID    `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	OrderID    uuid.UUID    `bun:"order_id,type:uuid,notnull" json:"order_id"`
+	FromStatus *OrderStatus `bun:"from_status" json:"from_status"`
+	ToStatus   OrderStatus  `bun:"to_status,notnull" json:"to_status"`
+	Actor      string       `bun:"actor,notnull" json:"actor"`
+	Reason     string       `bun:"reason" json:"reason"`
+	CreatedAt  time.Time    `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// CreateOrderRequest represents the request to create an order.
+// Each reservation in ReservationIDs is converted into an order item for its product and quantity.
+// CouponCodes name the coupons to apply on top of the promotions every eligible order gets.
+// ShippingCountry and ShippingCity default to the customer's; ShippingMethodID, when set,
+// ships the order by that method and charges for it.
 type CreateOrderRequest struct {
-	CustomerID      uuid.UUID                `json:"customer_id" validate:"required"`
-	ShippingAddress string                   `json:"shipping_address" validate:"required"`
-	BillingAddress  string                   `json:"billing_address" validate:"required"`
-	Notes           string                   `json:"notes"`
-	OrderItems      []CreateOrderItemRequest `json:"order_items" validate:"required,min=1"`
+	CustomerID       uuid.UUID                `json:"customer_id" validate:"required"`
+	ShippingAddress  string                   `json:"shipping_address" validate:"required"`
+	ShippingCountry  string                   `json:"shipping_country"`
+	ShippingCity     string                   `json:"shipping_city"`
+	ShippingMethodID *uuid.UUID               `json:"shipping_method_id,omitempty"`
+	BillingAddress   string                   `json:"billing_address" validate:"required"`
+	Notes            string                   `json:"notes"`
+	OrderItems       []CreateOrderItemRequest `json:"order_items" validate:"required_without=ReservationIDs"`
+	ReservationIDs   []uuid.UUID              `json:"reservation_ids"`
+	CouponCodes      []string                 `json:"coupon_codes"`
 }
 
-// CreateOrderItemRequest represents the request to create an order item
+// CreateOrderItemRequest represents the request to create an order item.
+// VariantID is required for products sold by variant.
 type CreateOrderItemRequest struct {
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	ProductID uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity  int        `json:"quantity" validate:"required,min=1"`
+}
+
+// UpdateOrderItemsRequest represents the request to change the items of an order.
+// Each entry sets the desired quantity of a product: products not yet on the order are
+// added, a quantity of zero removes the product, and products not listed are left as they are.
+type UpdateOrderItemsRequest struct {
+	Items []OrderItemChange `json:"items" validate:"required,min=1"`
+}
+
+// OrderItemChange represents the desired quantity of a product, or of one of its variants, on an order
+type OrderItemChange struct {
+	ProductID uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity  int        `json:"quantity" validate:"min=0"`
 }
 
 // UpdateOrderRequest represents the request to update an order
 type UpdateOrderRequest struct {
 	Status          *OrderStatus `json:"status,omitempty"`
+	StatusReason    *string      `json:"status_reason,omitempty"`
 	ShippingAddress *string      `json:"shipping_address,omitempty"`
 	BillingAddress  *string      `json:"billing_address,omitempty"`
 	Notes           *string      `json:"notes,omitempty"`
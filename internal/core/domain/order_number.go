@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOrderNumber is returned when an order number fails format or check digit validation
+var ErrInvalidOrderNumber = errors.New("invalid order number")
+
+// orderNumberSequenceWidth is the minimum number of digits in the sequence segment
+const orderNumberSequenceWidth = 6
+
+// legacyOrderNumberPrefix is the prefix of order numbers issued before check digits were introduced
+const legacyOrderNumberPrefix = "ORD-"
+
+// OrderNumberFormat describes how order numbers are rendered: PREFIX-DATE-SEQUENCE followed by a Luhn check digit,
+// e.g. ORD-20240131-0000427
+type OrderNumberFormat struct {
+	// Prefix is prepended to every order number; empty omits the segment
+	Prefix string
+	// DateLayout is the Go time layout of the date segment; empty omits the segment
+	DateLayout string
+}
+
+// Format renders the order number for a sequence value issued at the given time
+func (f OrderNumberFormat) Format(seq int64, at time.Time) string {
+	digits := fmt.Sprintf("%0*d", orderNumberSequenceWidth, seq)
+
+	var b strings.Builder
+	if f.Prefix != "" {
+		b.WriteString(f.Prefix)
+		b.WriteByte('-')
+	}
+	payload := digits
+	if f.DateLayout != "" {
+		date := at.UTC().Format(f.DateLayout)
+		b.WriteString(date)
+		b.WriteByte('-')
+		payload = date + digits
+	}
+	b.WriteString(digits)
+	b.WriteByte(luhnCheckDigit(payload))
+
+	return b.String()
+}
+
+// Validate reports whether the order number has this format and a correct check digit.
+// Numbers issued before check digits were introduced (ORD-<unix time>) are accepted as-is.
+func (f OrderNumberFormat) Validate(orderNumber string) bool {
+	if isLegacyOrderNumber(orderNumber) {
+		return true
+	}
+
+	rest := orderNumber
+	if f.Prefix != "" {
+		if !strings.HasPrefix(rest, f.Prefix+"-") {
+			return false
+		}
+		rest = rest[len(f.Prefix)+1:]
+	}
+
+	payload := ""
+	if f.DateLayout != "" {
+		n := len(time.Time{}.Format(f.DateLayout))
+		if len(rest) < n+1 || rest[n] != '-' {
+			return false
+		}
+		if _, err := time.Parse(f.DateLayout, rest[:n]); err != nil {
+			return false
+		}
+		payload = rest[:n]
+		rest = rest[n+1:]
+	}
+
+	if len(rest) < orderNumberSequenceWidth+1 || !isDigits(rest) {
+		return false
+	}
+	payload += rest[:len(rest)-1]
+
+	return luhnCheckDigit(payload) == rest[len(rest)-1]
+}
+
+// isLegacyOrderNumber reports whether the order number uses the old ORD-<unix time> scheme
+func isLegacyOrderNumber(orderNumber string) bool {
+	digits := strings.TrimPrefix(orderNumber, legacyOrderNumberPrefix)
+	return digits != orderNumber && len(digits) >= 9 && len(digits) <= 10 && isDigits(digits)
+}
+
+// luhnCheckDigit computes the Luhn check digit over the decimal digits of s
+func luhnCheckDigit(s string) byte {
+	sum := 0
+	double := true
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
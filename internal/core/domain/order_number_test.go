@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderNumberFormat(t *testing.T) {
+	format := OrderNumberFormat{Prefix: "ORD", DateLayout: "20060102"}
+	at := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Formats prefix, date, sequence and check digit", func(t *testing.T) {
+		number := format.Format(42, at)
+		assert.Regexp(t, `^ORD-20240131-000042\d$`, number)
+		assert.True(t, format.Validate(number))
+	})
+
+	t.Run("Sequence grows beyond the minimum width", func(t *testing.T) {
+		number := format.Format(12345678, at)
+		assert.Regexp(t, `^ORD-20240131-12345678\d$`, number)
+		assert.True(t, format.Validate(number))
+	})
+
+	t.Run("Detects single digit typos and transpositions", func(t *testing.T) {
+		number := format.Format(427, at)
+		check := number[len(number)-1]
+		wrongCheck := byte('0' + (int(check-'0')+1)%10)
+
+		assert.False(t, format.Validate(number[:len(number)-1]+string(wrongCheck)))
+		assert.False(t, format.Validate("ORD-20240131-000247"+string(check)))
+	})
+
+	t.Run("Rejects malformed numbers", func(t *testing.T) {
+		assert.False(t, format.Validate(""))
+		assert.False(t, format.Validate("INV-20240131-0000427"))
+		assert.False(t, format.Validate("ORD-20241331-0000427"))
+		assert.False(t, format.Validate("ORD-20240131-42"))
+		assert.False(t, format.Validate("ORD-20240131-00004x7"))
+	})
+
+	t.Run("Accepts legacy timestamp numbers", func(t *testing.T) {
+		assert.True(t, format.Validate("ORD-1700000000"))
+		assert.False(t, format.Validate("ORD-17000"))
+	})
+
+	t.Run("Optional segments", func(t *testing.T) {
+		plain := OrderNumberFormat{}
+		number := plain.Format(7, at)
+		assert.Regexp(t, `^000007\d$`, number)
+		assert.True(t, plain.Validate(number))
+	})
+}
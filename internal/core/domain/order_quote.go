@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderQuoteDiscount is a promotion an order quote gets and what it takes off
+type OrderQuoteDiscount struct {
+	PromotionID uuid.UUID `json:"promotion_id"`
+	Code        string    `json:"code,omitempty"`
+	Name        string    `json:"name"`
+	Amount      Money     `json:"amount"`
+}
+
+// OrderQuoteLine is a line of an order quote, priced as the order item it would become
+type OrderQuoteLine struct {
+	ProductID      uuid.UUID  `json:"product_id"`
+	VariantID      *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity       int        `json:"quantity"`
+	UnitPrice      Money      `json:"unit_price"`
+	DiscountAmount Money      `json:"discount_amount"`
+	TotalPrice     Money      `json:"total_price"`
+	TaxRate        int        `json:"tax_rate"`
+	TaxInclusive   bool       `json:"tax_inclusive"`
+	TaxAmount      Money      `json:"tax_amount"`
+}
+
+// OrderQuote previews what an order would cost if it were placed when quoted. Its totals
+// mean what they do on Order.
+type OrderQuote struct {
+	CustomerID         uuid.UUID            `json:"customer_id"`
+	ShippingCountry    string               `json:"shipping_country"`
+	ShippingCity       string               `json:"shipping_city"`
+	ShippingMethodID   *uuid.UUID           `json:"shipping_method_id,omitempty"`
+	ShippingMethodName string               `json:"shipping_method_name,omitempty"`
+	Lines              []OrderQuoteLine     `json:"lines"`
+	Discounts          []OrderQuoteDiscount `json:"discounts"`
+	Subtotal           Money                `json:"subtotal"`
+	DiscountTotal      Money                `json:"discount_total"`
+	ShippingTotal      Money                `json:"shipping_total"`
+	TaxTotal           Money                `json:"tax_total"`
+	GrandTotal         Money                `json:"grand_total"`
+	QuotedAt           time.Time            `json:"quoted_at"`
+}
+
+// NewOrderQuote returns the quote for the unsaved order, shipped as set on it, with the
+// order items, priced, discounted and taxed, and the promotions applied to them
+func NewOrderQuote(order *Order, items []*OrderItem, applied []*AppliedPromotion, at time.Time) (*OrderQuote, error) {
+	if err := order.SetTotals(items); err != nil {
+		return nil, err
+	}
+
+	quote := &OrderQuote{
+		CustomerID:         order.CustomerID,
+		ShippingCountry:    order.ShippingCountry,
+		ShippingCity:       order.ShippingCity,
+		ShippingMethodID:   order.ShippingMethodID,
+		ShippingMethodName: order.ShippingMethodName,
+		Lines:              make([]OrderQuoteLine, len(items)),
+		Discounts:          make([]OrderQuoteDiscount, len(applied)),
+		Subtotal:           order.Subtotal,
+		DiscountTotal:      order.DiscountTotal,
+		ShippingTotal:      order.ShippingTotal,
+		TaxTotal:           order.TaxTotal,
+		GrandTotal:         order.GrandTotal,
+		QuotedAt:           at,
+	}
+	for i, item := range items {
+		quote.Lines[i] = OrderQuoteLine{
+			ProductID:      item.ProductID,
+			VariantID:      item.VariantID,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice,
+			DiscountAmount: item.DiscountAmount,
+			TotalPrice:     item.TotalPrice,
+			TaxRate:        item.TaxRate,
+			TaxInclusive:   item.TaxInclusive,
+			TaxAmount:      item.TaxAmount,
+		}
+	}
+	for i, a := range applied {
+		quote.Discounts[i] = OrderQuoteDiscount{PromotionID: a.Promotion.ID, Code: a.Promotion.code(), Name: a.Promotion.Name, Amount: a.Amount}
+	}
+	return quote, nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderStatusTransitions(t *testing.T) {
+	t.Run("Follows the fulfilment flow", func(t *testing.T) {
+		assert.True(t, OrderStatusPending.CanTransitionTo(OrderStatusConfirmed))
+		assert.True(t, OrderStatusConfirmed.CanTransitionTo(OrderStatusProcessing))
+		assert.True(t, OrderStatusProcessing.CanTransitionTo(OrderStatusShipped))
+		assert.True(t, OrderStatusShipped.CanTransitionTo(OrderStatusDelivered))
+	})
+
+	t.Run("Partial shipment leads to shipped", func(t *testing.T) {
+		assert.True(t, OrderStatusProcessing.CanTransitionTo(OrderStatusPartiallyShipped))
+		assert.True(t, OrderStatusPartiallyShipped.CanTransitionTo(OrderStatusShipped))
+		assert.False(t, OrderStatusPartiallyShipped.CanTransitionTo(OrderStatusCancelled))
+	})
+
+	t.Run("Cancellation only before processing", func(t *testing.T) {
+		assert.True(t, OrderStatusPending.CanTransitionTo(OrderStatusCancelled))
+		assert.True(t, OrderStatusConfirmed.CanTransitionTo(OrderStatusCancelled))
+		assert.False(t, OrderStatusProcessing.CanTransitionTo(OrderStatusCancelled))
+		assert.False(t, OrderStatusShipped.CanTransitionTo(OrderStatusCancelled))
+	})
+
+	t.Run("Rejects going backwards or skipping", func(t *testing.T) {
+		assert.ErrorIs(t, OrderStatusDelivered.ValidateTransition(OrderStatusPending), ErrInvalidStatusTransition)
+		assert.ErrorIs(t, OrderStatusPending.ValidateTransition(OrderStatusShipped), ErrInvalidStatusTransition)
+		assert.ErrorIs(t, OrderStatusPending.ValidateTransition(OrderStatus("unknown")), ErrInvalidStatusTransition)
+	})
+
+	t.Run("GraphQL enum round trip", func(t *testing.T) {
+		var status OrderStatus
+		assert.NoError(t, status.UnmarshalGQL("SHIPPED"))
+		assert.Equal(t, OrderStatusShipped, status)
+		assert.Error(t, status.UnmarshalGQL("LOST"))
+
+		var buf bytes.Buffer
+		OrderStatusShipped.MarshalGQL(&buf)
+		assert.Equal(t, `"SHIPPED"`, buf.String())
+	})
+}
@@ -1,41 +1,82 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
 
+// ErrInsufficientStock is returned when a stock change would drop below zero
+var ErrInsufficientStock = errors.New("insufficient stock")
+
 // Product represents a product in the system
 type Product struct {
 	bun.BaseModel `bun:"table:products,alias:p"`
 
-	ID          uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
-	Name        string    `bun:"name,notnull" json:"name"`
-	Description string    `bun:"description" json:"description"`
-	SKU         string    `bun:"sku,unique,notnull" json:"sku"`
-	Price       float64   `bun:"price,notnull" json:"price"`
-	Stock       int       `bun:"stock,notnull,default:0" json:"stock"`
-	CategoryID  uuid.UUID `bun:"category_id,type:uuid,notnull" json:"category_id"`
-	IsActive    bool      `bun:"is_active,notnull,default:true" json:"is_active"`
-	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
-	UpdatedAt   time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+	ID          uuid.UUID         `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Name        string            `bun:"name,notnull" json:"name"`
+	Description string            `bun:"description" json:"description"`
+	SKU         string            `bun:"sku,unique,notnull" json:"sku"`
+	Price       Money             `bun:"price,type:money_amount,notnull" json:"price"`
+	Stock       int               `bun:"stock,notnull,default:0" json:"stock"`
+	CategoryID  uuid.UUID         `bun:"category_id,type:uuid,notnull" json:"category_id"`
+	IsActive    bool              `bun:"is_active,notnull,default:true" json:"is_active"`
+	Attributes  ProductAttributes `bun:"attributes,type:jsonb,notnull,default:'{}'" json:"attributes"`
+	CreatedAt   time.Time         `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt   time.Time         `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+
+	// ReorderPoint is the stock level below which the product should be reordered;
+	// ReorderQuantity is how much to order when it is.
+	ReorderPoint    int `bun:"reorder_point,notnull,default:10" json:"reorder_point"`
+	ReorderQuantity int `bun:"reorder_quantity,notnull,default:0" json:"reorder_quantity"`
+
+	// ReservedStock is held by active reservations; AvailableStock is what can still be sold.
+	// Both are computed when the product is loaded.
+	ReservedStock  int `bun:"reserved_stock,scanonly" json:"reserved_stock"`
+	AvailableStock int `bun:"available_stock,scanonly" json:"available_stock"`
+
+	// WeightGrams is the weight of one packed unit and LengthMM, WidthMM and HeightMM the
+	// size of its package, zero when not known.
+	WeightGrams int `bun:"weight_grams,notnull,default:0" json:"weight_grams"`
+	LengthMM    int `bun:"length_mm,notnull,default:0" json:"length_mm"`
+	WidthMM     int `bun:"width_mm,notnull,default:0" json:"width_mm"`
+	HeightMM    int `bun:"height_mm,notnull,default:0" json:"height_mm"`
 
 	// Relations
-	Category   Category    `bun:"rel:belongs-to,join:category_id=id" json:"category"`
-	OrderItems []OrderItem `bun:"rel:has-many,join:id=product_id" json:"order_items,omitempty"`
+	Category    Category          `bun:"rel:belongs-to,join:category_id=id" json:"category"`
+	StockLevels []*StockLevel     `bun:"rel:has-many,join:id=product_id" json:"stock_levels,omitempty"`
+	Variants    []*ProductVariant `bun:"rel:has-many,join:id=product_id" json:"variants,omitempty"`
+	OrderItems  []OrderItem       `bun:"rel:has-many,join:id=product_id" json:"order_items,omitempty"`
+}
+
+// ShippingWeight returns the grams shipping one unit of the product is charged for: its
+// weight, or the volumetric weight of its package when that is more
+func (p *Product) ShippingWeight() int {
+	volumetric := p.LengthMM * p.WidthMM * p.HeightMM / volumetricDivisor
+	return max(p.WeightGrams, volumetric)
 }
 
 // CreateProductRequest represents the request to create a product
 type CreateProductRequest struct {
-	Name        string    `json:"name" validate:"required"`
-	Description string    `json:"description"`
-	SKU         string    `json:"sku" validate:"required"`
-	Price       float64   `json:"price" validate:"required,min=0"`
-	Stock       int       `json:"stock" validate:"min=0"`
-	CategoryID  uuid.UUID `json:"category_id" validate:"required"`
-	IsActive    bool      `json:"is_active"`
+	Name        string            `json:"name" validate:"required"`
+	Description string            `json:"description"`
+	SKU         string            `json:"sku" validate:"required"`
+	Price       Money             `json:"price" validate:"required"`
+	Stock       int               `json:"stock" validate:"min=0"`
+	CategoryID  uuid.UUID         `json:"category_id" validate:"required"`
+	IsActive    bool              `json:"is_active"`
+	Attributes  ProductAttributes `json:"attributes,omitempty"`
+
+	// ReorderPoint defaults to DefaultReorderPoint when omitted
+	ReorderPoint    *int `json:"reorder_point,omitempty" validate:"omitempty,min=0"`
+	ReorderQuantity int  `json:"reorder_quantity" validate:"min=0"`
+
+	WeightGrams int `json:"weight_grams" validate:"min=0"`
+	LengthMM    int `json:"length_mm" validate:"min=0"`
+	WidthMM     int `json:"width_mm" validate:"min=0"`
+	HeightMM    int `json:"height_mm" validate:"min=0"`
 }
 
 // UpdateProductRequest represents the request to update a product
@@ -43,8 +84,18 @@ type UpdateProductRequest struct {
 	Name        *string    `json:"name,omitempty"`
 	Description *string    `json:"description,omitempty"`
 	SKU         *string    `json:"sku,omitempty"`
-	Price       *float64   `json:"price,omitempty"`
+	Price       *Money     `json:"price,omitempty"`
 	Stock       *int       `json:"stock,omitempty"`
 	CategoryID  *uuid.UUID `json:"category_id,omitempty"`
 	IsActive    *bool      `json:"is_active,omitempty"`
+	// Attributes replace the product's attributes when set
+	Attributes ProductAttributes `json:"attributes,omitempty"`
+
+	ReorderPoint    *int `json:"reorder_point,omitempty"`
+	ReorderQuantity *int `json:"reorder_quantity,omitempty"`
+
+	WeightGrams *int `json:"weight_grams,omitempty"`
+	LengthMM    *int `json:"length_mm,omitempty"`
+	WidthMM     *int `json:"width_mm,omitempty"`
+	HeightMM    *int `json:"height_mm,omitempty"`
 }
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var (
+	// ErrInvalidPrice is returned when a scheduled price or its window is malformed
+	ErrInvalidPrice = errors.New("invalid price")
+	// ErrPriceStarted is returned when cancelling a price that has already taken effect
+	ErrPriceStarted = errors.New("price has already taken effect")
+)
+
+// ListPriceLabel labels the open-ended prices recorded when a product's list price is set
+const ListPriceLabel = "list price"
+
+// ProductPrice is a price a product sells at from EffectiveFrom until EffectiveTo, or
+// indefinitely when EffectiveTo is nil. Prices with an end, such as sales, win over
+// open-ended list prices while they last; among prices of the same kind the one that
+// started last wins. CompareAtPrice is the strikethrough price shown next to a sale price.
+type ProductPrice struct {
+	bun.BaseModel `bun:"table:product_prices,alias:pp"`
+
+	ID             uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ProductID      uuid.UUID  `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	Price          Money      `bun:"price,type:money_amount,notnull" json:"price"`
+	CompareAtPrice *Money     `bun:"compare_at_price,type:money_amount" json:"compare_at_price,omitempty"`
+	Label          string     `bun:"label,notnull,default:''" json:"label"`
+	EffectiveFrom  time.Time  `bun:"effective_from,notnull" json:"effective_from"`
+	EffectiveTo    *time.Time `bun:"effective_to" json:"effective_to,omitempty"`
+	CreatedAt      time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// NewListPrice returns the open-ended price recording that a product's list price was set
+func NewListPrice(productID uuid.UUID, price Money, from time.Time) *ProductPrice {
+	return &ProductPrice{
+		ID:            uuid.New(),
+		ProductID:     productID,
+		Price:         price,
+		Label:         ListPriceLabel,
+		EffectiveFrom: from,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ActiveAt reports whether the price is in effect at t
+func (p *ProductPrice) ActiveAt(t time.Time) bool {
+	return !t.Before(p.EffectiveFrom) && (p.EffectiveTo == nil || t.Before(*p.EffectiveTo))
+}
+
+// Overlaps reports whether the price is in effect at any time in [from, to); a nil bound
+// leaves that side open
+func (p *ProductPrice) Overlaps(from, to *time.Time) bool {
+	return (to == nil || p.EffectiveFrom.Before(*to)) && (from == nil || p.EffectiveTo == nil || p.EffectiveTo.After(*from))
+}
+
+// outranks reports whether p wins over other when both are in effect
+func (p *ProductPrice) outranks(other *ProductPrice) bool {
+	if (p.EffectiveTo != nil) != (other.EffectiveTo != nil) {
+		return p.EffectiveTo != nil
+	}
+	if !p.EffectiveFrom.Equal(other.EffectiveFrom) {
+		return p.EffectiveFrom.After(other.EffectiveFrom)
+	}
+	return p.CreatedAt.After(other.CreatedAt)
+}
+
+// Validate checks that the price is in the product's currency, that a compare-at price is
+// above it and that the window ends after it starts
+func (p *ProductPrice) Validate(currency string) error {
+	if p.Price.IsNegative() {
+		return fmt.Errorf("%w: price cannot be negative", ErrInvalidPrice)
+	}
+	if p.Price.Currency != currency {
+		return fmt.Errorf("%w: price is in %s but the product is priced in %s", ErrCurrencyMismatch, p.Price.Currency, currency)
+	}
+	if p.CompareAtPrice != nil {
+		if p.CompareAtPrice.Currency != currency {
+			return fmt.Errorf("%w: compare-at price is in %s but the product is priced in %s", ErrCurrencyMismatch, p.CompareAtPrice.Currency, currency)
+		}
+		if p.CompareAtPrice.Amount <= p.Price.Amount {
+			return fmt.Errorf("%w: compare-at price must be above the price", ErrInvalidPrice)
+		}
+	}
+	if p.EffectiveTo != nil && !p.EffectiveTo.After(p.EffectiveFrom) {
+		return fmt.Errorf("%w: effective_to must be after effective_from", ErrInvalidPrice)
+	}
+	return nil
+}
+
+// EffectivePriceAt returns the price in effect at t among prices, or nil when none is
+func EffectivePriceAt(prices []*ProductPrice, t time.Time) *ProductPrice {
+	var effective *ProductPrice
+	for _, price := range prices {
+		if price.ActiveAt(t) && (effective == nil || price.outranks(effective)) {
+			effective = price
+		}
+	}
+	return effective
+}
+
+// EffectivePrice is what a product sells at at a given time. PriceID is the scheduled
+// price in effect, nil when the product sells at its list price.
+type EffectivePrice struct {
+	ProductID      uuid.UUID  `json:"product_id"`
+	Price          Money      `json:"price"`
+	CompareAtPrice *Money     `json:"compare_at_price,omitempty"`
+	Label          string     `json:"label"`
+	PriceID        *uuid.UUID `json:"price_id,omitempty"`
+	At             time.Time  `json:"at"`
+}
+
+// NewEffectivePrice returns what the product sells at at the time given the price in
+// effect then, its list price when price is nil
+func NewEffectivePrice(product *Product, price *ProductPrice, at time.Time) *EffectivePrice {
+	if price == nil {
+		return &EffectivePrice{ProductID: product.ID, Price: product.Price, Label: ListPriceLabel, At: at}
+	}
+	return &EffectivePrice{
+		ProductID:      product.ID,
+		Price:          price.Price,
+		CompareAtPrice: price.CompareAtPrice,
+		Label:          price.Label,
+		PriceID:        &price.ID,
+		At:             at,
+	}
+}
+
+// UnitPrice returns what one unit sells at: the variant's own price when it has one,
+// the effective price of the product otherwise
+func (p *EffectivePrice) UnitPrice(variant *ProductVariant) Money {
+	if variant != nil && variant.Price != nil {
+		return *variant.Price
+	}
+	return p.Price
+}
+
+// CreateProductPriceRequest represents the request to schedule a price. The price takes
+// effect now when EffectiveFrom is omitted and lasts until changed when EffectiveTo is.
+type CreateProductPriceRequest struct {
+	Price          Money      `json:"price" validate:"required"`
+	CompareAtPrice *Money     `json:"compare_at_price,omitempty"`
+	Label          string     `json:"label"`
+	EffectiveFrom  *time.Time `json:"effective_from,omitempty"`
+	EffectiveTo    *time.Time `json:"effective_to,omitempty"`
+}
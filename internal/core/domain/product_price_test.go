@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectivePriceAt(t *testing.T) {
+	start := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	saleEnd := start.Add(72 * time.Hour)
+	productID := uuid.New()
+
+	list := NewListPrice(productID, NewMoney(2000, "USD"), start.Add(-24*time.Hour))
+	raised := NewListPrice(productID, NewMoney(2200, "USD"), start.Add(24*time.Hour))
+	compareAt := NewMoney(2000, "USD")
+	sale := &ProductPrice{ID: uuid.New(), ProductID: productID, Price: NewMoney(1500, "USD"), CompareAtPrice: &compareAt, Label: "Black Friday", EffectiveFrom: start, EffectiveTo: &saleEnd}
+	prices := []*ProductPrice{sale, raised, list}
+
+	assert.Nil(t, EffectivePriceAt(prices, start.Add(-48*time.Hour)))
+	assert.Equal(t, list, EffectivePriceAt(prices, start.Add(-time.Hour)))
+	assert.Equal(t, sale, EffectivePriceAt(prices, start), "a sale wins over a list price")
+	assert.Equal(t, sale, EffectivePriceAt(prices, start.Add(48*time.Hour)), "a sale wins over a later list price")
+	assert.Equal(t, raised, EffectivePriceAt(prices, saleEnd), "the sale ends at effective_to")
+}
+
+func TestProductPrice_Validate(t *testing.T) {
+	from := time.Now()
+	to := from.Add(time.Hour)
+	price := func(amount int64, currency string) *ProductPrice {
+		return &ProductPrice{Price: NewMoney(amount, currency), EffectiveFrom: from, EffectiveTo: &to}
+	}
+
+	assert.NoError(t, price(1000, "USD").Validate("USD"))
+	assert.ErrorIs(t, price(-1, "USD").Validate("USD"), ErrInvalidPrice)
+	assert.ErrorIs(t, price(1000, "EUR").Validate("USD"), ErrCurrencyMismatch)
+
+	compareAt := NewMoney(1000, "USD")
+	withCompareAt := price(1000, "USD")
+	withCompareAt.CompareAtPrice = &compareAt
+	assert.ErrorIs(t, withCompareAt.Validate("USD"), ErrInvalidPrice)
+
+	backwards := price(1000, "USD")
+	backwards.EffectiveTo = &from
+	assert.ErrorIs(t, backwards.Validate("USD"), ErrInvalidPrice)
+}
+
+func TestProductPrice_Overlaps(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	price := &ProductPrice{EffectiveFrom: from, EffectiveTo: &to}
+
+	before, after := from.AddDate(0, 0, -7), to.AddDate(0, 0, 7)
+	middle := from.AddDate(0, 0, 14)
+	assert.True(t, price.Overlaps(nil, nil))
+	assert.True(t, price.Overlaps(&middle, &after))
+	assert.False(t, price.Overlaps(&before, &from))
+	assert.False(t, price.Overlaps(&to, nil))
+
+	open := &ProductPrice{EffectiveFrom: from}
+	assert.True(t, open.Overlaps(&after, nil))
+	assert.False(t, open.Overlaps(nil, &before))
+}
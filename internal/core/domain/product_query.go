@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ProductAttributes are free-form properties of a product used for filtering and facets,
+// e.g. {"brand": "Acme", "material": "cotton"}
+type ProductAttributes map[string]string
+
+// Normalize trims attribute names and values, failing on an empty name. Nil becomes an
+// empty set so the stored column is always a JSON object.
+func (a ProductAttributes) Normalize() (ProductAttributes, error) {
+	normalized := make(ProductAttributes, len(a))
+	for name, value := range a {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("attribute name cannot be empty")
+		}
+		normalized[name] = strings.TrimSpace(value)
+	}
+	return normalized, nil
+}
+
+// ProductAttribute is a single attribute of a product
+type ProductAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// List returns the attributes ordered by name
+func (a ProductAttributes) List() []ProductAttribute {
+	list := make([]ProductAttribute, 0, len(a))
+	for name, value := range a {
+		list = append(list, ProductAttribute{Name: name, Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// ProductQuery selects products by any combination of filters. Unset filters match every
+// product; an attribute matches when the product has any of the listed values.
+type ProductQuery struct {
+	CategoryID *uuid.UUID
+	IsActive   *bool
+	// MinPrice and MaxPrice only match products priced in their currency
+	MinPrice   *Money
+	MaxPrice   *Money
+	MinStock   *int
+	Search     string
+	Attributes map[string][]string
+}
+
+// Validate checks that the query's ranges make sense
+func (q *ProductQuery) Validate() error {
+	if q.MinPrice != nil && q.MinPrice.IsNegative() || q.MaxPrice != nil && q.MaxPrice.IsNegative() {
+		return fmt.Errorf("price filters cannot be negative")
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil {
+		if q.MinPrice.Currency != q.MaxPrice.Currency {
+			return fmt.Errorf("%w: minimum price in %s, maximum price in %s", ErrCurrencyMismatch, q.MinPrice.Currency, q.MaxPrice.Currency)
+		}
+		if q.MinPrice.Amount > q.MaxPrice.Amount {
+			return fmt.Errorf("minimum price cannot exceed maximum price")
+		}
+	}
+	if q.MinStock != nil && *q.MinStock < 0 {
+		return fmt.Errorf("minimum stock cannot be negative")
+	}
+	for name, values := range q.Attributes {
+		if strings.TrimSpace(name) == "" || len(values) == 0 {
+			return fmt.Errorf("attribute filters need a name and at least one value")
+		}
+	}
+	return nil
+}
+
+// AttributeNames returns the names of the filtered attributes in order
+func (q *ProductQuery) AttributeNames() []string {
+	names := make([]string, 0, len(q.Attributes))
+	for name := range q.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseAttributeFilter parses "name:value" into an attribute name and value
+func ParseAttributeFilter(filter string) (string, string, error) {
+	name, value, ok := strings.Cut(filter, ":")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if !ok || name == "" || value == "" {
+		return "", "", fmt.Errorf("invalid attribute filter %q, expected name:value", filter)
+	}
+	return name, value, nil
+}
+
+// FacetValue is one value of a facet and how many matching products have it
+type FacetValue struct {
+	Value string `bun:"value" json:"value"`
+	Count int    `bun:"count" json:"count"`
+}
+
+// CategoryFacet counts the matching products in a category
+type CategoryFacet struct {
+	CategoryID uuid.UUID `bun:"category_id" json:"category_id"`
+	Name       string    `bun:"name" json:"name"`
+	Count      int       `bun:"count" json:"count"`
+}
+
+// AttributeFacet counts the matching products per value of an attribute
+type AttributeFacet struct {
+	Name   string       `json:"name"`
+	Values []FacetValue `json:"values"`
+}
+
+// ProductFacets summarises the products matching a query. Each facet ignores its own
+// filter, so the counts show what selecting another value would return.
+type ProductFacets struct {
+	Total      int              `json:"total"`
+	Categories []CategoryFacet  `json:"categories"`
+	Attributes []AttributeFacet `json:"attributes"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductQueryValidate(t *testing.T) {
+	minPrice, maxPrice := NewMoney(1000, "USD"), NewMoney(5000, "USD")
+	assert.NoError(t, (&ProductQuery{MinPrice: &minPrice, MaxPrice: &maxPrice}).Validate())
+	assert.Error(t, (&ProductQuery{MinPrice: &maxPrice, MaxPrice: &minPrice}).Validate())
+
+	euros := NewMoney(5000, "EUR")
+	err := (&ProductQuery{MinPrice: &minPrice, MaxPrice: &euros}).Validate()
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	negative := -1
+	assert.Error(t, (&ProductQuery{MinStock: &negative}).Validate())
+	assert.Error(t, (&ProductQuery{Attributes: map[string][]string{"brand": nil}}).Validate())
+}
+
+func TestProductAttributes(t *testing.T) {
+	attributes, err := ProductAttributes{" brand ": " Acme ", "colour": "red"}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "colour", Value: "red"}}, attributes.List())
+
+	_, err = ProductAttributes{" ": "Acme"}.Normalize()
+	assert.Error(t, err)
+
+	attributes, err = ProductAttributes(nil).Normalize()
+	assert.NoError(t, err)
+	assert.NotNil(t, attributes)
+
+	name, value, err := ParseAttributeFilter("brand:Acme")
+	assert.NoError(t, err)
+	assert.Equal(t, "brand", name)
+	assert.Equal(t, "Acme", value)
+	_, _, err = ParseAttributeFilter("brand")
+	assert.Error(t, err)
+}
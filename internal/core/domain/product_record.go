@@ -0,0 +1,529 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUnsupportedFormat is returned for product record formats other than CSV and JSON Lines
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	// ErrInvalidProductRecord is returned for a record that cannot be read or turned into a product
+	ErrInvalidProductRecord = errors.New("invalid product record")
+)
+
+// ProductRecordFormat is a file format products are imported from and exported to
+type ProductRecordFormat string
+
+// Product record formats
+const (
+	ProductFormatCSV   ProductRecordFormat = "csv"
+	ProductFormatJSONL ProductRecordFormat = "jsonl"
+)
+
+// ParseProductRecordFormat reads a format name, accepting "ndjson" for JSON Lines
+func ParseProductRecordFormat(format string) (ProductRecordFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "csv":
+		return ProductFormatCSV, nil
+	case "jsonl", "ndjson":
+		return ProductFormatJSONL, nil
+	default:
+		return "", fmt.Errorf("%w: %q, use csv or jsonl", ErrUnsupportedFormat, format)
+	}
+}
+
+// ContentType returns the media type of files in the format
+func (f ProductRecordFormat) ContentType() string {
+	if f == ProductFormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// ProductRecordColumns are the columns of a product CSV file, in the order exports write them
+var ProductRecordColumns = []string{
+	"sku", "name", "description", "price", "currency", "stock", "category_id", "category",
+	"is_active", "reorder_point", "reorder_quantity", "weight_grams", "length_mm", "width_mm",
+	"height_mm", "attributes",
+}
+
+// ProductRecord is a product as a row of an import or export file. Fields left empty are
+// not set: a new product takes their defaults and an existing one keeps its values. The
+// category is given by ID or, when CategoryID is empty, by name; the price is a decimal
+// amount in major units of Currency.
+type ProductRecord struct {
+	SKU             string            `json:"sku"`
+	Name            string            `json:"name,omitempty"`
+	Description     *string           `json:"description,omitempty"`
+	Price           string            `json:"price,omitempty"`
+	Currency        string            `json:"currency,omitempty"`
+	Stock           *int              `json:"stock,omitempty"`
+	CategoryID      string            `json:"category_id,omitempty"`
+	Category        string            `json:"category,omitempty"`
+	IsActive        *bool             `json:"is_active,omitempty"`
+	ReorderPoint    *int              `json:"reorder_point,omitempty"`
+	ReorderQuantity *int              `json:"reorder_quantity,omitempty"`
+	WeightGrams     *int              `json:"weight_grams,omitempty"`
+	LengthMM        *int              `json:"length_mm,omitempty"`
+	WidthMM         *int              `json:"width_mm,omitempty"`
+	HeightMM        *int              `json:"height_mm,omitempty"`
+	Attributes      ProductAttributes `json:"attributes,omitempty"`
+}
+
+// NewProductRecord returns the record of a product, naming its category when it is loaded
+func NewProductRecord(product *Product) *ProductRecord {
+	return &ProductRecord{
+		SKU:             product.SKU,
+		Name:            product.Name,
+		Description:     &product.Description,
+		Price:           product.Price.Decimal(),
+		Currency:        product.Price.Currency,
+		Stock:           &product.Stock,
+		CategoryID:      product.CategoryID.String(),
+		Category:        product.Category.Name,
+		IsActive:        &product.IsActive,
+		ReorderPoint:    &product.ReorderPoint,
+		ReorderQuantity: &product.ReorderQuantity,
+		WeightGrams:     &product.WeightGrams,
+		LengthMM:        &product.LengthMM,
+		WidthMM:         &product.WidthMM,
+		HeightMM:        &product.HeightMM,
+		Attributes:      product.Attributes,
+	}
+}
+
+// price parses the record's price, returning nil when it has none
+func (r *ProductRecord) price() (*Money, error) {
+	if r.Price == "" {
+		if r.Currency != "" {
+			return nil, fmt.Errorf("%w: currency is given without a price", ErrInvalidProductRecord)
+		}
+		return nil, nil
+	}
+	price, err := ParseMoney(r.Price, r.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProductRecord, err)
+	}
+	if price.IsNegative() {
+		return nil, fmt.Errorf("%w: price cannot be negative", ErrInvalidProductRecord)
+	}
+	return &price, nil
+}
+
+// validate checks the fields that must hold whether the record creates or updates a product
+func (r *ProductRecord) validate() error {
+	if strings.TrimSpace(r.SKU) == "" {
+		return fmt.Errorf("%w: sku is required", ErrInvalidProductRecord)
+	}
+	if (r.Stock != nil && *r.Stock < 0) || (r.ReorderPoint != nil && *r.ReorderPoint < 0) ||
+		(r.ReorderQuantity != nil && *r.ReorderQuantity < 0) {
+		return fmt.Errorf("%w: stock and reorder levels cannot be negative", ErrInvalidProductRecord)
+	}
+	for _, n := range []*int{r.WeightGrams, r.LengthMM, r.WidthMM, r.HeightMM} {
+		if n != nil && *n < 0 {
+			return fmt.Errorf("%w: weight and dimensions cannot be negative", ErrInvalidProductRecord)
+		}
+	}
+	if _, err := r.Attributes.Normalize(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProductRecord, err)
+	}
+	return nil
+}
+
+// CreateRequest turns the record into a request creating a product in the category.
+// Imported products are active unless the record says otherwise.
+func (r *ProductRecord) CreateRequest(categoryID uuid.UUID) (*CreateProductRequest, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required for a new product", ErrInvalidProductRecord)
+	}
+	price, err := r.price()
+	if err != nil {
+		return nil, err
+	}
+	if price == nil {
+		return nil, fmt.Errorf("%w: price is required for a new product", ErrInvalidProductRecord)
+	}
+
+	req := &CreateProductRequest{
+		Name:         r.Name,
+		SKU:          r.SKU,
+		Price:        *price,
+		CategoryID:   categoryID,
+		IsActive:     true,
+		Attributes:   r.Attributes,
+		ReorderPoint: r.ReorderPoint,
+	}
+	if r.Description != nil {
+		req.Description = *r.Description
+	}
+	if r.Stock != nil {
+		req.Stock = *r.Stock
+	}
+	if r.IsActive != nil {
+		req.IsActive = *r.IsActive
+	}
+	if r.ReorderQuantity != nil {
+		req.ReorderQuantity = *r.ReorderQuantity
+	}
+	if r.WeightGrams != nil {
+		req.WeightGrams = *r.WeightGrams
+	}
+	if r.LengthMM != nil {
+		req.LengthMM = *r.LengthMM
+	}
+	if r.WidthMM != nil {
+		req.WidthMM = *r.WidthMM
+	}
+	if r.HeightMM != nil {
+		req.HeightMM = *r.HeightMM
+	}
+	return req, nil
+}
+
+// UpdateRequest turns the record into a request updating the fields it sets, moving the
+// product to the category when categoryID is set
+func (r *ProductRecord) UpdateRequest(categoryID *uuid.UUID) (*UpdateProductRequest, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	price, err := r.price()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &UpdateProductRequest{
+		Description:     r.Description,
+		Price:           price,
+		Stock:           r.Stock,
+		CategoryID:      categoryID,
+		IsActive:        r.IsActive,
+		Attributes:      r.Attributes,
+		ReorderPoint:    r.ReorderPoint,
+		ReorderQuantity: r.ReorderQuantity,
+		WeightGrams:     r.WeightGrams,
+		LengthMM:        r.LengthMM,
+		WidthMM:         r.WidthMM,
+		HeightMM:        r.HeightMM,
+	}
+	if r.Name != "" {
+		req.Name = &r.Name
+	}
+	return req, nil
+}
+
+// ProductRecordReader reads product records from an import file
+type ProductRecordReader interface {
+	// Read returns the next record and the line it starts on, or io.EOF after the last.
+	// An error wrapping ErrInvalidProductRecord is about that record alone, and reading
+	// can go on past it.
+	Read() (*ProductRecord, int, error)
+}
+
+// NewProductRecordReader returns a reader of records in the format. CSV files must start
+// with a header naming their columns, in any order, from ProductRecordColumns.
+func NewProductRecordReader(format ProductRecordFormat, r io.Reader) (ProductRecordReader, error) {
+	switch format {
+	case ProductFormatCSV:
+		reader, err := newCSVRecordReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return reader, nil
+	case ProductFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLRecordSize)
+		return &jsonlRecordReader{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// maxJSONLRecordSize is the longest line a JSON Lines import may have
+const maxJSONLRecordSize = 1 << 20
+
+type csvRecordReader struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVRecordReader(r io.Reader) (*csvRecordReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidProductRecord)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidProductRecord, err)
+	}
+
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !isProductRecordColumn(column) {
+			return nil, fmt.Errorf("%w: unknown column %q, columns are %s", ErrInvalidProductRecord, column, strings.Join(ProductRecordColumns, ", "))
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: column %q appears more than once", ErrInvalidProductRecord, column)
+		}
+		seen[column] = true
+		header[i] = column
+	}
+	if !seen["sku"] {
+		return nil, fmt.Errorf("%w: the sku column is required", ErrInvalidProductRecord)
+	}
+	return &csvRecordReader{reader: reader, columns: header}, nil
+}
+
+func isProductRecordColumn(column string) bool {
+	for _, c := range ProductRecordColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *csvRecordReader) Read() (*ProductRecord, int, error) {
+	fields, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, parseErr.StartLine, fmt.Errorf("%w: %v", ErrInvalidProductRecord, parseErr.Err)
+		}
+		return nil, 0, err
+	}
+	line, _ := r.reader.FieldPos(0)
+	if len(fields) != len(r.columns) {
+		return nil, line, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidProductRecord, len(r.columns), len(fields))
+	}
+
+	record := &ProductRecord{}
+	for i, column := range r.columns {
+		if err := record.setColumn(column, strings.TrimSpace(fields[i])); err != nil {
+			return nil, line, err
+		}
+	}
+	return record, line, nil
+}
+
+// setColumn sets the field of a CSV column, leaving it unset when value is empty
+func (r *ProductRecord) setColumn(column, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	var err error
+	switch column {
+	case "sku":
+		r.SKU = value
+	case "name":
+		r.Name = value
+	case "description":
+		r.Description = &value
+	case "price":
+		r.Price = value
+	case "currency":
+		r.Currency = value
+	case "stock":
+		r.Stock, err = parseRecordInt(value)
+	case "category_id":
+		r.CategoryID = value
+	case "category":
+		r.Category = value
+	case "is_active":
+		var active bool
+		active, err = strconv.ParseBool(value)
+		r.IsActive = &active
+	case "reorder_point":
+		r.ReorderPoint, err = parseRecordInt(value)
+	case "reorder_quantity":
+		r.ReorderQuantity, err = parseRecordInt(value)
+	case "weight_grams":
+		r.WeightGrams, err = parseRecordInt(value)
+	case "length_mm":
+		r.LengthMM, err = parseRecordInt(value)
+	case "width_mm":
+		r.WidthMM, err = parseRecordInt(value)
+	case "height_mm":
+		r.HeightMM, err = parseRecordInt(value)
+	case "attributes":
+		err = json.Unmarshal([]byte(value), &r.Attributes)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %q is not valid", ErrInvalidProductRecord, column, value)
+	}
+	return nil
+}
+
+func parseRecordInt(value string) (*int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+type jsonlRecordReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *jsonlRecordReader) Read() (*ProductRecord, int, error) {
+	for r.scanner.Scan() {
+		r.line++
+		raw := bytes.TrimSpace(r.scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		record := &ProductRecord{}
+		if err := decoder.Decode(record); err != nil {
+			return nil, r.line, fmt.Errorf("%w: %v", ErrInvalidProductRecord, err)
+		}
+		return record, r.line, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, r.line + 1, err
+	}
+	return nil, r.line, io.EOF
+}
+
+// ProductRecordWriter writes product records to an export file
+type ProductRecordWriter interface {
+	Write(record *ProductRecord) error
+	// Flush writes any buffered records to the underlying writer
+	Flush() error
+}
+
+// NewProductRecordWriter returns a writer of records in the format. CSV files start with
+// a header of ProductRecordColumns.
+func NewProductRecordWriter(format ProductRecordFormat, w io.Writer) (ProductRecordWriter, error) {
+	switch format {
+	case ProductFormatCSV:
+		return &csvRecordWriter{writer: csv.NewWriter(w)}, nil
+	case ProductFormatJSONL:
+		buffered := bufio.NewWriter(w)
+		return &jsonlRecordWriter{writer: buffered, encoder: json.NewEncoder(buffered)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+type csvRecordWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvRecordWriter) Write(record *ProductRecord) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(ProductRecordColumns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	attributes := ""
+	if len(record.Attributes) > 0 {
+		raw, err := json.Marshal(record.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = string(raw)
+	}
+	return w.writer.Write([]string{
+		record.SKU, record.Name, stringOrEmpty(record.Description), record.Price, record.Currency,
+		intOrEmpty(record.Stock), record.CategoryID, record.Category, boolOrEmpty(record.IsActive),
+		intOrEmpty(record.ReorderPoint), intOrEmpty(record.ReorderQuantity), intOrEmpty(record.WeightGrams),
+		intOrEmpty(record.LengthMM), intOrEmpty(record.WidthMM), intOrEmpty(record.HeightMM), attributes,
+	})
+}
+
+// Flush writes the header too when no record was written, so that empty exports are
+// still valid import files
+func (w *csvRecordWriter) Flush() error {
+	if !w.headerWritten {
+		if err := w.writer.Write(ProductRecordColumns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonlRecordWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (w *jsonlRecordWriter) Write(record *ProductRecord) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonlRecordWriter) Flush() error {
+	return w.writer.Flush()
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func intOrEmpty(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func boolOrEmpty(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// ProductImportError is a record an import skipped and why
+type ProductImportError struct {
+	Line  int    `json:"line"`
+	SKU   string `json:"sku,omitempty"`
+	Error string `json:"error"`
+}
+
+// ProductImportReport is the outcome of an import: how many records created and updated
+// products, and the records that failed. A dry run reports what an import would do
+// without writing anything.
+type ProductImportReport struct {
+	DryRun  bool                 `json:"dry_run"`
+	Records int                  `json:"records"`
+	Created int                  `json:"created"`
+	Updated int                  `json:"updated"`
+	Failed  int                  `json:"failed"`
+	Errors  []ProductImportError `json:"errors"`
+}
+
+// Fail records that the record on the line failed
+func (r *ProductImportReport) Fail(line int, sku string, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, ProductImportError{Line: line, SKU: sku, Error: err.Error()})
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProductRecordFormat(t *testing.T) {
+	format, err := ParseProductRecordFormat("CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, ProductFormatCSV, format)
+
+	format, err = ParseProductRecordFormat("ndjson")
+	assert.NoError(t, err)
+	assert.Equal(t, ProductFormatJSONL, format)
+
+	_, err = ParseProductRecordFormat("xlsx")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestProductRecordReader_CSV(t *testing.T) {
+	file := "\ufeffSKU, name ,attributes,stock\n" +
+		"PH-1,Phone,\"{\"\"colour\"\":\"\"red\"\"}\",4\n" +
+		"PH-2,Phone 2,,lots\n" +
+		"PH-3\n" +
+		"PH-4,\"Phone\n4\",,\n"
+	reader, err := NewProductRecordReader(ProductFormatCSV, strings.NewReader(file))
+	require.NoError(t, err)
+
+	record, line, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, "PH-1", record.SKU)
+	assert.Equal(t, ProductAttributes{"colour": "red"}, record.Attributes)
+	assert.Equal(t, 4, *record.Stock)
+	assert.Nil(t, record.Description)
+
+	_, line, err = reader.Read()
+	assert.ErrorIs(t, err, ErrInvalidProductRecord)
+	assert.Equal(t, 3, line)
+
+	_, line, err = reader.Read()
+	assert.ErrorIs(t, err, ErrInvalidProductRecord)
+	assert.Equal(t, 4, line)
+
+	record, line, err = reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 5, line)
+	assert.Equal(t, "Phone\n4", record.Name)
+	assert.Nil(t, record.Stock)
+
+	_, _, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestProductRecordReader_CSVHeader(t *testing.T) {
+	for _, file := range []string{"", "name,price\n", "sku,sku\n", "sku,colour\n"} {
+		_, err := NewProductRecordReader(ProductFormatCSV, strings.NewReader(file))
+		assert.ErrorIs(t, err, ErrInvalidProductRecord, file)
+	}
+}
+
+func TestProductRecord_Requests(t *testing.T) {
+	categoryID := uuid.New()
+	stock := -1
+
+	req, err := (&ProductRecord{SKU: "PH-1", Name: "Phone", Price: "12.5", Currency: "eur"}).CreateRequest(categoryID)
+	require.NoError(t, err)
+	assert.Equal(t, NewMoney(1250, "EUR"), req.Price)
+	assert.True(t, req.IsActive)
+
+	_, err = (&ProductRecord{SKU: "PH-1", Name: "Phone"}).CreateRequest(categoryID)
+	assert.ErrorIs(t, err, ErrInvalidProductRecord)
+	_, err = (&ProductRecord{SKU: "PH-1", Price: "12"}).CreateRequest(categoryID)
+	assert.ErrorIs(t, err, ErrInvalidProductRecord)
+
+	update, err := (&ProductRecord{SKU: "PH-1"}).UpdateRequest(nil)
+	require.NoError(t, err)
+	assert.Nil(t, update.Name)
+	assert.Nil(t, update.Price)
+
+	_, err = (&ProductRecord{SKU: "PH-1", Stock: &stock}).UpdateRequest(nil)
+	assert.ErrorIs(t, err, ErrInvalidProductRecord)
+	_, err = (&ProductRecord{SKU: "PH-1", Currency: "EUR"}).UpdateRequest(nil)
+	assert.ErrorIs(t, err, ErrInvalidProductRecord)
+}
+
+func TestProductRecordWriter_CSVRoundTrip(t *testing.T) {
+	product := &Product{
+		SKU: "PH-1", Name: "Phone, \"Pro\"", Description: "Our best phone", Price: NewMoney(1999, "USD"), Stock: 3,
+		CategoryID: uuid.New(), Category: Category{Name: "Phones"}, IsActive: true,
+		Attributes: ProductAttributes{"colour": "red"}, ReorderPoint: 10,
+	}
+
+	var out bytes.Buffer
+	writer, err := NewProductRecordWriter(ProductFormatCSV, &out)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(NewProductRecord(product)))
+	require.NoError(t, writer.Flush())
+
+	reader, err := NewProductRecordReader(ProductFormatCSV, &out)
+	require.NoError(t, err)
+	record, _, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, NewProductRecord(product), record)
+}
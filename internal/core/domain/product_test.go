@@ -16,7 +16,7 @@ func TestProduct(t *testing.T) {
 			Name:        "Test Product",
 			Description: "A test product description",
 			SKU:         "TEST-SKU-001",
-			Price:       99.99,
+			Price:       NewMoney(9999, "USD"),
 			Stock:       100,
 			CategoryID:  categoryID,
 			IsActive:    true,
@@ -29,7 +29,7 @@ func TestProduct(t *testing.T) {
 		assert.Equal(t, "Test Product", product.Name)
 		assert.Equal(t, "A test product description", product.Description)
 		assert.Equal(t, "TEST-SKU-001", product.SKU)
-		assert.Equal(t, 99.99, product.Price)
+		assert.Equal(t, NewMoney(9999, "USD"), product.Price)
 		assert.Equal(t, 100, product.Stock)
 		assert.Equal(t, categoryID, product.CategoryID)
 		assert.True(t, product.IsActive)
@@ -43,7 +43,7 @@ func TestProduct(t *testing.T) {
 			ID:         uuid.New(),
 			Name:       "Minimal Product",
 			SKU:        "MIN-SKU-001",
-			Price:      0.0,
+			Price:      NewMoney(0, "USD"),
 			Stock:      0,
 			CategoryID: categoryID,
 			IsActive:   false,
@@ -55,7 +55,7 @@ func TestProduct(t *testing.T) {
 		assert.Equal(t, "Minimal Product", product.Name)
 		assert.Equal(t, "", product.Description)
 		assert.Equal(t, "MIN-SKU-001", product.SKU)
-		assert.Equal(t, 0.0, product.Price)
+		assert.Equal(t, NewMoney(0, "USD"), product.Price)
 		assert.Equal(t, 0, product.Stock)
 		assert.Equal(t, categoryID, product.CategoryID)
 		assert.False(t, product.IsActive)
@@ -68,7 +68,7 @@ func TestProduct(t *testing.T) {
 			Name:        "Café & Tea Set - 100% Organic",
 			Description: "Premium café & tea set with 100% organic ingredients. Perfect for coffee lovers!",
 			SKU:         "CAFÉ-TEA-001",
-			Price:       149.99,
+			Price:       NewMoney(14999, "USD"),
 			Stock:       50,
 			CategoryID:  categoryID,
 			IsActive:    true,
@@ -80,7 +80,7 @@ func TestProduct(t *testing.T) {
 		assert.Equal(t, "Café & Tea Set - 100% Organic", product.Name)
 		assert.Equal(t, "Premium café & tea set with 100% organic ingredients. Perfect for coffee lovers!", product.Description)
 		assert.Equal(t, "CAFÉ-TEA-001", product.SKU)
-		assert.Equal(t, 149.99, product.Price)
+		assert.Equal(t, NewMoney(14999, "USD"), product.Price)
 		assert.Equal(t, 50, product.Stock)
 		assert.Equal(t, categoryID, product.CategoryID)
 		assert.True(t, product.IsActive)
@@ -93,7 +93,7 @@ func TestProduct(t *testing.T) {
 			Name:        "Precision Product",
 			Description: "Product with high precision pricing",
 			SKU:         "PREC-001",
-			Price:       123.456789,
+			Price:       NewMoney(123457, "KWD"),
 			Stock:       1,
 			CategoryID:  categoryID,
 			IsActive:    true,
@@ -102,7 +102,7 @@ func TestProduct(t *testing.T) {
 		}
 
 		assert.NotNil(t, product)
-		assert.Equal(t, 123.456789, product.Price)
+		assert.Equal(t, NewMoney(123457, "KWD"), product.Price)
 	})
 }
 
@@ -113,7 +113,7 @@ func TestCreateProductRequest(t *testing.T) {
 			Name:        "New Product",
 			Description: "A new product description",
 			SKU:         "NEW-SKU-001",
-			Price:       199.99,
+			Price:       NewMoney(19999, "USD"),
 			Stock:       200,
 			CategoryID:  categoryID,
 			IsActive:    true,
@@ -123,7 +123,7 @@ func TestCreateProductRequest(t *testing.T) {
 		assert.Equal(t, "New Product", req.Name)
 		assert.Equal(t, "A new product description", req.Description)
 		assert.Equal(t, "NEW-SKU-001", req.SKU)
-		assert.Equal(t, 199.99, req.Price)
+		assert.Equal(t, NewMoney(19999, "USD"), req.Price)
 		assert.Equal(t, 200, req.Stock)
 		assert.Equal(t, categoryID, req.CategoryID)
 		assert.True(t, req.IsActive)
@@ -134,7 +134,7 @@ func TestCreateProductRequest(t *testing.T) {
 		req := &CreateProductRequest{
 			Name:       "Minimal Product",
 			SKU:        "MIN-SKU-002",
-			Price:      0.0,
+			Price:      NewMoney(0, "USD"),
 			Stock:      0,
 			CategoryID: categoryID,
 			IsActive:   false,
@@ -144,7 +144,7 @@ func TestCreateProductRequest(t *testing.T) {
 		assert.Equal(t, "Minimal Product", req.Name)
 		assert.Equal(t, "", req.Description)
 		assert.Equal(t, "MIN-SKU-002", req.SKU)
-		assert.Equal(t, 0.0, req.Price)
+		assert.Equal(t, NewMoney(0, "USD"), req.Price)
 		assert.Equal(t, 0, req.Stock)
 		assert.Equal(t, categoryID, req.CategoryID)
 		assert.False(t, req.IsActive)
@@ -156,7 +156,7 @@ func TestCreateProductRequest(t *testing.T) {
 			Name:        "Negative Product",
 			Description: "Product with negative values",
 			SKU:         "NEG-SKU-001",
-			Price:       -10.0,
+			Price:       NewMoney(-1000, "USD"),
 			Stock:       -5,
 			CategoryID:  categoryID,
 			IsActive:    true,
@@ -164,7 +164,7 @@ func TestCreateProductRequest(t *testing.T) {
 
 		assert.NotNil(t, req)
 		assert.Equal(t, "Negative Product", req.Name)
-		assert.Equal(t, -10.0, req.Price)
+		assert.Equal(t, NewMoney(-1000, "USD"), req.Price)
 		assert.Equal(t, -5, req.Stock)
 	})
 }
@@ -175,7 +175,7 @@ func TestUpdateProductRequest(t *testing.T) {
 		newName := "Updated Product"
 		newDescription := "Updated description"
 		newSKU := "UPD-SKU-001"
-		newPrice := 299.99
+		newPrice := NewMoney(29999, "USD")
 		newStock := 300
 		newIsActive := false
 
@@ -200,7 +200,7 @@ func TestUpdateProductRequest(t *testing.T) {
 		assert.Equal(t, "Updated Product", *req.Name)
 		assert.Equal(t, "Updated description", *req.Description)
 		assert.Equal(t, "UPD-SKU-001", *req.SKU)
-		assert.Equal(t, 299.99, *req.Price)
+		assert.Equal(t, NewMoney(29999, "USD"), *req.Price)
 		assert.Equal(t, 300, *req.Stock)
 		assert.Equal(t, categoryID, *req.CategoryID)
 		assert.False(t, *req.IsActive)
@@ -208,7 +208,7 @@ func TestUpdateProductRequest(t *testing.T) {
 
 	t.Run("Update product request with partial fields", func(t *testing.T) {
 		newName := "Partially Updated Product"
-		newPrice := 399.99
+		newPrice := NewMoney(39999, "USD")
 
 		req := &UpdateProductRequest{
 			Name:  &newName,
@@ -224,7 +224,7 @@ func TestUpdateProductRequest(t *testing.T) {
 		assert.Nil(t, req.CategoryID)
 		assert.Nil(t, req.IsActive)
 		assert.Equal(t, "Partially Updated Product", *req.Name)
-		assert.Equal(t, 399.99, *req.Price)
+		assert.Equal(t, NewMoney(39999, "USD"), *req.Price)
 	})
 
 	t.Run("Update product request with no fields", func(t *testing.T) {
@@ -244,7 +244,7 @@ func TestUpdateProductRequest(t *testing.T) {
 		emptyName := ""
 		emptyDescription := ""
 		emptySKU := ""
-		zeroPrice := 0.0
+		zeroPrice := NewMoney(0, "USD")
 		zeroStock := 0
 		zeroCategoryID := uuid.Nil
 		falseActive := false
@@ -270,7 +270,7 @@ func TestUpdateProductRequest(t *testing.T) {
 		assert.Equal(t, "", *req.Name)
 		assert.Equal(t, "", *req.Description)
 		assert.Equal(t, "", *req.SKU)
-		assert.Equal(t, 0.0, *req.Price)
+		assert.Equal(t, NewMoney(0, "USD"), *req.Price)
 		assert.Equal(t, 0, *req.Stock)
 		assert.Equal(t, uuid.Nil, *req.CategoryID)
 		assert.False(t, *req.IsActive)
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ErrVariantRequired is returned when a product with variants is ordered without naming one
+var ErrVariantRequired = errors.New("variant required")
+
+// VariantOptions are the option values that set a variant apart, e.g. {"size": "M", "colour": "red"}
+type VariantOptions map[string]string
+
+// VariantOption is a single option value of a variant
+type VariantOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// List returns the options ordered by name
+func (o VariantOptions) List() []VariantOption {
+	list := make([]VariantOption, 0, len(o))
+	for name, value := range o {
+		list = append(list, VariantOption{Name: name, Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// ProductVariant is a sellable version of a product, such as one size and colour of a shirt.
+// Its stock is the share of the product's stock held as this variant, so the product's stock,
+// stock levels and ledger keep covering every variant.
+type ProductVariant struct {
+	bun.BaseModel `bun:"table:product_variants,alias:pv"`
+
+	ID        uuid.UUID      `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ProductID uuid.UUID      `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	SKU       string         `bun:"sku,unique,notnull" json:"sku"`
+	Options   VariantOptions `bun:"options,type:jsonb,notnull" json:"options"`
+	Price     *Money         `bun:"price,type:money_amount" json:"price,omitempty"`
+	Stock     int            `bun:"stock,notnull,default:0" json:"stock"`
+	IsActive  bool           `bun:"is_active,notnull,default:true" json:"is_active"`
+	CreatedAt time.Time      `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time      `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// UnitPrice returns the variant's price override, or the product's price when it has none
+func (v *ProductVariant) UnitPrice(product *Product) Money {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return product.Price
+}
+
+// ProductOption is an option of a product's variants and the values they take, e.g. size: S, M, L
+type ProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// Options lists the options of the product's variants ordered by name, with each
+// option's values in the order its variants were loaded
+func (p *Product) Options() []ProductOption {
+	var options []ProductOption
+	positions := make(map[string]int)
+	seen := make(map[VariantOption]bool)
+	for _, variant := range p.Variants {
+		for _, option := range variant.Options.List() {
+			if seen[option] {
+				continue
+			}
+			seen[option] = true
+			i, ok := positions[option.Name]
+			if !ok {
+				i = len(options)
+				positions[option.Name] = i
+				options = append(options, ProductOption{Name: option.Name})
+			}
+			options[i].Values = append(options[i].Values, option.Value)
+		}
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Name < options[j].Name })
+	return options
+}
+
+// ResolveVariant returns the variant of the product an order item names. Products with
+// variants are sold through them, so naming none returns ErrVariantRequired; products
+// without variants return nil.
+func (p *Product) ResolveVariant(variantID *uuid.UUID) (*ProductVariant, error) {
+	if variantID == nil {
+		if len(p.Variants) > 0 {
+			return nil, fmt.Errorf("%w: product %s is sold by variant", ErrVariantRequired, p.Name)
+		}
+		return nil, nil
+	}
+	for _, variant := range p.Variants {
+		if variant.ID == *variantID {
+			if !variant.IsActive {
+				return nil, fmt.Errorf("variant is not active: %s", variant.SKU)
+			}
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("variant %s does not belong to product %s", *variantID, p.Name)
+}
+
+// CreateProductVariantRequest represents the request to add a variant to a product
+type CreateProductVariantRequest struct {
+	SKU      string         `json:"sku" validate:"required"`
+	Options  VariantOptions `json:"options" validate:"required,min=1"`
+	Price    *Money         `json:"price,omitempty"`
+	Stock    int            `json:"stock" validate:"min=0"`
+	IsActive *bool          `json:"is_active,omitempty"`
+}
+
+// UpdateProductVariantRequest represents the request to update a variant
+type UpdateProductVariantRequest struct {
+	SKU      *string        `json:"sku,omitempty"`
+	Options  VariantOptions `json:"options,omitempty"`
+	Price    *Money         `json:"price,omitempty"`
+	Stock    *int           `json:"stock,omitempty"`
+	IsActive *bool          `json:"is_active,omitempty"`
+}
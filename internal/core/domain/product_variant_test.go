@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductOptions(t *testing.T) {
+	product := &Product{Variants: []*ProductVariant{
+		{SKU: "TS-M-RED", Options: VariantOptions{"size": "M", "colour": "red"}},
+		{SKU: "TS-L-RED", Options: VariantOptions{"size": "L", "colour": "red"}},
+		{SKU: "TS-M-BLUE", Options: VariantOptions{"size": "M", "colour": "blue"}},
+	}}
+
+	assert.Equal(t, []ProductOption{
+		{Name: "colour", Values: []string{"red", "blue"}},
+		{Name: "size", Values: []string{"M", "L"}},
+	}, product.Options())
+	assert.Empty(t, (&Product{}).Options())
+}
+
+func TestProductResolveVariant(t *testing.T) {
+	active := &ProductVariant{ID: uuid.New(), SKU: "TS-M", IsActive: true}
+	inactive := &ProductVariant{ID: uuid.New(), SKU: "TS-L"}
+	product := &Product{Name: "T-Shirt", Variants: []*ProductVariant{active, inactive}}
+
+	variant, err := product.ResolveVariant(&active.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, active, variant)
+
+	_, err = product.ResolveVariant(nil)
+	assert.True(t, errors.Is(err, ErrVariantRequired))
+
+	_, err = product.ResolveVariant(&inactive.ID)
+	assert.Error(t, err)
+
+	other := uuid.New()
+	_, err = product.ResolveVariant(&other)
+	assert.Error(t, err)
+
+	variant, err = (&Product{}).ResolveVariant(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, variant)
+}
+
+func TestProductVariantUnitPrice(t *testing.T) {
+	product := &Product{Price: NewMoney(1500, "USD")}
+	override := NewMoney(1800, "USD")
+
+	assert.Equal(t, product.Price, (&ProductVariant{}).UnitPrice(product))
+	assert.Equal(t, override, (&ProductVariant{Price: &override}).UnitPrice(product))
+}
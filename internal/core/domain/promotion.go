@@ -0,0 +1,445 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var (
+	// ErrInvalidPromotion is returned when a promotion's rule or limits are malformed
+	ErrInvalidPromotion = errors.New("invalid promotion")
+	// ErrPromotionUnavailable is returned when a promotion cannot be used: it is inactive,
+	// outside its dates, used up or not open to the customer
+	ErrPromotionUnavailable = errors.New("promotion is not available")
+	// ErrInvalidCoupon is returned when an order names a coupon code that cannot be used
+	ErrInvalidCoupon = errors.New("invalid coupon code")
+)
+
+// PromotionType is the rule a promotion discounts by
+type PromotionType string
+
+const (
+	// PromotionTypePercentage takes PercentOff percent off the eligible items
+	PromotionTypePercentage PromotionType = "percentage"
+	// PromotionTypeFixedAmount takes AmountOff off the eligible items, spread over them
+	PromotionTypeFixedAmount PromotionType = "fixed_amount"
+	// PromotionTypeBuyXGetY gives GetQuantity eligible items free for every BuyQuantity
+	// bought, the cheapest of each group being the free ones
+	PromotionTypeBuyXGetY PromotionType = "buy_x_get_y"
+)
+
+// IsValid reports whether the type is a known promotion type
+func (t PromotionType) IsValid() bool {
+	switch t {
+	case PromotionTypePercentage, PromotionTypeFixedAmount, PromotionTypeBuyXGetY:
+		return true
+	}
+	return false
+}
+
+// couponCodePattern is what coupon codes may look like once normalized
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{2,31}$`)
+
+// NormalizeCouponCode returns the code as stored: trimmed and upper case, so that codes
+// match however customers type them
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Promotion is a discount rule. Promotions with a Code are coupons that apply only to
+// orders naming the code; those without apply to every order they are eligible for.
+//
+// ProductIDs and CategoryIDs limit the items a promotion discounts, an item being eligible
+// when either list names its product or its category or one of the category's ancestors;
+// with both empty every item is. CustomerIDs likewise limits who may use it.
+//
+// Stackable promotions combine with each other, applied by Priority, highest first, each on
+// what the ones before left to pay. A promotion that is not stackable applies alone: an
+// order gets either the best of those or all the stackable ones, whichever saves more.
+type Promotion struct {
+	bun.BaseModel `bun:"table:promotions,alias:pm"`
+
+	ID               uuid.UUID     `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Name             string        `bun:"name,notnull" json:"name"`
+	Description      string        `bun:"description" json:"description"`
+	Code             *string       `bun:"code,unique" json:"code,omitempty"`
+	Type             PromotionType `bun:"type,notnull" json:"type"`
+	PercentOff       int           `bun:"percent_off,notnull,default:0" json:"percent_off,omitempty"`
+	AmountOff        *Money        `bun:"amount_off,type:money_amount" json:"amount_off,omitempty"`
+	BuyQuantity      int           `bun:"buy_quantity,notnull,default:0" json:"buy_quantity,omitempty"`
+	GetQuantity      int           `bun:"get_quantity,notnull,default:0" json:"get_quantity,omitempty"`
+	MinSubtotal      *Money        `bun:"min_subtotal,type:money_amount" json:"min_subtotal,omitempty"`
+	ProductIDs       []uuid.UUID   `bun:"product_ids,type:uuid[],array" json:"product_ids"`
+	CategoryIDs      []uuid.UUID   `bun:"category_ids,type:uuid[],array" json:"category_ids"`
+	CustomerIDs      []uuid.UUID   `bun:"customer_ids,type:uuid[],array" json:"customer_ids"`
+	Stackable        bool          `bun:"stackable,notnull,default:false" json:"stackable"`
+	Priority         int           `bun:"priority,notnull,default:0" json:"priority"`
+	UsageLimit       *int          `bun:"usage_limit" json:"usage_limit,omitempty"`
+	PerCustomerLimit *int          `bun:"per_customer_limit" json:"per_customer_limit,omitempty"`
+	TimesUsed        int           `bun:"times_used,notnull,default:0" json:"times_used"`
+	StartsAt         *time.Time    `bun:"starts_at" json:"starts_at,omitempty"`
+	ExpiresAt        *time.Time    `bun:"expires_at" json:"expires_at,omitempty"`
+	IsActive         bool          `bun:"is_active,notnull,default:true" json:"is_active"`
+	CreatedAt        time.Time     `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt        time.Time     `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// IsCoupon reports whether the promotion only applies to orders naming its code
+func (p *Promotion) IsCoupon() bool {
+	return p.Code != nil
+}
+
+// Validate checks the promotion's code, that its rule has the amounts its type needs and
+// that its limits and dates make sense
+func (p *Promotion) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPromotion)
+	}
+	if p.Code != nil && !couponCodePattern.MatchString(*p.Code) {
+		return fmt.Errorf("%w: code must be 3 to 32 letters, digits, '-' or '_'", ErrInvalidPromotion)
+	}
+
+	switch p.Type {
+	case PromotionTypePercentage:
+		if p.PercentOff < 1 || p.PercentOff > 100 {
+			return fmt.Errorf("%w: percent_off must be between 1 and 100", ErrInvalidPromotion)
+		}
+	case PromotionTypeFixedAmount:
+		if p.AmountOff == nil || p.AmountOff.Amount <= 0 {
+			return fmt.Errorf("%w: amount_off must be above zero", ErrInvalidPromotion)
+		}
+	case PromotionTypeBuyXGetY:
+		if p.BuyQuantity < 1 || p.GetQuantity < 1 {
+			return fmt.Errorf("%w: buy_quantity and get_quantity must be at least 1", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidPromotion, p.Type)
+	}
+
+	if p.MinSubtotal != nil {
+		if p.MinSubtotal.IsNegative() {
+			return fmt.Errorf("%w: min_subtotal cannot be negative", ErrInvalidPromotion)
+		}
+		if p.AmountOff != nil && p.MinSubtotal.Currency != p.AmountOff.Currency {
+			return fmt.Errorf("%w: min_subtotal is in %s but amount_off is in %s", ErrCurrencyMismatch, p.MinSubtotal.Currency, p.AmountOff.Currency)
+		}
+	}
+	if p.UsageLimit != nil && *p.UsageLimit < 1 {
+		return fmt.Errorf("%w: usage_limit must be at least 1", ErrInvalidPromotion)
+	}
+	if p.PerCustomerLimit != nil && *p.PerCustomerLimit < 1 {
+		return fmt.Errorf("%w: per_customer_limit must be at least 1", ErrInvalidPromotion)
+	}
+	if p.StartsAt != nil && p.ExpiresAt != nil && !p.ExpiresAt.After(*p.StartsAt) {
+		return fmt.Errorf("%w: expires_at must be after starts_at", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+// Available returns ErrPromotionUnavailable when the promotion cannot be used at t: it is
+// inactive, has not started, has expired or has reached its usage limit
+func (p *Promotion) Available(t time.Time) error {
+	switch {
+	case !p.IsActive:
+		return fmt.Errorf("%w: %s is inactive", ErrPromotionUnavailable, p.Name)
+	case p.StartsAt != nil && t.Before(*p.StartsAt):
+		return fmt.Errorf("%w: %s starts at %s", ErrPromotionUnavailable, p.Name, p.StartsAt.Format(time.RFC3339))
+	case p.ExpiresAt != nil && !t.Before(*p.ExpiresAt):
+		return fmt.Errorf("%w: %s expired at %s", ErrPromotionUnavailable, p.Name, p.ExpiresAt.Format(time.RFC3339))
+	case p.UsageLimit != nil && p.TimesUsed >= *p.UsageLimit:
+		return fmt.Errorf("%w: %s has reached its usage limit", ErrPromotionUnavailable, p.Name)
+	}
+	return nil
+}
+
+// AvailableTo returns ErrPromotionUnavailable when the customer may not use the promotion,
+// given how many orders they have already used it on
+func (p *Promotion) AvailableTo(customerID uuid.UUID, timesUsed int) error {
+	if len(p.CustomerIDs) > 0 && !slices.Contains(p.CustomerIDs, customerID) {
+		return fmt.Errorf("%w: %s is not open to this customer", ErrPromotionUnavailable, p.Name)
+	}
+	if p.PerCustomerLimit != nil && timesUsed >= *p.PerCustomerLimit {
+		return fmt.Errorf("%w: %s has already been used the most times allowed by this customer", ErrPromotionUnavailable, p.Name)
+	}
+	return nil
+}
+
+// Eligible reports whether the promotion discounts the line's item
+func (p *Promotion) Eligible(line *PricedLine) bool {
+	if len(p.ProductIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	if slices.Contains(p.ProductIDs, line.ProductID) {
+		return true
+	}
+	return slices.ContainsFunc(line.CategoryIDs, func(id uuid.UUID) bool { return slices.Contains(p.CategoryIDs, id) })
+}
+
+// PricedLine is an order line as promotions see it. CategoryIDs holds the product's
+// category and that category's ancestors. Discount is set by ApplyPromotions.
+type PricedLine struct {
+	ProductID   uuid.UUID
+	VariantID   *uuid.UUID
+	CategoryIDs []uuid.UUID
+	Quantity    int
+	UnitPrice   Money
+	Discount    Money
+}
+
+// NewPricedLine returns the line for quantity of the product, or of its variant, at unitPrice
+func NewPricedLine(product *Product, variantID *uuid.UUID, quantity int, unitPrice Money) *PricedLine {
+	return &PricedLine{
+		ProductID:   product.ID,
+		VariantID:   variantID,
+		CategoryIDs: append(product.Category.AncestorIDs(), product.CategoryID),
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Discount:    Money{Currency: unitPrice.Currency},
+	}
+}
+
+// Subtotal returns the line's price before discounts
+func (l *PricedLine) Subtotal() Money {
+	return l.UnitPrice.Multiply(l.Quantity)
+}
+
+// Total returns the line's price after discounts
+func (l *PricedLine) Total() Money {
+	return Money{Amount: l.Subtotal().Amount - l.Discount.Amount, Currency: l.UnitPrice.Currency}
+}
+
+// AppliedPromotion is a promotion an order gets and what it takes off the order in all,
+// and off each line, in the order of the lines
+type AppliedPromotion struct {
+	Promotion *Promotion
+	Amount    Money
+	Lines     []int64
+}
+
+// ApplyPromotions works out which of the promotions the lines get, and what each takes off,
+// under the stacking rules described on Promotion. It sets the Discount of each line and
+// returns the promotions applied in the order they were. Promotions are expected to have
+// been checked as available to the customer; those whose minimum subtotal the lines do not
+// reach, that discount none of the lines or that are in another currency are left out.
+func ApplyPromotions(lines []*PricedLine, promotions []*Promotion) []*AppliedPromotion {
+	ordered := append([]*Promotion(nil), promotions...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+	})
+
+	var stackable []*Promotion
+	for _, promotion := range ordered {
+		if promotion.Stackable {
+			stackable = append(stackable, promotion)
+		}
+	}
+	best, bestAmount := applyInTurn(lines, stackable)
+	for _, promotion := range ordered {
+		if promotion.Stackable {
+			continue
+		}
+		if applied, amount := applyInTurn(lines, []*Promotion{promotion}); amount > bestAmount {
+			best, bestAmount = applied, amount
+		}
+	}
+
+	for i, line := range lines {
+		line.Discount = Money{Currency: line.UnitPrice.Currency}
+		for _, applied := range best {
+			line.Discount.Amount += applied.Lines[i]
+		}
+	}
+	return best
+}
+
+// applyInTurn applies the promotions one after the other, each to what is left to pay after
+// the ones before, returning those that took something off and how much they took in all
+func applyInTurn(lines []*PricedLine, promotions []*Promotion) ([]*AppliedPromotion, int64) {
+	if len(lines) == 0 {
+		return nil, 0
+	}
+	currency := lines[0].UnitPrice.Currency
+	var subtotal int64
+	remaining := make([]int64, len(lines))
+	for i, line := range lines {
+		remaining[i] = line.Subtotal().Amount
+		subtotal += remaining[i]
+	}
+
+	var applied []*AppliedPromotion
+	var total int64
+	for _, promotion := range promotions {
+		if promotion.MinSubtotal != nil && (promotion.MinSubtotal.Currency != currency || subtotal < promotion.MinSubtotal.Amount) {
+			continue
+		}
+		if promotion.AmountOff != nil && promotion.AmountOff.Currency != currency {
+			continue
+		}
+
+		amounts := promotion.discount(lines, remaining)
+		var amount int64
+		for i := range amounts {
+			remaining[i] -= amounts[i]
+			amount += amounts[i]
+		}
+		if amount == 0 {
+			continue
+		}
+		applied = append(applied, &AppliedPromotion{Promotion: promotion, Amount: NewMoney(amount, currency), Lines: amounts})
+		total += amount
+	}
+	return applied, total
+}
+
+// discount returns what the promotion takes off each line given what is left to pay on them
+func (p *Promotion) discount(lines []*PricedLine, remaining []int64) []int64 {
+	amounts := make([]int64, len(lines))
+	var eligible []int
+	for i, line := range lines {
+		if p.Eligible(line) && remaining[i] > 0 {
+			eligible = append(eligible, i)
+		}
+	}
+	if len(eligible) == 0 {
+		return amounts
+	}
+
+	switch p.Type {
+	case PromotionTypePercentage:
+		for _, i := range eligible {
+			amounts[i] = Money{Amount: remaining[i] * int64(p.PercentOff)}.Divide(100).Amount
+		}
+
+	case PromotionTypeFixedAmount:
+		// Spread the amount over the eligible lines in proportion to what is left on them,
+		// handing the cents lost to rounding down to the lines in turn
+		var left int64
+		for _, i := range eligible {
+			left += remaining[i]
+		}
+		off := min(p.AmountOff.Amount, left)
+		spread := int64(0)
+		for _, i := range eligible {
+			amounts[i] = off * remaining[i] / left
+			spread += amounts[i]
+		}
+		for n := 0; spread < off; n++ {
+			if i := eligible[n%len(eligible)]; amounts[i] < remaining[i] {
+				amounts[i]++
+				spread++
+			}
+		}
+
+	case PromotionTypeBuyXGetY:
+		// Line up the eligible units dearest first; in each group of BuyQuantity+GetQuantity
+		// the last GetQuantity, the cheapest, are free
+		type unit struct {
+			line  int
+			price int64
+		}
+		var units []unit
+		for _, i := range eligible {
+			price := Money{Amount: remaining[i]}.Divide(lines[i].Quantity).Amount
+			for n := 0; n < lines[i].Quantity; n++ {
+				units = append(units, unit{line: i, price: price})
+			}
+		}
+		sort.SliceStable(units, func(a, b int) bool { return units[a].price > units[b].price })
+
+		group := p.BuyQuantity + p.GetQuantity
+		for n := range units {
+			if n%group >= p.BuyQuantity && len(units)-n+n%group >= group {
+				i := units[n].line
+				amounts[i] = min(amounts[i]+units[n].price, remaining[i])
+			}
+		}
+	}
+	return amounts
+}
+
+// OrderDiscount records a promotion applied to an order and what it took off in all.
+// Code is the coupon code the customer gave, empty for promotions applied automatically.
+type OrderDiscount struct {
+	bun.BaseModel `bun:"table:order_discounts,alias:od"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	OrderID     uuid.UUID `bun:"order_id,type:uuid,notnull" json:"order_id"`
+	PromotionID uuid.UUID `bun:"promotion_id,type:uuid,notnull" json:"promotion_id"`
+	Code        string    `bun:"code,notnull,default:''" json:"code,omitempty"`
+	Name        string    `bun:"name,notnull" json:"name"`
+	Amount      Money     `bun:"amount,type:money_amount,notnull" json:"amount"`
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// NewOrderDiscount returns the record of the applied promotion on the order
+func NewOrderDiscount(orderID uuid.UUID, applied *AppliedPromotion) *OrderDiscount {
+	return &OrderDiscount{
+		ID:          uuid.New(),
+		OrderID:     orderID,
+		PromotionID: applied.Promotion.ID,
+		Code:        applied.Promotion.code(),
+		Name:        applied.Promotion.Name,
+		Amount:      applied.Amount,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// code returns the promotion's coupon code, empty when it has none
+func (p *Promotion) code() string {
+	if p.Code == nil {
+		return ""
+	}
+	return *p.Code
+}
+
+// CreatePromotionRequest represents the request to create a promotion. Leaving Code empty
+// creates a promotion applied automatically to every eligible order.
+type CreatePromotionRequest struct {
+	Name             string        `json:"name" validate:"required"`
+	Description      string        `json:"description"`
+	Code             string        `json:"code"`
+	Type             PromotionType `json:"type" validate:"required"`
+	PercentOff       int           `json:"percent_off"`
+	AmountOff        *Money        `json:"amount_off,omitempty"`
+	BuyQuantity      int           `json:"buy_quantity"`
+	GetQuantity      int           `json:"get_quantity"`
+	MinSubtotal      *Money        `json:"min_subtotal,omitempty"`
+	ProductIDs       []uuid.UUID   `json:"product_ids"`
+	CategoryIDs      []uuid.UUID   `json:"category_ids"`
+	CustomerIDs      []uuid.UUID   `json:"customer_ids"`
+	Stackable        bool          `json:"stackable"`
+	Priority         int           `json:"priority"`
+	UsageLimit       *int          `json:"usage_limit,omitempty"`
+	PerCustomerLimit *int          `json:"per_customer_limit,omitempty"`
+	StartsAt         *time.Time    `json:"starts_at,omitempty"`
+	ExpiresAt        *time.Time    `json:"expires_at,omitempty"`
+}
+
+// UpdatePromotionRequest represents the request to update a promotion. Its rule and code
+// are fixed once created; create a new promotion to change them.
+type UpdatePromotionRequest struct {
+	Name             *string      `json:"name,omitempty"`
+	Description      *string      `json:"description,omitempty"`
+	MinSubtotal      *Money       `json:"min_subtotal,omitempty"`
+	ProductIDs       *[]uuid.UUID `json:"product_ids,omitempty"`
+	CategoryIDs      *[]uuid.UUID `json:"category_ids,omitempty"`
+	CustomerIDs      *[]uuid.UUID `json:"customer_ids,omitempty"`
+	Stackable        *bool        `json:"stackable,omitempty"`
+	Priority         *int         `json:"priority,omitempty"`
+	UsageLimit       *int         `json:"usage_limit,omitempty"`
+	PerCustomerLimit *int         `json:"per_customer_limit,omitempty"`
+	StartsAt         *time.Time   `json:"starts_at,omitempty"`
+	ExpiresAt        *time.Time   `json:"expires_at,omitempty"`
+	IsActive         *bool        `json:"is_active,omitempty"`
+}
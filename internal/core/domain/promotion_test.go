@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pricedLine(quantity int, unitPrice int64) *PricedLine {
+	return &PricedLine{ProductID: uuid.New(), Quantity: quantity, UnitPrice: NewMoney(unitPrice, "USD"), Discount: NewMoney(0, "USD")}
+}
+
+func TestApplyPromotions_Types(t *testing.T) {
+	t.Run("Percentage", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(3, 333)}
+		applied := ApplyPromotions(lines, []*Promotion{{Name: "10%", Type: PromotionTypePercentage, PercentOff: 10}})
+
+		require.Len(t, applied, 1)
+		assert.Equal(t, NewMoney(100, "USD"), applied[0].Amount)
+		assert.Equal(t, NewMoney(899, "USD"), lines[0].Total())
+	})
+
+	t.Run("Fixed amount is spread over the lines", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(1, 1000), pricedLine(1, 2000)}
+		amountOff := NewMoney(1000, "USD")
+		applied := ApplyPromotions(lines, []*Promotion{{Name: "10 off", Type: PromotionTypeFixedAmount, AmountOff: &amountOff}})
+
+		require.Len(t, applied, 1)
+		assert.Equal(t, []int64{334, 666}, applied[0].Lines)
+		assert.Equal(t, NewMoney(1000, "USD"), applied[0].Amount)
+	})
+
+	t.Run("Fixed amount never exceeds the order", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(1, 500)}
+		amountOff := NewMoney(1000, "USD")
+		applied := ApplyPromotions(lines, []*Promotion{{Name: "10 off", Type: PromotionTypeFixedAmount, AmountOff: &amountOff}})
+
+		require.Len(t, applied, 1)
+		assert.Equal(t, NewMoney(0, "USD"), lines[0].Total())
+	})
+
+	t.Run("Buy two get one frees the cheapest unit of each full group", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(2, 1000), pricedLine(3, 400)}
+		applied := ApplyPromotions(lines, []*Promotion{{Name: "3 for 2", Type: PromotionTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1}})
+
+		// Units 1000, 1000, 400 | 400, 400: only the first group is full
+		require.Len(t, applied, 1)
+		assert.Equal(t, []int64{0, 400}, applied[0].Lines)
+	})
+}
+
+func TestApplyPromotions_Stacking(t *testing.T) {
+	amountOff := NewMoney(500, "USD")
+	tenPercent := &Promotion{ID: uuid.New(), Name: "10%", Type: PromotionTypePercentage, PercentOff: 10, Stackable: true, Priority: 1}
+	fiveOff := &Promotion{ID: uuid.New(), Name: "5 off", Type: PromotionTypeFixedAmount, AmountOff: &amountOff, Stackable: true}
+	quarter := &Promotion{ID: uuid.New(), Name: "25%", Type: PromotionTypePercentage, PercentOff: 25}
+
+	t.Run("Stackable promotions apply in priority order", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(1, 10000)}
+		applied := ApplyPromotions(lines, []*Promotion{fiveOff, tenPercent})
+
+		require.Len(t, applied, 2)
+		assert.Equal(t, tenPercent, applied[0].Promotion)
+		assert.Equal(t, NewMoney(1000, "USD"), applied[0].Amount)
+		assert.Equal(t, NewMoney(500, "USD"), applied[1].Amount)
+		assert.Equal(t, NewMoney(1500, "USD"), lines[0].Discount)
+	})
+
+	t.Run("A better exclusive promotion wins over the stack", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(1, 10000)}
+		applied := ApplyPromotions(lines, []*Promotion{fiveOff, tenPercent, quarter})
+
+		require.Len(t, applied, 1)
+		assert.Equal(t, quarter, applied[0].Promotion)
+		assert.Equal(t, NewMoney(2500, "USD"), lines[0].Discount)
+	})
+
+	t.Run("The stack wins over a worse exclusive promotion", func(t *testing.T) {
+		lines := []*PricedLine{pricedLine(1, 1000)}
+		applied := ApplyPromotions(lines, []*Promotion{fiveOff, tenPercent, quarter})
+
+		// 10% then 5.00 off is 6.00, 25% is 2.50
+		require.Len(t, applied, 2)
+		assert.Equal(t, NewMoney(600, "USD"), lines[0].Discount)
+	})
+
+	t.Run("Minimum subtotal", func(t *testing.T) {
+		minSubtotal := NewMoney(5000, "USD")
+		promotion := &Promotion{Name: "Big spender", Type: PromotionTypePercentage, PercentOff: 10, MinSubtotal: &minSubtotal}
+
+		assert.Empty(t, ApplyPromotions([]*PricedLine{pricedLine(4, 1000)}, []*Promotion{promotion}))
+		assert.Len(t, ApplyPromotions([]*PricedLine{pricedLine(5, 1000)}, []*Promotion{promotion}), 1)
+	})
+}
+
+func TestPromotion_Eligible(t *testing.T) {
+	parentID, childID := uuid.New(), uuid.New()
+	product := &Product{
+		ID:         uuid.New(),
+		CategoryID: childID,
+		Category:   Category{ID: childID, Path: "/" + parentID.String() + "/" + childID.String() + "/"},
+	}
+	line := NewPricedLine(product, nil, 1, NewMoney(1000, "USD"))
+
+	assert.True(t, (&Promotion{}).Eligible(line), "a promotion without limits covers every item")
+	assert.True(t, (&Promotion{ProductIDs: []uuid.UUID{product.ID}}).Eligible(line))
+	assert.True(t, (&Promotion{CategoryIDs: []uuid.UUID{childID}}).Eligible(line))
+	assert.True(t, (&Promotion{CategoryIDs: []uuid.UUID{parentID}}).Eligible(line), "a category covers its subcategories")
+	assert.False(t, (&Promotion{ProductIDs: []uuid.UUID{uuid.New()}, CategoryIDs: []uuid.UUID{uuid.New()}}).Eligible(line))
+}
+
+func TestPromotion_Validate(t *testing.T) {
+	code := "SAVE10"
+	promotion := func() *Promotion {
+		return &Promotion{Name: "Save 10", Code: &code, Type: PromotionTypePercentage, PercentOff: 10}
+	}
+	assert.NoError(t, promotion().Validate())
+
+	badCode := "no spaces"
+	withBadCode := promotion()
+	withBadCode.Code = &badCode
+	assert.ErrorIs(t, withBadCode.Validate(), ErrInvalidPromotion)
+
+	tooMuch := promotion()
+	tooMuch.PercentOff = 101
+	assert.ErrorIs(t, tooMuch.Validate(), ErrInvalidPromotion)
+
+	fixed := promotion()
+	fixed.Type = PromotionTypeFixedAmount
+	assert.ErrorIs(t, fixed.Validate(), ErrInvalidPromotion, "a fixed amount promotion needs amount_off")
+
+	buyGet := promotion()
+	buyGet.Type = PromotionTypeBuyXGetY
+	buyGet.BuyQuantity = 2
+	assert.ErrorIs(t, buyGet.Validate(), ErrInvalidPromotion, "a buy X get Y promotion needs get_quantity")
+
+	now := time.Now()
+	backwards := promotion()
+	backwards.StartsAt, backwards.ExpiresAt = &now, &now
+	assert.ErrorIs(t, backwards.Validate(), ErrInvalidPromotion)
+
+	assert.Equal(t, "SAVE10", NormalizeCouponCode("  save10 "))
+}
+
+func TestPromotion_Available(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	limit := 2
+	promotion := &Promotion{Name: "Save 10", IsActive: true, ExpiresAt: &later, UsageLimit: &limit, TimesUsed: 1}
+
+	assert.NoError(t, promotion.Available(now))
+	assert.ErrorIs(t, promotion.Available(later), ErrPromotionUnavailable, "a promotion expires at expires_at")
+
+	promotion.TimesUsed = 2
+	assert.ErrorIs(t, promotion.Available(now), ErrPromotionUnavailable)
+
+	promotion.TimesUsed = 0
+	promotion.IsActive = false
+	assert.ErrorIs(t, promotion.Available(now), ErrPromotionUnavailable)
+
+	customerID := uuid.New()
+	perCustomer := 1
+	promotion.PerCustomerLimit = &perCustomer
+	assert.NoError(t, promotion.AvailableTo(customerID, 0))
+	assert.ErrorIs(t, promotion.AvailableTo(customerID, 1), ErrPromotionUnavailable)
+
+	promotion.CustomerIDs = []uuid.UUID{uuid.New()}
+	assert.ErrorIs(t, promotion.AvailableTo(customerID, 0), ErrPromotionUnavailable)
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PurchaseOrderStatus represents the status of a purchase order
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+)
+
+// ErrInvalidPurchaseOrder is returned when a purchase order or a receipt against it is not valid
+var ErrInvalidPurchaseOrder = errors.New("invalid purchase order")
+
+// purchaseOrderStatusTransitions lists the statuses each purchase order status may move to
+var purchaseOrderStatusTransitions = map[PurchaseOrderStatus][]PurchaseOrderStatus{
+	PurchaseOrderStatusDraft:             {PurchaseOrderStatusSent},
+	PurchaseOrderStatusSent:              {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived},
+	PurchaseOrderStatusPartiallyReceived: {PurchaseOrderStatusReceived},
+	PurchaseOrderStatusReceived:          {},
+}
+
+// IsValid reports whether the status is a known purchase order status
+func (s PurchaseOrderStatus) IsValid() bool {
+	_, ok := purchaseOrderStatusTransitions[s]
+	return ok
+}
+
+// ValidateTransition returns ErrInvalidStatusTransition when next is not reachable from s
+func (s PurchaseOrderStatus) ValidateTransition(next PurchaseOrderStatus) error {
+	if !next.IsValid() {
+		return fmt.Errorf("%w: unknown purchase order status %q", ErrInvalidStatusTransition, next)
+	}
+	for _, allowed := range purchaseOrderStatusTransitions[s] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: purchase order %s -> %s", ErrInvalidStatusTransition, s, next)
+}
+
+// UnmarshalGQL maps GraphQL enum values (e.g. PARTIALLY_RECEIVED) to purchase order statuses
+func (s *PurchaseOrderStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("purchase order status must be a string")
+	}
+	*s = PurchaseOrderStatus(strings.ToLower(str))
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid PurchaseOrderStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the purchase order status as a GraphQL enum value
+func (s PurchaseOrderStatus) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// PurchaseOrder is an order for stock placed with a supplier. Received stock is
+// added to the purchase order's warehouse, or the default warehouse when it has none.
+type PurchaseOrder struct {
+	bun.BaseModel `bun:"table:purchase_orders,alias:po"`
+
+	ID           uuid.UUID           `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	SupplierID   uuid.UUID           `bun:"supplier_id,type:uuid,notnull" json:"supplier_id"`
+	WarehouseID  *uuid.UUID          `bun:"warehouse_id,type:uuid" json:"warehouse_id,omitempty"`
+	Status       PurchaseOrderStatus `bun:"status,notnull,default:'draft'" json:"status"`
+	ExpectedDate *time.Time          `bun:"expected_date" json:"expected_date"`
+	Notes        string              `bun:"notes" json:"notes"`
+	SentAt       *time.Time          `bun:"sent_at" json:"sent_at"`
+	ReceivedAt   *time.Time          `bun:"received_at" json:"received_at"`
+	CreatedAt    time.Time           `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt    time.Time           `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Relations
+	Supplier *Supplier           `bun:"rel:belongs-to,join:supplier_id=id" json:"supplier,omitempty"`
+	Lines    []PurchaseOrderLine `bun:"rel:has-many,join:id=purchase_order_id" json:"lines"`
+}
+
+// PurchaseOrderLine is the quantity of a product ordered on a purchase order and how much of it has arrived
+type PurchaseOrderLine struct {
+	bun.BaseModel `bun:"table:purchase_order_lines,alias:pol"`
+
+	ID               uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	PurchaseOrderID  uuid.UUID  `bun:"purchase_order_id,type:uuid,notnull" json:"purchase_order_id"`
+	ProductID        uuid.UUID  `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	VariantID        *uuid.UUID `bun:"variant_id,type:uuid" json:"variant_id,omitempty"`
+	QuantityOrdered  int        `bun:"quantity_ordered,notnull" json:"quantity_ordered"`
+	QuantityReceived int        `bun:"quantity_received,notnull,default:0" json:"quantity_received"`
+	UnitCost         Money      `bun:"unit_cost,type:money_amount,notnull" json:"unit_cost"`
+	CreatedAt        time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt        time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// Outstanding returns the quantity of the line still to be received
+func (l PurchaseOrderLine) Outstanding() int {
+	return l.QuantityOrdered - l.QuantityReceived
+}
+
+// TotalCost returns the cost of every line of the purchase order; all lines must share a currency
+func (po *PurchaseOrder) TotalCost() (Money, error) {
+	var total Money
+	for _, line := range po.Lines {
+		var err error
+		if total, err = total.Add(line.UnitCost.Multiply(line.QuantityOrdered)); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// ReceiptStatus returns the status implied by the quantities received so far
+func (po *PurchaseOrder) ReceiptStatus() PurchaseOrderStatus {
+	for _, line := range po.Lines {
+		if line.Outstanding() > 0 {
+			return PurchaseOrderStatusPartiallyReceived
+		}
+	}
+	return PurchaseOrderStatusReceived
+}
+
+// CreatePurchaseOrderRequest represents the request to create a draft purchase order
+type CreatePurchaseOrderRequest struct {
+	SupplierID   uuid.UUID                        `json:"supplier_id" validate:"required"`
+	WarehouseID  *uuid.UUID                       `json:"warehouse_id,omitempty"`
+	ExpectedDate *time.Time                       `json:"expected_date,omitempty"`
+	Notes        string                           `json:"notes"`
+	Lines        []CreatePurchaseOrderLineRequest `json:"lines" validate:"required,min=1"`
+}
+
+// CreatePurchaseOrderLineRequest represents a product quantity to order from the supplier.
+// VariantID is required for products with variants, whose stock is kept per variant.
+type CreatePurchaseOrderLineRequest struct {
+	ProductID uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity  int        `json:"quantity" validate:"required,min=1"`
+	UnitCost  Money      `json:"unit_cost" validate:"required"`
+}
+
+// UpdatePurchaseOrderRequest represents the request to update a purchase order.
+// Lines replace the existing lines and can only be changed while the order is a draft.
+type UpdatePurchaseOrderRequest struct {
+	WarehouseID  *uuid.UUID                       `json:"warehouse_id,omitempty"`
+	ExpectedDate *time.Time                       `json:"expected_date,omitempty"`
+	Notes        *string                          `json:"notes,omitempty"`
+	Lines        []CreatePurchaseOrderLineRequest `json:"lines,omitempty"`
+}
+
+// ReceivePurchaseOrderRequest represents stock arriving against a purchase order.
+// When Lines is empty, every outstanding quantity is received.
+type ReceivePurchaseOrderRequest struct {
+	Lines []ReceivePurchaseOrderLineRequest `json:"lines"`
+}
+
+// ReceivePurchaseOrderLineRequest represents a quantity of a purchase order line that arrived
+type ReceivePurchaseOrderLineRequest struct {
+	LineID   uuid.UUID `json:"line_id" validate:"required"`
+	Quantity int       `json:"quantity" validate:"required,min=1"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurchaseOrderStatusTransitions(t *testing.T) {
+	assert.NoError(t, PurchaseOrderStatusDraft.ValidateTransition(PurchaseOrderStatusSent))
+	assert.NoError(t, PurchaseOrderStatusSent.ValidateTransition(PurchaseOrderStatusPartiallyReceived))
+	assert.NoError(t, PurchaseOrderStatusSent.ValidateTransition(PurchaseOrderStatusReceived))
+	assert.NoError(t, PurchaseOrderStatusPartiallyReceived.ValidateTransition(PurchaseOrderStatusReceived))
+
+	assert.ErrorIs(t, PurchaseOrderStatusDraft.ValidateTransition(PurchaseOrderStatusReceived), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, PurchaseOrderStatusReceived.ValidateTransition(PurchaseOrderStatusSent), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, PurchaseOrderStatusSent.ValidateTransition("lost"), ErrInvalidStatusTransition)
+}
+
+func TestPurchaseOrder(t *testing.T) {
+	po := &PurchaseOrder{Lines: []PurchaseOrderLine{
+		{QuantityOrdered: 10, QuantityReceived: 10, UnitCost: NewMoney(250, "KES")},
+		{QuantityOrdered: 5, QuantityReceived: 2, UnitCost: NewMoney(1000, "KES")},
+	}}
+
+	t.Run("Outstanding quantity", func(t *testing.T) {
+		assert.Equal(t, 0, po.Lines[0].Outstanding())
+		assert.Equal(t, 3, po.Lines[1].Outstanding())
+	})
+
+	t.Run("Receipt status", func(t *testing.T) {
+		assert.Equal(t, PurchaseOrderStatusPartiallyReceived, po.ReceiptStatus())
+
+		received := &PurchaseOrder{Lines: []PurchaseOrderLine{po.Lines[0]}}
+		assert.Equal(t, PurchaseOrderStatusReceived, received.ReceiptStatus())
+	})
+
+	t.Run("Total cost", func(t *testing.T) {
+		total, err := po.TotalCost()
+		assert.NoError(t, err)
+		assert.Equal(t, NewMoney(7500, "KES"), total)
+	})
+}
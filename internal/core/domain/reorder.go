@@ -0,0 +1,79 @@
+package domain
+
+import "github.com/google/uuid"
+
+// DefaultReorderPoint is the reorder point given to products created without one
+const DefaultReorderPoint = 10
+
+// IsLowStock reports whether the product's stock is below its reorder point
+func (p *Product) IsLowStock() bool {
+	return p.Stock < p.ReorderPoint
+}
+
+// SuggestedReorderQuantity returns how much to order to restock the product: its reorder
+// quantity, raised if needed so the stock gets back up to the reorder point
+func (p *Product) SuggestedReorderQuantity() int {
+	if !p.IsLowStock() {
+		return 0
+	}
+	quantity := p.ReorderQuantity
+	if shortfall := p.ReorderPoint - p.Stock; quantity < shortfall {
+		quantity = shortfall
+	}
+	return quantity
+}
+
+// LowStockEvent is emitted when a stock change drops a product below its reorder point
+type LowStockEvent struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	SKU             string    `json:"sku"`
+	Name            string    `json:"name"`
+	PreviousStock   int       `json:"previous_stock"`
+	Stock           int       `json:"stock"`
+	ReorderPoint    int       `json:"reorder_point"`
+	ReorderQuantity int       `json:"reorder_quantity"`
+}
+
+// NewLowStockEvent returns the event for a product whose stock changed from previousStock,
+// or nil unless the change took it from at or above its reorder point to below it
+func NewLowStockEvent(p *Product, previousStock int) *LowStockEvent {
+	if !p.IsLowStock() || previousStock < p.ReorderPoint {
+		return nil
+	}
+	return &LowStockEvent{
+		ProductID:       p.ID,
+		SKU:             p.SKU,
+		Name:            p.Name,
+		PreviousStock:   previousStock,
+		Stock:           p.Stock,
+		ReorderPoint:    p.ReorderPoint,
+		ReorderQuantity: p.SuggestedReorderQuantity(),
+	}
+}
+
+// ReorderSuggestion is a line of the reorder report: a product below its reorder point
+// and how much of it to order
+type ReorderSuggestion struct {
+	ProductID         uuid.UUID `json:"product_id"`
+	SKU               string    `json:"sku"`
+	Name              string    `json:"name"`
+	Stock             int       `json:"stock"`
+	AvailableStock    int       `json:"available_stock"`
+	ReorderPoint      int       `json:"reorder_point"`
+	ReorderQuantity   int       `json:"reorder_quantity"`
+	SuggestedQuantity int       `json:"suggested_quantity"`
+}
+
+// NewReorderSuggestion builds the reorder report line for a product
+func NewReorderSuggestion(p *Product) *ReorderSuggestion {
+	return &ReorderSuggestion{
+		ProductID:         p.ID,
+		SKU:               p.SKU,
+		Name:              p.Name,
+		Stock:             p.Stock,
+		AvailableStock:    p.AvailableStock,
+		ReorderPoint:      p.ReorderPoint,
+		ReorderQuantity:   p.ReorderQuantity,
+		SuggestedQuantity: p.SuggestedReorderQuantity(),
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductReorder(t *testing.T) {
+	t.Run("Low stock is below the reorder point", func(t *testing.T) {
+		product := &Product{Stock: 10, ReorderPoint: 10}
+		assert.False(t, product.IsLowStock())
+		assert.Equal(t, 0, product.SuggestedReorderQuantity())
+
+		product.Stock = 9
+		assert.True(t, product.IsLowStock())
+	})
+
+	t.Run("A zero reorder point disables reordering", func(t *testing.T) {
+		product := &Product{Stock: 0, ReorderPoint: 0, ReorderQuantity: 50}
+		assert.False(t, product.IsLowStock())
+		assert.Equal(t, 0, product.SuggestedReorderQuantity())
+	})
+
+	t.Run("Suggested quantity covers at least the shortfall", func(t *testing.T) {
+		product := &Product{Stock: 2, ReorderPoint: 20, ReorderQuantity: 50}
+		assert.Equal(t, 50, product.SuggestedReorderQuantity())
+
+		product.ReorderQuantity = 5
+		assert.Equal(t, 18, product.SuggestedReorderQuantity())
+
+		product.ReorderQuantity = 0
+		assert.Equal(t, 18, product.SuggestedReorderQuantity())
+	})
+}
+
+func TestNewLowStockEvent(t *testing.T) {
+	product := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget", Stock: 8, ReorderPoint: 10, ReorderQuantity: 25}
+
+	t.Run("Crossing the reorder point emits an event", func(t *testing.T) {
+		event := NewLowStockEvent(product, 12)
+		if assert.NotNil(t, event) {
+			assert.Equal(t, product.ID, event.ProductID)
+			assert.Equal(t, 12, event.PreviousStock)
+			assert.Equal(t, 8, event.Stock)
+			assert.Equal(t, 25, event.ReorderQuantity)
+		}
+
+		assert.NotNil(t, NewLowStockEvent(product, 10))
+	})
+
+	t.Run("Already low stock does not emit again", func(t *testing.T) {
+		assert.Nil(t, NewLowStockEvent(product, 9))
+	})
+
+	t.Run("Stock at or above the reorder point does not emit", func(t *testing.T) {
+		restocked := *product
+		restocked.Stock = 10
+		assert.Nil(t, NewLowStockEvent(&restocked, 12))
+	})
+}
+
+func TestNewReorderSuggestion(t *testing.T) {
+	product := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget", Stock: 3, AvailableStock: 1, ReorderPoint: 10, ReorderQuantity: 4}
+
+	suggestion := NewReorderSuggestion(product)
+
+	assert.Equal(t, product.ID, suggestion.ProductID)
+	assert.Equal(t, 1, suggestion.AvailableStock)
+	assert.Equal(t, 4, suggestion.ReorderQuantity)
+	assert.Equal(t, 7, suggestion.SuggestedQuantity)
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ReservationStatus represents the status of a stock reservation
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusConverted ReservationStatus = "converted"
+	ReservationStatusReleased  ReservationStatus = "released"
+	ReservationStatusExpired   ReservationStatus = "expired"
+)
+
+// DefaultReservationTTL is how long stock is held when no expiry is requested
+const DefaultReservationTTL = 15 * time.Minute
+
+// DefaultMaxReservationTTL is the longest expiry a reservation may request when no limit is configured
+const DefaultMaxReservationTTL = 24 * time.Hour
+
+// ErrReservationUnavailable is returned when a reservation cannot be converted into an order
+var ErrReservationUnavailable = errors.New("reservation unavailable")
+
+// reservationStatusTransitions lists the statuses each reservation status may move to
+var reservationStatusTransitions = map[ReservationStatus][]ReservationStatus{
+	ReservationStatusActive:    {ReservationStatusConverted, ReservationStatusReleased, ReservationStatusExpired},
+	ReservationStatusConverted: {},
+	ReservationStatusReleased:  {},
+	ReservationStatusExpired:   {},
+}
+
+// IsValid reports whether the status is a known reservation status
+func (s ReservationStatus) IsValid() bool {
+	_, ok := reservationStatusTransitions[s]
+	return ok
+}
+
+// ValidateTransition returns ErrInvalidStatusTransition when next is not reachable from s
+func (s ReservationStatus) ValidateTransition(next ReservationStatus) error {
+	for _, allowed := range reservationStatusTransitions[s] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: reservation %s -> %s", ErrInvalidStatusTransition, s, next)
+}
+
+// UnmarshalGQL maps GraphQL enum values (e.g. ACTIVE) to reservation statuses
+func (s *ReservationStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("reservation status must be a string")
+	}
+	*s = ReservationStatus(strings.ToLower(str))
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid ReservationStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the reservation status as a GraphQL enum value
+func (s ReservationStatus) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// StockReservation holds a quantity of a product for a customer while they complete checkout.
+// Active, unexpired reservations reduce the available-to-sell stock but not the on-hand stock.
+type StockReservation struct {
+	bun.BaseModel `bun:"table:stock_reservations,alias:sr"`
+
+	ID         uuid.UUID         `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ProductID  uuid.UUID         `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	CustomerID uuid.UUID         `bun:"customer_id,type:uuid,notnull" json:"customer_id"`
+	Quantity   int               `bun:"quantity,notnull" json:"quantity"`
+	Status     ReservationStatus `bun:"status,notnull,default:'active'" json:"status"`
+	ExpiresAt  time.Time         `bun:"expires_at,notnull" json:"expires_at"`
+	OrderID    *uuid.UUID        `bun:"order_id,type:uuid" json:"order_id,omitempty"`
+	CreatedAt  time.Time         `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt  time.Time         `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// IsHeld reports whether the reservation still holds stock at the given time
+func (r *StockReservation) IsHeld(now time.Time) bool {
+	return r.Status == ReservationStatusActive && now.Before(r.ExpiresAt)
+}
+
+// CreateReservationRequest represents the request to hold stock for a customer.
+// TTLSeconds defaults to the configured reservation TTL when zero and may not exceed the configured maximum.
+type CreateReservationRequest struct {
+	ProductID  uuid.UUID `json:"product_id" validate:"required"`
+	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
+	Quantity   int       `json:"quantity" validate:"required,min=1"`
+	TTLSeconds int       `json:"ttl_seconds" validate:"min=0"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationStatusTransitions(t *testing.T) {
+	assert.NoError(t, ReservationStatusActive.ValidateTransition(ReservationStatusConverted))
+	assert.NoError(t, ReservationStatusActive.ValidateTransition(ReservationStatusReleased))
+	assert.NoError(t, ReservationStatusActive.ValidateTransition(ReservationStatusExpired))
+	assert.ErrorIs(t, ReservationStatusExpired.ValidateTransition(ReservationStatusConverted), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, ReservationStatusConverted.ValidateTransition(ReservationStatusReleased), ErrInvalidStatusTransition)
+}
+
+func TestStockReservation_IsHeld(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, (&StockReservation{Status: ReservationStatusActive, ExpiresAt: now.Add(time.Minute)}).IsHeld(now))
+	assert.False(t, (&StockReservation{Status: ReservationStatusActive, ExpiresAt: now}).IsHeld(now))
+	assert.False(t, (&StockReservation{Status: ReservationStatusReleased, ExpiresAt: now.Add(time.Minute)}).IsHeld(now))
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ReturnStatus represents the status of a return request
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+)
+
+// ErrInvalidReturn is returned when a return request does not match the order it belongs to
+var ErrInvalidReturn = errors.New("invalid return")
+
+// returnStatusTransitions lists the statuses each return status may move to
+var returnStatusTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected},
+	ReturnStatusApproved:  {ReturnStatusReceived},
+	ReturnStatusRejected:  {},
+	ReturnStatusReceived:  {},
+}
+
+// IsValid reports whether the status is a known return status
+func (s ReturnStatus) IsValid() bool {
+	_, ok := returnStatusTransitions[s]
+	return ok
+}
+
+// ValidateTransition returns ErrInvalidStatusTransition when next is not reachable from s
+func (s ReturnStatus) ValidateTransition(next ReturnStatus) error {
+	for _, allowed := range returnStatusTransitions[s] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: return %s -> %s", ErrInvalidStatusTransition, s, next)
+}
+
+// UnmarshalGQL maps GraphQL enum values (e.g. APPROVED) to return statuses
+func (s *ReturnStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("return status must be a string")
+	}
+	*s = ReturnStatus(strings.ToLower(str))
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid ReturnStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the return status as a GraphQL enum value
+func (s ReturnStatus) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// ReturnRequest represents a customer's request to return items of a delivered order
+type ReturnRequest struct {
+	bun.BaseModel `bun:"table:return_requests,alias:rr"`
+
+	ID         uuid.UUID    `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	OrderID    uuid.UUID    `bun:"order_id,type:uuid,notnull" json:"order_id"`
+	CustomerID uuid.UUID    `bun:"customer_id,type:uuid,notnull" json:"customer_id"`
+	Status     ReturnStatus `bun:"status,notnull,default:'requested'" json:"status"`
+	Reason     string       `bun:"reason" json:"reason"`
+	ReviewNote string       `bun:"review_note" json:"review_note"`
+	ReviewedBy string       `bun:"reviewed_by" json:"reviewed_by"`
+	ReviewedAt *time.Time   `bun:"reviewed_at" json:"reviewed_at"`
+	ReceivedAt *time.Time   `bun:"received_at" json:"received_at"`
+	CreatedAt  time.Time    `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt  time.Time    `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Relations
+	Items  []ReturnItem `bun:"rel:has-many,join:id=return_request_id" json:"items"`
+	Refund *Refund      `bun:"rel:has-one,join:id=return_request_id" json:"refund,omitempty"`
+}
+
+// ReturnItem represents the quantity of an order item being returned
+type ReturnItem struct {
+	bun.BaseModel `bun:"table:return_items,alias:ri"`
+
+	ID              uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ReturnRequestID uuid.UUID `bun:"return_request_id,type:uuid,notnull" json:"return_request_id"`
+	OrderItemID     uuid.UUID `bun:"order_item_id,type:uuid,notnull" json:"order_item_id"`
+	Quantity        int       `bun:"quantity,notnull" json:"quantity"`
+	CreatedAt       time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// Refund records the amount owed to the customer for a received return
+type Refund struct {
+	bun.BaseModel `bun:"table:refunds,alias:rf"`
+
+	ID              uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ReturnRequestID uuid.UUID `bun:"return_request_id,type:uuid,notnull" json:"return_request_id"`
+	OrderID         uuid.UUID `bun:"order_id,type:uuid,notnull" json:"order_id"`
+	Amount          Money     `bun:"amount,type:money_amount,notnull" json:"amount"`
+	CreatedAt       time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// CreateReturnRequest represents the request to return items of an order
+type CreateReturnRequest struct {
+	Reason string                    `json:"reason" validate:"required"`
+	Items  []CreateReturnItemRequest `json:"items" validate:"required,min=1"`
+}
+
+// CreateReturnItemRequest represents an order item quantity to return
+type CreateReturnItemRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" validate:"required"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+}
+
+// ReviewReturnRequest represents a staff decision on a return request
+type ReviewReturnRequest struct {
+	Note string `json:"note"`
+}
+
+// ReturnedQuantities returns, per order item, the quantity included in return requests that were not rejected
+func ReturnedQuantities(returns []*ReturnRequest) map[uuid.UUID]int {
+	returned := make(map[uuid.UUID]int)
+	for _, ret := range returns {
+		if ret.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range ret.Items {
+			returned[item.OrderItemID] += item.Quantity
+		}
+	}
+	return returned
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrEmptySearch is returned when a search query has no words to search for
+var ErrEmptySearch = errors.New("search query has no words to search for")
+
+// SearchHighlightStart and SearchHighlightStop enclose the matched words in search highlights
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+)
+
+// ProductSearchResult is a product matching a full-text search, with its relevance and
+// a snippet of its text with the matched words highlighted
+type ProductSearchResult struct {
+	Product   *Product `json:"product"`
+	Rank      float64  `json:"rank"`
+	Highlight string   `json:"highlight"`
+}
+
+// CustomerSearchResult is a customer matching a full-text search
+type CustomerSearchResult struct {
+	Customer  *Customer `json:"customer"`
+	Rank      float64   `json:"rank"`
+	Highlight string    `json:"highlight"`
+}
+
+// UserSearchResult is a user matching a full-text search
+type UserSearchResult struct {
+	User      *User   `json:"user"`
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
+// SearchTerms splits a search query into lower-case words. Letters and digits make up
+// words, along with the '@', '.', '_', '+' and '-' found in emails, phone numbers and SKUs;
+// every other character separates words.
+func SearchTerms(query string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("@._+-", r)
+	})
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, "@._+-")
+		if field != "" {
+			terms = append(terms, field)
+		}
+	}
+	return terms
+}
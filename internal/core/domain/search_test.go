@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"iph", "128gb"}, SearchTerms("  iPh, 128GB! "))
+	assert.Equal(t, []string{"jane@example.com", "254700"}, SearchTerms("Jane@Example.com. +254700"))
+	assert.Equal(t, []string{"iph-15"}, SearchTerms("'IPH-15'"))
+	assert.Empty(t, SearchTerms(" -- !? "))
+}
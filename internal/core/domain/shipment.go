@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ShipmentStatus represents the status of a shipment
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPending   ShipmentStatus = "pending"
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+	ShipmentStatusCancelled ShipmentStatus = "cancelled"
+)
+
+// ErrInvalidShipment is returned when a shipment does not match the order it belongs to
+var ErrInvalidShipment = errors.New("invalid shipment")
+
+// shipmentStatusTransitions lists the statuses each shipment status may move to
+var shipmentStatusTransitions = map[ShipmentStatus][]ShipmentStatus{
+	ShipmentStatusPending:   {ShipmentStatusShipped, ShipmentStatusCancelled},
+	ShipmentStatusShipped:   {ShipmentStatusDelivered},
+	ShipmentStatusDelivered: {},
+	ShipmentStatusCancelled: {},
+}
+
+// IsValid reports whether the status is a known shipment status
+func (s ShipmentStatus) IsValid() bool {
+	_, ok := shipmentStatusTransitions[s]
+	return ok
+}
+
+// ValidateTransition returns ErrInvalidStatusTransition when next is not reachable from s
+func (s ShipmentStatus) ValidateTransition(next ShipmentStatus) error {
+	if !next.IsValid() {
+		return fmt.Errorf("%w: unknown shipment status %q", ErrInvalidStatusTransition, next)
+	}
+	for _, allowed := range shipmentStatusTransitions[s] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: shipment %s -> %s", ErrInvalidStatusTransition, s, next)
+}
+
+// UnmarshalGQL maps GraphQL enum values (e.g. SHIPPED) to shipment statuses
+func (s *ShipmentStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("shipment status must be a string")
+	}
+	*s = ShipmentStatus(strings.ToLower(str))
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid ShipmentStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the shipment status as a GraphQL enum value
+func (s ShipmentStatus) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// Shipment represents a parcel sent for part or all of an order
+type Shipment struct {
+	bun.BaseModel `bun:"table:shipments,alias:sh"`
+
+	ID             uuid.UUID      `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	OrderID        uuid.UUID      `bun:"order_id,type:uuid,notnull" json:"order_id"`
+	Carrier        string         `bun:"carrier,notnull" json:"carrier"`
+	TrackingNumber string         `bun:"tracking_number" json:"tracking_number"`
+	Status         ShipmentStatus `bun:"status,notnull,default:'pending'" json:"status"`
+	ShippedAt      *time.Time     `bun:"shipped_at" json:"shipped_at"`
+	DeliveredAt    *time.Time     `bun:"delivered_at" json:"delivered_at"`
+	CreatedAt      time.Time      `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt      time.Time      `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Relations
+	Items []ShipmentItem `bun:"rel:has-many,join:id=shipment_id" json:"items"`
+}
+
+// ShipmentItem represents the quantity of an order item included in a shipment
+type ShipmentItem struct {
+	bun.BaseModel `bun:"table:shipment_items,alias:si"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ShipmentID  uuid.UUID `bun:"shipment_id,type:uuid,notnull" json:"shipment_id"`
+	OrderItemID uuid.UUID `bun:"order_item_id,type:uuid,notnull" json:"order_item_id"`
+	Quantity    int       `bun:"quantity,notnull" json:"quantity"`
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// CreateShipmentRequest represents the request to create a shipment.
+// When Items is empty, every unallocated quantity of the order is included.
+type CreateShipmentRequest struct {
+	Carrier        string                      `json:"carrier" validate:"required"`
+	TrackingNumber string                      `json:"tracking_number"`
+	Items          []CreateShipmentItemRequest `json:"items"`
+}
+
+// CreateShipmentItemRequest represents an order item quantity to include in a shipment
+type CreateShipmentItemRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" validate:"required"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+}
+
+// UpdateShipmentRequest represents the request to update a shipment
+type UpdateShipmentRequest struct {
+	Carrier        *string         `json:"carrier,omitempty"`
+	TrackingNumber *string         `json:"tracking_number,omitempty"`
+	Status         *ShipmentStatus `json:"status,omitempty"`
+}
+
+// AllocatedQuantities returns, per order item, the quantity included in shipments that are not cancelled
+func AllocatedQuantities(shipments []*Shipment) map[uuid.UUID]int {
+	allocated := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		if shipment.Status == ShipmentStatusCancelled {
+			continue
+		}
+		for _, item := range shipment.Items {
+			allocated[item.OrderItemID] += item.Quantity
+		}
+	}
+	return allocated
+}
+
+// DeriveOrderStatus returns the fulfilment status implied by the order's shipments:
+// delivered when every unit is delivered, shipped when every unit has left the warehouse,
+// partially shipped when some have. It returns false when no unit has shipped yet.
+func DeriveOrderStatus(orderItems []*OrderItem, shipments []*Shipment) (OrderStatus, bool) {
+	shipped := make(map[uuid.UUID]int)
+	delivered := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			switch shipment.Status {
+			case ShipmentStatusDelivered:
+				delivered[item.OrderItemID] += item.Quantity
+				shipped[item.OrderItemID] += item.Quantity
+			case ShipmentStatusShipped:
+				shipped[item.OrderItemID] += item.Quantity
+			}
+		}
+	}
+
+	allShipped, allDelivered, anyShipped := true, true, false
+	for _, item := range orderItems {
+		if shipped[item.ID] > 0 {
+			anyShipped = true
+		}
+		if shipped[item.ID] < item.Quantity {
+			allShipped = false
+		}
+		if delivered[item.ID] < item.Quantity {
+			allDelivered = false
+		}
+	}
+
+	switch {
+	case !anyShipped:
+		return "", false
+	case allDelivered:
+		return OrderStatusDelivered, true
+	case allShipped:
+		return OrderStatusShipped, true
+	default:
+		return OrderStatusPartiallyShipped, true
+	}
+}
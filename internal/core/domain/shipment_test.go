@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipmentStatusTransitions(t *testing.T) {
+	assert.NoError(t, ShipmentStatusPending.ValidateTransition(ShipmentStatusShipped))
+	assert.NoError(t, ShipmentStatusPending.ValidateTransition(ShipmentStatusCancelled))
+	assert.NoError(t, ShipmentStatusShipped.ValidateTransition(ShipmentStatusDelivered))
+	assert.ErrorIs(t, ShipmentStatusShipped.ValidateTransition(ShipmentStatusCancelled), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, ShipmentStatusPending.ValidateTransition(ShipmentStatusDelivered), ErrInvalidStatusTransition)
+}
+
+func TestDeriveOrderStatus(t *testing.T) {
+	itemA := &OrderItem{ID: uuid.New(), Quantity: 3}
+	itemB := &OrderItem{ID: uuid.New(), Quantity: 1}
+	orderItems := []*OrderItem{itemA, itemB}
+
+	shipment := func(status ShipmentStatus, items ...ShipmentItem) *Shipment {
+		return &Shipment{Status: status, Items: items}
+	}
+
+	t.Run("Nothing shipped yet", func(t *testing.T) {
+		_, ok := DeriveOrderStatus(orderItems, []*Shipment{
+			shipment(ShipmentStatusPending, ShipmentItem{OrderItemID: itemA.ID, Quantity: 3}),
+		})
+		assert.False(t, ok)
+	})
+
+	t.Run("Some units shipped", func(t *testing.T) {
+		status, ok := DeriveOrderStatus(orderItems, []*Shipment{
+			shipment(ShipmentStatusShipped, ShipmentItem{OrderItemID: itemA.ID, Quantity: 2}),
+		})
+		assert.True(t, ok)
+		assert.Equal(t, OrderStatusPartiallyShipped, status)
+	})
+
+	t.Run("All units shipped across shipments", func(t *testing.T) {
+		status, ok := DeriveOrderStatus(orderItems, []*Shipment{
+			shipment(ShipmentStatusDelivered, ShipmentItem{OrderItemID: itemA.ID, Quantity: 3}),
+			shipment(ShipmentStatusShipped, ShipmentItem{OrderItemID: itemB.ID, Quantity: 1}),
+		})
+		assert.True(t, ok)
+		assert.Equal(t, OrderStatusShipped, status)
+	})
+
+	t.Run("All units delivered", func(t *testing.T) {
+		status, ok := DeriveOrderStatus(orderItems, []*Shipment{
+			shipment(ShipmentStatusDelivered, ShipmentItem{OrderItemID: itemA.ID, Quantity: 3}, ShipmentItem{OrderItemID: itemB.ID, Quantity: 1}),
+		})
+		assert.True(t, ok)
+		assert.Equal(t, OrderStatusDelivered, status)
+	})
+
+	t.Run("Cancelled shipments release their allocation", func(t *testing.T) {
+		allocated := AllocatedQuantities([]*Shipment{
+			shipment(ShipmentStatusCancelled, ShipmentItem{OrderItemID: itemA.ID, Quantity: 3}),
+			shipment(ShipmentStatusPending, ShipmentItem{OrderItemID: itemA.ID, Quantity: 1}),
+		})
+		assert.Equal(t, 1, allocated[itemA.ID])
+	})
+}
@@ -0,0 +1,328 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ErrInvalidShippingMethod is returned when a shipping method is malformed
+var ErrInvalidShippingMethod = errors.New("invalid shipping method")
+
+// ErrShippingUnavailable is returned when a shipping method does not ship an order
+var ErrShippingUnavailable = errors.New("shipping method unavailable")
+
+// gramsPerKilogram is the grams in a kilogram, weight-based methods charging per started one
+const gramsPerKilogram = 1000
+
+// volumetricDivisor turns a package's size in cubic millimetres into the grams carriers
+// charge for it, 5000 cubic centimetres counting as a kilogram
+const volumetricDivisor = 5000
+
+// ShippingMethodType decides how a shipping method charges for an order
+type ShippingMethodType string
+
+const (
+	// ShippingFlatRate charges the same whatever the order
+	ShippingFlatRate ShippingMethodType = "flat_rate"
+	// ShippingWeightBased charges Amount plus PerKgAmount for each started kilogram the
+	// order weighs
+	ShippingWeightBased ShippingMethodType = "weight_based"
+	// ShippingFreeOverThreshold charges Amount unless the order's items come to FreeOver
+	// or more
+	ShippingFreeOverThreshold ShippingMethodType = "free_over_threshold"
+)
+
+// IsValid reports whether the type is a known shipping method type
+func (t ShippingMethodType) IsValid() bool {
+	switch t {
+	case ShippingFlatRate, ShippingWeightBased, ShippingFreeOverThreshold:
+		return true
+	}
+	return false
+}
+
+// ShippingDestination is where an order ships to
+type ShippingDestination struct {
+	Country string `json:"country"`
+	City    string `json:"city,omitempty"`
+}
+
+// NewShippingDestination returns the destination with its country normalized and its
+// city trimmed
+func NewShippingDestination(country, city string) ShippingDestination {
+	return ShippingDestination{Country: NormalizeCountry(country), City: strings.TrimSpace(city)}
+}
+
+// String describes the destination, as "Nairobi, KE"
+func (d ShippingDestination) String() string {
+	if d.City == "" {
+		return d.Country
+	}
+	return d.City + ", " + d.Country
+}
+
+// ShippingZone is a destination a shipping method serves: a country, or a city in it when
+// City is set. Amount, when set, is what the method charges there instead of its own Amount.
+type ShippingZone struct {
+	Country string `json:"country"`
+	City    string `json:"city,omitempty"`
+	Amount  *Money `json:"amount,omitempty"`
+}
+
+// matches reports whether the zone covers the destination; cities are matched
+// case-insensitively
+func (z ShippingZone) matches(dest ShippingDestination) bool {
+	if z.Country != dest.Country {
+		return false
+	}
+	return z.City == "" || strings.EqualFold(z.City, dest.City)
+}
+
+// Parcel is what a shipping method is asked to carry: the shipping weight of an order's
+// items and what they come to after discounts
+type Parcel struct {
+	WeightGrams int
+	ItemsTotal  Money
+}
+
+// NewParcel returns the parcel of the order items weighing weightGrams
+func NewParcel(weightGrams int, items []*OrderItem) Parcel {
+	parcel := Parcel{WeightGrams: weightGrams}
+	for _, item := range items {
+		parcel.ItemsTotal = Money{Amount: parcel.ItemsTotal.Amount + item.TotalPrice.Amount, Currency: item.TotalPrice.Currency}
+	}
+	return parcel
+}
+
+// ShippingMethod is a way of shipping orders and what it charges for them. A method with
+// Zones only ships to them, the most specific zone covering a destination deciding what is
+// charged there; a method without zones ships anywhere.
+type ShippingMethod struct {
+	bun.BaseModel `bun:"table:shipping_methods,alias:sm"`
+
+	ID          uuid.UUID          `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Name        string             `bun:"name,notnull" json:"name"`
+	Description string             `bun:"description" json:"description"`
+	Type        ShippingMethodType `bun:"type,notnull" json:"type"`
+	Amount      Money              `bun:"amount,type:money_amount,notnull" json:"amount"`
+	PerKgAmount *Money             `bun:"per_kg_amount,type:money_amount" json:"per_kg_amount,omitempty"`
+	FreeOver    *Money             `bun:"free_over,type:money_amount" json:"free_over,omitempty"`
+	Zones       []ShippingZone     `bun:"zones,type:jsonb,notnull,default:'[]'" json:"zones"`
+	IsActive    bool               `bun:"is_active,notnull,default:true" json:"is_active"`
+	CreatedAt   time.Time          `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt   time.Time          `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// Validate checks that the method has what its type charges by, in the currency of its
+// Amount, and that its zones are distinct
+func (m *ShippingMethod) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidShippingMethod)
+	}
+	if !m.Type.IsValid() {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidShippingMethod, m.Type)
+	}
+	if err := m.checkAmount("amount", &m.Amount); err != nil {
+		return err
+	}
+
+	switch m.Type {
+	case ShippingWeightBased:
+		if m.PerKgAmount == nil {
+			return fmt.Errorf("%w: a weight based method needs per_kg_amount", ErrInvalidShippingMethod)
+		}
+	case ShippingFreeOverThreshold:
+		if m.FreeOver == nil || m.FreeOver.Amount <= 0 {
+			return fmt.Errorf("%w: a free over threshold method needs a positive free_over", ErrInvalidShippingMethod)
+		}
+	}
+	if err := m.checkAmount("per_kg_amount", m.PerKgAmount); err != nil {
+		return err
+	}
+	if err := m.checkAmount("free_over", m.FreeOver); err != nil {
+		return err
+	}
+
+	seen := make(map[ShippingDestination]bool, len(m.Zones))
+	for _, zone := range m.Zones {
+		if zone.Country == "" {
+			return fmt.Errorf("%w: every zone needs a country", ErrInvalidShippingMethod)
+		}
+		dest := ShippingDestination{Country: zone.Country, City: strings.ToLower(zone.City)}
+		if seen[dest] {
+			return fmt.Errorf("%w: zone %s is listed twice", ErrInvalidShippingMethod, NewShippingDestination(zone.Country, zone.City))
+		}
+		seen[dest] = true
+		if err := m.checkAmount("zone amount", zone.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAmount checks that an amount of the method is not negative and shares the
+// currency of its Amount; nil amounts are left to the caller
+func (m *ShippingMethod) checkAmount(field string, amount *Money) error {
+	if amount == nil {
+		return nil
+	}
+	if amount.IsNegative() {
+		return fmt.Errorf("%w: %s cannot be negative", ErrInvalidShippingMethod, field)
+	}
+	if amount.Currency != m.Amount.Currency {
+		return fmt.Errorf("%w: %s must be in %s", ErrInvalidShippingMethod, field, m.Amount.Currency)
+	}
+	return nil
+}
+
+// Normalize prices the method in DefaultCurrency when its Amount has no currency, and its
+// other amounts in the currency of its Amount when they have none, and normalizes the
+// countries and trims the cities of its zones
+func (m *ShippingMethod) Normalize() {
+	if m.Amount.Currency == "" {
+		m.Amount.Currency = DefaultCurrency
+	}
+	inCurrency := func(amount *Money) {
+		if amount != nil && amount.Currency == "" {
+			amount.Currency = m.Amount.Currency
+		}
+	}
+	inCurrency(m.PerKgAmount)
+	inCurrency(m.FreeOver)
+	for i := range m.Zones {
+		dest := NewShippingDestination(m.Zones[i].Country, m.Zones[i].City)
+		m.Zones[i].Country, m.Zones[i].City = dest.Country, dest.City
+		inCurrency(m.Zones[i].Amount)
+	}
+}
+
+// zoneFor returns the zone covering the destination, a city's zone before its country's.
+// It returns nil and true for a method without zones, and false when the method does not
+// ship there.
+func (m *ShippingMethod) zoneFor(dest ShippingDestination) (*ShippingZone, bool) {
+	if len(m.Zones) == 0 {
+		return nil, true
+	}
+	var match *ShippingZone
+	for i, zone := range m.Zones {
+		if zone.matches(dest) && (match == nil || match.City == "") {
+			match = &m.Zones[i]
+		}
+	}
+	return match, match != nil
+}
+
+// Serves returns ErrShippingUnavailable unless the method is active and ships to the
+// destination
+func (m *ShippingMethod) Serves(dest ShippingDestination) error {
+	if !m.IsActive {
+		return fmt.Errorf("%w: %s is not active", ErrShippingUnavailable, m.Name)
+	}
+	if _, ok := m.zoneFor(dest); !ok {
+		return fmt.Errorf("%w: %s does not ship to %s", ErrShippingUnavailable, m.Name, dest)
+	}
+	return nil
+}
+
+// Charge returns what the method charges to ship the parcel to the destination, whether or
+// not it serves it now, so that orders already placed with it can be charged again when
+// their items change. The parcel's items must be priced in the method's currency.
+func (m *ShippingMethod) Charge(dest ShippingDestination, parcel Parcel) (Money, error) {
+	amount := m.Amount
+	if zone, _ := m.zoneFor(dest); zone != nil && zone.Amount != nil {
+		amount = *zone.Amount
+	}
+	if parcel.ItemsTotal.Currency != "" && parcel.ItemsTotal.Currency != amount.Currency {
+		return Money{}, fmt.Errorf("%w: %s charges in %s, the order is in %s", ErrShippingUnavailable, m.Name, amount.Currency, parcel.ItemsTotal.Currency)
+	}
+
+	switch m.Type {
+	case ShippingWeightBased:
+		kilograms := (parcel.WeightGrams + gramsPerKilogram - 1) / gramsPerKilogram
+		amount.Amount += m.PerKgAmount.Amount * int64(kilograms)
+	case ShippingFreeOverThreshold:
+		if parcel.ItemsTotal.Amount >= m.FreeOver.Amount {
+			amount.Amount = 0
+		}
+	}
+	return amount, nil
+}
+
+// Quote returns what the method charges to ship the parcel to the destination, or
+// ErrShippingUnavailable when it does not ship there
+func (m *ShippingMethod) Quote(dest ShippingDestination, parcel Parcel) (Money, error) {
+	if err := m.Serves(dest); err != nil {
+		return Money{}, err
+	}
+	return m.Charge(dest, parcel)
+}
+
+// ShippingRate is what a shipping method would charge for an order
+type ShippingRate struct {
+	MethodID    uuid.UUID          `json:"method_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Type        ShippingMethodType `json:"type"`
+	Amount      Money              `json:"amount"`
+}
+
+// ShippingRates returns the rates of the methods that ship the parcel to the destination,
+// cheapest first
+func ShippingRates(methods []*ShippingMethod, dest ShippingDestination, parcel Parcel) []ShippingRate {
+	rates := make([]ShippingRate, 0, len(methods))
+	for _, method := range methods {
+		amount, err := method.Quote(dest, parcel)
+		if err != nil {
+			continue
+		}
+		rates = append(rates, ShippingRate{
+			MethodID:    method.ID,
+			Name:        method.Name,
+			Description: method.Description,
+			Type:        method.Type,
+			Amount:      amount,
+		})
+	}
+	slices.SortStableFunc(rates, func(a, b ShippingRate) int {
+		if a.Amount.Amount != b.Amount.Amount {
+			if a.Amount.Amount < b.Amount.Amount {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return rates
+}
+
+// CreateShippingMethodRequest represents the request to create a shipping method. Amount
+// defaults to DefaultCurrency when it has no currency.
+type CreateShippingMethodRequest struct {
+	Name        string             `json:"name" validate:"required"`
+	Description string             `json:"description"`
+	Type        ShippingMethodType `json:"type" validate:"required"`
+	Amount      Money              `json:"amount"`
+	PerKgAmount *Money             `json:"per_kg_amount,omitempty"`
+	FreeOver    *Money             `json:"free_over,omitempty"`
+	Zones       []ShippingZone     `json:"zones"`
+	IsActive    *bool              `json:"is_active,omitempty"`
+}
+
+// UpdateShippingMethodRequest represents the request to update a shipping method. Zones
+// replace the method's zones when set. Orders keep what they were charged until their items change.
+type UpdateShippingMethodRequest struct {
+	Name        *string             `json:"name,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Type        *ShippingMethodType `json:"type,omitempty"`
+	Amount      *Money              `json:"amount,omitempty"`
+	PerKgAmount *Money              `json:"per_kg_amount,omitempty"`
+	FreeOver    *Money              `json:"free_over,omitempty"`
+	Zones       *[]ShippingZone     `json:"zones,omitempty"`
+	IsActive    *bool               `json:"is_active,omitempty"`
+}
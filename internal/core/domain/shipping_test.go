@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippingMethod_Charge(t *testing.T) {
+	nairobi := NewShippingDestination("ke", " Nairobi ")
+	parcel := Parcel{WeightGrams: 2500, ItemsTotal: NewMoney(4000, "KES")}
+
+	t.Run("Flat rate", func(t *testing.T) {
+		method := &ShippingMethod{Name: "Standard", Type: ShippingFlatRate, Amount: NewMoney(300, "KES"), IsActive: true}
+		amount, err := method.Quote(nairobi, parcel)
+		require.NoError(t, err)
+		assert.Equal(t, NewMoney(300, "KES"), amount)
+	})
+
+	t.Run("Weight based charges per started kilogram", func(t *testing.T) {
+		perKg := NewMoney(100, "KES")
+		method := &ShippingMethod{Name: "Courier", Type: ShippingWeightBased, Amount: NewMoney(200, "KES"), PerKgAmount: &perKg, IsActive: true}
+		amount, err := method.Quote(nairobi, parcel)
+		require.NoError(t, err)
+		assert.Equal(t, NewMoney(500, "KES"), amount, "2.5kg is charged as 3kg")
+	})
+
+	t.Run("Free over threshold", func(t *testing.T) {
+		freeOver := NewMoney(5000, "KES")
+		method := &ShippingMethod{Name: "Free over 50", Type: ShippingFreeOverThreshold, Amount: NewMoney(300, "KES"), FreeOver: &freeOver, IsActive: true}
+
+		amount, err := method.Quote(nairobi, parcel)
+		require.NoError(t, err)
+		assert.Equal(t, NewMoney(300, "KES"), amount)
+
+		amount, err = method.Quote(nairobi, Parcel{ItemsTotal: NewMoney(5000, "KES")})
+		require.NoError(t, err)
+		assert.True(t, amount.IsZero())
+	})
+
+	t.Run("A city's zone comes before its country's", func(t *testing.T) {
+		cityAmount, countryAmount := NewMoney(150, "KES"), NewMoney(450, "KES")
+		method := &ShippingMethod{Name: "Boda", Type: ShippingFlatRate, Amount: NewMoney(300, "KES"), IsActive: true, Zones: []ShippingZone{
+			{Country: "KE", Amount: &countryAmount},
+			{Country: "KE", City: "nairobi", Amount: &cityAmount},
+		}}
+
+		amount, err := method.Quote(nairobi, parcel)
+		require.NoError(t, err)
+		assert.Equal(t, cityAmount, amount)
+
+		amount, err = method.Quote(NewShippingDestination("KE", "Mombasa"), parcel)
+		require.NoError(t, err)
+		assert.Equal(t, countryAmount, amount)
+
+		_, err = method.Quote(NewShippingDestination("UG", "Kampala"), parcel)
+		assert.ErrorIs(t, err, ErrShippingUnavailable)
+	})
+
+	t.Run("Inactive methods or another currency", func(t *testing.T) {
+		method := &ShippingMethod{Name: "Standard", Type: ShippingFlatRate, Amount: NewMoney(300, "KES")}
+		_, err := method.Quote(nairobi, parcel)
+		assert.ErrorIs(t, err, ErrShippingUnavailable)
+
+		method.IsActive = true
+		_, err = method.Quote(nairobi, Parcel{ItemsTotal: NewMoney(4000, "USD")})
+		assert.ErrorIs(t, err, ErrShippingUnavailable)
+	})
+}
+
+func TestShippingRates(t *testing.T) {
+	express := &ShippingMethod{ID: uuid.New(), Name: "Express", Type: ShippingFlatRate, Amount: NewMoney(900, "USD"), IsActive: true}
+	standard := &ShippingMethod{ID: uuid.New(), Name: "Standard", Type: ShippingFlatRate, Amount: NewMoney(400, "USD"), IsActive: true}
+	local := &ShippingMethod{ID: uuid.New(), Name: "Local pickup", Type: ShippingFlatRate, Amount: NewMoney(0, "USD"), IsActive: true, Zones: []ShippingZone{{Country: "US", City: "Austin"}}}
+
+	rates := ShippingRates([]*ShippingMethod{express, standard, local}, NewShippingDestination("US", "Denver"), Parcel{ItemsTotal: NewMoney(1000, "USD")})
+
+	require.Len(t, rates, 2)
+	assert.Equal(t, standard.ID, rates[0].MethodID, "cheapest first")
+	assert.Equal(t, NewMoney(900, "USD"), rates[1].Amount)
+}
+
+func TestShippingMethod_Validate(t *testing.T) {
+	method := func() *ShippingMethod {
+		return &ShippingMethod{Name: "Standard", Type: ShippingFlatRate, Amount: NewMoney(300, "")}
+	}
+
+	valid := method()
+	valid.Normalize()
+	require.NoError(t, valid.Validate())
+	assert.Equal(t, DefaultCurrency, valid.Amount.Currency)
+
+	unknown := method()
+	unknown.Type = "teleport"
+	assert.ErrorIs(t, unknown.Validate(), ErrInvalidShippingMethod)
+
+	weightBased := method()
+	weightBased.Type = ShippingWeightBased
+	assert.ErrorIs(t, weightBased.Validate(), ErrInvalidShippingMethod, "a weight based method needs per_kg_amount")
+
+	freeOver := method()
+	freeOver.Type = ShippingFreeOverThreshold
+	assert.ErrorIs(t, freeOver.Validate(), ErrInvalidShippingMethod, "a free over threshold method needs free_over")
+
+	otherCurrency := NewMoney(100, "EUR")
+	mixed := method()
+	mixed.Normalize()
+	mixed.Zones = []ShippingZone{{Country: "DE", Amount: &otherCurrency}}
+	assert.ErrorIs(t, mixed.Validate(), ErrInvalidShippingMethod)
+
+	twice := method()
+	twice.Zones = []ShippingZone{{Country: " ke", City: "Nairobi"}, {Country: "KE", City: "nairobi "}}
+	twice.Normalize()
+	assert.ErrorIs(t, twice.Validate(), ErrInvalidShippingMethod)
+}
+
+func TestProduct_ShippingWeight(t *testing.T) {
+	assert.Equal(t, 800, (&Product{WeightGrams: 800, LengthMM: 100, WidthMM: 100, HeightMM: 100}).ShippingWeight())
+	// A 40 x 30 x 20 cm box is 24000 cubic centimetres, charged as 4.8kg
+	assert.Equal(t, 4800, (&Product{WeightGrams: 800, LengthMM: 400, WidthMM: 300, HeightMM: 200}).ShippingWeight())
+}
+
+func TestOrder_SetTotalsWithShipping(t *testing.T) {
+	item := &OrderItem{Quantity: 1, UnitPrice: NewMoney(10000, "USD"), TotalPrice: NewMoney(10000, "USD")}
+	item.SetTax(&TaxRate{Rate: 800})
+
+	order := &Order{}
+	order.SetShipping(&ShippingMethod{ID: uuid.New(), Name: "Standard"}, NewMoney(500, "USD"))
+	require.NoError(t, order.SetTotals([]*OrderItem{item}))
+
+	assert.Equal(t, "Standard", order.ShippingMethodName)
+	assert.Equal(t, NewMoney(11300, "USD"), order.GrandTotal, "100.00 + 5.00 shipping + 8.00 tax")
+
+	unshipped := &Order{}
+	require.NoError(t, unshipped.SetTotals([]*OrderItem{item}))
+	assert.Equal(t, NewMoney(0, "USD"), unshipped.ShippingTotal, "orders without a method have no shipping charge")
+}
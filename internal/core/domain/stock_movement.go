@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// StockMovementReason explains why a product's stock changed
+type StockMovementReason string
+
+const (
+	StockMovementReasonInitial    StockMovementReason = "initial"
+	StockMovementReasonSale       StockMovementReason = "sale"
+	StockMovementReasonCancel     StockMovementReason = "cancel"
+	StockMovementReasonOrderEdit  StockMovementReason = "order_edit"
+	StockMovementReasonReturn     StockMovementReason = "return"
+	StockMovementReasonAdjustment StockMovementReason = "adjustment"
+	StockMovementReasonPurchase   StockMovementReason = "purchase"
+)
+
+// IsValid reports whether the reason is a known stock movement reason
+func (r StockMovementReason) IsValid() bool {
+	switch r {
+	case StockMovementReasonInitial, StockMovementReasonSale, StockMovementReasonCancel,
+		StockMovementReasonOrderEdit, StockMovementReasonReturn, StockMovementReasonAdjustment,
+		StockMovementReasonPurchase:
+		return true
+	}
+	return false
+}
+
+// UnmarshalGQL maps GraphQL enum values (e.g. SALE) to stock movement reasons
+func (r *StockMovementReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("stock movement reason must be a string")
+	}
+	*r = StockMovementReason(strings.ToLower(str))
+	if !r.IsValid() {
+		return fmt.Errorf("%s is not a valid StockMovementReason", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the stock movement reason as a GraphQL enum value
+func (r StockMovementReason) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(strings.ToUpper(string(r))))
+}
+
+// StockMovement is an append-only ledger entry recording a change to a product's stock.
+// The sum of a product's movements equals its stock, and the sum of its movements at a
+// warehouse equals its stock level there.
+type StockMovement struct {
+	bun.BaseModel `bun:"table:stock_movements,alias:sm"`
+
+	ID          uuid.UUID           `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ProductID   uuid.UUID           `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	Delta       int                 `bun:"delta,notnull" json:"delta"`
+	Reason      StockMovementReason `bun:"reason,notnull" json:"reason"`
+	OrderID     *uuid.UUID          `bun:"order_id,type:uuid" json:"order_id,omitempty"`
+	WarehouseID *uuid.UUID          `bun:"warehouse_id,type:uuid" json:"warehouse_id,omitempty"`
+	VariantID   *uuid.UUID          `bun:"variant_id,type:uuid" json:"variant_id,omitempty"`
+	Actor       string              `bun:"actor,notnull" json:"actor"`
+	Note        string              `bun:"note" json:"note,omitempty"`
+	CreatedAt   time.Time           `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// NewStockMovement creates a ledger entry for a stock change, optionally tied to an order
+func NewStockMovement(productID uuid.UUID, delta int, reason StockMovementReason, orderID *uuid.UUID) *StockMovement {
+	return &StockMovement{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    reason,
+		OrderID:   orderID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// InWarehouse sets the warehouse whose stock level the movement changes. Movements
+// without a warehouse apply to the default warehouse.
+func (m *StockMovement) InWarehouse(warehouseID uuid.UUID) *StockMovement {
+	m.WarehouseID = &warehouseID
+	return m
+}
+
+// ForVariant sets the product variant whose stock the movement also changes.
+// A nil variant leaves the movement applying to the product alone.
+func (m *StockMovement) ForVariant(variantID *uuid.UUID) *StockMovement {
+	m.VariantID = variantID
+	return m
+}
+
+// StockDiscrepancy describes a product whose stock does not match the sum of its ledger
+type StockDiscrepancy struct {
+	ProductID   uuid.UUID `bun:"product_id" json:"product_id"`
+	SKU         string    `bun:"sku" json:"sku"`
+	Stock       int       `bun:"stock" json:"stock"`
+	LedgerStock int       `bun:"ledger_stock" json:"ledger_stock"`
+}
+
+// Difference returns how far the recorded stock is from the ledger
+func (d StockDiscrepancy) Difference() int {
+	return d.Stock - d.LedgerStock
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Supplier is a vendor that stock is purchased from
+type Supplier struct {
+	bun.BaseModel `bun:"table:suppliers,alias:su"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Name      string    `bun:"name,unique,notnull" json:"name"`
+	Email     string    `bun:"email" json:"email"`
+	Phone     string    `bun:"phone" json:"phone"`
+	Address   string    `bun:"address" json:"address"`
+	IsActive  bool      `bun:"is_active,notnull,default:true" json:"is_active"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// CreateSupplierRequest represents the request to create a supplier
+type CreateSupplierRequest struct {
+	Name    string `json:"name" validate:"required"`
+	Email   string `json:"email" validate:"omitempty,email"`
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+// UpdateSupplierRequest represents the request to update a supplier
+type UpdateSupplierRequest struct {
+	Name     *string `json:"name,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Phone    *string `json:"phone,omitempty"`
+	Address  *string `json:"address,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ErrInvalidTaxRate is returned when a tax rate is malformed
+var ErrInvalidTaxRate = errors.New("invalid tax rate")
+
+// basisPoints is a rate of 100%, rates being kept in hundredths of a percent
+const basisPoints = 10000
+
+// NormalizeCountry returns the country as tax rates and orders store it, trimmed and upper-cased
+func NormalizeCountry(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+// TaxRate is the tax charged on items shipped to Country. A rate without a CategoryID is
+// the country's standard rate; a rate for a category covers its subcategories unless they
+// have a rate of their own. Rate is in basis points, 1600 being 16%, and may be zero for
+// zero-rated or exempt goods.
+//
+// PricesIncludeTax says whether the prices of the items the rate covers already include the
+// tax, as retail prices in Kenya include VAT, or whether the tax is charged on top of them.
+type TaxRate struct {
+	bun.BaseModel `bun:"table:tax_rates,alias:tr"`
+
+	ID               uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Country          string     `bun:"country,notnull" json:"country"`
+	CategoryID       *uuid.UUID `bun:"category_id,type:uuid" json:"category_id,omitempty"`
+	Name             string     `bun:"name,notnull" json:"name"`
+	Rate             int        `bun:"rate,notnull" json:"rate"`
+	PricesIncludeTax bool       `bun:"prices_include_tax,notnull,default:false" json:"prices_include_tax"`
+	CreatedAt        time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt        time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// Validate checks that the rate names a country and is between 0 and 100%
+func (r *TaxRate) Validate() error {
+	if r.Country == "" {
+		return fmt.Errorf("%w: country is required", ErrInvalidTaxRate)
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTaxRate)
+	}
+	if r.Rate < 0 || r.Rate > basisPoints {
+		return fmt.Errorf("%w: rate must be between 0 and %d basis points", ErrInvalidTaxRate, basisPoints)
+	}
+	return nil
+}
+
+// TaxRateFor returns the rate among a country's rates that covers the product: the rate of
+// its category, or of the nearest of the category's ancestors that has one, or else the
+// country's standard rate. It returns nil when none does and the product is not taxed.
+func TaxRateFor(rates []*TaxRate, product *Product) *TaxRate {
+	byCategory := make(map[uuid.UUID]*TaxRate, len(rates))
+	var standard *TaxRate
+	for _, rate := range rates {
+		if rate.CategoryID == nil {
+			standard = rate
+		} else {
+			byCategory[*rate.CategoryID] = rate
+		}
+	}
+
+	if rate, ok := byCategory[product.CategoryID]; ok {
+		return rate
+	}
+	ancestors := product.Category.AncestorIDs()
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if rate, ok := byCategory[ancestors[i]]; ok {
+			return rate
+		}
+	}
+	return standard
+}
+
+// taxOn returns the tax on amount at rate basis points, the part of amount that is tax when
+// it includes the tax and the tax to add to it otherwise
+func taxOn(amount Money, rate int, inclusive bool) Money {
+	if inclusive {
+		return Money{Amount: amount.Amount * int64(rate), Currency: amount.Currency}.Divide(basisPoints + rate)
+	}
+	return Money{Amount: amount.Amount * int64(rate), Currency: amount.Currency}.Divide(basisPoints)
+}
+
+// CreateTaxRateRequest represents the request to create a tax rate. Omitting CategoryID
+// sets the country's standard rate.
+type CreateTaxRateRequest struct {
+	Country          string     `json:"country" validate:"required"`
+	CategoryID       *uuid.UUID `json:"category_id,omitempty"`
+	Name             string     `json:"name" validate:"required"`
+	Rate             int        `json:"rate" validate:"min=0,max=10000"`
+	PricesIncludeTax bool       `json:"prices_include_tax"`
+}
+
+// UpdateTaxRateRequest represents the request to update a tax rate. Orders keep the rate
+// they were placed at.
+type UpdateTaxRateRequest struct {
+	Name             *string `json:"name,omitempty"`
+	Rate             *int    `json:"rate,omitempty"`
+	PricesIncludeTax *bool   `json:"prices_include_tax,omitempty"`
+}
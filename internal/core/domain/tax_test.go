@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxRateFor(t *testing.T) {
+	foodID, drinksID, juiceID := uuid.New(), uuid.New(), uuid.New()
+	standard := &TaxRate{Country: "KE", Name: "VAT", Rate: 1600}
+	food := &TaxRate{Country: "KE", CategoryID: &foodID, Name: "Zero-rated food", Rate: 0}
+	rates := []*TaxRate{food, standard}
+
+	juice := &Product{CategoryID: juiceID, Category: Category{ID: juiceID, Path: "/" + foodID.String() + "/" + drinksID.String() + "/" + juiceID.String() + "/"}}
+	phone := &Product{CategoryID: uuid.New()}
+
+	assert.Equal(t, food, TaxRateFor(rates, juice), "a category's rate covers its subcategories")
+	assert.Equal(t, standard, TaxRateFor(rates, phone))
+	assert.Nil(t, TaxRateFor([]*TaxRate{food}, phone), "a country without a standard rate leaves other products untaxed")
+
+	drinks := &TaxRate{Country: "KE", CategoryID: &drinksID, Name: "Drinks", Rate: 1600}
+	assert.Equal(t, drinks, TaxRateFor(append(rates, drinks), juice), "the nearest category wins")
+}
+
+func TestOrderItem_Tax(t *testing.T) {
+	t.Run("Inclusive prices contain the tax", func(t *testing.T) {
+		item := &OrderItem{Quantity: 2, UnitPrice: NewMoney(5800, "KES"), TotalPrice: NewMoney(11600, "KES")}
+		item.SetTax(&TaxRate{Rate: 1600, PricesIncludeTax: true})
+
+		assert.Equal(t, NewMoney(1600, "KES"), item.TaxAmount)
+		assert.Equal(t, NewMoney(10000, "KES"), item.NetPrice())
+		assert.Equal(t, NewMoney(11600, "KES"), item.GrossPrice())
+	})
+
+	t.Run("Exclusive prices have the tax added", func(t *testing.T) {
+		item := &OrderItem{Quantity: 1, UnitPrice: NewMoney(1999, "USD"), TotalPrice: NewMoney(1999, "USD")}
+		item.SetTax(&TaxRate{Rate: 825})
+
+		assert.Equal(t, NewMoney(165, "USD"), item.TaxAmount)
+		assert.Equal(t, NewMoney(2164, "USD"), item.GrossPrice())
+		assert.Equal(t, NewMoney(2164, "USD"), (&OrderItem{Quantity: 2, TotalPrice: NewMoney(3998, "USD"), TaxAmount: NewMoney(330, "USD")}).PaidFor(1))
+	})
+
+	t.Run("A discount is taxed at the rate the line keeps", func(t *testing.T) {
+		item := &OrderItem{Quantity: 1, UnitPrice: NewMoney(11600, "KES"), TotalPrice: NewMoney(11600, "KES")}
+		item.SetTax(&TaxRate{Rate: 1600, PricesIncludeTax: true})
+		item.SetDiscount(5800)
+
+		assert.Equal(t, NewMoney(800, "KES"), item.TaxAmount)
+	})
+
+	t.Run("No rate, no tax", func(t *testing.T) {
+		item := &OrderItem{Quantity: 1, UnitPrice: NewMoney(1000, "USD"), TotalPrice: NewMoney(1000, "USD")}
+		item.SetTax(nil)
+
+		assert.Zero(t, item.TaxAmount.Amount)
+		assert.Equal(t, item.TotalPrice, item.GrossPrice())
+	})
+}
+
+func TestOrder_SetTotals(t *testing.T) {
+	inclusive := &OrderItem{Quantity: 1, UnitPrice: NewMoney(11600, "KES")}
+	inclusive.SetTax(&TaxRate{Rate: 1600, PricesIncludeTax: true})
+	inclusive.SetDiscount(0)
+	exclusive := &OrderItem{Quantity: 2, UnitPrice: NewMoney(2500, "KES")}
+	exclusive.SetTax(&TaxRate{Rate: 1600})
+	exclusive.SetDiscount(1000)
+
+	var order Order
+	require.NoError(t, order.SetTotals([]*OrderItem{inclusive, exclusive}))
+
+	assert.Equal(t, NewMoney(14000, "KES"), order.Subtotal)
+	assert.Equal(t, NewMoney(1000, "KES"), order.DiscountTotal)
+	assert.Equal(t, NewMoney(2240, "KES"), order.TaxTotal)
+	assert.Equal(t, NewMoney(16240, "KES"), order.GrandTotal)
+	assert.Equal(t, order.GrandTotal, order.TotalAmount)
+
+	other := &OrderItem{Quantity: 1, UnitPrice: NewMoney(1000, "USD")}
+	other.SetDiscount(0)
+	assert.ErrorIs(t, order.SetTotals([]*OrderItem{inclusive, other}), ErrCurrencyMismatch)
+}
+
+func TestTaxRate_Validate(t *testing.T) {
+	assert.NoError(t, (&TaxRate{Country: "KE", Name: "VAT", Rate: 1600}).Validate())
+	assert.NoError(t, (&TaxRate{Country: "KE", Name: "Exempt", Rate: 0}).Validate())
+	assert.ErrorIs(t, (&TaxRate{Name: "VAT", Rate: 1600}).Validate(), ErrInvalidTaxRate)
+	assert.ErrorIs(t, (&TaxRate{Country: "KE", Name: "VAT", Rate: 10001}).Validate(), ErrInvalidTaxRate)
+	assert.Equal(t, "KE", NormalizeCountry(" ke "))
+}
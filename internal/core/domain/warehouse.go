@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// AllocationStrategy decides which warehouses an order's items are taken from
+type AllocationStrategy string
+
+const (
+	// AllocationStrategyNearest prefers warehouses in the order's shipping city, then its country
+	AllocationStrategyNearest AllocationStrategy = "nearest"
+	// AllocationStrategyMostStock prefers the warehouses holding the most stock of the product
+	AllocationStrategyMostStock AllocationStrategy = "most_stock"
+)
+
+// DefaultAllocationStrategy is used when no strategy is configured
+const DefaultAllocationStrategy = AllocationStrategyNearest
+
+// IsValid reports whether the strategy is a known allocation strategy
+func (s AllocationStrategy) IsValid() bool {
+	switch s {
+	case AllocationStrategyNearest, AllocationStrategyMostStock:
+		return true
+	}
+	return false
+}
+
+// ErrNoDefaultWarehouse is returned when stock is added without a warehouse and none is marked default
+var ErrNoDefaultWarehouse = errors.New("no default warehouse")
+
+// Warehouse is a location holding stock. Stock added without naming a warehouse
+// (new products, manual adjustments, returns) goes to the default warehouse.
+type Warehouse struct {
+	bun.BaseModel `bun:"table:warehouses,alias:w"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Code      string    `bun:"code,unique,notnull" json:"code"`
+	Name      string    `bun:"name,notnull" json:"name"`
+	City      string    `bun:"city,notnull" json:"city"`
+	Country   string    `bun:"country,notnull" json:"country"`
+	IsDefault bool      `bun:"is_default,notnull,default:false" json:"is_default"`
+	IsActive  bool      `bun:"is_active,notnull,default:true" json:"is_active"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// Proximity scores how close the warehouse is to where an order ships: 3 when it is in the
+// destination's city, 1 when it is elsewhere in the destination's country and 0 otherwise.
+// Countries are compared as NormalizeCountry leaves them, so warehouses should name their
+// country the way customers do, such as by ISO code.
+func (w *Warehouse) Proximity(dest ShippingDestination) int {
+	if dest.Country == "" || NormalizeCountry(w.Country) != dest.Country {
+		return 0
+	}
+	if dest.City != "" && strings.EqualFold(strings.TrimSpace(w.City), dest.City) {
+		return 3
+	}
+	return 1
+}
+
+// StockLevel is the quantity of a product held at one warehouse.
+// A product's stock is the sum of its stock levels.
+type StockLevel struct {
+	bun.BaseModel `bun:"table:stock_levels,alias:sl"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	ProductID   uuid.UUID `bun:"product_id,type:uuid,notnull" json:"product_id"`
+	WarehouseID uuid.UUID `bun:"warehouse_id,type:uuid,notnull" json:"warehouse_id"`
+	Quantity    int       `bun:"quantity,notnull,default:0" json:"quantity"`
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt   time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Relations
+	Warehouse *Warehouse `bun:"rel:belongs-to,join:warehouse_id=id" json:"warehouse,omitempty"`
+}
+
+// StockAllocation is a quantity of a product taken from, or held by an order at, a warehouse
+type StockAllocation struct {
+	ProductID   uuid.UUID `bun:"product_id" json:"product_id"`
+	WarehouseID uuid.UUID `bun:"warehouse_id" json:"warehouse_id"`
+	Quantity    int       `bun:"quantity" json:"quantity"`
+}
+
+// CreateWarehouseRequest represents the request to create a warehouse
+type CreateWarehouseRequest struct {
+	Code      string `json:"code" validate:"required"`
+	Name      string `json:"name" validate:"required"`
+	City      string `json:"city" validate:"required"`
+	Country   string `json:"country" validate:"required"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// UpdateWarehouseRequest represents the request to update a warehouse
+type UpdateWarehouseRequest struct {
+	Name      *string `json:"name,omitempty"`
+	City      *string `json:"city,omitempty"`
+	Country   *string `json:"country,omitempty"`
+	IsDefault *bool   `json:"is_default,omitempty"`
+	IsActive  *bool   `json:"is_active,omitempty"`
+}
+
+// SetStockLevelRequest represents the request to set a product's quantity at a warehouse
+type SetStockLevelRequest struct {
+	Quantity int `json:"quantity" validate:"min=0"`
+}
+
+// AllocateStock splits quantity of a product across the stock levels of active warehouses,
+// taking as much as possible from each warehouse in the order the strategy prefers.
+// Stock levels must have their warehouse loaded. It returns ErrInsufficientStock when the
+// warehouses cannot cover the quantity together.
+func AllocateStock(levels []*StockLevel, quantity int, strategy AllocationStrategy, dest ShippingDestination) ([]StockAllocation, error) {
+	candidates := make([]*StockLevel, 0, len(levels))
+	for _, level := range levels {
+		if level.Warehouse != nil && level.Warehouse.IsActive && level.Quantity > 0 {
+			candidates = append(candidates, level)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if strategy == AllocationStrategyNearest {
+			if pa, pb := a.Warehouse.Proximity(dest), b.Warehouse.Proximity(dest); pa != pb {
+				return pa > pb
+			}
+		}
+		if a.Quantity != b.Quantity {
+			return a.Quantity > b.Quantity
+		}
+		return a.Warehouse.Code < b.Warehouse.Code
+	})
+
+	var allocations []StockAllocation
+	remaining := quantity
+	for _, level := range candidates {
+		if remaining == 0 {
+			break
+		}
+		take := level.Quantity
+		if take > remaining {
+			take = remaining
+		}
+		allocations = append(allocations, StockAllocation{
+			ProductID:   level.ProductID,
+			WarehouseID: level.WarehouseID,
+			Quantity:    take,
+		})
+		remaining -= take
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("%w: warehouses hold %d of %d", ErrInsufficientStock, quantity-remaining, quantity)
+	}
+
+	return allocations, nil
+}
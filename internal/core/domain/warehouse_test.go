@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarehouse_Proximity(t *testing.T) {
+	warehouse := &Warehouse{City: "Mombasa", Country: "ke"}
+
+	assert.Equal(t, 3, warehouse.Proximity(NewShippingDestination("KE", "mombasa")))
+	assert.Equal(t, 1, warehouse.Proximity(NewShippingDestination("KE", "Nairobi")))
+	assert.Equal(t, 0, warehouse.Proximity(NewShippingDestination("UG", "Mombasa")), "a city of the same name in another country")
+	assert.Equal(t, 0, warehouse.Proximity(NewShippingDestination("", "Mombasa")))
+}
+
+func TestAllocateStock(t *testing.T) {
+	productID := uuid.New()
+	nairobi := &Warehouse{ID: uuid.New(), Code: "NBO", City: "Nairobi", Country: "KE", IsActive: true}
+	mombasa := &Warehouse{ID: uuid.New(), Code: "MBA", City: "Mombasa", Country: "KE", IsActive: true}
+	level := func(warehouse *Warehouse, quantity int) *StockLevel {
+		return &StockLevel{ProductID: productID, WarehouseID: warehouse.ID, Quantity: quantity, Warehouse: warehouse}
+	}
+	levels := []*StockLevel{level(nairobi, 10), level(mombasa, 4)}
+
+	t.Run("Nearest prefers the shipping city", func(t *testing.T) {
+		allocations, err := AllocateStock(levels, 3, AllocationStrategyNearest, NewShippingDestination("KE", "Mombasa"))
+		assert.NoError(t, err)
+		assert.Equal(t, []StockAllocation{{ProductID: productID, WarehouseID: mombasa.ID, Quantity: 3}}, allocations)
+	})
+
+	t.Run("Nearest splits across warehouses", func(t *testing.T) {
+		allocations, err := AllocateStock(levels, 6, AllocationStrategyNearest, NewShippingDestination("KE", "Mombasa"))
+		assert.NoError(t, err)
+		assert.Equal(t, []StockAllocation{
+			{ProductID: productID, WarehouseID: mombasa.ID, Quantity: 4},
+			{ProductID: productID, WarehouseID: nairobi.ID, Quantity: 2},
+		}, allocations)
+	})
+
+	t.Run("Most stock ignores the destination", func(t *testing.T) {
+		allocations, err := AllocateStock(levels, 3, AllocationStrategyMostStock, NewShippingDestination("KE", "Mombasa"))
+		assert.NoError(t, err)
+		assert.Equal(t, []StockAllocation{{ProductID: productID, WarehouseID: nairobi.ID, Quantity: 3}}, allocations)
+	})
+
+	t.Run("Inactive warehouses are skipped", func(t *testing.T) {
+		closed := &Warehouse{ID: uuid.New(), Code: "KSM", City: "Kisumu", Country: "KE"}
+		_, err := AllocateStock([]*StockLevel{level(closed, 50), level(mombasa, 4)}, 5, AllocationStrategyNearest, NewShippingDestination("KE", "Kisumu"))
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+	})
+}
@@ -13,9 +13,26 @@ type CategoryRepository interface {
 	Create(ctx context.Context, category *domain.Category) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Category, error)
 	GetByName(ctx context.Context, name string) (*domain.Category, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*domain.Category, error)
+	// GetBySlug returns the child of a parent, or the root category when parentID is nil,
+	// with the slug
+	GetBySlug(ctx context.Context, parentID *uuid.UUID, slug string) (*domain.Category, error)
+	// GetBySlugRedirect returns the category that answered to the slug under the parent
+	// before it was renamed or moved
+	GetBySlugRedirect(ctx context.Context, parentID *uuid.UUID, slug string) (*domain.Category, error)
+	// AddSlugRedirect records an old slug, replacing any older redirect of it
+	AddSlugRedirect(ctx context.Context, redirect *domain.CategorySlugRedirect) error
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Category], error)
 	GetByParentID(ctx context.Context, parentID uuid.UUID) ([]*domain.Category, error)
 	GetRootCategories(ctx context.Context) ([]*domain.Category, error)
+	// GetAncestors returns the ancestors of a category, root first
+	GetAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Category, error)
+	// GetDescendants returns every category below a category, at any depth
+	GetDescendants(ctx context.Context, id uuid.UUID) ([]*domain.Category, error)
+	// Update saves the name and description of a category; its place in the tree only
+	// changes through Move
 	Update(ctx context.Context, category *domain.Category) error
+	// Move puts a category and its subtree under a new parent, or at the root when parentID
+	// is nil, returning domain.ErrCategoryCycle when the parent is inside the subtree
+	Move(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -12,9 +12,13 @@ import (
 type CategoryService interface {
 	CreateCategory(ctx context.Context, req *domain.CreateCategoryRequest) (*domain.Category, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (*domain.Category, error)
-	GetCategories(ctx context.Context, limit, offset int) ([]*domain.Category, error)
+	GetCategoryByPath(ctx context.Context, path string) (*domain.CategoryPathLookup, error)
+	GetCategories(ctx context.Context, page PageRequest) (*Page[*domain.Category], error)
 	GetRootCategories(ctx context.Context) ([]*domain.Category, error)
 	GetSubCategories(ctx context.Context, parentID uuid.UUID) ([]*domain.Category, error)
+	GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Category, error)
+	GetCategoryTree(ctx context.Context, id uuid.UUID) (*domain.Category, error)
 	UpdateCategory(ctx context.Context, id uuid.UUID, req *domain.UpdateCategoryRequest) (*domain.Category, error)
+	MoveCategory(ctx context.Context, id uuid.UUID, req *domain.MoveCategoryRequest) (*domain.Category, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
 }
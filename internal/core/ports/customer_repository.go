@@ -13,7 +13,8 @@ type CustomerRepository interface {
 	Create(ctx context.Context, customer *domain.Customer) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Customer, error)
 	GetByEmail(ctx context.Context, email string) (*domain.Customer, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*domain.Customer, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Customer], error)
+	Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.CustomerSearchResult, error)
 	Update(ctx context.Context, customer *domain.Customer) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
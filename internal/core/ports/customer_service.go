@@ -12,7 +12,8 @@ import (
 type CustomerService interface {
 	CreateCustomer(ctx context.Context, req *domain.CreateCustomerRequest) (*domain.Customer, error)
 	GetCustomer(ctx context.Context, id uuid.UUID) (*domain.Customer, error)
-	GetCustomers(ctx context.Context, limit, offset int) ([]*domain.Customer, error)
+	GetCustomers(ctx context.Context, page PageRequest) (*Page[*domain.Customer], error)
+	SearchCustomers(ctx context.Context, query string, limit, offset int) ([]*domain.CustomerSearchResult, error)
 	UpdateCustomer(ctx context.Context, id uuid.UUID, req *domain.UpdateCustomerRequest) (*domain.Customer, error)
 	DeleteCustomer(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// IdempotencyRecord is the stored state of a request made with an Idempotency-Key
+type IdempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// IdempotencyStore defines the interface for persisting idempotent requests and their responses
+type IdempotencyStore interface {
+	// Reserve atomically claims the key for a request; it returns false if the key is already taken
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (bool, error)
+	// Get returns the record stored under the key, or nil if there is none
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+	// Save stores the completed record under the key
+	Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	// Release removes the key so the request can be retried
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// InventoryService defines the contract for stock ledger business logic
+type InventoryService interface {
+	GetStockMovements(ctx context.Context, productID uuid.UUID, page PageRequest) (*Page[*domain.StockMovement], error)
+	ReconcileStock(ctx context.Context, fix bool) ([]*domain.StockDiscrepancy, error)
+	GetReorderSuggestions(ctx context.Context, limit, offset int) ([]*domain.ReorderSuggestion, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+)
+
+// LowStockNotifier alerts staff when stock changes drop products below their reorder point.
+// Alerts are best effort: failures are logged rather than failing the stock change.
+type LowStockNotifier interface {
+	NotifyLowStock(ctx context.Context, events []*domain.LowStockEvent)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+// OrderNumberGenerator defines the contract for issuing and validating order numbers
+type OrderNumberGenerator interface {
+	// Next issues a new, unique order number
+	Next(ctx context.Context) (string, error)
+	// Validate checks the format and check digit of an order number without touching storage
+	Validate(orderNumber string) bool
+}
@@ -13,9 +13,9 @@ type OrderRepository interface {
 	Create(ctx context.Context, order *domain.Order) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Order, error)
 	GetByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error)
-	GetByCustomerID(ctx context.Context, customerID uuid.UUID, limit, offset int) ([]*domain.Order, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*domain.Order, error)
-	GetByStatus(ctx context.Context, status domain.OrderStatus, limit, offset int) ([]*domain.Order, error)
+	GetByCustomerID(ctx context.Context, customerID uuid.UUID, page PageRequest) (*Page[*domain.Order], error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Order], error)
+	GetByStatus(ctx context.Context, status domain.OrderStatus, page PageRequest) (*Page[*domain.Order], error)
 	Update(ctx context.Context, order *domain.Order) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -31,3 +31,17 @@ type OrderItemRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByOrderID(ctx context.Context, orderID uuid.UUID) error
 }
+
+// OrderStatusHistoryRepository defines the contract for order status history data operations
+type OrderStatusHistoryRepository interface {
+	Create(ctx context.Context, entry *domain.OrderStatusHistory) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderStatusHistory, error)
+}
+
+// OrderDiscountRepository defines the contract for the data operations of the discounts applied to orders
+type OrderDiscountRepository interface {
+	Create(ctx context.Context, discount *domain.OrderDiscount) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderDiscount, error)
+	Update(ctx context.Context, discount *domain.OrderDiscount) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -11,13 +11,19 @@ import (
 // OrderService defines the contract for order business logic
 type OrderService interface {
 	CreateOrder(ctx context.Context, req *domain.CreateOrderRequest) (*domain.Order, error)
+	QuoteOrder(ctx context.Context, req *domain.CreateOrderRequest) (*domain.OrderQuote, error)
+	// QuoteShipping returns what each shipping method that ships the order the request
+	// would place charges for it, cheapest first
+	QuoteShipping(ctx context.Context, req *domain.CreateOrderRequest) ([]domain.ShippingRate, error)
 	GetOrder(ctx context.Context, id uuid.UUID) (*domain.Order, error)
 	GetOrderByNumber(ctx context.Context, orderNumber string) (*domain.Order, error)
-	GetOrders(ctx context.Context, limit, offset int) ([]*domain.Order, error)
-	GetOrdersByCustomer(ctx context.Context, customerID uuid.UUID, limit, offset int) ([]*domain.Order, error)
-	GetOrdersByStatus(ctx context.Context, status domain.OrderStatus, limit, offset int) ([]*domain.Order, error)
+	GetOrders(ctx context.Context, page PageRequest) (*Page[*domain.Order], error)
+	GetOrdersByCustomer(ctx context.Context, customerID uuid.UUID, page PageRequest) (*Page[*domain.Order], error)
+	GetOrdersByStatus(ctx context.Context, status domain.OrderStatus, page PageRequest) (*Page[*domain.Order], error)
 	UpdateOrder(ctx context.Context, id uuid.UUID, req *domain.UpdateOrderRequest) (*domain.Order, error)
-	UpdateOrderStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error
+	UpdateOrderItems(ctx context.Context, id uuid.UUID, req *domain.UpdateOrderItemsRequest) (*domain.Order, error)
+	UpdateOrderStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus, reason string) error
+	GetOrderStatusHistory(ctx context.Context, id uuid.UUID) ([]*domain.OrderStatusHistory, error)
 	CancelOrder(ctx context.Context, id uuid.UUID) error
 	DeleteOrder(ctx context.Context, id uuid.UUID) error
 }
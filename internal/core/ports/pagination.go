@@ -0,0 +1,124 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultPageSize is the page size used when a page request does not set one
+const DefaultPageSize = 10
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks a position in a sorted list: the sort the list was in and the values the
+// record at the position has for each sorted field, followed by its ID, which breaks ties
+type Cursor struct {
+	Sort   string
+	Values []interface{}
+}
+
+// cursorToken is the encoded form of a cursor
+type cursorToken struct {
+	Sort   string        `json:"s"`
+	Values []interface{} `json:"v"`
+}
+
+// NewCursor returns the cursor of a record in a list sorted by sort
+func NewCursor(sort Sort, values ...interface{}) Cursor {
+	return Cursor{Sort: sort.String(), Values: values}
+}
+
+// String encodes the cursor as an opaque URL-safe token
+func (c Cursor) String() string {
+	raw, err := json.Marshal(cursorToken{Sort: c.Sort, Values: c.Values})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// ParseCursor decodes a token produced by Cursor.String. Numbers are kept in their
+// decimal form so that large integers survive the round trip.
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded cursorToken
+	if err := decoder.Decode(&decoded); err != nil || len(decoded.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Sort: decoded.Sort, Values: decoded.Values}, nil
+}
+
+// PageRequest selects a page of a list sorted by Sort, newest first when it is empty.
+// After continues the list past a cursor and is preferred for deep pages; Offset pages
+// by position and is ignored when After is set.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+	Sort   Sort
+}
+
+// NewPageRequest builds a page request from a limit, an offset, an optional cursor token
+// and a sort. A cursor only continues the list in the sort it was taken in.
+func NewPageRequest(limit, offset int, after string, sort Sort) (PageRequest, error) {
+	page := PageRequest{Limit: limit, Offset: offset, Sort: sort}
+	if after != "" {
+		cursor, err := ParseCursor(after)
+		if err != nil {
+			return PageRequest{}, fmt.Errorf("%w: %s", err, after)
+		}
+		if cursor.Sort != page.Order().String() {
+			return PageRequest{}, fmt.Errorf("%w: the cursor was taken in sort %q", ErrInvalidCursor, cursor.Sort)
+		}
+		page.After = cursor
+	}
+	return page, nil
+}
+
+// Order returns the sort of the page, DefaultSort when none is set
+func (p PageRequest) Order() Sort {
+	if len(p.Sort) == 0 {
+		return DefaultSort
+	}
+	return p.Sort
+}
+
+// Size returns the number of items the page holds at most
+func (p PageRequest) Size() int {
+	if p.Limit <= 0 {
+		return DefaultPageSize
+	}
+	return p.Limit
+}
+
+// Page is one page of a list, with the cursor of each item, the total number of items in
+// the list and the cursor continuing it when more items follow
+type Page[T any] struct {
+	Items      []T
+	Cursors    []Cursor
+	TotalCount int
+	NextCursor *Cursor
+}
+
+// HasNextPage reports whether more items follow the page
+func (p *Page[T]) HasNextPage() bool {
+	return p.NextCursor != nil
+}
+
+// NextCursorString returns the encoded next cursor, or nil on the last page
+func (p *Page[T]) NextCursorString() *string {
+	if p.NextCursor == nil {
+		return nil
+	}
+	token := p.NextCursor.String()
+	return &token
+}
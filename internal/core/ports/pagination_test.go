@@ -0,0 +1,52 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	id := uuid.New()
+	cursor := NewCursor(Sort{{Field: "price", Desc: true}}, int64(1999), createdAt, id)
+
+	parsed, err := ParseCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "-price", parsed.Sort)
+	assert.Len(t, parsed.Values, 3)
+	assert.Equal(t, "1999", parsed.Values[0].(fmt.Stringer).String())
+	assert.Equal(t, createdAt.Format(time.RFC3339Nano), parsed.Values[1])
+	assert.Equal(t, id.String(), parsed.Values[2])
+	assert.Equal(t, cursor.String(), parsed.String())
+
+	for _, token := range []string{"not base64!", "bm8tc2VwYXJhdG9y", cursor.String()[:10]} {
+		_, err := ParseCursor(token)
+		assert.True(t, errors.Is(err, ErrInvalidCursor), token)
+	}
+}
+
+func TestNewPageRequest(t *testing.T) {
+	page, err := NewPageRequest(0, 5, "", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, page.After)
+	assert.Equal(t, DefaultPageSize, page.Size())
+	assert.Equal(t, DefaultSort, page.Order())
+
+	cursor := NewCursor(DefaultSort, time.Now(), uuid.New())
+	page, err = NewPageRequest(20, 0, cursor.String(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor.String(), page.After.String())
+	assert.Equal(t, 20, page.Size())
+
+	_, err = NewPageRequest(20, 0, "garbage", nil)
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+
+	// a cursor only continues the sort it was taken in
+	_, err = NewPageRequest(20, 0, cursor.String(), Sort{{Field: "name"}})
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ProductPriceRepository defines the contract for product price data operations
+type ProductPriceRepository interface {
+	Create(ctx context.Context, price *domain.ProductPrice) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductPrice, error)
+	// GetByProductID returns the product's prices in effect at any time in [from, to),
+	// oldest first; a nil bound leaves that side open
+	GetByProductID(ctx context.Context, productID uuid.UUID, from, to *time.Time) ([]*domain.ProductPrice, error)
+	// GetEffective returns the product's price in effect at the time, or nil when none is
+	GetEffective(ctx context.Context, productID uuid.UUID, at time.Time) (*domain.ProductPrice, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -8,16 +8,25 @@ import (
 	"github.com/google/uuid"
 )
 
-// ProductRepository defines the contract for product data operations
+// ProductRepository defines the contract for product data operations.
+// Every stock change is recorded in the stock movement ledger.
 type ProductRepository interface {
 	Create(ctx context.Context, product *domain.Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error)
 	GetBySKU(ctx context.Context, sku string) (*domain.Product, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*domain.Product, error)
-	GetByCategoryID(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Product, error)
-	GetActiveProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error)
-	SearchByName(ctx context.Context, name string, limit, offset int) ([]*domain.Product, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Product], error)
+	// GetByCategoryID returns the products in a category and all its descendants
+	GetByCategoryID(ctx context.Context, categoryID uuid.UUID, page PageRequest) (*Page[*domain.Product], error)
+	GetActiveProducts(ctx context.Context, page PageRequest) (*Page[*domain.Product], error)
+	Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.ProductSearchResult, error)
+	Query(ctx context.Context, query *domain.ProductQuery, page PageRequest) (*Page[*domain.Product], error)
+	GetFacets(ctx context.Context, query *domain.ProductQuery) (*domain.ProductFacets, error)
+	GetLowStock(ctx context.Context, limit, offset int) ([]*domain.Product, error)
+	// ListAfterSKU returns up to limit products with their category, in SKU order after
+	// afterSKU, for walking the whole catalogue a batch at a time
+	ListAfterSKU(ctx context.Context, afterSKU string, limit int) ([]*domain.Product, error)
 	Update(ctx context.Context, product *domain.Product) error
 	UpdateStock(ctx context.Context, id uuid.UUID, stock int) error
+	AdjustStock(ctx context.Context, movement *domain.StockMovement) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"silbackendassessment/internal/core/domain"
 
@@ -12,11 +14,33 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, req *domain.CreateProductRequest) (*domain.Product, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error)
-	GetProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error)
-	GetProductsByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Product, error)
-	GetActiveProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error)
-	SearchProducts(ctx context.Context, name string, limit, offset int) ([]*domain.Product, error)
+	GetProducts(ctx context.Context, page PageRequest) (*Page[*domain.Product], error)
+	GetProductsByCategory(ctx context.Context, categoryID uuid.UUID, page PageRequest) (*Page[*domain.Product], error)
+	GetActiveProducts(ctx context.Context, page PageRequest) (*Page[*domain.Product], error)
+	SearchProducts(ctx context.Context, query string, limit, offset int) ([]*domain.ProductSearchResult, error)
+	QueryProducts(ctx context.Context, query *domain.ProductQuery, page PageRequest) (*Page[*domain.Product], error)
+	GetProductFacets(ctx context.Context, query *domain.ProductQuery) (*domain.ProductFacets, error)
 	UpdateProduct(ctx context.Context, id uuid.UUID, req *domain.UpdateProductRequest) (*domain.Product, error)
 	UpdateStock(ctx context.Context, id uuid.UUID, stock int) error
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
+
+	// SchedulePrice adds a price the product sells at during a window
+	SchedulePrice(ctx context.Context, productID uuid.UUID, req *domain.CreateProductPriceRequest) (*domain.ProductPrice, error)
+	// GetPriceHistory returns the product's prices in effect at any time in [from, to)
+	GetPriceHistory(ctx context.Context, productID uuid.UUID, from, to *time.Time) ([]*domain.ProductPrice, error)
+	// GetEffectivePrice returns what the product sells at at the time
+	GetEffectivePrice(ctx context.Context, productID uuid.UUID, at time.Time) (*domain.EffectivePrice, error)
+	// CancelPrice removes a scheduled price that has not taken effect yet
+	CancelPrice(ctx context.Context, productID, priceID uuid.UUID) error
+
+	// ImportProducts creates or updates, by SKU, a product for each record read from r. A
+	// record that fails is reported and skipped; a dry run only validates the records.
+	ImportProducts(ctx context.Context, format domain.ProductRecordFormat, r io.Reader, dryRun bool) (*domain.ProductImportReport, error)
+	// ExportProducts writes every product to w, a batch at a time
+	ExportProducts(ctx context.Context, format domain.ProductRecordFormat, w io.Writer) error
+
+	CreateVariant(ctx context.Context, productID uuid.UUID, req *domain.CreateProductVariantRequest) (*domain.ProductVariant, error)
+	GetVariants(ctx context.Context, productID uuid.UUID) ([]*domain.ProductVariant, error)
+	UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, req *domain.UpdateProductVariantRequest) (*domain.ProductVariant, error)
+	DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error
 }
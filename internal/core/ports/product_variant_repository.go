@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ProductVariantRepository defines the contract for product variant data operations.
+// Variant stock is part of the product's stock, so it changes through the stock ledger.
+type ProductVariantRepository interface {
+	Create(ctx context.Context, variant *domain.ProductVariant) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductVariant, error)
+	GetBySKU(ctx context.Context, sku string) (*domain.ProductVariant, error)
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]*domain.ProductVariant, error)
+	Update(ctx context.Context, variant *domain.ProductVariant) error
+	UpdateStock(ctx context.Context, id uuid.UUID, stock int) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
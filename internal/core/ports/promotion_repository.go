@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// PromotionRepository defines the contract for promotion data operations
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *domain.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*domain.Promotion, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Promotion], error)
+	// GetAutomatic returns the active promotions without a code running at the time
+	GetAutomatic(ctx context.Context, at time.Time) ([]*domain.Promotion, error)
+	Update(ctx context.Context, promotion *domain.Promotion) error
+	// Redeem counts a use of the promotion, returning domain.ErrPromotionUnavailable
+	// when it has reached its usage limit
+	Redeem(ctx context.Context, id uuid.UUID) error
+	// Release gives back a use of the promotion counted by Redeem
+	Release(ctx context.Context, id uuid.UUID) error
+	// CountCustomerUses returns how many of the customer's orders, cancelled ones aside,
+	// the promotion was applied to
+	CountCustomerUses(ctx context.Context, id, customerID uuid.UUID) (int, error)
+}
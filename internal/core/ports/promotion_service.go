@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// PromotionService defines the contract for promotion business logic
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req *domain.CreatePromotionRequest) (*domain.Promotion, error)
+	GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error)
+	GetPromotions(ctx context.Context, page PageRequest) (*Page[*domain.Promotion], error)
+	UpdatePromotion(ctx context.Context, id uuid.UUID, req *domain.UpdatePromotionRequest) (*domain.Promotion, error)
+}
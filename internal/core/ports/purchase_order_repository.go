@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderRepository defines the contract for purchase order data operations
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, po *domain.PurchaseOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.PurchaseOrder], error)
+	GetBySupplierID(ctx context.Context, supplierID uuid.UUID, page PageRequest) (*Page[*domain.PurchaseOrder], error)
+	Update(ctx context.Context, po *domain.PurchaseOrder) error
+	// ReplaceLines swaps the purchase order's lines for po.Lines
+	ReplaceLines(ctx context.Context, po *domain.PurchaseOrder) error
+	// ReceiveLine adds quantity to the line's received quantity. It returns
+	// ErrInvalidPurchaseOrder when that would exceed the quantity ordered.
+	ReceiveLine(ctx context.Context, lineID uuid.UUID, quantity int) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderService defines the contract for purchase order business logic
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(ctx context.Context, req *domain.CreatePurchaseOrderRequest) (*domain.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error)
+	GetPurchaseOrders(ctx context.Context, page PageRequest) (*Page[*domain.PurchaseOrder], error)
+	GetPurchaseOrdersBySupplier(ctx context.Context, supplierID uuid.UUID, page PageRequest) (*Page[*domain.PurchaseOrder], error)
+	UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req *domain.UpdatePurchaseOrderRequest) (*domain.PurchaseOrder, error)
+	SendPurchaseOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error)
+	ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req *domain.ReceivePurchaseOrderRequest) (*domain.PurchaseOrder, error)
+}
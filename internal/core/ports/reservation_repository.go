@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReservationRepository defines the contract for stock reservation data operations
+type ReservationRepository interface {
+	// Create stores the reservation, returning domain.ErrInsufficientStock when
+	// the product has too little available stock to hold it
+	Create(ctx context.Context, reservation *domain.StockReservation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error)
+	// Convert marks a held reservation as turned into the given order,
+	// returning domain.ErrReservationUnavailable when it is no longer held
+	Convert(ctx context.Context, id, orderID uuid.UUID) error
+	Release(ctx context.Context, id uuid.UUID) error
+	// ExpireDue marks every active reservation that expired before now and returns how many there were
+	ExpireDue(ctx context.Context, now time.Time) (int, error)
+}
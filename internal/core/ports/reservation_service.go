@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReservationService defines the contract for stock reservation business logic
+type ReservationService interface {
+	CreateReservation(ctx context.Context, req *domain.CreateReservationRequest) (*domain.StockReservation, error)
+	GetReservation(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error)
+	ReleaseReservation(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error)
+	ReleaseExpired(ctx context.Context) (int, error)
+}
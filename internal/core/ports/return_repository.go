@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReturnRepository defines the contract for return request data operations
+type ReturnRepository interface {
+	Create(ctx context.Context, ret *domain.ReturnRequest) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.ReturnRequest, error)
+	Update(ctx context.Context, ret *domain.ReturnRequest) error
+}
+
+// RefundRepository defines the contract for refund data operations
+type RefundRepository interface {
+	Create(ctx context.Context, refund *domain.Refund) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.Refund, error)
+}
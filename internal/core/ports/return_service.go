@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReturnService defines the contract for return and refund business logic
+type ReturnService interface {
+	RequestReturn(ctx context.Context, orderID uuid.UUID, req *domain.CreateReturnRequest) (*domain.ReturnRequest, error)
+	GetReturn(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error)
+	GetReturnsByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.ReturnRequest, error)
+	ApproveReturn(ctx context.Context, id uuid.UUID, req *domain.ReviewReturnRequest) (*domain.ReturnRequest, error)
+	RejectReturn(ctx context.Context, id uuid.UUID, req *domain.ReviewReturnRequest) (*domain.ReturnRequest, error)
+	ReceiveReturn(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error)
+}
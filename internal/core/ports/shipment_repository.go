@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentRepository defines the contract for shipment data operations
+type ShipmentRepository interface {
+	Create(ctx context.Context, shipment *domain.Shipment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Shipment, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.Shipment, error)
+	Update(ctx context.Context, shipment *domain.Shipment) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentService defines the contract for shipment business logic
+type ShipmentService interface {
+	CreateShipment(ctx context.Context, orderID uuid.UUID, req *domain.CreateShipmentRequest) (*domain.Shipment, error)
+	GetShipment(ctx context.Context, id uuid.UUID) (*domain.Shipment, error)
+	GetShipmentsByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.Shipment, error)
+	UpdateShipment(ctx context.Context, id uuid.UUID, req *domain.UpdateShipmentRequest) (*domain.Shipment, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ShippingMethodRepository defines the contract for shipping method data operations
+type ShippingMethodRepository interface {
+	Create(ctx context.Context, method *domain.ShippingMethod) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ShippingMethod, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.ShippingMethod], error)
+	// GetActive returns the methods orders may be shipped by
+	GetActive(ctx context.Context) ([]*domain.ShippingMethod, error)
+	Update(ctx context.Context, method *domain.ShippingMethod) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
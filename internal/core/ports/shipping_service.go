@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ShippingService defines the contract for shipping method business logic
+type ShippingService interface {
+	CreateShippingMethod(ctx context.Context, req *domain.CreateShippingMethodRequest) (*domain.ShippingMethod, error)
+	GetShippingMethod(ctx context.Context, id uuid.UUID) (*domain.ShippingMethod, error)
+	GetShippingMethods(ctx context.Context, page PageRequest) (*Page[*domain.ShippingMethod], error)
+	UpdateShippingMethod(ctx context.Context, id uuid.UUID, req *domain.UpdateShippingMethodRequest) (*domain.ShippingMethod, error)
+	DeleteShippingMethod(ctx context.Context, id uuid.UUID) error
+}
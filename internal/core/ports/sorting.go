@@ -0,0 +1,82 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidSort is returned when a sort names a field its list cannot be sorted by
+var ErrInvalidSort = errors.New("invalid sort")
+
+// SortTerm orders a list by one field, ascending unless Desc is set
+type SortTerm struct {
+	Field string
+	Desc  bool
+}
+
+// Sort orders a list by its terms in turn, each breaking the ties of the one before
+type Sort []SortTerm
+
+// DefaultSort lists newest first, the order of lists that are not given a sort
+var DefaultSort = Sort{{Field: "created_at", Desc: true}}
+
+// String encodes the sort as a comma-separated list of fields, descending ones prefixed
+// with '-', the form ParseSort reads
+func (s Sort) String() string {
+	terms := make([]string, len(s))
+	for i, term := range s {
+		terms[i] = term.Field
+		if term.Desc {
+			terms[i] = "-" + term.Field
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// SortFields is the allow-list of the fields a list can be sorted by
+type SortFields []string
+
+// Sortable fields of each list
+var (
+	UserSortFields           = SortFields{"name", "email", "created_at"}
+	CustomerSortFields       = SortFields{"first_name", "last_name", "email", "created_at"}
+	CategorySortFields       = SortFields{"name", "position", "created_at"}
+	ProductSortFields        = SortFields{"name", "sku", "price", "stock", "created_at"}
+	OrderSortFields          = SortFields{"order_number", "status", "total_amount", "order_date", "created_at"}
+	WarehouseSortFields      = SortFields{"code", "name", "created_at"}
+	SupplierSortFields       = SortFields{"name", "created_at"}
+	PurchaseOrderSortFields  = SortFields{"status", "created_at"}
+	StockMovementSortFields  = SortFields{"created_at"}
+	PromotionSortFields      = SortFields{"name", "priority", "created_at"}
+	TaxRateSortFields        = SortFields{"country", "rate", "created_at"}
+	ShippingMethodSortFields = SortFields{"name", "type", "created_at"}
+)
+
+// ParseSort reads a sort such as "-price,name" against the allow-list. Fields are sorted
+// ascending unless prefixed with '-'; an empty sort returns nil, the default order.
+func (f SortFields) ParseSort(spec string) (Sort, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	var sort Sort
+	for _, raw := range strings.Split(spec, ",") {
+		term := SortTerm{Field: strings.TrimSpace(raw)}
+		if rest, ok := strings.CutPrefix(term.Field, "-"); ok {
+			term.Field, term.Desc = rest, true
+		} else {
+			term.Field = strings.TrimPrefix(term.Field, "+")
+		}
+		if !slices.Contains(f, term.Field) {
+			return nil, fmt.Errorf("%w: cannot sort by %q, sortable fields are %s", ErrInvalidSort, term.Field, strings.Join(f, ", "))
+		}
+		if slices.ContainsFunc(sort, func(t SortTerm) bool { return t.Field == term.Field }) {
+			return nil, fmt.Errorf("%w: %q is sorted by more than once", ErrInvalidSort, term.Field)
+		}
+		sort = append(sort, term)
+	}
+	return sort, nil
+}
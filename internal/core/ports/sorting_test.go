@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	sort, err := ProductSortFields.ParseSort("-price, name")
+	assert.NoError(t, err)
+	assert.Equal(t, Sort{{Field: "price", Desc: true}, {Field: "name"}}, sort)
+	assert.Equal(t, "-price,name", sort.String())
+
+	sort, err = ProductSortFields.ParseSort("")
+	assert.NoError(t, err)
+	assert.Nil(t, sort)
+
+	for _, spec := range []string{"password", "name,-name", "price;drop table products", "-", "name,"} {
+		_, err := ProductSortFields.ParseSort(spec)
+		assert.True(t, errors.Is(err, ErrInvalidSort), spec)
+	}
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// StockMovementRepository defines the contract for reading the stock movement ledger.
+// Movements are written by ProductRepository alongside the stock change they record.
+type StockMovementRepository interface {
+	GetByProductID(ctx context.Context, productID uuid.UUID, page PageRequest) (*Page[*domain.StockMovement], error)
+	GetDiscrepancies(ctx context.Context) ([]*domain.StockDiscrepancy, error)
+	SyncStock(ctx context.Context, productID uuid.UUID) error
+}
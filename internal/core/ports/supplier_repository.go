@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SupplierRepository defines the contract for supplier data operations
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *domain.Supplier) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error)
+	GetByName(ctx context.Context, name string) (*domain.Supplier, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Supplier], error)
+	Update(ctx context.Context, supplier *domain.Supplier) error
+}
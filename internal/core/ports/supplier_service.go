@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SupplierService defines the contract for supplier business logic
+type SupplierService interface {
+	CreateSupplier(ctx context.Context, req *domain.CreateSupplierRequest) (*domain.Supplier, error)
+	GetSupplier(ctx context.Context, id uuid.UUID) (*domain.Supplier, error)
+	GetSuppliers(ctx context.Context, page PageRequest) (*Page[*domain.Supplier], error)
+	UpdateSupplier(ctx context.Context, id uuid.UUID, req *domain.UpdateSupplierRequest) (*domain.Supplier, error)
+}
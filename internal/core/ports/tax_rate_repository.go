@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TaxRateRepository defines the contract for tax rate data operations
+type TaxRateRepository interface {
+	Create(ctx context.Context, rate *domain.TaxRate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.TaxRate], error)
+	// GetByCountry returns the standard rate and category rates of the country
+	GetByCountry(ctx context.Context, country string) ([]*domain.TaxRate, error)
+	Update(ctx context.Context, rate *domain.TaxRate) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
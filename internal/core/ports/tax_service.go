@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TaxService defines the contract for tax rate business logic
+type TaxService interface {
+	CreateTaxRate(ctx context.Context, req *domain.CreateTaxRateRequest) (*domain.TaxRate, error)
+	GetTaxRate(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error)
+	GetTaxRates(ctx context.Context, page PageRequest) (*Page[*domain.TaxRate], error)
+	UpdateTaxRate(ctx context.Context, id uuid.UUID, req *domain.UpdateTaxRateRequest) (*domain.TaxRate, error)
+	DeleteTaxRate(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// TxManager defines the contract for running a unit of work atomically.
+// Repositories invoked with the context passed to fn join the same transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
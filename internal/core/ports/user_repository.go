@@ -12,7 +12,8 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.User], error)
+	Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.UserSearchResult, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
\ No newline at end of file
@@ -11,7 +11,8 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, req *domain.CreateUserRequest) (*domain.User, error)
 	GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	GetUsers(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	GetUsers(ctx context.Context, page PageRequest) (*Page[*domain.User], error)
+	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.UserSearchResult, error)
 	UpdateUser(ctx context.Context, id uuid.UUID, req *domain.UpdateUserRequest) (*domain.User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
\ No newline at end of file
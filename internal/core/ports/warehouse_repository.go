@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// WarehouseRepository defines the contract for warehouse data operations.
+// Saving a warehouse marked default clears the flag on every other warehouse.
+type WarehouseRepository interface {
+	Create(ctx context.Context, warehouse *domain.Warehouse) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error)
+	GetByCode(ctx context.Context, code string) (*domain.Warehouse, error)
+	GetAll(ctx context.Context, page PageRequest) (*Page[*domain.Warehouse], error)
+	Update(ctx context.Context, warehouse *domain.Warehouse) error
+}
+
+// StockLevelRepository defines the contract for per-warehouse stock data operations
+type StockLevelRepository interface {
+	// GetByProductID returns the product's stock levels with their warehouses loaded
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]*domain.StockLevel, error)
+	GetByWarehouseID(ctx context.Context, warehouseID uuid.UUID, limit, offset int) ([]*domain.StockLevel, error)
+	// Set changes the product's quantity at the warehouse, recording the difference as an
+	// adjustment in the ledger. It returns domain.ErrInsufficientStock when lowering the
+	// quantity would eat into reserved stock.
+	Set(ctx context.Context, productID, warehouseID uuid.UUID, quantity int) error
+	// GetOrderAllocations returns, per product and warehouse, the quantity an order
+	// currently holds according to its sale, edit and cancel movements
+	GetOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*domain.StockAllocation, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// WarehouseService defines the contract for warehouse and per-location stock business logic
+type WarehouseService interface {
+	CreateWarehouse(ctx context.Context, req *domain.CreateWarehouseRequest) (*domain.Warehouse, error)
+	GetWarehouse(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error)
+	GetWarehouses(ctx context.Context, page PageRequest) (*Page[*domain.Warehouse], error)
+	UpdateWarehouse(ctx context.Context, id uuid.UUID, req *domain.UpdateWarehouseRequest) (*domain.Warehouse, error)
+	GetWarehouseStock(ctx context.Context, id uuid.UUID, limit, offset int) ([]*domain.StockLevel, error)
+	GetStockLevels(ctx context.Context, productID uuid.UUID) ([]*domain.StockLevel, error)
+	SetStockLevel(ctx context.Context, productID, warehouseID uuid.UUID, req *domain.SetStockLevelRequest) ([]*domain.StockLevel, error)
+}
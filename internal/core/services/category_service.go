@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"silbackendassessment/internal/core/domain"
@@ -13,12 +14,14 @@ import (
 
 type categoryService struct {
 	categoryRepo ports.CategoryRepository
+	txManager    ports.TxManager
 }
 
 // NewCategoryService creates a new category service
-func NewCategoryService(categoryRepo ports.CategoryRepository) ports.CategoryService {
+func NewCategoryService(categoryRepo ports.CategoryRepository, txManager ports.TxManager) ports.CategoryService {
 	return &categoryService{
 		categoryRepo: categoryRepo,
+		txManager:    txManager,
 	}
 }
 
@@ -38,16 +41,41 @@ func (s *categoryService) CreateCategory(ctx context.Context, req *domain.Create
 		if parentCategory == nil {
 			return nil, fmt.Errorf("parent category not found")
 		}
+		if err := parentCategory.CanHaveChildren(); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create new category
 	category := &domain.Category{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Description: req.Description,
-		ParentID:    req.ParentID,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:              uuid.New(),
+		Name:            req.Name,
+		Description:     req.Description,
+		ParentID:        req.ParentID,
+		Slug:            req.Slug,
+		Position:        req.Position,
+		ImageURL:        req.ImageURL,
+		MetaTitle:       req.MetaTitle,
+		MetaDescription: req.MetaDescription,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	if err := category.ValidateMetadata(); err != nil {
+		return nil, err
+	}
+
+	// Generate the slug from the name unless one was chosen
+	if category.Slug == "" {
+		if category.Slug, err = s.freeSlug(ctx, req.ParentID, domain.Slugify(req.Name)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := domain.ValidateSlug(category.Slug); err != nil {
+			return nil, err
+		}
+		if err := s.checkSlugFree(ctx, req.ParentID, category.Slug, category.ID); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.categoryRepo.Create(ctx, category); err != nil {
@@ -70,8 +98,8 @@ func (s *categoryService) GetCategory(ctx context.Context, id uuid.UUID) (*domai
 	return category, nil
 }
 
-func (s *categoryService) GetCategories(ctx context.Context, limit, offset int) ([]*domain.Category, error) {
-	categories, err := s.categoryRepo.GetAll(ctx, limit, offset)
+func (s *categoryService) GetCategories(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Category], error) {
+	categories, err := s.categoryRepo.GetAll(ctx, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories: %w", err)
 	}
@@ -97,6 +125,83 @@ func (s *categoryService) GetSubCategories(ctx context.Context, parentID uuid.UU
 	return categories, nil
 }
 
+func (s *categoryService) GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Category, error) {
+	if _, err := s.GetCategory(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.categoryRepo.GetAncestors(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category ancestors: %w", err)
+	}
+
+	return ancestors, nil
+}
+
+// GetCategoryTree returns the category with its whole subtree nested in Children
+func (s *categoryService) GetCategoryTree(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
+	category, err := s.GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := s.categoryRepo.GetDescendants(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category subtree: %w", err)
+	}
+
+	return domain.BuildCategoryTree(category, descendants), nil
+}
+
+// GetCategoryByPath finds the category a slug path such as "electronics/phones" leads to,
+// following the slugs categories answered to before they were renamed or moved
+func (s *categoryService) GetCategoryByPath(ctx context.Context, path string) (*domain.CategoryPathLookup, error) {
+	slugs := domain.SplitSlugPath(strings.ToLower(path))
+	if len(slugs) == 0 {
+		return nil, fmt.Errorf("%w: empty category path", domain.ErrInvalidSlug)
+	}
+
+	var category *domain.Category
+	for _, slug := range slugs {
+		var parentID *uuid.UUID
+		if category != nil {
+			parentID = &category.ID
+		}
+		next, err := s.categoryRepo.GetBySlug(ctx, parentID, slug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		if next == nil {
+			if next, err = s.categoryRepo.GetBySlugRedirect(ctx, parentID, slug); err != nil {
+				return nil, fmt.Errorf("failed to get category: %w", err)
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("category not found")
+		}
+		category = next
+	}
+
+	// A redirect may lead anywhere in the tree, so the canonical path is rebuilt from the
+	// ancestors of the category found
+	ancestors, err := s.categoryRepo.GetAncestors(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category ancestors: %w", err)
+	}
+	canonical := make([]string, 0, len(ancestors)+1)
+	for _, ancestor := range ancestors {
+		canonical = append(canonical, ancestor.Slug)
+	}
+	canonical = append(canonical, category.Slug)
+
+	canonicalPath := strings.Join(canonical, "/")
+	return &domain.CategoryPathLookup{
+		Category:      category,
+		CanonicalPath: canonicalPath,
+		Redirected:    canonicalPath != strings.Join(domain.SplitSlugPath(path), "/"),
+	}, nil
+}
+
 func (s *categoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req *domain.UpdateCategoryRequest) (*domain.Category, error) {
 	category, err := s.categoryRepo.GetByID(ctx, id)
 	if err != nil {
@@ -107,6 +212,27 @@ func (s *categoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req
 		return nil, fmt.Errorf("category not found")
 	}
 
+	oldParentID, oldSlug := category.ParentID, category.Slug
+	parentID, moving := category.ParentID, false
+	if req.ParentID != nil {
+		parentID, moving = req.ParentID, true
+		if *parentID == uuid.Nil {
+			parentID = nil
+		}
+	}
+	slug := category.Slug
+	if req.Slug != nil && *req.Slug != category.Slug {
+		if err := domain.ValidateSlug(*req.Slug); err != nil {
+			return nil, err
+		}
+		slug = *req.Slug
+		if !moving {
+			if err := s.checkSlugFree(ctx, parentID, slug, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Update fields if provided
 	if req.Name != nil {
 		category.Name = *req.Name
@@ -114,26 +240,142 @@ func (s *categoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req
 	if req.Description != nil {
 		category.Description = *req.Description
 	}
-	if req.ParentID != nil {
-		// Validate parent category if provided
-		if *req.ParentID != uuid.Nil {
-			parentCategory, err := s.categoryRepo.GetByID(ctx, *req.ParentID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get parent category: %w", err)
-			}
-			if parentCategory == nil {
-				return nil, fmt.Errorf("parent category not found")
+	if req.Position != nil {
+		category.Position = *req.Position
+	}
+	if req.ImageURL != nil {
+		category.ImageURL = *req.ImageURL
+	}
+	if req.MetaTitle != nil {
+		category.MetaTitle = *req.MetaTitle
+	}
+	if req.MetaDescription != nil {
+		category.MetaDescription = *req.MetaDescription
+	}
+	if err := category.ValidateMetadata(); err != nil {
+		return nil, err
+	}
+
+	// The move, the new fields and the redirect from the old slug are saved together, so
+	// that a failure leaves the category where and as it was
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if moving {
+			if err := s.move(ctx, category, parentID, slug); err != nil {
+				return err
 			}
+			category.ParentID = parentID
+		}
+
+		category.Slug = slug
+		category.UpdatedAt = time.Now()
+
+		if err := s.categoryRepo.Update(ctx, category); err != nil {
+			return fmt.Errorf("failed to update category: %w", err)
+		}
+		return s.keepSlugRedirect(ctx, category, oldParentID, oldSlug)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCategory(ctx, id)
+}
+
+// MoveCategory puts a category and its subtree under a new parent, refusing to move it
+// under itself or one of its descendants, or next to a sibling with the same slug
+func (s *categoryService) MoveCategory(ctx context.Context, id uuid.UUID, req *domain.MoveCategoryRequest) (*domain.Category, error) {
+	category, err := s.GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldParentID := category.ParentID
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.move(ctx, category, req.ParentID, category.Slug); err != nil {
+			return err
 		}
 		category.ParentID = req.ParentID
+		return s.keepSlugRedirect(ctx, category, oldParentID, category.Slug)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCategory(ctx, id)
+}
+
+// move puts the category under parentID, where it will answer to slug
+func (s *categoryService) move(ctx context.Context, category *domain.Category, parentID *uuid.UUID, slug string) error {
+	var parent *domain.Category
+	if parentID != nil {
+		var err error
+		parent, err = s.categoryRepo.GetByID(ctx, *parentID)
+		if err != nil {
+			return fmt.Errorf("failed to get parent category: %w", err)
+		}
+		if parent == nil {
+			return fmt.Errorf("parent category not found")
+		}
+	}
+	if err := category.CanMoveUnder(parent); err != nil {
+		return err
+	}
+	if err := s.checkSlugFree(ctx, parentID, slug, category.ID); err != nil {
+		return err
 	}
-	category.UpdatedAt = time.Now()
 
-	if err := s.categoryRepo.Update(ctx, category); err != nil {
-		return nil, fmt.Errorf("failed to update category: %w", err)
+	if err := s.categoryRepo.Move(ctx, category.ID, parentID); err != nil {
+		return fmt.Errorf("failed to move category: %w", err)
 	}
+	return nil
+}
 
-	return category, nil
+// checkSlugFree returns ErrSlugTaken when a category other than id has the slug under parentID
+func (s *categoryService) checkSlugFree(ctx context.Context, parentID *uuid.UUID, slug string, id uuid.UUID) error {
+	owner, err := s.categoryRepo.GetBySlug(ctx, parentID, slug)
+	if err != nil {
+		return fmt.Errorf("failed to check slug: %w", err)
+	}
+	if owner != nil && owner.ID != id {
+		return fmt.Errorf("%w: %s", domain.ErrSlugTaken, slug)
+	}
+	return nil
+}
+
+// freeSlug returns the first numbered form of slug no category uses under parentID
+func (s *categoryService) freeSlug(ctx context.Context, parentID *uuid.UUID, slug string) (string, error) {
+	for n := 1; ; n++ {
+		candidate := domain.NumberedSlug(slug, n)
+		owner, err := s.categoryRepo.GetBySlug(ctx, parentID, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if owner == nil {
+			return candidate, nil
+		}
+	}
+}
+
+// keepSlugRedirect keeps the slug the category answered to under its old parent as a
+// redirect, when it now answers to another slug or lives under another parent
+func (s *categoryService) keepSlugRedirect(ctx context.Context, category *domain.Category, oldParentID *uuid.UUID, oldSlug string) error {
+	sameParent := (oldParentID == nil && category.ParentID == nil) ||
+		(oldParentID != nil && category.ParentID != nil && *oldParentID == *category.ParentID)
+	if sameParent && oldSlug == category.Slug {
+		return nil
+	}
+
+	redirect := &domain.CategorySlugRedirect{
+		ID:         uuid.New(),
+		CategoryID: category.ID,
+		ParentID:   oldParentID,
+		Slug:       oldSlug,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.categoryRepo.AddSlugRedirect(ctx, redirect); err != nil {
+		return fmt.Errorf("failed to keep old slug: %w", err)
+	}
+	return nil
 }
 
 func (s *categoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
 	"silbackendassessment/internal/testutils"
 
 	"github.com/google/uuid"
@@ -14,7 +15,7 @@ import (
 
 func TestCategoryService_CreateCategory(t *testing.T) {
 	mockRepo := testutils.NewMockCategoryRepository()
-	service := NewCategoryService(mockRepo)
+	service := NewCategoryService(mockRepo, testutils.NewMockTxManager())
 	ctx := context.Background()
 
 	t.Run("Create category successfully", func(t *testing.T) {
@@ -60,6 +61,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 		parentCategory := &domain.Category{
 			ID:        parentID,
 			Name:      "Parent Category",
+			Path:      domain.CategoryPath("", parentID),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
@@ -162,7 +164,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 
 func TestCategoryService_GetCategory(t *testing.T) {
 	mockRepo := testutils.NewMockCategoryRepository()
-	service := NewCategoryService(mockRepo)
+	service := NewCategoryService(mockRepo, testutils.NewMockTxManager())
 	ctx := context.Background()
 
 	t.Run("Get existing category", func(t *testing.T) {
@@ -227,7 +229,7 @@ func TestCategoryService_GetCategory(t *testing.T) {
 
 func TestCategoryService_GetCategories(t *testing.T) {
 	mockRepo := testutils.NewMockCategoryRepository()
-	service := NewCategoryService(mockRepo)
+	service := NewCategoryService(mockRepo, testutils.NewMockTxManager())
 	ctx := context.Background()
 
 	t.Run("Get categories successfully", func(t *testing.T) {
@@ -247,21 +249,21 @@ func TestCategoryService_GetCategories(t *testing.T) {
 		}
 		mockRepo.AllCategories = categories
 
-		result, err := service.GetCategories(ctx, 10, 0)
+		result, err := service.GetCategories(ctx, ports.PageRequest{Limit: 10})
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
 		}
 
-		if len(result) != 2 {
-			t.Errorf("Expected 2 categories, got: %d", len(result))
+		if len(result.Items) != 2 {
+			t.Errorf("Expected 2 categories, got: %d", len(result.Items))
 		}
 	})
 
 	t.Run("Repository error", func(t *testing.T) {
 		mockRepo.GetAllError = errors.New("database error")
 
-		categories, err := service.GetCategories(ctx, 10, 0)
+		categories, err := service.GetCategories(ctx, ports.PageRequest{Limit: 10})
 
 		if err == nil {
 			t.Error("Expected error from repository")
@@ -275,7 +277,7 @@ func TestCategoryService_GetCategories(t *testing.T) {
 
 func TestCategoryService_UpdateCategory(t *testing.T) {
 	mockRepo := testutils.NewMockCategoryRepository()
-	service := NewCategoryService(mockRepo)
+	service := NewCategoryService(mockRepo, testutils.NewMockTxManager())
 	ctx := context.Background()
 
 	t.Run("Update category successfully", func(t *testing.T) {
@@ -367,7 +369,7 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 
 func TestCategoryService_DeleteCategory(t *testing.T) {
 	mockRepo := testutils.NewMockCategoryRepository()
-	service := NewCategoryService(mockRepo)
+	service := NewCategoryService(mockRepo, testutils.NewMockTxManager())
 	ctx := context.Background()
 
 	t.Run("Delete category successfully", func(t *testing.T) {
@@ -424,3 +426,220 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 		}
 	})
 }
+
+func TestCategoryService_MoveCategory(t *testing.T) {
+	mockRepo := testutils.NewMockCategoryRepository()
+	mockTxManager := testutils.NewMockTxManager()
+	service := NewCategoryService(mockRepo, mockTxManager)
+	ctx := context.Background()
+
+	create := func(name string, parentID *uuid.UUID) *domain.Category {
+		category, err := service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: name, ParentID: parentID})
+		if err != nil {
+			t.Fatalf("Expected no error creating %s, got: %v", name, err)
+		}
+		return category
+	}
+	electronics := create("Electronics", nil)
+	phones := create("Phones", &electronics.ID)
+	android := create("Android", &phones.ID)
+	gadgets := create("Gadgets", nil)
+
+	t.Run("Move a subtree under another category", func(t *testing.T) {
+		moved, err := service.MoveCategory(ctx, phones.ID, &domain.MoveCategoryRequest{ParentID: &gadgets.ID})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if moved.ParentID == nil || *moved.ParentID != gadgets.ID {
+			t.Errorf("Expected ParentID to be %s, got: %v", gadgets.ID, moved.ParentID)
+		}
+
+		ancestors, err := service.GetCategoryAncestors(ctx, android.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(ancestors) != 2 || ancestors[0].ID != gadgets.ID || ancestors[1].ID != phones.ID {
+			t.Errorf("Expected the descendant's ancestors to follow the move, got: %v", ancestors)
+		}
+	})
+
+	t.Run("Refuse to move a category under its descendant", func(t *testing.T) {
+		_, err := service.MoveCategory(ctx, gadgets.ID, &domain.MoveCategoryRequest{ParentID: &android.ID})
+
+		if !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Errorf("Expected ErrCategoryCycle, got: %v", err)
+		}
+
+		parentID := phones.ID
+		_, err = service.UpdateCategory(ctx, phones.ID, &domain.UpdateCategoryRequest{ParentID: &parentID})
+
+		if !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Errorf("Expected ErrCategoryCycle when made its own parent, got: %v", err)
+		}
+	})
+
+	t.Run("Move to the root", func(t *testing.T) {
+		root := uuid.Nil
+		moved, err := service.UpdateCategory(ctx, phones.ID, &domain.UpdateCategoryRequest{ParentID: &root})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if moved.ParentID != nil || moved.Depth() != 0 {
+			t.Errorf("Expected a root category, got parent %v at depth %d", moved.ParentID, moved.Depth())
+		}
+	})
+
+	t.Run("A failed redirect does not commit the move", func(t *testing.T) {
+		mockRepo.RedirectError = errors.New("connection reset")
+		defer func() { mockRepo.RedirectError = nil }()
+		commits := mockTxManager.CommitCount
+
+		_, err := service.MoveCategory(ctx, gadgets.ID, &domain.MoveCategoryRequest{ParentID: &electronics.ID})
+
+		if err == nil {
+			t.Fatal("Expected error when the old slug cannot be kept")
+		}
+		if mockTxManager.CommitCount != commits {
+			t.Error("Expected the move to be rolled back with the redirect")
+		}
+	})
+
+	t.Run("Move a category out of a parent cycle", func(t *testing.T) {
+		// Categories caught in a cycle before paths were added are left without one
+		looped := &domain.Category{ID: uuid.New(), Name: "Looped", Slug: "looped"}
+		other := &domain.Category{ID: uuid.New(), Name: "Other", Slug: "other", ParentID: &looped.ID}
+		child := &domain.Category{ID: uuid.New(), Name: "Child", Slug: "child", ParentID: &other.ID}
+		looped.ParentID = &other.ID
+		for _, c := range []*domain.Category{looped, other, child} {
+			mockRepo.Categories[c.ID] = c
+		}
+
+		_, err := service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: "Stray", ParentID: &other.ID})
+		if !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Errorf("Expected ErrCategoryCycle creating under a looped category, got: %v", err)
+		}
+		_, err = service.MoveCategory(ctx, gadgets.ID, &domain.MoveCategoryRequest{ParentID: &other.ID})
+		if !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Errorf("Expected ErrCategoryCycle moving under a looped category, got: %v", err)
+		}
+
+		if _, err := service.MoveCategory(ctx, looped.ID, &domain.MoveCategoryRequest{ParentID: &electronics.ID}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if want := domain.CategoryPath(electronics.Path, looped.ID); looped.Path != want {
+			t.Errorf("Expected path %s, got: %s", want, looped.Path)
+		}
+		if child.Depth() != 3 || other.Depth() != 2 {
+			t.Errorf("Expected the categories below to get paths too, got depths %d and %d", other.Depth(), child.Depth())
+		}
+		if gadgets.Depth() != 0 {
+			t.Errorf("Expected other categories to keep their paths, got depth %d", gadgets.Depth())
+		}
+	})
+
+	t.Run("Get the whole subtree", func(t *testing.T) {
+		tree, err := service.GetCategoryTree(ctx, phones.ID)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(tree.Children) != 1 || tree.Children[0].ID != android.ID {
+			t.Errorf("Expected Android under Phones, got: %v", tree.Children)
+		}
+	})
+}
+
+func TestCategoryService_Slugs(t *testing.T) {
+	mockRepo := testutils.NewMockCategoryRepository()
+	service := NewCategoryService(mockRepo, testutils.NewMockTxManager())
+	ctx := context.Background()
+
+	electronics, err := service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: "Electronics & Gadgets"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	phones, err := service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: "Phones", ParentID: &electronics.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	t.Run("Generate slugs from names", func(t *testing.T) {
+		if electronics.Slug != "electronics-and-gadgets" {
+			t.Errorf("Expected slug electronics-and-gadgets, got: %s", electronics.Slug)
+		}
+
+		other, err := service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: "Phones!", ParentID: &electronics.ID})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if other.Slug != "phones-2" {
+			t.Errorf("Expected the taken slug to be numbered, got: %s", other.Slug)
+		}
+	})
+
+	t.Run("Refuse a slug taken by a sibling", func(t *testing.T) {
+		_, err := service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: "Mobiles", Slug: "phones", ParentID: &electronics.ID})
+
+		if !errors.Is(err, domain.ErrSlugTaken) {
+			t.Errorf("Expected ErrSlugTaken, got: %v", err)
+		}
+
+		_, err = service.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: "Mobiles", Slug: "Mobiles!"})
+
+		if !errors.Is(err, domain.ErrInvalidSlug) {
+			t.Errorf("Expected ErrInvalidSlug, got: %v", err)
+		}
+	})
+
+	t.Run("Find a category by its slug path", func(t *testing.T) {
+		lookup, err := service.GetCategoryByPath(ctx, "/electronics-and-gadgets/phones/")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if lookup.Category.ID != phones.ID || lookup.Redirected {
+			t.Errorf("Expected Phones without a redirect, got: %+v", lookup)
+		}
+
+		if _, err := service.GetCategoryByPath(ctx, "electronics-and-gadgets/tablets"); err == nil {
+			t.Error("Expected an error for an unknown path, got nil")
+		}
+	})
+
+	t.Run("Redirect old slugs after a rename and a move", func(t *testing.T) {
+		slug := "electronics"
+		if _, err := service.UpdateCategory(ctx, electronics.ID, &domain.UpdateCategoryRequest{Slug: &slug}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := service.MoveCategory(ctx, phones.ID, &domain.MoveCategoryRequest{}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		lookup, err := service.GetCategoryByPath(ctx, "Electronics-And-Gadgets/Phones")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if lookup.Category.ID != phones.ID || !lookup.Redirected || lookup.CanonicalPath != "phones" {
+			t.Errorf("Expected a redirect to phones, got: %+v", lookup)
+		}
+	})
+
+	t.Run("Refuse invalid metadata", func(t *testing.T) {
+		imageURL := "javascript:alert(1)"
+		_, err := service.UpdateCategory(ctx, phones.ID, &domain.UpdateCategoryRequest{ImageURL: &imageURL})
+
+		if !errors.Is(err, domain.ErrInvalidCategoryMetadata) {
+			t.Errorf("Expected ErrInvalidCategoryMetadata, got: %v", err)
+		}
+	})
+}
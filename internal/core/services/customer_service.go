@@ -65,8 +65,8 @@ func (s *customerService) GetCustomer(ctx context.Context, id uuid.UUID) (*domai
 	return customer, nil
 }
 
-func (s *customerService) GetCustomers(ctx context.Context, limit, offset int) ([]*domain.Customer, error) {
-	customers, err := s.customerRepo.GetAll(ctx, limit, offset)
+func (s *customerService) GetCustomers(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Customer], error) {
+	customers, err := s.customerRepo.GetAll(ctx, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get customers: %w", err)
 	}
@@ -74,6 +74,22 @@ func (s *customerService) GetCustomers(ctx context.Context, limit, offset int) (
 	return customers, nil
 }
 
+// SearchCustomers finds the customers with words in their name, email or phone number starting with
+// every word of the query, best match first
+func (s *customerService) SearchCustomers(ctx context.Context, query string, limit, offset int) ([]*domain.CustomerSearchResult, error) {
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, domain.ErrEmptySearch
+	}
+
+	results, err := s.customerRepo.Search(ctx, terms, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search customers: %w", err)
+	}
+
+	return results, nil
+}
+
 func (s *customerService) UpdateCustomer(ctx context.Context, id uuid.UUID, req *domain.UpdateCustomerRequest) (*domain.Customer, error) {
 	customer, err := s.customerRepo.GetByID(ctx, id)
 	if err != nil {
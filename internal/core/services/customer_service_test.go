@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
 	"silbackendassessment/internal/testutils"
 
 	"github.com/google/uuid"
@@ -216,21 +217,21 @@ func TestCustomerService_GetCustomers(t *testing.T) {
 		}
 		mockRepo.AllCustomers = customers
 
-		result, err := service.GetCustomers(ctx, 10, 0)
+		result, err := service.GetCustomers(ctx, ports.PageRequest{Limit: 10})
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
 		}
 
-		if len(result) != 2 {
-			t.Errorf("Expected 2 customers, got: %d", len(result))
+		if len(result.Items) != 2 {
+			t.Errorf("Expected 2 customers, got: %d", len(result.Items))
 		}
 	})
 
 	t.Run("Repository error", func(t *testing.T) {
 		mockRepo.GetAllError = errors.New("database error")
 
-		customers, err := service.GetCustomers(ctx, 10, 0)
+		customers, err := service.GetCustomers(ctx, ports.PageRequest{Limit: 10})
 
 		if err == nil {
 			t.Error("Expected error from repository")
@@ -242,6 +243,49 @@ func TestCustomerService_GetCustomers(t *testing.T) {
 	})
 }
 
+func TestCustomerService_SearchCustomers(t *testing.T) {
+	mockRepo := testutils.NewMockCustomerRepository()
+	service := NewCustomerService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.AllCustomers = []*domain.Customer{
+		{ID: uuid.New(), FirstName: "Jane", LastName: "Wanjiru", Email: "jane@example.com", Phone: "+254700000001"},
+		{ID: uuid.New(), FirstName: "John", LastName: "Otieno", Email: "john@example.com", Phone: "+254700000002"},
+	}
+
+	t.Run("Search by partial name", func(t *testing.T) {
+		results, err := service.SearchCustomers(ctx, "jan wan", 10, 0)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if len(results) != 1 || results[0].Customer.FirstName != "Jane" {
+			t.Errorf("Expected Jane to match, got: %v", results)
+		}
+	})
+
+	t.Run("Search by phone", func(t *testing.T) {
+		results, err := service.SearchCustomers(ctx, "+254700000002", 10, 0)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if len(results) != 1 || results[0].Customer.FirstName != "John" {
+			t.Errorf("Expected John to match, got: %v", results)
+		}
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		_, err := service.SearchCustomers(ctx, " ,. ", 10, 0)
+
+		if !errors.Is(err, domain.ErrEmptySearch) {
+			t.Errorf("Expected ErrEmptySearch, got: %v", err)
+		}
+	})
+}
+
 func TestCustomerService_UpdateCustomer(t *testing.T) {
 	mockRepo := testutils.NewMockCustomerRepository()
 	service := NewCustomerService(mockRepo)
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type inventoryService struct {
+	productRepo       ports.ProductRepository
+	stockMovementRepo ports.StockMovementRepository
+	txManager         ports.TxManager
+}
+
+// NewInventoryService creates a new inventory service
+func NewInventoryService(
+	productRepo ports.ProductRepository,
+	stockMovementRepo ports.StockMovementRepository,
+	txManager ports.TxManager,
+) ports.InventoryService {
+	return &inventoryService{
+		productRepo:       productRepo,
+		stockMovementRepo: stockMovementRepo,
+		txManager:         txManager,
+	}
+}
+
+func (s *inventoryService) GetStockMovements(ctx context.Context, productID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.StockMovement], error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	movements, err := s.stockMovementRepo.GetByProductID(ctx, productID, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// ReconcileStock compares every product's stock with its ledger. When fix is set,
+// the ledger is treated as the source of truth and the stock is reset to match it.
+func (s *inventoryService) ReconcileStock(ctx context.Context, fix bool) ([]*domain.StockDiscrepancy, error) {
+	var discrepancies []*domain.StockDiscrepancy
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		discrepancies, err = s.stockMovementRepo.GetDiscrepancies(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get stock discrepancies: %w", err)
+		}
+		if !fix {
+			return nil
+		}
+
+		for _, d := range discrepancies {
+			if err := s.stockMovementRepo.SyncStock(ctx, d.ProductID); err != nil {
+				return fmt.Errorf("failed to sync stock for product %s: %w", d.SKU, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return discrepancies, nil
+}
+
+// GetReorderSuggestions lists active products below their reorder point with how much of each to order
+func (s *inventoryService) GetReorderSuggestions(ctx context.Context, limit, offset int) ([]*domain.ReorderSuggestion, error) {
+	products, err := s.productRepo.GetLowStock(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get low stock products: %w", err)
+	}
+
+	suggestions := make([]*domain.ReorderSuggestion, 0, len(products))
+	for _, product := range products {
+		suggestions = append(suggestions, domain.NewReorderSuggestion(product))
+	}
+
+	return suggestions, nil
+}
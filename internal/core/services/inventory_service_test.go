@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestInventoryService_GetStockMovements(t *testing.T) {
+	ctx := context.Background()
+
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockStockMovementRepo := testutils.NewMockStockMovementRepository()
+	service := NewInventoryService(mockProductRepo, mockStockMovementRepo, testutils.NewMockTxManager())
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Product 1", Stock: 8}
+	mockStockMovementRepo.Movements = []*domain.StockMovement{
+		domain.NewStockMovement(productID, 10, domain.StockMovementReasonInitial, nil),
+		domain.NewStockMovement(uuid.New(), 3, domain.StockMovementReasonInitial, nil),
+		domain.NewStockMovement(productID, -2, domain.StockMovementReasonSale, nil),
+	}
+
+	t.Run("Returns the product's ledger", func(t *testing.T) {
+		movements, err := service.GetStockMovements(ctx, productID, ports.PageRequest{Limit: 10})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(movements.Items) != 2 {
+			t.Fatalf("Expected 2 movements, got: %d", len(movements.Items))
+		}
+	})
+
+	t.Run("Unknown product", func(t *testing.T) {
+		_, err := service.GetStockMovements(ctx, uuid.New(), ports.PageRequest{Limit: 10})
+		if err == nil {
+			t.Error("Expected error for unknown product")
+		}
+	})
+}
+
+func TestInventoryService_ReconcileStock(t *testing.T) {
+	ctx := context.Background()
+
+	mockStockMovementRepo := testutils.NewMockStockMovementRepository()
+	service := NewInventoryService(testutils.NewMockProductRepository(), mockStockMovementRepo, testutils.NewMockTxManager())
+
+	productID := uuid.New()
+	mockStockMovementRepo.Discrepancies = []*domain.StockDiscrepancy{
+		{ProductID: productID, SKU: "SKU-1", Stock: 7, LedgerStock: 5},
+	}
+
+	t.Run("Report only", func(t *testing.T) {
+		discrepancies, err := service.ReconcileStock(ctx, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(discrepancies) != 1 || discrepancies[0].Difference() != 2 {
+			t.Errorf("Expected one discrepancy of 2, got: %v", discrepancies)
+		}
+		if len(mockStockMovementRepo.Synced) != 0 {
+			t.Errorf("Expected no stock to be synced, got: %v", mockStockMovementRepo.Synced)
+		}
+	})
+
+	t.Run("Fix resets stock to the ledger", func(t *testing.T) {
+		if _, err := service.ReconcileStock(ctx, true); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(mockStockMovementRepo.Synced) != 1 || mockStockMovementRepo.Synced[0] != productID {
+			t.Errorf("Expected product %s to be synced, got: %v", productID, mockStockMovementRepo.Synced)
+		}
+	})
+}
+
+func TestInventoryService_GetReorderSuggestions(t *testing.T) {
+	ctx := context.Background()
+
+	mockProductRepo := testutils.NewMockProductRepository()
+	service := NewInventoryService(mockProductRepo, testutils.NewMockStockMovementRepository(), testutils.NewMockTxManager())
+
+	low := &domain.Product{ID: uuid.New(), SKU: "LOW", Stock: 3, ReorderPoint: 10, ReorderQuantity: 20, IsActive: true}
+	mockProductRepo.AllProducts = []*domain.Product{
+		low,
+		{ID: uuid.New(), SKU: "OK", Stock: 15, ReorderPoint: 10, IsActive: true},
+		{ID: uuid.New(), SKU: "INACTIVE", Stock: 0, ReorderPoint: 10, IsActive: false},
+	}
+
+	suggestions, err := service.GetReorderSuggestions(ctx, 50, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].ProductID != low.ID {
+		t.Fatalf("Expected only the low stock product, got: %v", suggestions)
+	}
+	if suggestions[0].SuggestedQuantity != 20 {
+		t.Errorf("Expected a suggested quantity of 20, got: %d", suggestions[0].SuggestedQuantity)
+	}
+}
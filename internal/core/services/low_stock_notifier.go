@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+)
+
+type lowStockNotifier struct {
+	notificationService ports.NotificationService
+	recipients          []string
+}
+
+// NewLowStockNotifier creates a notifier that emails low-stock alerts to the given staff
+// addresses. It does nothing when there are no recipients.
+func NewLowStockNotifier(notificationService ports.NotificationService, recipients []string) ports.LowStockNotifier {
+	return &lowStockNotifier{
+		notificationService: notificationService,
+		recipients:          recipients,
+	}
+}
+
+// NotifyLowStock sends one alert listing every product in events
+func (n *lowStockNotifier) NotifyLowStock(ctx context.Context, events []*domain.LowStockEvent) {
+	if len(events) == 0 || len(n.recipients) == 0 || n.notificationService == nil {
+		return
+	}
+
+	subject := fmt.Sprintf("Low Stock Alert - %s", events[0].SKU)
+	if len(events) > 1 {
+		subject = fmt.Sprintf("Low Stock Alert - %d products", len(events))
+	}
+
+	var body strings.Builder
+	body.WriteString("The following products have dropped below their reorder point:\n\n")
+	for _, e := range events {
+		fmt.Fprintf(&body, "- %s (%s): %d in stock, reorder point %d, suggested reorder %d\n",
+			e.Name, e.SKU, e.Stock, e.ReorderPoint, e.ReorderQuantity)
+	}
+	body.WriteString("\nSee /api/inventory/reorder-suggestions for the full reorder report.")
+
+	if err := n.notificationService.SendBulkEmail(ctx, n.recipients, subject, body.String()); err != nil {
+		log.Printf("failed to send low stock alert for %d products: %v", len(events), err)
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestLowStockNotifier(t *testing.T) {
+	ctx := context.Background()
+	events := []*domain.LowStockEvent{
+		{ProductID: uuid.New(), SKU: "SKU-1", Name: "Widget", PreviousStock: 12, Stock: 4, ReorderPoint: 10, ReorderQuantity: 20},
+		{ProductID: uuid.New(), SKU: "SKU-2", Name: "Gadget", PreviousStock: 5, Stock: 1, ReorderPoint: 5, ReorderQuantity: 10},
+	}
+
+	t.Run("Emails every recipient", func(t *testing.T) {
+		mockNotifications := testutils.NewMockNotificationService()
+		notifier := NewLowStockNotifier(mockNotifications, []string{"stock@example.com", "buyer@example.com"})
+
+		notifier.NotifyLowStock(ctx, events)
+
+		if len(mockNotifications.SentEmails) != 2 {
+			t.Fatalf("Expected 2 emails, got: %d", len(mockNotifications.SentEmails))
+		}
+		email := mockNotifications.SentEmails[0]
+		if email.To != "stock@example.com" {
+			t.Errorf("Expected email to stock@example.com, got: %s", email.To)
+		}
+		if !strings.Contains(email.Body, "SKU-1") || !strings.Contains(email.Body, "SKU-2") {
+			t.Errorf("Expected alert to list both products, got: %s", email.Body)
+		}
+	})
+
+	t.Run("No recipients sends nothing", func(t *testing.T) {
+		mockNotifications := testutils.NewMockNotificationService()
+		notifier := NewLowStockNotifier(mockNotifications, nil)
+
+		notifier.NotifyLowStock(ctx, events)
+
+		if len(mockNotifications.SentEmails) != 0 {
+			t.Errorf("Expected no emails, got: %d", len(mockNotifications.SentEmails))
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 
+	"silbackendassessment/internal/core/domain"
 	"silbackendassessment/internal/core/ports"
 )
 
@@ -117,12 +118,15 @@ Order Date: %s
 Order Items:
 `, customerName, orderNumber, fmt.Sprintf("%d-%02d-%02d", 2024, 1, 1)) // You can get actual date from order
 
-	total := 0.0
+	var total domain.Money
 	for _, item := range orderItems {
-		body += fmt.Sprintf("- %s x%d @ $%.2f = $%.2f\n", item.ProductName, item.Quantity, item.UnitPrice, item.TotalPrice)
-		total += item.TotalPrice
+		body += fmt.Sprintf("- %s x%d @ %s = %s\n", item.ProductName, item.Quantity, item.UnitPrice, item.TotalPrice)
+		var err error
+		if total, err = total.Add(item.TotalPrice); err != nil {
+			return fmt.Errorf("failed to calculate order total: %w", err)
+		}
 	}
-	body += fmt.Sprintf("\nTotal: $%.2f\n\nThank you for your business!", total)
+	body += fmt.Sprintf("\nTotal: %s\n\nThank you for your business!", total)
 
 	// Create HTML body
 	htmlBody := fmt.Sprintf(`
@@ -162,8 +166,8 @@ Order Items:
                 <tr>
                     <td style="padding: 10px; border: 1px solid #dee2e6;">%s</td>
                     <td style="padding: 10px; text-align: center; border: 1px solid #dee2e6;">%d</td>
-                    <td style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">$%.2f</td>
-                    <td style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">$%.2f</td>
+                    <td style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">%s</td>
+                    <td style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">%s</td>
                 </tr>`, item.ProductName, item.Quantity, item.UnitPrice, item.TotalPrice)
 	}
 
@@ -172,7 +176,7 @@ Order Items:
             <tfoot>
                 <tr style="background-color: #f8f9fa; font-weight: bold;">
                     <td colspan="3" style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">Total:</td>
-                    <td style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">$%.2f</td>
+                    <td style="padding: 10px; text-align: right; border: 1px solid #dee2e6;">%s</td>
                 </tr>
             </tfoot>
         </table>
@@ -189,8 +193,8 @@ Order Items:
 }
 
 // SendOrderConfirmationSMS sends an order confirmation SMS
-func (s *NotificationService) SendOrderConfirmationSMS(ctx context.Context, phoneNumber, customerName, orderNumber string, totalAmount float64) error {
-	message := fmt.Sprintf("Hi %s! Your order #%s has been confirmed. Total: $%.2f. Thank you for your business!",
+func (s *NotificationService) SendOrderConfirmationSMS(ctx context.Context, phoneNumber, customerName, orderNumber string, totalAmount domain.Money) error {
+	message := fmt.Sprintf("Hi %s! Your order #%s has been confirmed. Total: %s. Thank you for your business!",
 		customerName, orderNumber, totalAmount)
 
 	return s.SendSMS(ctx, phoneNumber, message)
@@ -208,6 +212,6 @@ func (s *NotificationService) SendOrderStatusUpdateSMS(ctx context.Context, phon
 type OrderItem struct {
 	ProductName string
 	Quantity    int
-	UnitPrice   float64
-	TotalPrice  float64
+	UnitPrice   domain.Money
+	TotalPrice  domain.Money
 }
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// promotionsFor returns the promotions an order placed by the customer at the time may get:
+// the automatic promotions open to them and the coupons they named. A coupon that does not
+// exist or cannot be used is an ErrInvalidCoupon error rather than silently left out.
+func (s *orderService) promotionsFor(ctx context.Context, customerID uuid.UUID, codes []string, at time.Time) ([]*domain.Promotion, error) {
+	automatic, err := s.promotionRepo.GetAutomatic(ctx, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotions: %w", err)
+	}
+
+	var promotions []*domain.Promotion
+	for _, promotion := range automatic {
+		if err := s.checkCustomer(ctx, promotion, customerID); err != nil {
+			if errors.Is(err, domain.ErrPromotionUnavailable) {
+				continue
+			}
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = domain.NormalizeCouponCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		promotion, err := s.promotionRepo.GetByCode(ctx, code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get coupon: %w", err)
+		}
+		if promotion == nil {
+			return nil, fmt.Errorf("%w: %s does not exist", domain.ErrInvalidCoupon, code)
+		}
+		if err := promotion.Available(at); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", domain.ErrInvalidCoupon, code, err)
+		}
+		if err := s.checkCustomer(ctx, promotion, customerID); err != nil {
+			if errors.Is(err, domain.ErrPromotionUnavailable) {
+				return nil, fmt.Errorf("%w: %s: %w", domain.ErrInvalidCoupon, code, err)
+			}
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, nil
+}
+
+// checkCustomer returns ErrPromotionUnavailable when the customer may not use the promotion
+func (s *orderService) checkCustomer(ctx context.Context, promotion *domain.Promotion, customerID uuid.UUID) error {
+	uses := 0
+	if promotion.PerCustomerLimit != nil {
+		var err error
+		if uses, err = s.promotionRepo.CountCustomerUses(ctx, promotion.ID, customerID); err != nil {
+			return fmt.Errorf("failed to count uses of promotion %s: %w", promotion.Name, err)
+		}
+	}
+	return promotion.AvailableTo(customerID, uses)
+}
+
+// redeem counts a use of each promotion applied to the order and records it on the order
+func (s *orderService) redeem(ctx context.Context, order *domain.Order, applied []*domain.AppliedPromotion) error {
+	order.Discounts = make([]domain.OrderDiscount, 0, len(applied))
+	for _, a := range applied {
+		if err := s.promotionRepo.Redeem(ctx, a.Promotion.ID); err != nil {
+			if errors.Is(err, domain.ErrPromotionUnavailable) {
+				return fmt.Errorf("%w: %s has reached its usage limit", err, a.Promotion.Name)
+			}
+			return fmt.Errorf("failed to redeem promotion %s: %w", a.Promotion.Name, err)
+		}
+
+		discount := domain.NewOrderDiscount(order.ID, a)
+		if err := s.discountRepo.Create(ctx, discount); err != nil {
+			return fmt.Errorf("failed to record discount: %w", err)
+		}
+		order.Discounts = append(order.Discounts, *discount)
+	}
+	return nil
+}
+
+// releaseDiscounts gives back the uses of the promotions applied to a cancelled order. The
+// discounts stay on the order as a record of what it was priced at.
+func (s *orderService) releaseDiscounts(ctx context.Context, orderID uuid.UUID) error {
+	discounts, err := s.discountRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order discounts: %w", err)
+	}
+	for _, discount := range discounts {
+		if err := s.promotionRepo.Release(ctx, discount.PromotionID); err != nil {
+			return fmt.Errorf("failed to release promotion %s: %w", discount.Name, err)
+		}
+	}
+	return nil
+}
+
+// reapplyDiscounts works out the discounts of an order again after its items changed, with
+// the promotions it was placed with: the order keeps the coupons it was redeemed with but
+// does not pick up promotions started since. Promotions that no longer apply, such as when
+// the order falls below their minimum subtotal, are dropped and their use given back. It
+// sets the discount of each item and returns the items whose discount changed.
+func (s *orderService) reapplyDiscounts(ctx context.Context, order *domain.Order, items []*domain.OrderItem) ([]*domain.OrderItem, error) {
+	discounts, err := s.discountRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order discounts: %w", err)
+	}
+
+	promotions := make([]*domain.Promotion, 0, len(discounts))
+	for _, discount := range discounts {
+		promotion, err := s.promotionRepo.GetByID(ctx, discount.PromotionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get promotion: %w", err)
+		}
+		if promotion != nil {
+			promotions = append(promotions, promotion)
+		}
+	}
+
+	lines := make([]*domain.PricedLine, len(items))
+	for i, item := range items {
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product not found: %s", item.ProductID)
+		}
+		lines[i] = domain.NewPricedLine(product, item.VariantID, item.Quantity, item.UnitPrice)
+	}
+	applied := make(map[uuid.UUID]*domain.AppliedPromotion, len(promotions))
+	for _, a := range domain.ApplyPromotions(lines, promotions) {
+		applied[a.Promotion.ID] = a
+	}
+
+	var changed []*domain.OrderItem
+	for i, item := range items {
+		before := item.TotalPrice
+		item.SetDiscount(lines[i].Discount.Amount)
+		if item.TotalPrice != before {
+			changed = append(changed, item)
+		}
+	}
+
+	order.Discounts = make([]domain.OrderDiscount, 0, len(applied))
+	for _, discount := range discounts {
+		a, ok := applied[discount.PromotionID]
+		if !ok {
+			if err := s.discountRepo.Delete(ctx, discount.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove discount: %w", err)
+			}
+			if err := s.promotionRepo.Release(ctx, discount.PromotionID); err != nil {
+				return nil, fmt.Errorf("failed to release promotion %s: %w", discount.Name, err)
+			}
+			continue
+		}
+		if discount.Amount != a.Amount {
+			discount.Amount = a.Amount
+			if err := s.discountRepo.Update(ctx, discount); err != nil {
+				return nil, fmt.Errorf("failed to update discount: %w", err)
+			}
+		}
+		order.Discounts = append(order.Discounts, *discount)
+	}
+
+	return changed, nil
+}
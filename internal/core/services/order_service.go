@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,28 +13,170 @@ import (
 )
 
 type orderService struct {
-	orderRepo     ports.OrderRepository
-	orderItemRepo ports.OrderItemRepository
-	customerRepo  ports.CustomerRepository
-	productRepo   ports.ProductRepository
+	orderRepo          ports.OrderRepository
+	orderItemRepo      ports.OrderItemRepository
+	statusHistoryRepo  ports.OrderStatusHistoryRepository
+	customerRepo       ports.CustomerRepository
+	productRepo        ports.ProductRepository
+	priceRepo          ports.ProductPriceRepository
+	promotionRepo      ports.PromotionRepository
+	discountRepo       ports.OrderDiscountRepository
+	taxRateRepo        ports.TaxRateRepository
+	shippingMethodRepo ports.ShippingMethodRepository
+	reservationRepo    ports.ReservationRepository
+	stockLevelRepo     ports.StockLevelRepository
+	txManager          ports.TxManager
+	orderNumbers       ports.OrderNumberGenerator
+	lowStock           ports.LowStockNotifier
+	allocation         domain.AllocationStrategy
 }
 
-// NewOrderService creates a new order service
+// NewOrderService creates a new order service. Order items are taken from warehouses
+// in the order allocation prefers, or DefaultAllocationStrategy when it is not valid.
+// lowStock is told about products an order drops below their reorder point and may be nil.
 func NewOrderService(
 	orderRepo ports.OrderRepository,
 	orderItemRepo ports.OrderItemRepository,
+	statusHistoryRepo ports.OrderStatusHistoryRepository,
 	customerRepo ports.CustomerRepository,
 	productRepo ports.ProductRepository,
+	priceRepo ports.ProductPriceRepository,
+	promotionRepo ports.PromotionRepository,
+	discountRepo ports.OrderDiscountRepository,
+	taxRateRepo ports.TaxRateRepository,
+	shippingMethodRepo ports.ShippingMethodRepository,
+	reservationRepo ports.ReservationRepository,
+	stockLevelRepo ports.StockLevelRepository,
+	txManager ports.TxManager,
+	orderNumbers ports.OrderNumberGenerator,
+	lowStock ports.LowStockNotifier,
+	allocation domain.AllocationStrategy,
 ) ports.OrderService {
+	if !allocation.IsValid() {
+		allocation = domain.DefaultAllocationStrategy
+	}
 	return &orderService{
-		orderRepo:     orderRepo,
-		orderItemRepo: orderItemRepo,
-		customerRepo:  customerRepo,
-		productRepo:   productRepo,
+		orderRepo:          orderRepo,
+		orderItemRepo:      orderItemRepo,
+		statusHistoryRepo:  statusHistoryRepo,
+		customerRepo:       customerRepo,
+		productRepo:        productRepo,
+		priceRepo:          priceRepo,
+		promotionRepo:      promotionRepo,
+		discountRepo:       discountRepo,
+		taxRateRepo:        taxRateRepo,
+		shippingMethodRepo: shippingMethodRepo,
+		reservationRepo:    reservationRepo,
+		stockLevelRepo:     stockLevelRepo,
+		txManager:          txManager,
+		orderNumbers:       orderNumbers,
+		lowStock:           lowStock,
+		allocation:         allocation,
+	}
+}
+
+// unitPrice returns what one unit of the product, or of its variant, sells at at the
+// time: the price in effect then, the list price when none is, or the variant's own price
+func (s *orderService) unitPrice(ctx context.Context, product *domain.Product, variant *domain.ProductVariant, at time.Time) (domain.Money, error) {
+	if variant != nil && variant.Price != nil {
+		return *variant.Price, nil
+	}
+	price, err := s.priceRepo.GetEffective(ctx, product.ID, at)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("failed to get price of product %s: %w", product.Name, err)
+	}
+	return domain.NewEffectivePrice(product, price, at).UnitPrice(variant), nil
+}
+
+// shippingDestination returns where the order the request places ships to, the customer's
+// own country and city unless the request names another country or city
+func shippingDestination(req *domain.CreateOrderRequest, customer *domain.Customer) domain.ShippingDestination {
+	dest := domain.NewShippingDestination(req.ShippingCountry, req.ShippingCity)
+	home := domain.NewShippingDestination(customer.Country, customer.City)
+	if dest.Country == "" {
+		dest.Country = home.Country
+	}
+	// The customer's city is only theirs in their own country
+	if dest.City == "" && dest.Country == home.Country {
+		dest.City = home.City
+	}
+	return dest
+}
+
+// shippingMethod returns the shipping method the request picks, checking it ships to the
+// destination; it returns nil when the request picks none
+func (s *orderService) shippingMethod(ctx context.Context, req *domain.CreateOrderRequest, dest domain.ShippingDestination) (*domain.ShippingMethod, error) {
+	if req.ShippingMethodID == nil {
+		return nil, nil
+	}
+	method, err := s.shippingMethodRepo.GetByID(ctx, *req.ShippingMethodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping method: %w", err)
+	}
+	if method == nil {
+		return nil, fmt.Errorf("%w: shipping method %s does not exist", domain.ErrShippingUnavailable, *req.ShippingMethodID)
+	}
+	if err := method.Serves(dest); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+// rechargeShipping works out the shipping of an order again after its items changed, at
+// the current rates of the method it ships by. An order whose method has been deleted
+// keeps what it was charged.
+func (s *orderService) rechargeShipping(ctx context.Context, order *domain.Order, items []*domain.OrderItem) error {
+	if order.ShippingMethodID == nil {
+		return nil
 	}
+	method, err := s.shippingMethodRepo.GetByID(ctx, *order.ShippingMethodID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipping method: %w", err)
+	}
+	if method == nil {
+		return nil
+	}
+
+	weight := 0
+	for _, item := range items {
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+		if product == nil {
+			return fmt.Errorf("product not found: %s", item.ProductID)
+		}
+		weight += product.ShippingWeight() * item.Quantity
+	}
+	amount, err := method.Charge(order.Destination(), domain.NewParcel(weight, items))
+	if err != nil {
+		return err
+	}
+	order.SetShipping(method, amount)
+	return nil
+}
+
+// taxRates returns the tax rates of the country an order ships to; orders without a
+// country are not taxed
+func (s *orderService) taxRates(ctx context.Context, country string) ([]*domain.TaxRate, error) {
+	if country == "" {
+		return nil, nil
+	}
+	rates, err := s.taxRateRepo.GetByCountry(ctx, country)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rates: %w", err)
+	}
+	return rates, nil
 }
 
 func (s *orderService) CreateOrder(ctx context.Context, req *domain.CreateOrderRequest) (*domain.Order, error) {
+	if len(req.OrderItems) == 0 && len(req.ReservationIDs) == 0 {
+		return nil, fmt.Errorf("at least one order item or reservation is required")
+	}
+	if err := validateOrderItems(req.OrderItems); err != nil {
+		return nil, err
+	}
+
 	// Validate customer exists
 	customer, err := s.customerRepo.GetByID(ctx, req.CustomerID)
 	if err != nil {
@@ -43,90 +186,376 @@ func (s *orderService) CreateOrder(ctx context.Context, req *domain.CreateOrderR
 		return nil, fmt.Errorf("customer not found")
 	}
 
-	// Validate products and calculate total
-	var totalAmount float64
-	var orderItems []*domain.OrderItem
+	// Reject unusable coupons before any stock is taken
+	orderDate := time.Now()
+	promotions, err := s.promotionsFor(ctx, req.CustomerID, req.CouponCodes, orderDate)
+	if err != nil {
+		return nil, err
+	}
+	dest := shippingDestination(req, customer)
+	taxRates, err := s.taxRates(ctx, dest.Country)
+	if err != nil {
+		return nil, err
+	}
+	method, err := s.shippingMethod(ctx, req, dest)
+	if err != nil {
+		return nil, err
+	}
+
+	var order *domain.Order
+	var lowStock []*domain.LowStockEvent
+	orderID := uuid.New()
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		lowStock = nil
 
-	for _, itemReq := range req.OrderItems {
-		// Validate product exists and is active
-		product, err := s.productRepo.GetByID(ctx, itemReq.ProductID)
+		// Release held stock into the order before it is taken off the shelf below
+		itemReqs, err := s.convertReservations(ctx, req, &orderID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get product: %w", err)
+			return err
 		}
-		if product == nil {
-			return nil, fmt.Errorf("product not found: %s", itemReq.ProductID)
+
+		// Validate products, reserve stock and price the items
+		var subtotal domain.Money
+		var orderItems []*domain.OrderItem
+		var lines []*domain.PricedLine
+		weight := 0
+
+		for _, itemReq := range itemReqs {
+			// Validate product exists and is active
+			product, err := s.productRepo.GetByID(ctx, itemReq.ProductID)
+			if err != nil {
+				return fmt.Errorf("failed to get product: %w", err)
+			}
+			if product == nil {
+				return fmt.Errorf("product not found: %s", itemReq.ProductID)
+			}
+			if !product.IsActive {
+				return fmt.Errorf("product is not active: %s", product.Name)
+			}
+			variant, err := product.ResolveVariant(itemReq.VariantID)
+			if err != nil {
+				return err
+			}
+			available := product.Stock
+			if variant != nil {
+				available = variant.Stock
+			}
+			if available < itemReq.Quantity {
+				return fmt.Errorf("insufficient stock for product %s: requested %d, available %d", itemName(product, variant), itemReq.Quantity, available)
+			}
+
+			previousStock := product.Stock
+			if err := s.takeStock(ctx, product, itemReq.VariantID, itemReq.Quantity, domain.StockMovementReasonSale, orderID, dest); err != nil {
+				return err
+			}
+			sold := *product
+			sold.Stock = previousStock - itemReq.Quantity
+			if event := domain.NewLowStockEvent(&sold, previousStock); event != nil {
+				lowStock = append(lowStock, event)
+			}
+
+			// Calculate item total at the price in effect when the order is placed; all
+			// items of an order must share a currency
+			unitPrice, err := s.unitPrice(ctx, product, variant, orderDate)
+			if err != nil {
+				return err
+			}
+			itemTotal := unitPrice.Multiply(itemReq.Quantity)
+			subtotal, err = subtotal.Add(itemTotal)
+			if err != nil {
+				return fmt.Errorf("failed to add product %s to order: %w", product.Name, err)
+			}
+
+			// Create order item
+			orderItem := &domain.OrderItem{
+				ID:         uuid.New(),
+				ProductID:  itemReq.ProductID,
+				VariantID:  itemReq.VariantID,
+				Quantity:   itemReq.Quantity,
+				UnitPrice:  unitPrice,
+				TotalPrice: itemTotal,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			}
+			orderItem.SetTax(domain.TaxRateFor(taxRates, product))
+			orderItems = append(orderItems, orderItem)
+			lines = append(lines, domain.NewPricedLine(product, itemReq.VariantID, itemReq.Quantity, unitPrice))
+			weight += product.ShippingWeight() * itemReq.Quantity
 		}
-		if !product.IsActive {
-			return nil, fmt.Errorf("product is not active: %s", product.Name)
+
+		// Spread the discounts of the promotions the order gets over its lines, which
+		// are taxed on what is left
+		applied := domain.ApplyPromotions(lines, promotions)
+		for i, orderItem := range orderItems {
+			orderItem.SetDiscount(lines[i].Discount.Amount)
+		}
+
+		// Generate order number
+		orderNumber, err := s.orderNumbers.Next(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate order number: %w", err)
+		}
+
+		// Create order
+		order = &domain.Order{
+			ID:              orderID,
+			CustomerID:      req.CustomerID,
+			OrderNumber:     orderNumber,
+			Status:          domain.OrderStatusPending,
+			ShippingAddress: req.ShippingAddress,
+			ShippingCountry: dest.Country,
+			ShippingCity:    dest.City,
+			BillingAddress:  req.BillingAddress,
+			Notes:           req.Notes,
+			OrderDate:       orderDate,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+		// Shipping is charged on the items after discounts
+		if method != nil {
+			amount, err := method.Charge(dest, domain.NewParcel(weight, orderItems))
+			if err != nil {
+				return err
+			}
+			order.SetShipping(method, amount)
 		}
-		if product.Stock < itemReq.Quantity {
-			return nil, fmt.Errorf("insufficient stock for product %s: requested %d, available %d", product.Name, itemReq.Quantity, product.Stock)
+		if err := order.SetTotals(orderItems); err != nil {
+			return fmt.Errorf("failed to total order: %w", err)
 		}
 
-		// Calculate item total
-		itemTotal := product.Price * float64(itemReq.Quantity)
-		totalAmount += itemTotal
+		// Create order in database
+		if err := s.orderRepo.Create(ctx, order); err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
 
-		// Create order item
-		orderItem := &domain.OrderItem{
-			ID:         uuid.New(),
-			ProductID:  itemReq.ProductID,
-			Quantity:   itemReq.Quantity,
-			UnitPrice:  product.Price,
-			TotalPrice: itemTotal,
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
+		// Create order items
+		for _, orderItem := range orderItems {
+			orderItem.OrderID = order.ID
+			if err := s.orderItemRepo.Create(ctx, orderItem); err != nil {
+				return fmt.Errorf("failed to create order item: %w", err)
+			}
 		}
-		orderItems = append(orderItems, orderItem)
+
+		// Count the uses of the promotions applied
+		if err := s.redeem(ctx, order, applied); err != nil {
+			return err
+		}
+
+		// Record the initial status
+		if err := s.recordStatusChange(ctx, order.ID, nil, order.Status, "order created"); err != nil {
+			return err
+		}
+
+		// Set order items for response
+		order.OrderItems = make([]domain.OrderItem, len(orderItems))
+		for i, item := range orderItems {
+			order.OrderItems[i] = *item
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate order number
-	orderNumber := fmt.Sprintf("ORD-%d", time.Now().Unix())
+	// Only alert once the sale is committed
+	if s.lowStock != nil && len(lowStock) > 0 {
+		s.lowStock.NotifyLowStock(ctx, lowStock)
+	}
 
-	// Create order
-	order := &domain.Order{
-		ID:              uuid.New(),
-		CustomerID:      req.CustomerID,
-		OrderNumber:     orderNumber,
-		Status:          domain.OrderStatusPending,
-		TotalAmount:     totalAmount,
-		ShippingAddress: req.ShippingAddress,
-		BillingAddress:  req.BillingAddress,
-		Notes:           req.Notes,
-		OrderDate:       time.Now(),
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+	return order, nil
+}
+
+// convertReservations marks the request's reservations as converted into the order and
+// returns the order items to create, merging reserved and requested quantities per product.
+// Quotes pass a nil orderID to read the reservations without converting them.
+func (s *orderService) convertReservations(ctx context.Context, req *domain.CreateOrderRequest, orderID *uuid.UUID) ([]domain.CreateOrderItemRequest, error) {
+	if len(req.ReservationIDs) == 0 {
+		return req.OrderItems, nil
 	}
 
-	// Create order in database
-	if err := s.orderRepo.Create(ctx, order); err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+	itemReqs := append([]domain.CreateOrderItemRequest(nil), req.OrderItems...)
+	positions := make(map[uuid.UUID]int, len(itemReqs))
+	for i, itemReq := range itemReqs {
+		positions[itemReq.ProductID] = i
 	}
 
-	// Create order items
-	for _, orderItem := range orderItems {
-		orderItem.OrderID = order.ID
-		if err := s.orderItemRepo.Create(ctx, orderItem); err != nil {
-			return nil, fmt.Errorf("failed to create order item: %w", err)
+	now := time.Now()
+	for _, id := range req.ReservationIDs {
+		reservation, err := s.reservationRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reservation: %w", err)
+		}
+		if reservation == nil {
+			return nil, fmt.Errorf("reservation not found: %s", id)
+		}
+		if reservation.CustomerID != req.CustomerID {
+			return nil, fmt.Errorf("%w: reservation %s belongs to another customer", domain.ErrReservationUnavailable, id)
+		}
+		if !reservation.IsHeld(now) {
+			return nil, fmt.Errorf("%w: reservation %s is %s", domain.ErrReservationUnavailable, id, reservationState(reservation, now))
+		}
+
+		if orderID != nil {
+			if err := s.reservationRepo.Convert(ctx, id, *orderID); err != nil {
+				return nil, fmt.Errorf("failed to convert reservation %s: %w", id, err)
+			}
 		}
+
+		if i, ok := positions[reservation.ProductID]; ok {
+			itemReqs[i].Quantity += reservation.Quantity
+			continue
+		}
+		positions[reservation.ProductID] = len(itemReqs)
+		itemReqs = append(itemReqs, domain.CreateOrderItemRequest{
+			ProductID: reservation.ProductID,
+			Quantity:  reservation.Quantity,
+		})
+	}
+
+	return itemReqs, nil
+}
+
+// reservationState describes why a reservation no longer holds stock
+func reservationState(reservation *domain.StockReservation, now time.Time) domain.ReservationStatus {
+	if reservation.Status == domain.ReservationStatusActive && !now.Before(reservation.ExpiresAt) {
+		return domain.ReservationStatusExpired
+	}
+	return reservation.Status
+}
+
+// QuoteOrder prices and taxes the order the request would place, with the promotions it
+// would get and the shipping method it picks, without placing it: no stock is taken, no
+// reservation converted and no coupon used up
+func (s *orderService) QuoteOrder(ctx context.Context, req *domain.CreateOrderRequest) (*domain.OrderQuote, error) {
+	quotedAt := time.Now()
+	quoted, err := s.quote(ctx, req, quotedAt)
+	if err != nil {
+		return nil, err
 	}
 
-	// Update product stock
-	for _, itemReq := range req.OrderItems {
-		product, _ := s.productRepo.GetByID(ctx, itemReq.ProductID)
-		newStock := product.Stock - itemReq.Quantity
-		if err := s.productRepo.UpdateStock(ctx, itemReq.ProductID, newStock); err != nil {
-			return nil, fmt.Errorf("failed to update product stock: %w", err)
+	dest := quoted.order.Destination()
+	method, err := s.shippingMethod(ctx, req, dest)
+	if err != nil {
+		return nil, err
+	}
+	if method != nil {
+		amount, err := method.Charge(dest, quoted.parcel)
+		if err != nil {
+			return nil, err
 		}
+		quoted.order.SetShipping(method, amount)
+	}
+	return domain.NewOrderQuote(quoted.order, quoted.items, quoted.applied, quotedAt)
+}
+
+// QuoteShipping prices the order the request would place as QuoteOrder does and returns
+// what each active shipping method that ships it charges, cheapest first
+func (s *orderService) QuoteShipping(ctx context.Context, req *domain.CreateOrderRequest) ([]domain.ShippingRate, error) {
+	quoted, err := s.quote(ctx, req, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
-	// Set order items for response
-	order.OrderItems = make([]domain.OrderItem, len(orderItems))
-	for i, item := range orderItems {
-		order.OrderItems[i] = *item
+	methods, err := s.shippingMethodRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping methods: %w", err)
 	}
+	return domain.ShippingRates(methods, quoted.order.Destination(), quoted.parcel), nil
+}
 
-	return order, nil
+// quotedOrder is the order a request would place, priced, discounted and taxed but not saved
+type quotedOrder struct {
+	order   *domain.Order
+	items   []*domain.OrderItem
+	applied []*domain.AppliedPromotion
+	parcel  domain.Parcel
+}
+
+// quote prices, discounts and taxes the order the request would place at the time
+func (s *orderService) quote(ctx context.Context, req *domain.CreateOrderRequest, at time.Time) (*quotedOrder, error) {
+	if len(req.OrderItems) == 0 && len(req.ReservationIDs) == 0 {
+		return nil, fmt.Errorf("at least one order item or reservation is required")
+	}
+	if err := validateOrderItems(req.OrderItems); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.customerRepo.GetByID(ctx, req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	if customer == nil {
+		return nil, fmt.Errorf("customer not found")
+	}
+
+	promotions, err := s.promotionsFor(ctx, req.CustomerID, req.CouponCodes, at)
+	if err != nil {
+		return nil, err
+	}
+	dest := shippingDestination(req, customer)
+	taxRates, err := s.taxRates(ctx, dest.Country)
+	if err != nil {
+		return nil, err
+	}
+	itemReqs, err := s.convertReservations(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subtotal domain.Money
+	lines := make([]*domain.PricedLine, 0, len(itemReqs))
+	items := make([]*domain.OrderItem, 0, len(itemReqs))
+	weight := 0
+	for _, itemReq := range itemReqs {
+		product, err := s.productRepo.GetByID(ctx, itemReq.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product not found: %s", itemReq.ProductID)
+		}
+		if !product.IsActive {
+			return nil, fmt.Errorf("product is not active: %s", product.Name)
+		}
+		variant, err := product.ResolveVariant(itemReq.VariantID)
+		if err != nil {
+			return nil, err
+		}
+
+		unitPrice, err := s.unitPrice(ctx, product, variant, at)
+		if err != nil {
+			return nil, err
+		}
+		line := domain.NewPricedLine(product, itemReq.VariantID, itemReq.Quantity, unitPrice)
+		if subtotal, err = subtotal.Add(line.Subtotal()); err != nil {
+			return nil, fmt.Errorf("failed to add product %s to order: %w", product.Name, err)
+		}
+		lines = append(lines, line)
+
+		item := &domain.OrderItem{
+			ProductID:  itemReq.ProductID,
+			VariantID:  itemReq.VariantID,
+			Quantity:   itemReq.Quantity,
+			UnitPrice:  unitPrice,
+			TotalPrice: line.Subtotal(),
+		}
+		item.SetTax(domain.TaxRateFor(taxRates, product))
+		items = append(items, item)
+		weight += product.ShippingWeight() * itemReq.Quantity
+	}
+
+	applied := domain.ApplyPromotions(lines, promotions)
+	for i, item := range items {
+		item.SetDiscount(lines[i].Discount.Amount)
+	}
+
+	order := &domain.Order{
+		CustomerID:      req.CustomerID,
+		ShippingCountry: dest.Country,
+		ShippingCity:    dest.City,
+	}
+	return &quotedOrder{order: order, items: items, applied: applied, parcel: domain.NewParcel(weight, items)}, nil
 }
 
 func (s *orderService) GetOrder(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
@@ -143,6 +572,11 @@ func (s *orderService) GetOrder(ctx context.Context, id uuid.UUID) (*domain.Orde
 }
 
 func (s *orderService) GetOrderByNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	// Reject mistyped numbers without a database round trip
+	if !s.orderNumbers.Validate(orderNumber) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidOrderNumber, orderNumber)
+	}
+
 	order, err := s.orderRepo.GetByOrderNumber(ctx, orderNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
@@ -155,8 +589,8 @@ func (s *orderService) GetOrderByNumber(ctx context.Context, orderNumber string)
 	return order, nil
 }
 
-func (s *orderService) GetOrders(ctx context.Context, limit, offset int) ([]*domain.Order, error) {
-	orders, err := s.orderRepo.GetAll(ctx, limit, offset)
+func (s *orderService) GetOrders(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
+	orders, err := s.orderRepo.GetAll(ctx, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get orders: %w", err)
 	}
@@ -164,7 +598,7 @@ func (s *orderService) GetOrders(ctx context.Context, limit, offset int) ([]*dom
 	return orders, nil
 }
 
-func (s *orderService) GetOrdersByCustomer(ctx context.Context, customerID uuid.UUID, limit, offset int) ([]*domain.Order, error) {
+func (s *orderService) GetOrdersByCustomer(ctx context.Context, customerID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	// Validate customer exists
 	customer, err := s.customerRepo.GetByID(ctx, customerID)
 	if err != nil {
@@ -174,7 +608,7 @@ func (s *orderService) GetOrdersByCustomer(ctx context.Context, customerID uuid.
 		return nil, fmt.Errorf("customer not found")
 	}
 
-	orders, err := s.orderRepo.GetByCustomerID(ctx, customerID, limit, offset)
+	orders, err := s.orderRepo.GetByCustomerID(ctx, customerID, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get orders by customer: %w", err)
 	}
@@ -182,8 +616,8 @@ func (s *orderService) GetOrdersByCustomer(ctx context.Context, customerID uuid.
 	return orders, nil
 }
 
-func (s *orderService) GetOrdersByStatus(ctx context.Context, status domain.OrderStatus, limit, offset int) ([]*domain.Order, error) {
-	orders, err := s.orderRepo.GetByStatus(ctx, status, limit, offset)
+func (s *orderService) GetOrdersByStatus(ctx context.Context, status domain.OrderStatus, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
+	orders, err := s.orderRepo.GetByStatus(ctx, status, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get orders by status: %w", err)
 	}
@@ -192,61 +626,306 @@ func (s *orderService) GetOrdersByStatus(ctx context.Context, status domain.Orde
 }
 
 func (s *orderService) UpdateOrder(ctx context.Context, id uuid.UUID, req *domain.UpdateOrderRequest) (*domain.Order, error) {
-	order, err := s.orderRepo.GetByID(ctx, id)
+	var order *domain.Order
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+
+		if order == nil {
+			return fmt.Errorf("order not found")
+		}
+
+		// Update fields if provided
+		if req.ShippingAddress != nil {
+			order.ShippingAddress = *req.ShippingAddress
+		}
+		if req.BillingAddress != nil {
+			order.BillingAddress = *req.BillingAddress
+		}
+		if req.Notes != nil {
+			order.Notes = *req.Notes
+		}
+		if req.ShippedDate != nil {
+			order.ShippedDate = req.ShippedDate
+		}
+		if req.DeliveredDate != nil {
+			order.DeliveredDate = req.DeliveredDate
+		}
+		if req.Status != nil && *req.Status != order.Status {
+			reason := ""
+			if req.StatusReason != nil {
+				reason = *req.StatusReason
+			}
+			if err := s.applyStatus(ctx, order, *req.Status, reason); err != nil {
+				return err
+			}
+		}
+		order.UpdatedAt = time.Now()
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		return nil, err
 	}
 
-	if order == nil {
-		return nil, fmt.Errorf("order not found")
-	}
+	return order, nil
+}
 
-	// Update fields if provided
-	if req.Status != nil {
-		order.Status = *req.Status
+func (s *orderService) UpdateOrderItems(ctx context.Context, id uuid.UUID, req *domain.UpdateOrderItemsRequest) (*domain.Order, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("at least one item change is required")
 	}
-	if req.ShippingAddress != nil {
-		order.ShippingAddress = *req.ShippingAddress
-	}
-	if req.BillingAddress != nil {
-		order.BillingAddress = *req.BillingAddress
-	}
-	if req.Notes != nil {
-		order.Notes = *req.Notes
-	}
-	if req.ShippedDate != nil {
-		order.ShippedDate = req.ShippedDate
-	}
-	if req.DeliveredDate != nil {
-		order.DeliveredDate = req.DeliveredDate
+	for _, change := range req.Items {
+		// A quantity of zero removes the item
+		if err := checkQuantity(change.ProductID, change.Quantity, 0); err != nil {
+			return nil, err
+		}
 	}
-	order.UpdatedAt = time.Now()
 
-	if err := s.orderRepo.Update(ctx, order); err != nil {
-		return nil, fmt.Errorf("failed to update order: %w", err)
+	var order *domain.Order
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return fmt.Errorf("order not found")
+		}
+
+		// Items are locked once the warehouse starts picking
+		if !order.Status.ItemsEditable() {
+			return fmt.Errorf("%w: order status is %s", domain.ErrOrderNotEditable, order.Status)
+		}
+
+		orderItems, err := s.orderItemRepo.GetByOrderID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		allocations, err := s.stockLevelRepo.GetOrderAllocations(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order stock allocations: %w", err)
+		}
+		itemsByLine := make(map[orderLine]*domain.OrderItem, len(orderItems))
+		for _, item := range orderItems {
+			itemsByLine[lineOf(item.ProductID, item.VariantID)] = item
+		}
+		lines := append([]*domain.OrderItem(nil), orderItems...)
+		// Lines are saved once the order's discounts have been worked out again
+		created := make(map[uuid.UUID]bool)
+		updated := make(map[uuid.UUID]bool)
+		// Existing lines keep the tax rate they were placed at; added ones are taxed at
+		// the rates of the order's country now
+		taxRates, err := s.taxRates(ctx, order.ShippingCountry)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range req.Items {
+			line := lineOf(change.ProductID, change.VariantID)
+			item, exists := itemsByLine[line]
+			current := 0
+			if exists {
+				current = item.Quantity
+			}
+			delta := change.Quantity - current
+			if delta == 0 {
+				continue
+			}
+
+			product, err := s.productRepo.GetByID(ctx, change.ProductID)
+			if err != nil {
+				return fmt.Errorf("failed to get product: %w", err)
+			}
+			if product == nil {
+				return fmt.Errorf("product not found: %s", change.ProductID)
+			}
+			if !exists && !product.IsActive {
+				return fmt.Errorf("product is not active: %s", product.Name)
+			}
+			var variant *domain.ProductVariant
+			if !exists {
+				if variant, err = product.ResolveVariant(change.VariantID); err != nil {
+					return err
+				}
+			}
+
+			// Take or release only the difference so concurrent orders cannot oversell
+			if delta > 0 {
+				err = s.takeStock(ctx, product, change.VariantID, delta, domain.StockMovementReasonOrderEdit, order.ID, order.Destination())
+			} else {
+				err = s.restock(ctx, order.ID, change.ProductID, change.VariantID, -delta, domain.StockMovementReasonOrderEdit, allocations)
+			}
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case change.Quantity == 0:
+				if err := s.orderItemRepo.Delete(ctx, item.ID); err != nil {
+					return fmt.Errorf("failed to remove order item: %w", err)
+				}
+				delete(itemsByLine, line)
+			case exists:
+				// Existing lines keep the price the customer was quoted
+				item.Quantity = change.Quantity
+				item.SetDiscount(0)
+				item.UpdatedAt = time.Now()
+				updated[item.ID] = true
+			default:
+				unitPrice, err := s.unitPrice(ctx, product, variant, time.Now())
+				if err != nil {
+					return err
+				}
+				item = &domain.OrderItem{
+					ID:        uuid.New(),
+					OrderID:   id,
+					ProductID: change.ProductID,
+					VariantID: change.VariantID,
+					Quantity:  change.Quantity,
+					UnitPrice: unitPrice,
+					CreatedAt: time.Now(),
+					UpdatedAt: time.Now(),
+				}
+				item.SetTax(domain.TaxRateFor(taxRates, product))
+				item.SetDiscount(0)
+				created[item.ID] = true
+				itemsByLine[line] = item
+				lines = append(lines, item)
+			}
+		}
+
+		remaining := make([]*domain.OrderItem, 0, len(itemsByLine))
+		for _, item := range lines {
+			if itemsByLine[lineOf(item.ProductID, item.VariantID)] == item {
+				remaining = append(remaining, item)
+			}
+		}
+		if len(remaining) == 0 {
+			return fmt.Errorf("an order must keep at least one item; cancel the order instead")
+		}
+
+		changed, err := s.reapplyDiscounts(ctx, order, remaining)
+		if err != nil {
+			return err
+		}
+		for _, item := range changed {
+			updated[item.ID] = true
+		}
+
+		// Save the changed lines and recalculate the totals from the remaining lines
+		order.OrderItems = make([]domain.OrderItem, 0, len(remaining))
+		for _, item := range remaining {
+			switch {
+			case created[item.ID]:
+				if err := s.orderItemRepo.Create(ctx, item); err != nil {
+					return fmt.Errorf("failed to create order item: %w", err)
+				}
+			case updated[item.ID]:
+				if err := s.orderItemRepo.Update(ctx, item); err != nil {
+					return fmt.Errorf("failed to update order item: %w", err)
+				}
+			}
+			order.OrderItems = append(order.OrderItems, *item)
+		}
+
+		if err := s.rechargeShipping(ctx, order, remaining); err != nil {
+			return err
+		}
+		if err := order.SetTotals(remaining); err != nil {
+			return fmt.Errorf("failed to total order: %w", err)
+		}
+		order.UpdatedAt = time.Now()
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return order, nil
 }
 
-func (s *orderService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus) error {
+func (s *orderService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, status domain.OrderStatus, reason string) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+
+		if order == nil {
+			return fmt.Errorf("order not found")
+		}
+
+		if err := s.applyStatus(ctx, order, status, reason); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, id uuid.UUID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+
+		if order == nil {
+			return fmt.Errorf("order not found")
+		}
+
+		// Only orders that have not started processing can be cancelled
+		if !order.Status.CanTransitionTo(domain.OrderStatusCancelled) {
+			return fmt.Errorf("cannot cancel order with status: %s", order.Status)
+		}
+
+		if err := s.applyStatus(ctx, order, domain.OrderStatusCancelled, ""); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to cancel order: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *orderService) GetOrderStatusHistory(ctx context.Context, id uuid.UUID) ([]*domain.OrderStatusHistory, error) {
 	order, err := s.orderRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get order: %w", err)
+		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 
 	if order == nil {
-		return fmt.Errorf("order not found")
+		return nil, fmt.Errorf("order not found")
 	}
 
-	if err := s.orderRepo.UpdateStatus(ctx, id, status); err != nil {
-		return fmt.Errorf("failed to update order status: %w", err)
+	entries, err := s.statusHistoryRepo.GetByOrderID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
 	}
 
-	return nil
+	return entries, nil
 }
 
-func (s *orderService) CancelOrder(ctx context.Context, id uuid.UUID) error {
+func (s *orderService) DeleteOrder(ctx context.Context, id uuid.UUID) error {
 	order, err := s.orderRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get order: %w", err)
@@ -256,53 +935,182 @@ func (s *orderService) CancelOrder(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("order not found")
 	}
 
-	// Only allow cancellation of pending or confirmed orders
-	if order.Status != domain.OrderStatusPending && order.Status != domain.OrderStatusConfirmed {
-		return fmt.Errorf("cannot cancel order with status: %s", order.Status)
+	// Only allow deletion of cancelled orders
+	if order.Status != domain.OrderStatusCancelled {
+		return fmt.Errorf("cannot delete order with status: %s", order.Status)
 	}
 
-	// Restore product stock
-	orderItems, err := s.orderItemRepo.GetByOrderID(ctx, id)
-	if err != nil {
-		return fmt.Errorf("failed to get order items: %w", err)
+	if err := s.orderRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete order: %w", err)
 	}
 
-	for _, item := range orderItems {
-		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+	return nil
+}
+
+// applyStatus moves order to status, restoring stock on cancellation, stamping
+// shipment dates and recording the change. The caller persists the order.
+func (s *orderService) applyStatus(ctx context.Context, order *domain.Order, status domain.OrderStatus, reason string) error {
+	from := order.Status
+	if err := from.ValidateTransition(status); err != nil {
+		return err
+	}
+
+	if status == domain.OrderStatusCancelled {
+		orderItems, err := s.orderItemRepo.GetByOrderID(ctx, order.ID)
 		if err != nil {
-			return fmt.Errorf("failed to get product: %w", err)
+			return fmt.Errorf("failed to get order items: %w", err)
 		}
-		newStock := product.Stock + item.Quantity
-		if err := s.productRepo.UpdateStock(ctx, item.ProductID, newStock); err != nil {
-			return fmt.Errorf("failed to restore product stock: %w", err)
+
+		allocations, err := s.stockLevelRepo.GetOrderAllocations(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order stock allocations: %w", err)
+		}
+
+		for _, item := range orderItems {
+			if err := s.restock(ctx, order.ID, item.ProductID, item.VariantID, item.Quantity, domain.StockMovementReasonCancel, allocations); err != nil {
+				return err
+			}
+		}
+
+		if err := s.releaseDiscounts(ctx, order.ID); err != nil {
+			return err
 		}
 	}
 
-	// Update order status to cancelled
-	if err := s.orderRepo.UpdateStatus(ctx, id, domain.OrderStatusCancelled); err != nil {
-		return fmt.Errorf("failed to cancel order: %w", err)
+	now := time.Now()
+	order.Status = status
+	switch status {
+	case domain.OrderStatusShipped:
+		if order.ShippedDate == nil {
+			order.ShippedDate = &now
+		}
+	case domain.OrderStatusDelivered:
+		if order.DeliveredDate == nil {
+			order.DeliveredDate = &now
+		}
 	}
+	order.UpdatedAt = now
+
+	return s.recordStatusChange(ctx, order.ID, &from, status, reason)
+}
 
+// validateOrderItems checks that each item of a new order asks for at least one unit, before
+// any stock is taken for it
+func validateOrderItems(items []domain.CreateOrderItemRequest) error {
+	for _, item := range items {
+		if err := checkQuantity(item.ProductID, item.Quantity, 1); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (s *orderService) DeleteOrder(ctx context.Context, id uuid.UUID) error {
-	order, err := s.orderRepo.GetByID(ctx, id)
+// checkQuantity returns ErrInvalidOrderItem when the quantity of the product is below min
+func checkQuantity(productID uuid.UUID, quantity, min int) error {
+	if quantity < min {
+		return fmt.Errorf("%w: quantity must be at least %d for product %s", domain.ErrInvalidOrderItem, min, productID)
+	}
+	return nil
+}
+
+// takeStock allocates quantity of product across warehouses using the configured strategy
+// and takes it off their shelves, and off the variant's stock when one is given, for the
+// order shipping to dest
+func (s *orderService) takeStock(ctx context.Context, product *domain.Product, variantID *uuid.UUID, quantity int, reason domain.StockMovementReason, orderID uuid.UUID, dest domain.ShippingDestination) error {
+	levels, err := s.stockLevelRepo.GetByProductID(ctx, product.ID)
 	if err != nil {
-		return fmt.Errorf("failed to get order: %w", err)
+		return fmt.Errorf("failed to get stock levels: %w", err)
+	}
+	allocations, err := domain.AllocateStock(levels, quantity, s.allocation, dest)
+	if err != nil {
+		return fmt.Errorf("insufficient stock for product %s: %w", product.Name, err)
 	}
 
-	if order == nil {
-		return fmt.Errorf("order not found")
+	// Decrement stock conditionally so concurrent orders cannot oversell
+	for _, allocation := range allocations {
+		movement := domain.NewStockMovement(product.ID, -allocation.Quantity, reason, &orderID).InWarehouse(allocation.WarehouseID).ForVariant(variantID)
+		if err := s.productRepo.AdjustStock(ctx, movement); err != nil {
+			if errors.Is(err, domain.ErrInsufficientStock) {
+				return fmt.Errorf("insufficient stock for product %s: %w", product.Name, err)
+			}
+			return fmt.Errorf("failed to update product stock: %w", err)
+		}
 	}
 
-	// Only allow deletion of cancelled orders
-	if order.Status != domain.OrderStatusCancelled {
-		return fmt.Errorf("cannot delete order with status: %s", order.Status)
+	return nil
+}
+
+// restock puts quantity of a product, and of its variant when one is given, back into the
+// warehouses the order took it from, consuming allocations as it goes. Stock the order holds
+// no allocation for (orders placed before stock was tracked per warehouse) goes to the
+// default warehouse.
+func (s *orderService) restock(ctx context.Context, orderID, productID uuid.UUID, variantID *uuid.UUID, quantity int, reason domain.StockMovementReason, allocations []*domain.StockAllocation) error {
+	for _, allocation := range allocations {
+		if quantity == 0 {
+			break
+		}
+		if allocation.ProductID != productID || allocation.Quantity == 0 {
+			continue
+		}
+
+		n := allocation.Quantity
+		if n > quantity {
+			n = quantity
+		}
+		movement := domain.NewStockMovement(productID, n, reason, &orderID).InWarehouse(allocation.WarehouseID).ForVariant(variantID)
+		if err := s.productRepo.AdjustStock(ctx, movement); err != nil {
+			return fmt.Errorf("failed to restore product stock: %w", err)
+		}
+		allocation.Quantity -= n
+		quantity -= n
 	}
 
-	if err := s.orderRepo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete order: %w", err)
+	if quantity > 0 {
+		if err := s.productRepo.AdjustStock(ctx, domain.NewStockMovement(productID, quantity, reason, &orderID).ForVariant(variantID)); err != nil {
+			return fmt.Errorf("failed to restore product stock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// orderLine identifies an order item by its product and variant (uuid.Nil when it has none)
+type orderLine struct {
+	productID uuid.UUID
+	variantID uuid.UUID
+}
+
+// lineOf returns the order line of a product and optional variant
+func lineOf(productID uuid.UUID, variantID *uuid.UUID) orderLine {
+	line := orderLine{productID: productID}
+	if variantID != nil {
+		line.variantID = *variantID
+	}
+	return line
+}
+
+// itemName describes a product, or one of its variants, in error messages
+func itemName(product *domain.Product, variant *domain.ProductVariant) string {
+	if variant == nil {
+		return product.Name
+	}
+	return fmt.Sprintf("%s (%s)", product.Name, variant.SKU)
+}
+
+// recordStatusChange appends an entry to the order status history
+func (s *orderService) recordStatusChange(ctx context.Context, orderID uuid.UUID, from *domain.OrderStatus, to domain.OrderStatus, reason string) error {
+	entry := &domain.OrderStatusHistory{
+		ID:         uuid.New(),
+		OrderID:    orderID,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      domain.ActorFromContext(ctx),
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.statusHistoryRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record order status change: %w", err)
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
 	"silbackendassessment/internal/testutils"
 
 	"github.com/google/uuid"
@@ -17,7 +18,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
 	mockCustomerRepo := testutils.NewMockCustomerRepository()
 	mockProductRepo := testutils.NewMockProductRepository()
-	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, mockCustomerRepo, mockProductRepo)
+	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
 	ctx := context.Background()
 
 	t.Run("Create order successfully", func(t *testing.T) {
@@ -39,7 +40,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			ID:       productID1,
 			Name:     "Product 1",
 			SKU:      "PROD-001",
-			Price:    99.99,
+			Price:    domain.NewMoney(9999, "USD"),
 			Stock:    10,
 			IsActive: true,
 		}
@@ -50,7 +51,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			ID:       productID2,
 			Name:     "Product 2",
 			SKU:      "PROD-002",
-			Price:    149.99,
+			Price:    domain.NewMoney(14999, "USD"),
 			Stock:    5,
 			IsActive: true,
 		}
@@ -87,8 +88,8 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			t.Errorf("Expected CustomerID to be %s, got: %s", customerID, order.CustomerID)
 		}
 
-		if order.TotalAmount != 349.97 { // (99.99 * 2) + (149.99 * 1)
-			t.Errorf("Expected TotalAmount to be 349.97, got: %f", order.TotalAmount)
+		if order.TotalAmount != domain.NewMoney(34997, "USD") { // (99.99 * 2) + (149.99 * 1)
+			t.Errorf("Expected TotalAmount to be 349.97 USD, got: %s", order.TotalAmount)
 		}
 
 		if order.Status != "pending" {
@@ -110,6 +111,48 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		if order.UpdatedAt.IsZero() {
 			t.Error("Expected UpdatedAt to be set")
 		}
+
+		if product1.Stock != 8 || product2.Stock != 4 {
+			t.Errorf("Expected stock to be decremented to 8 and 4, got: %d and %d", product1.Stock, product2.Stock)
+		}
+
+		if len(mockProductRepo.Movements) != 2 {
+			t.Fatalf("Expected 2 stock movements, got: %d", len(mockProductRepo.Movements))
+		}
+		for _, movement := range mockProductRepo.Movements {
+			if movement.Reason != domain.StockMovementReasonSale || movement.OrderID == nil || *movement.OrderID != order.ID {
+				t.Errorf("Expected sale movement referencing order %s, got: %+v", order.ID, movement)
+			}
+		}
+	})
+
+	t.Run("Create order with mixed currencies", func(t *testing.T) {
+		customerID := uuid.New()
+		mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID}
+
+		usdProduct := &domain.Product{ID: uuid.New(), Name: "USD Product", Price: domain.NewMoney(1000, "USD"), Stock: 10, IsActive: true}
+		kesProduct := &domain.Product{ID: uuid.New(), Name: "KES Product", Price: domain.NewMoney(100000, "KES"), Stock: 10, IsActive: true}
+		mockProductRepo.Products[usdProduct.ID] = usdProduct
+		mockProductRepo.Products[kesProduct.ID] = kesProduct
+
+		req := &domain.CreateOrderRequest{
+			CustomerID: customerID,
+			OrderItems: []domain.CreateOrderItemRequest{
+				{ProductID: usdProduct.ID, Quantity: 1},
+				{ProductID: kesProduct.ID, Quantity: 1},
+			},
+			ShippingAddress: "123 Main St, New York, NY 10001",
+			BillingAddress:  "123 Main St, New York, NY 10001",
+		}
+
+		order, err := service.CreateOrder(ctx, req)
+
+		if !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("Expected ErrCurrencyMismatch, got: %v", err)
+		}
+		if order != nil {
+			t.Error("Expected order to be nil")
+		}
 	})
 
 	t.Run("Create order with non-existent customer", func(t *testing.T) {
@@ -201,7 +244,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			ID:       productID,
 			Name:     "Product 1",
 			SKU:      "PROD-001",
-			Price:    99.99,
+			Price:    domain.NewMoney(9999, "USD"),
 			Stock:    5, // Only 5 in stock
 			IsActive: true,
 		}
@@ -234,6 +277,37 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		}
 	})
 
+	t.Run("Quantities below one are rejected", func(t *testing.T) {
+		customerID := uuid.New()
+		mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "John", Email: "john@example.com"}
+		productID := uuid.New()
+		mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Product 1", SKU: "PROD-NEG", Price: domain.NewMoney(9999, "USD"), Stock: 5, IsActive: true}
+
+		for _, quantity := range []int{0, -3} {
+			req := &domain.CreateOrderRequest{
+				CustomerID: customerID,
+				OrderItems: []domain.CreateOrderItemRequest{{ProductID: productID, Quantity: quantity}},
+			}
+
+			if _, err := service.CreateOrder(ctx, req); !errors.Is(err, domain.ErrInvalidOrderItem) {
+				t.Errorf("Expected ErrInvalidOrderItem ordering %d, got: %v", quantity, err)
+			}
+			if _, err := service.QuoteOrder(ctx, req); !errors.Is(err, domain.ErrInvalidOrderItem) {
+				t.Errorf("Expected ErrInvalidOrderItem quoting %d, got: %v", quantity, err)
+			}
+		}
+		if stock := mockProductRepo.Products[productID].Stock; stock != 5 {
+			t.Errorf("Expected stock to be untouched, got: %d", stock)
+		}
+
+		_, err := service.UpdateOrderItems(ctx, uuid.New(), &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{{ProductID: productID, Quantity: -1}},
+		})
+		if !errors.Is(err, domain.ErrInvalidOrderItem) {
+			t.Errorf("Expected ErrInvalidOrderItem editing to -1, got: %v", err)
+		}
+	})
+
 	t.Run("Repository error during order creation", func(t *testing.T) {
 		// Set up customer
 		customerID := uuid.New()
@@ -253,7 +327,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			ID:       productID,
 			Name:     "Product 1",
 			SKU:      "PROD-001",
-			Price:    99.99,
+			Price:    domain.NewMoney(9999, "USD"),
 			Stock:    10,
 			IsActive: true,
 		}
@@ -284,12 +358,697 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	})
 }
 
+func TestOrderService_CreateOrderFromReservation(t *testing.T) {
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockReservationRepo := testutils.NewMockReservationRepository()
+	service := NewOrderService(testutils.NewMockOrderRepository(), testutils.NewMockOrderItemRepository(), testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), mockReservationRepo, testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "John", Email: "john@example.com"}
+
+	productID := uuid.New()
+	product := &domain.Product{ID: productID, Name: "Product 1", Price: domain.NewMoney(1000, "USD"), Stock: 5, IsActive: true}
+	mockProductRepo.Products[productID] = product
+
+	newReservation := func(owner uuid.UUID, quantity int, expiresAt time.Time) *domain.StockReservation {
+		reservation := &domain.StockReservation{ID: uuid.New(), ProductID: productID, CustomerID: owner, Quantity: quantity, Status: domain.ReservationStatusActive, ExpiresAt: expiresAt}
+		mockReservationRepo.Reservations[reservation.ID] = reservation
+		return reservation
+	}
+
+	newRequest := func(reservationIDs ...uuid.UUID) *domain.CreateOrderRequest {
+		return &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			ShippingAddress: "123 Main St",
+			BillingAddress:  "123 Main St",
+			ReservationIDs:  reservationIDs,
+		}
+	}
+
+	t.Run("Held stock cannot be sold to others", func(t *testing.T) {
+		mockProductRepo.Reserved[productID] = 4
+
+		_, err := service.CreateOrder(ctx, &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			ShippingAddress: "123 Main St",
+			BillingAddress:  "123 Main St",
+			OrderItems:      []domain.CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		})
+
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock, got: %v", err)
+		}
+		mockProductRepo.Reserved[productID] = 0
+	})
+
+	t.Run("Reservation is converted into an order item", func(t *testing.T) {
+		reservation := newReservation(customerID, 3, time.Now().Add(time.Minute))
+
+		order, err := service.CreateOrder(ctx, newRequest(reservation.ID))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if reservation.Status != domain.ReservationStatusConverted || reservation.OrderID == nil || *reservation.OrderID != order.ID {
+			t.Errorf("Expected reservation to be converted into order %s, got: %s", order.ID, reservation.Status)
+		}
+		if product.Stock != 2 {
+			t.Errorf("Expected on-hand stock to drop to 2, got: %d", product.Stock)
+		}
+		if order.TotalAmount != domain.NewMoney(3000, "USD") {
+			t.Errorf("Expected total of 30.00 USD, got: %s", order.TotalAmount)
+		}
+	})
+
+	t.Run("Expired reservation is rejected", func(t *testing.T) {
+		reservation := newReservation(customerID, 1, time.Now().Add(-time.Minute))
+
+		_, err := service.CreateOrder(ctx, newRequest(reservation.ID))
+
+		if !errors.Is(err, domain.ErrReservationUnavailable) {
+			t.Errorf("Expected ErrReservationUnavailable, got: %v", err)
+		}
+	})
+
+	t.Run("Reservation of another customer is rejected", func(t *testing.T) {
+		reservation := newReservation(uuid.New(), 1, time.Now().Add(time.Minute))
+
+		_, err := service.CreateOrder(ctx, newRequest(reservation.ID))
+
+		if !errors.Is(err, domain.ErrReservationUnavailable) {
+			t.Errorf("Expected ErrReservationUnavailable, got: %v", err)
+		}
+		if reservation.Status != domain.ReservationStatusActive {
+			t.Errorf("Expected reservation to stay active, got: %s", reservation.Status)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderAllocatesWarehouses(t *testing.T) {
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockStockLevelRepo := testutils.NewMockStockLevelRepository(mockProductRepo)
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	newService := func(strategy domain.AllocationStrategy) ports.OrderService {
+		return NewOrderService(testutils.NewMockOrderRepository(), mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), mockStockLevelRepo, testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, strategy)
+	}
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "John", Email: "john@example.com", Country: "KE", City: "Mombasa"}
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Product 1", Price: domain.NewMoney(1000, "KES"), Stock: 14, IsActive: true}
+
+	nairobi := &domain.Warehouse{ID: uuid.New(), Code: "NBO", City: "Nairobi", Country: "KE", IsActive: true}
+	mombasa := &domain.Warehouse{ID: uuid.New(), Code: "MBA", City: "Mombasa", Country: "KE", IsActive: true}
+	mockStockLevelRepo.Levels[productID] = []*domain.StockLevel{
+		{ProductID: productID, WarehouseID: nairobi.ID, Quantity: 10, Warehouse: nairobi},
+		{ProductID: productID, WarehouseID: mombasa.ID, Quantity: 4, Warehouse: mombasa},
+	}
+
+	newRequest := func(quantity int) *domain.CreateOrderRequest {
+		return &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			ShippingAddress: "Nyali Road, Mombasa, Kenya",
+			BillingAddress:  "Nyali Road, Mombasa, Kenya",
+			OrderItems:      []domain.CreateOrderItemRequest{{ProductID: productID, Quantity: quantity}},
+		}
+	}
+
+	takenFrom := func(orderID uuid.UUID) map[uuid.UUID]int {
+		taken := make(map[uuid.UUID]int)
+		for _, movement := range mockProductRepo.Movements {
+			if movement.OrderID != nil && *movement.OrderID == orderID && movement.WarehouseID != nil {
+				taken[*movement.WarehouseID] -= movement.Delta
+			}
+		}
+		return taken
+	}
+
+	t.Run("Nearest warehouse is used first, then the rest", func(t *testing.T) {
+		service := newService(domain.AllocationStrategyNearest)
+
+		order, err := service.CreateOrder(ctx, newRequest(6))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		taken := takenFrom(order.ID)
+		if taken[mombasa.ID] != 4 || taken[nairobi.ID] != 2 {
+			t.Errorf("Expected 4 from Mombasa and 2 from Nairobi, got: %v", taken)
+		}
+
+		// Cancelling puts the stock back where it came from
+		mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{&order.OrderItems[0]}
+		if err := service.CancelOrder(ctx, order.ID); err != nil {
+			t.Fatalf("Expected no error cancelling, got: %v", err)
+		}
+		if taken := takenFrom(order.ID); taken[mombasa.ID] != 0 || taken[nairobi.ID] != 0 {
+			t.Errorf("Expected cancellation to restock both warehouses, got: %v", taken)
+		}
+	})
+
+	t.Run("Most stock ignores the shipping address", func(t *testing.T) {
+		service := newService(domain.AllocationStrategyMostStock)
+
+		order, err := service.CreateOrder(ctx, newRequest(3))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if taken := takenFrom(order.ID); taken[nairobi.ID] != 3 {
+			t.Errorf("Expected 3 from Nairobi, got: %v", taken)
+		}
+	})
+
+	t.Run("Warehouses that cannot cover the order reject it", func(t *testing.T) {
+		service := newService(domain.AllocationStrategyNearest)
+		nairobi.IsActive = false
+		defer func() { nairobi.IsActive = true }()
+
+		_, err := service.CreateOrder(ctx, newRequest(5))
+
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock, got: %v", err)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderWithVariant(t *testing.T) {
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	service := NewOrderService(testutils.NewMockOrderRepository(), mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+
+	large := domain.NewMoney(1800, "USD")
+	small := &domain.ProductVariant{ID: uuid.New(), SKU: "TS-S", Options: domain.VariantOptions{"size": "S"}, Stock: 4, IsActive: true}
+	big := &domain.ProductVariant{ID: uuid.New(), SKU: "TS-XL", Options: domain.VariantOptions{"size": "XL"}, Price: &large, Stock: 1, IsActive: true}
+	productID := uuid.New()
+	product := &domain.Product{ID: productID, Name: "T-Shirt", SKU: "TS", Price: domain.NewMoney(1500, "USD"), Stock: 5, IsActive: true, Variants: []*domain.ProductVariant{small, big}}
+	small.ProductID, big.ProductID = productID, productID
+	mockProductRepo.Products[productID] = product
+
+	newOrder := func(items ...domain.CreateOrderItemRequest) (*domain.Order, error) {
+		return service.CreateOrder(ctx, &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			OrderItems:      items,
+			ShippingAddress: "1 Main St",
+			BillingAddress:  "1 Main St",
+		})
+	}
+
+	t.Run("Variant is required", func(t *testing.T) {
+		_, err := newOrder(domain.CreateOrderItemRequest{ProductID: productID, Quantity: 1})
+		if !errors.Is(err, domain.ErrVariantRequired) {
+			t.Errorf("Expected ErrVariantRequired, got: %v", err)
+		}
+	})
+
+	var order *domain.Order
+
+	t.Run("Variant price and stock are used", func(t *testing.T) {
+		var err error
+		order, err = newOrder(
+			domain.CreateOrderItemRequest{ProductID: productID, VariantID: &small.ID, Quantity: 2},
+			domain.CreateOrderItemRequest{ProductID: productID, VariantID: &big.ID, Quantity: 1},
+		)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if order.TotalAmount != domain.NewMoney(2*1500+1800, "USD") {
+			t.Errorf("Expected total of 48.00 USD, got: %s", order.TotalAmount)
+		}
+		if small.Stock != 2 || big.Stock != 0 || product.Stock != 2 {
+			t.Errorf("Expected variant stock 2 and 0 and product stock 2, got: %d, %d and %d", small.Stock, big.Stock, product.Stock)
+		}
+		if order.OrderItems[1].VariantID == nil || *order.OrderItems[1].VariantID != big.ID {
+			t.Errorf("Expected the order item to keep its variant")
+		}
+	})
+
+	t.Run("Out of stock variant", func(t *testing.T) {
+		_, err := newOrder(domain.CreateOrderItemRequest{ProductID: productID, VariantID: &big.ID, Quantity: 1})
+		if err == nil {
+			t.Error("Expected error ordering a variant without stock")
+		}
+	})
+
+	t.Run("Cancelling restores variant stock", func(t *testing.T) {
+		for i := range order.OrderItems {
+			mockOrderItemRepo.AllOrderItems = append(mockOrderItemRepo.AllOrderItems, &order.OrderItems[i])
+		}
+
+		if err := service.CancelOrder(ctx, order.ID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if small.Stock != 4 || big.Stock != 1 || product.Stock != 5 {
+			t.Errorf("Expected variant stock 4 and 1 and product stock 5, got: %d, %d and %d", small.Stock, big.Stock, product.Stock)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderUsesEffectivePrice(t *testing.T) {
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockPriceRepo := testutils.NewMockProductPriceRepository()
+	service := NewOrderService(testutils.NewMockOrderRepository(), testutils.NewMockOrderItemRepository(), testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, mockPriceRepo, testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+
+	large := domain.NewMoney(2500, "USD")
+	big := &domain.ProductVariant{ID: uuid.New(), SKU: "HD-XL", Options: domain.VariantOptions{"size": "XL"}, Price: &large, Stock: 5, IsActive: true}
+	productID := uuid.New()
+	big.ProductID = productID
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Hoodie", SKU: "HD", Price: domain.NewMoney(3000, "USD"), Stock: 10, IsActive: true}
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	sale := &domain.ProductPrice{ID: uuid.New(), ProductID: productID, Price: domain.NewMoney(2000, "USD"), Label: "Sale", EffectiveFrom: from, EffectiveTo: &to}
+	mockPriceRepo.Prices[sale.ID] = sale
+	future := &domain.ProductPrice{ID: uuid.New(), ProductID: productID, Price: domain.NewMoney(1000, "USD"), EffectiveFrom: to}
+	mockPriceRepo.Prices[future.ID] = future
+
+	order, err := service.CreateOrder(ctx, &domain.CreateOrderRequest{
+		CustomerID:      customerID,
+		OrderItems:      []domain.CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		ShippingAddress: "1 Main St",
+		BillingAddress:  "1 Main St",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if order.OrderItems[0].UnitPrice != sale.Price || order.TotalAmount != domain.NewMoney(4000, "USD") {
+		t.Errorf("Expected the sale price of 20.00 USD, got: %s", order.OrderItems[0].UnitPrice)
+	}
+
+	t.Run("Variant price overrides the sale", func(t *testing.T) {
+		mockProductRepo.Products[productID].Variants = []*domain.ProductVariant{big}
+		order, err := service.CreateOrder(ctx, &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			OrderItems:      []domain.CreateOrderItemRequest{{ProductID: productID, VariantID: &big.ID, Quantity: 1}},
+			ShippingAddress: "1 Main St",
+			BillingAddress:  "1 Main St",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if order.OrderItems[0].UnitPrice != large {
+			t.Errorf("Expected the variant price of 25.00 USD, got: %s", order.OrderItems[0].UnitPrice)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderWithPromotions(t *testing.T) {
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockPromotionRepo := testutils.NewMockPromotionRepository()
+	mockDiscountRepo := testutils.NewMockOrderDiscountRepository()
+	service := NewOrderService(testutils.NewMockOrderRepository(), mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), mockPromotionRepo, mockDiscountRepo, testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Mug", SKU: "MUG", Price: domain.NewMoney(1000, "USD"), Stock: 20, IsActive: true}
+
+	code := "SAVE10"
+	limit := 1
+	minSubtotal := domain.NewMoney(3000, "USD")
+	coupon := &domain.Promotion{ID: uuid.New(), Name: "Save 10%", Code: &code, Type: domain.PromotionTypePercentage, PercentOff: 10, MinSubtotal: &minSubtotal, UsageLimit: &limit, IsActive: true}
+	mockPromotionRepo.Promotions[coupon.ID] = coupon
+
+	itemsOf := func(repo *testutils.MockOrderItemRepository, orderID uuid.UUID) []*domain.OrderItem {
+		var items []*domain.OrderItem
+		for _, item := range repo.OrderItems {
+			if item.OrderID == orderID {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	newRequest := func(quantity int, codes ...string) *domain.CreateOrderRequest {
+		return &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			OrderItems:      []domain.CreateOrderItemRequest{{ProductID: productID, Quantity: quantity}},
+			ShippingAddress: "1 Main St",
+			BillingAddress:  "1 Main St",
+			CouponCodes:     codes,
+		}
+	}
+
+	t.Run("Quote previews the discount without redeeming it", func(t *testing.T) {
+		quote, err := service.QuoteOrder(ctx, newRequest(4, "save10"))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if quote.DiscountTotal != domain.NewMoney(400, "USD") || quote.GrandTotal != domain.NewMoney(3600, "USD") {
+			t.Errorf("Expected 4.00 off and a grand total of 36.00, got: %s off and %s", quote.DiscountTotal, quote.GrandTotal)
+		}
+		if coupon.TimesUsed != 0 || len(mockDiscountRepo.Discounts) != 0 {
+			t.Errorf("Expected the quote to leave the coupon unused")
+		}
+	})
+
+	t.Run("Unknown coupon is rejected", func(t *testing.T) {
+		_, err := service.CreateOrder(ctx, newRequest(4, "NOPE"))
+		if !errors.Is(err, domain.ErrInvalidCoupon) {
+			t.Errorf("Expected ErrInvalidCoupon, got: %v", err)
+		}
+	})
+
+	var order *domain.Order
+	t.Run("Coupon is applied and redeemed", func(t *testing.T) {
+		var err error
+		order, err = service.CreateOrder(ctx, newRequest(4, "SAVE10"))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if order.TotalAmount != domain.NewMoney(3600, "USD") || order.DiscountTotal != domain.NewMoney(400, "USD") {
+			t.Errorf("Expected a total of 36.00 after 4.00 off, got: %s after %s off", order.TotalAmount, order.DiscountTotal)
+		}
+		if order.OrderItems[0].DiscountAmount != domain.NewMoney(400, "USD") {
+			t.Errorf("Expected the line to carry the discount, got: %s", order.OrderItems[0].DiscountAmount)
+		}
+		if coupon.TimesUsed != 1 || len(order.Discounts) != 1 || len(mockDiscountRepo.Discounts) != 1 {
+			t.Errorf("Expected the coupon to be redeemed once, got %d uses", coupon.TimesUsed)
+		}
+	})
+
+	t.Run("Coupon past its usage limit is rejected", func(t *testing.T) {
+		_, err := service.CreateOrder(ctx, newRequest(4, "SAVE10"))
+		if !errors.Is(err, domain.ErrInvalidCoupon) {
+			t.Errorf("Expected ErrInvalidCoupon, got: %v", err)
+		}
+	})
+
+	t.Run("Discount is dropped when an edit falls below the minimum subtotal", func(t *testing.T) {
+		mockOrderItemRepo.AllOrderItems = itemsOf(mockOrderItemRepo, order.ID)
+		order, err := service.UpdateOrderItems(ctx, order.ID, &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{{ProductID: productID, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if order.TotalAmount != domain.NewMoney(2000, "USD") || order.DiscountTotal.Amount != 0 {
+			t.Errorf("Expected a total of 20.00 without discount, got: %s after %s off", order.TotalAmount, order.DiscountTotal)
+		}
+		if coupon.TimesUsed != 0 || len(mockDiscountRepo.Discounts) != 0 {
+			t.Errorf("Expected the coupon use to be given back")
+		}
+	})
+
+	t.Run("Cancelling an order gives back the coupon use", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, newRequest(3, "SAVE10"))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		mockOrderItemRepo.AllOrderItems = itemsOf(mockOrderItemRepo, order.ID)
+		if err := service.CancelOrder(ctx, order.ID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if coupon.TimesUsed != 0 {
+			t.Errorf("Expected the coupon use to be given back, got %d uses", coupon.TimesUsed)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderWithTax(t *testing.T) {
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockTaxRateRepo := testutils.NewMockTaxRateRepository()
+	service := NewOrderService(testutils.NewMockOrderRepository(), mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), mockTaxRateRepo, testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "Wanjiku", Email: "wanjiku@example.com", Country: "ke"}
+
+	foodID := uuid.New()
+	phone := &domain.Product{ID: uuid.New(), Name: "Phone", SKU: "PH-1", CategoryID: uuid.New(), Price: domain.NewMoney(11600, "KES"), Stock: 10, IsActive: true}
+	flour := &domain.Product{ID: uuid.New(), Name: "Flour", SKU: "FL-1", CategoryID: foodID, Price: domain.NewMoney(200, "KES"), Stock: 50, IsActive: true}
+	mockProductRepo.Products[phone.ID] = phone
+	mockProductRepo.Products[flour.ID] = flour
+
+	vat := &domain.TaxRate{ID: uuid.New(), Country: "KE", Name: "VAT", Rate: 1600, PricesIncludeTax: true}
+	zeroRated := &domain.TaxRate{ID: uuid.New(), Country: "KE", CategoryID: &foodID, Name: "Zero-rated", Rate: 0, PricesIncludeTax: true}
+	salesTax := &domain.TaxRate{ID: uuid.New(), Country: "US", Name: "Sales tax", Rate: 800}
+	for _, rate := range []*domain.TaxRate{vat, zeroRated, salesTax} {
+		mockTaxRateRepo.Rates[rate.ID] = rate
+	}
+
+	newRequest := func(country string) *domain.CreateOrderRequest {
+		return &domain.CreateOrderRequest{
+			CustomerID:      customerID,
+			OrderItems:      []domain.CreateOrderItemRequest{{ProductID: phone.ID, Quantity: 1}, {ProductID: flour.ID, Quantity: 5}},
+			ShippingAddress: "Moi Avenue, Nairobi",
+			ShippingCountry: country,
+			BillingAddress:  "Moi Avenue, Nairobi",
+		}
+	}
+
+	t.Run("Prices include VAT in the customer's country", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, newRequest(""))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if order.ShippingCountry != "KE" {
+			t.Errorf("Expected the order to ship to KE, got: %q", order.ShippingCountry)
+		}
+		if order.OrderItems[0].TaxAmount != domain.NewMoney(1600, "KES") || order.OrderItems[1].TaxAmount.Amount != 0 {
+			t.Errorf("Expected VAT on the phone only, got: %s and %s", order.OrderItems[0].TaxAmount, order.OrderItems[1].TaxAmount)
+		}
+		// 116.00 with 16.00 VAT in it, and 10.00 of zero-rated flour
+		if order.Subtotal != domain.NewMoney(11000, "KES") || order.TaxTotal != domain.NewMoney(1600, "KES") || order.GrandTotal != domain.NewMoney(12600, "KES") {
+			t.Errorf("Expected 110.00 + 16.00 = 126.00, got: %s + %s = %s", order.Subtotal, order.TaxTotal, order.GrandTotal)
+		}
+		if order.TotalAmount != order.GrandTotal {
+			t.Errorf("Expected the total amount to be the grand total, got: %s", order.TotalAmount)
+		}
+	})
+
+	t.Run("Cross-border sales are taxed at the destination's rate", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, newRequest("us"))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		// Sales tax of 8% on top of 126.00
+		if order.TaxTotal != domain.NewMoney(1008, "KES") || order.GrandTotal != domain.NewMoney(13608, "KES") {
+			t.Errorf("Expected 10.08 tax and a grand total of 136.08, got: %s and %s", order.TaxTotal, order.GrandTotal)
+		}
+	})
+
+	t.Run("Edited lines keep their rate", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, newRequest(""))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		vat.Rate = 1800
+		defer func() { vat.Rate = 1600 }()
+
+		mockOrderItemRepo.AllOrderItems = nil
+		for _, item := range mockOrderItemRepo.OrderItems {
+			if item.OrderID == order.ID {
+				mockOrderItemRepo.AllOrderItems = append(mockOrderItemRepo.AllOrderItems, item)
+			}
+		}
+		order, err = service.UpdateOrderItems(ctx, order.ID, &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{{ProductID: phone.ID, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if order.TaxTotal != domain.NewMoney(3200, "KES") {
+			t.Errorf("Expected 32.00 VAT at the original 16%%, got: %s", order.TaxTotal)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderWithShipping(t *testing.T) {
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockShippingMethodRepo := testutils.NewMockShippingMethodRepository()
+	service := NewOrderService(testutils.NewMockOrderRepository(), mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), mockShippingMethodRepo, testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "Wanjiku", Email: "wanjiku@example.com", Country: "KE", City: "Nairobi"}
+
+	speaker := &domain.Product{ID: uuid.New(), Name: "Speaker", SKU: "SP-1", CategoryID: uuid.New(), Price: domain.NewMoney(5000, "KES"), Stock: 20, IsActive: true, WeightGrams: 1500}
+	book := &domain.Product{ID: uuid.New(), Name: "Book", SKU: "BK-1", CategoryID: uuid.New(), Price: domain.NewMoney(1000, "KES"), Stock: 20, IsActive: true, WeightGrams: 400}
+	mockProductRepo.Products[speaker.ID] = speaker
+	mockProductRepo.Products[book.ID] = book
+
+	perKg, freeOver := domain.NewMoney(100, "KES"), domain.NewMoney(10000, "KES")
+	courier := &domain.ShippingMethod{ID: uuid.New(), Name: "Courier", Type: domain.ShippingWeightBased, Amount: domain.NewMoney(200, "KES"), PerKgAmount: &perKg, Zones: []domain.ShippingZone{{Country: "KE"}}, IsActive: true}
+	boda := &domain.ShippingMethod{ID: uuid.New(), Name: "Boda", Type: domain.ShippingFlatRate, Amount: domain.NewMoney(150, "KES"), Zones: []domain.ShippingZone{{Country: "KE", City: "Nairobi"}}, IsActive: true}
+	free := &domain.ShippingMethod{ID: uuid.New(), Name: "Free over 100", Type: domain.ShippingFreeOverThreshold, Amount: domain.NewMoney(300, "KES"), FreeOver: &freeOver, IsActive: true}
+	express := &domain.ShippingMethod{ID: uuid.New(), Name: "Express", Type: domain.ShippingFlatRate, Amount: domain.NewMoney(900, "KES")}
+	for _, method := range []*domain.ShippingMethod{courier, boda, free, express} {
+		mockShippingMethodRepo.Methods[method.ID] = method
+	}
+
+	newRequest := func(methodID *uuid.UUID, speakers int) *domain.CreateOrderRequest {
+		return &domain.CreateOrderRequest{
+			CustomerID:       customerID,
+			OrderItems:       []domain.CreateOrderItemRequest{{ProductID: speaker.ID, Quantity: speakers}, {ProductID: book.ID, Quantity: 1}},
+			ShippingAddress:  "Moi Avenue, Nairobi",
+			ShippingMethodID: methodID,
+			BillingAddress:   "Moi Avenue, Nairobi",
+		}
+	}
+
+	t.Run("Rates of the methods that ship to the customer's city", func(t *testing.T) {
+		rates, err := service.QuoteShipping(ctx, newRequest(nil, 1))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		// 1.9kg is charged as 2kg by the courier; the items come to 60.00
+		if len(rates) != 3 || rates[0].MethodID != boda.ID || rates[1].MethodID != free.ID || rates[2].Amount != domain.NewMoney(400, "KES") {
+			t.Errorf("Expected boda 1.50, free over 100 3.00 and courier 4.00, got: %+v", rates)
+		}
+
+		elsewhere := newRequest(nil, 1)
+		elsewhere.ShippingCity = "Mombasa"
+		rates, err = service.QuoteShipping(ctx, elsewhere)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(rates) != 2 {
+			t.Errorf("Expected boda not to ship to Mombasa, got: %+v", rates)
+		}
+	})
+
+	t.Run("The order is charged for its method", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, newRequest(&courier.ID, 1))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if order.ShippingMethodID == nil || *order.ShippingMethodID != courier.ID || order.ShippingMethodName != "Courier" || order.ShippingCity != "Nairobi" {
+			t.Errorf("Expected the order to ship to Nairobi by courier, got: %+v", order)
+		}
+		if order.ShippingTotal != domain.NewMoney(400, "KES") || order.GrandTotal != domain.NewMoney(6400, "KES") {
+			t.Errorf("Expected 4.00 shipping and a grand total of 64.00, got: %s and %s", order.ShippingTotal, order.GrandTotal)
+		}
+	})
+
+	t.Run("A method that does not ship to the destination", func(t *testing.T) {
+		req := newRequest(&boda.ID, 1)
+		req.ShippingCity = "Mombasa"
+		if _, err := service.CreateOrder(ctx, req); !errors.Is(err, domain.ErrShippingUnavailable) {
+			t.Errorf("Expected ErrShippingUnavailable, got: %v", err)
+		}
+		if _, err := service.CreateOrder(ctx, newRequest(&express.ID, 1)); !errors.Is(err, domain.ErrShippingUnavailable) {
+			t.Errorf("Expected ErrShippingUnavailable for an inactive method, got: %v", err)
+		}
+	})
+
+	t.Run("Free over the threshold", func(t *testing.T) {
+		quote, err := service.QuoteOrder(ctx, newRequest(&free.ID, 2))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !quote.ShippingTotal.IsZero() || quote.GrandTotal != domain.NewMoney(11000, "KES") {
+			t.Errorf("Expected free shipping over 100.00, got: %s and a grand total of %s", quote.ShippingTotal, quote.GrandTotal)
+		}
+	})
+
+	t.Run("Editing the items charges shipping again", func(t *testing.T) {
+		order, err := service.CreateOrder(ctx, newRequest(&courier.ID, 1))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		mockOrderItemRepo.AllOrderItems = nil
+		for _, item := range mockOrderItemRepo.OrderItems {
+			if item.OrderID == order.ID {
+				mockOrderItemRepo.AllOrderItems = append(mockOrderItemRepo.AllOrderItems, item)
+			}
+		}
+		order, err = service.UpdateOrderItems(ctx, order.ID, &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{{ProductID: speaker.ID, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		// 3.4kg is charged as 4kg
+		if order.ShippingTotal != domain.NewMoney(600, "KES") || order.GrandTotal != domain.NewMoney(11600, "KES") {
+			t.Errorf("Expected 6.00 shipping and a grand total of 116.00, got: %s and %s", order.ShippingTotal, order.GrandTotal)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderLowStockAlert(t *testing.T) {
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockLowStock := testutils.NewMockLowStockNotifier()
+	service := NewOrderService(testutils.NewMockOrderRepository(), testutils.NewMockOrderItemRepository(), testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), mockLowStock, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "John", Email: "john@example.com"}
+
+	widgetID, gadgetID := uuid.New(), uuid.New()
+	mockProductRepo.Products[widgetID] = &domain.Product{ID: widgetID, Name: "Widget", SKU: "W-1", Price: domain.NewMoney(1000, "KES"), Stock: 12, ReorderPoint: 10, IsActive: true}
+	mockProductRepo.Products[gadgetID] = &domain.Product{ID: gadgetID, Name: "Gadget", SKU: "G-1", Price: domain.NewMoney(500, "KES"), Stock: 50, ReorderPoint: 10, IsActive: true}
+
+	newRequest := func(items ...domain.CreateOrderItemRequest) *domain.CreateOrderRequest {
+		return &domain.CreateOrderRequest{CustomerID: customerID, ShippingAddress: "Nairobi", BillingAddress: "Nairobi", OrderItems: items}
+	}
+
+	t.Run("Only products dropping below their reorder point alert", func(t *testing.T) {
+		_, err := service.CreateOrder(ctx, newRequest(
+			domain.CreateOrderItemRequest{ProductID: widgetID, Quantity: 5},
+			domain.CreateOrderItemRequest{ProductID: gadgetID, Quantity: 5},
+		))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(mockLowStock.Events) != 1 {
+			t.Fatalf("Expected 1 low stock event, got: %d", len(mockLowStock.Events))
+		}
+		if event := mockLowStock.Events[0]; event.ProductID != widgetID || event.Stock != 7 {
+			t.Errorf("Expected Widget to drop to 7, got: %+v", event)
+		}
+	})
+
+	t.Run("Failed orders do not alert", func(t *testing.T) {
+		mockLowStock.Events = nil
+		mockProductRepo.Products[gadgetID].Stock = 12
+
+		_, err := service.CreateOrder(ctx, newRequest(
+			domain.CreateOrderItemRequest{ProductID: gadgetID, Quantity: 5},
+			domain.CreateOrderItemRequest{ProductID: uuid.New(), Quantity: 1},
+		))
+		if err == nil {
+			t.Fatal("Expected error for unknown product")
+		}
+
+		if len(mockLowStock.Events) != 0 {
+			t.Errorf("Expected no low stock events, got: %d", len(mockLowStock.Events))
+		}
+	})
+}
+
 func TestOrderService_GetOrder(t *testing.T) {
 	mockOrderRepo := testutils.NewMockOrderRepository()
 	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
 	mockCustomerRepo := testutils.NewMockCustomerRepository()
 	mockProductRepo := testutils.NewMockProductRepository()
-	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, mockCustomerRepo, mockProductRepo)
+	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
 	ctx := context.Background()
 
 	t.Run("Get existing order", func(t *testing.T) {
@@ -299,7 +1058,7 @@ func TestOrderService_GetOrder(t *testing.T) {
 			CustomerID:      uuid.New(),
 			OrderNumber:     "ORD-001",
 			Status:          "PENDING",
-			TotalAmount:     199.98,
+			TotalAmount:     domain.NewMoney(19998, "USD"),
 			ShippingAddress: "123 Main St",
 			BillingAddress:  "123 Main St",
 			CreatedAt:       time.Now(),
@@ -356,12 +1115,48 @@ func TestOrderService_GetOrder(t *testing.T) {
 	})
 }
 
+func TestOrderService_GetOrderByNumber(t *testing.T) {
+	mockOrderRepo := testutils.NewMockOrderRepository()
+	mockOrderNumbers := testutils.NewMockOrderNumberGenerator()
+	service := NewOrderService(mockOrderRepo, testutils.NewMockOrderItemRepository(), testutils.NewMockOrderStatusHistoryRepository(), testutils.NewMockCustomerRepository(), testutils.NewMockProductRepository(), testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(nil), testutils.NewMockTxManager(), mockOrderNumbers, nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	orderNumber, _ := mockOrderNumbers.Next(ctx)
+	orderID := uuid.New()
+	mockOrderRepo.Orders[orderID] = &domain.Order{ID: orderID, OrderNumber: orderNumber}
+
+	t.Run("Get existing order", func(t *testing.T) {
+		order, err := service.GetOrderByNumber(ctx, orderNumber)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if order == nil || order.ID != orderID {
+			t.Errorf("Expected order %s to be returned", orderID)
+		}
+	})
+
+	t.Run("Invalid check digit is rejected before the repository", func(t *testing.T) {
+		last := orderNumber[len(orderNumber)-1]
+		mistyped := orderNumber[:len(orderNumber)-1] + string(byte('0'+(int(last-'0')+1)%10))
+
+		order, err := service.GetOrderByNumber(ctx, mistyped)
+
+		if !errors.Is(err, domain.ErrInvalidOrderNumber) {
+			t.Errorf("Expected ErrInvalidOrderNumber, got: %v", err)
+		}
+		if order != nil {
+			t.Error("Expected order to be nil")
+		}
+	})
+}
+
 func TestOrderService_GetOrders(t *testing.T) {
 	mockOrderRepo := testutils.NewMockOrderRepository()
 	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
 	mockCustomerRepo := testutils.NewMockCustomerRepository()
 	mockProductRepo := testutils.NewMockProductRepository()
-	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, mockCustomerRepo, mockProductRepo)
+	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
 	ctx := context.Background()
 
 	t.Run("Get orders successfully", func(t *testing.T) {
@@ -370,7 +1165,7 @@ func TestOrderService_GetOrders(t *testing.T) {
 				ID:          uuid.New(),
 				OrderNumber: "ORD-001",
 				Status:      "PENDING",
-				TotalAmount: 199.98,
+				TotalAmount: domain.NewMoney(19998, "USD"),
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
@@ -378,28 +1173,28 @@ func TestOrderService_GetOrders(t *testing.T) {
 				ID:          uuid.New(),
 				OrderNumber: "ORD-002",
 				Status:      "SHIPPED",
-				TotalAmount: 299.97,
+				TotalAmount: domain.NewMoney(29997, "USD"),
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
 		}
 		mockOrderRepo.AllOrders = orders
 
-		result, err := service.GetOrders(ctx, 10, 0)
+		result, err := service.GetOrders(ctx, ports.PageRequest{Limit: 10})
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
 		}
 
-		if len(result) != 2 {
-			t.Errorf("Expected 2 orders, got: %d", len(result))
+		if len(result.Items) != 2 {
+			t.Errorf("Expected 2 orders, got: %d", len(result.Items))
 		}
 	})
 
 	t.Run("Repository error", func(t *testing.T) {
 		mockOrderRepo.GetAllError = errors.New("database error")
 
-		orders, err := service.GetOrders(ctx, 10, 0)
+		orders, err := service.GetOrders(ctx, ports.PageRequest{Limit: 10})
 
 		if err == nil {
 			t.Error("Expected error from repository")
@@ -414,24 +1209,25 @@ func TestOrderService_GetOrders(t *testing.T) {
 func TestOrderService_UpdateOrderStatus(t *testing.T) {
 	mockOrderRepo := testutils.NewMockOrderRepository()
 	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockHistoryRepo := testutils.NewMockOrderStatusHistoryRepository()
 	mockCustomerRepo := testutils.NewMockCustomerRepository()
 	mockProductRepo := testutils.NewMockProductRepository()
-	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, mockCustomerRepo, mockProductRepo)
-	ctx := context.Background()
+	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, mockHistoryRepo, mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := domain.ContextWithActor(context.Background(), "user:admin")
 
 	t.Run("Update order status successfully", func(t *testing.T) {
 		orderID := uuid.New()
 		existingOrder := &domain.Order{
 			ID:          orderID,
 			OrderNumber: "ORD-001",
-			Status:      "PENDING",
-			TotalAmount: 199.98,
+			Status:      domain.OrderStatusPending,
+			TotalAmount: domain.NewMoney(19998, "USD"),
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}
 		mockOrderRepo.Orders[orderID] = existingOrder
 
-		err := service.UpdateOrderStatus(ctx, orderID, "SHIPPED")
+		err := service.UpdateOrderStatus(ctx, orderID, domain.OrderStatusConfirmed, "payment received")
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
@@ -439,15 +1235,65 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 
 		// Verify status was updated
 		updatedOrder := mockOrderRepo.Orders[orderID]
-		if updatedOrder.Status != "SHIPPED" {
-			t.Errorf("Expected status to be 'SHIPPED', got: %s", updatedOrder.Status)
+		if updatedOrder.Status != domain.OrderStatusConfirmed {
+			t.Errorf("Expected status to be 'confirmed', got: %s", updatedOrder.Status)
+		}
+
+		// Verify the change was recorded
+		history, _ := mockHistoryRepo.GetByOrderID(ctx, orderID)
+		if len(history) != 1 {
+			t.Fatalf("Expected 1 history entry, got: %d", len(history))
+		}
+		if *history[0].FromStatus != domain.OrderStatusPending || history[0].ToStatus != domain.OrderStatusConfirmed {
+			t.Errorf("Expected pending -> confirmed, got: %s -> %s", *history[0].FromStatus, history[0].ToStatus)
+		}
+		if history[0].Actor != "user:admin" || history[0].Reason != "payment received" {
+			t.Errorf("Expected actor and reason to be recorded, got: %s, %s", history[0].Actor, history[0].Reason)
+		}
+	})
+
+	t.Run("Shipping sets shipped date", func(t *testing.T) {
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{
+			ID:          orderID,
+			OrderNumber: "ORD-002",
+			Status:      domain.OrderStatusProcessing,
+		}
+
+		err := service.UpdateOrderStatus(ctx, orderID, domain.OrderStatusShipped, "")
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if mockOrderRepo.Orders[orderID].ShippedDate == nil {
+			t.Error("Expected ShippedDate to be set")
+		}
+	})
+
+	t.Run("Invalid transition is rejected", func(t *testing.T) {
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{
+			ID:          orderID,
+			OrderNumber: "ORD-003",
+			Status:      domain.OrderStatusDelivered,
+		}
+
+		err := service.UpdateOrderStatus(ctx, orderID, domain.OrderStatusPending, "")
+
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+
+		if mockOrderRepo.Orders[orderID].Status != domain.OrderStatusDelivered {
+			t.Errorf("Expected status to remain 'delivered', got: %s", mockOrderRepo.Orders[orderID].Status)
 		}
 	})
 
 	t.Run("Update non-existent order status", func(t *testing.T) {
 		orderID := uuid.New()
 
-		err := service.UpdateOrderStatus(ctx, orderID, "SHIPPED")
+		err := service.UpdateOrderStatus(ctx, orderID, domain.OrderStatusShipped, "")
 
 		if err == nil {
 			t.Error("Expected error for non-existent order")
@@ -463,18 +1309,175 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		existingOrder := &domain.Order{
 			ID:          orderID,
 			OrderNumber: "ORD-001",
-			Status:      "PENDING",
-			TotalAmount: 199.98,
+			Status:      domain.OrderStatusPending,
+			TotalAmount: domain.NewMoney(19998, "USD"),
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}
 		mockOrderRepo.Orders[orderID] = existingOrder
 		mockOrderRepo.UpdateError = errors.New("database error")
 
-		err := service.UpdateOrderStatus(ctx, orderID, "SHIPPED")
+		err := service.UpdateOrderStatus(ctx, orderID, domain.OrderStatusConfirmed, "")
 
 		if err == nil {
 			t.Error("Expected error from repository")
 		}
 	})
 }
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	mockOrderRepo := testutils.NewMockOrderRepository()
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockTxManager := testutils.NewMockTxManager()
+	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), mockCustomerRepo, mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), mockTxManager, testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	t.Run("Cancel pending order restores stock", func(t *testing.T) {
+		productID := uuid.New()
+		product := &domain.Product{
+			ID:       productID,
+			Name:     "Product 1",
+			SKU:      "PROD-001",
+			Price:    domain.NewMoney(9999, "USD"),
+			Stock:    3,
+			IsActive: true,
+		}
+		mockProductRepo.Products[productID] = product
+
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{
+			ID:          orderID,
+			OrderNumber: "ORD-001",
+			Status:      domain.OrderStatusPending,
+		}
+		mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{
+			{ID: uuid.New(), OrderID: orderID, ProductID: productID, Quantity: 2},
+		}
+
+		err := service.CancelOrder(ctx, orderID)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if product.Stock != 5 {
+			t.Errorf("Expected stock to be restored to 5, got: %d", product.Stock)
+		}
+
+		if n := len(mockProductRepo.Movements); n != 1 || mockProductRepo.Movements[0].Reason != domain.StockMovementReasonCancel || mockProductRepo.Movements[0].Delta != 2 {
+			t.Errorf("Expected one cancel movement of +2, got: %+v", mockProductRepo.Movements)
+		}
+
+		if mockOrderRepo.Orders[orderID].Status != domain.OrderStatusCancelled {
+			t.Errorf("Expected status to be cancelled, got: %s", mockOrderRepo.Orders[orderID].Status)
+		}
+
+		if mockTxManager.CommitCount != 1 {
+			t.Errorf("Expected cancellation to commit one transaction, got: %d", mockTxManager.CommitCount)
+		}
+	})
+
+	t.Run("Cancel shipped order fails", func(t *testing.T) {
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{
+			ID:          orderID,
+			OrderNumber: "ORD-002",
+			Status:      domain.OrderStatusShipped,
+		}
+
+		err := service.CancelOrder(ctx, orderID)
+
+		if err == nil {
+			t.Error("Expected error when cancelling shipped order")
+		}
+	})
+}
+
+func TestOrderService_UpdateOrderItems(t *testing.T) {
+	mockOrderRepo := testutils.NewMockOrderRepository()
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	service := NewOrderService(mockOrderRepo, mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), testutils.NewMockCustomerRepository(), mockProductRepo, testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(mockProductRepo), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	ctx := context.Background()
+
+	t.Run("Add, change and remove items", func(t *testing.T) {
+		kept := &domain.Product{ID: uuid.New(), Name: "Kept", Price: domain.NewMoney(1000, "USD"), Stock: 10, IsActive: true}
+		removed := &domain.Product{ID: uuid.New(), Name: "Removed", Price: domain.NewMoney(500, "USD"), Stock: 0, IsActive: true}
+		added := &domain.Product{ID: uuid.New(), Name: "Added", Price: domain.NewMoney(250, "USD"), Stock: 4, IsActive: true}
+		for _, p := range []*domain.Product{kept, removed, added} {
+			mockProductRepo.Products[p.ID] = p
+		}
+
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{
+			ID:          orderID,
+			OrderNumber: "ORD-001",
+			Status:      domain.OrderStatusConfirmed,
+			TotalAmount: domain.NewMoney(3000, "USD"),
+		}
+		// The kept line was priced at 8.00 when the order was placed
+		mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{
+			{ID: uuid.New(), OrderID: orderID, ProductID: kept.ID, Quantity: 2, UnitPrice: domain.NewMoney(800, "USD"), TotalPrice: domain.NewMoney(1600, "USD")},
+			{ID: uuid.New(), OrderID: orderID, ProductID: removed.ID, Quantity: 2, UnitPrice: domain.NewMoney(500, "USD"), TotalPrice: domain.NewMoney(1000, "USD")},
+		}
+		for _, item := range mockOrderItemRepo.AllOrderItems {
+			mockOrderItemRepo.OrderItems[item.ID] = item
+		}
+
+		order, err := service.UpdateOrderItems(ctx, orderID, &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{
+				{ProductID: kept.ID, Quantity: 5},
+				{ProductID: removed.ID, Quantity: 0},
+				{ProductID: added.ID, Quantity: 4},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(order.OrderItems) != 2 {
+			t.Errorf("Expected 2 order items, got: %d", len(order.OrderItems))
+		}
+		// 5 x 8.00 + 4 x 2.50
+		if expected := domain.NewMoney(5000, "USD"); order.TotalAmount != expected {
+			t.Errorf("Expected total %s, got: %s", expected, order.TotalAmount)
+		}
+		if kept.Stock != 7 || removed.Stock != 2 || added.Stock != 0 {
+			t.Errorf("Expected stock 7/2/0, got: %d/%d/%d", kept.Stock, removed.Stock, added.Stock)
+		}
+	})
+
+	t.Run("Insufficient stock for an increase is rejected", func(t *testing.T) {
+		product := &domain.Product{ID: uuid.New(), Name: "Scarce", Price: domain.NewMoney(1000, "USD"), Stock: 1, IsActive: true}
+		mockProductRepo.Products[product.ID] = product
+
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{ID: orderID, OrderNumber: "ORD-002", Status: domain.OrderStatusPending}
+		mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{
+			{ID: uuid.New(), OrderID: orderID, ProductID: product.ID, Quantity: 1, UnitPrice: product.Price, TotalPrice: product.Price},
+		}
+
+		_, err := service.UpdateOrderItems(ctx, orderID, &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{{ProductID: product.ID, Quantity: 3}},
+		})
+
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock, got: %v", err)
+		}
+	})
+
+	t.Run("Processing order cannot be edited", func(t *testing.T) {
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{ID: orderID, OrderNumber: "ORD-003", Status: domain.OrderStatusProcessing}
+
+		_, err := service.UpdateOrderItems(ctx, orderID, &domain.UpdateOrderItemsRequest{
+			Items: []domain.OrderItemChange{{ProductID: uuid.New(), Quantity: 1}},
+		})
+
+		if !errors.Is(err, domain.ErrOrderNotEditable) {
+			t.Errorf("Expected ErrOrderNotEditable, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// exportBatchSize is how many products an export reads at a time
+const exportBatchSize = 500
+
+// ImportProducts reads the records one at a time, so that imports of any size run in
+// constant memory apart from the SKUs seen, which catch a file listing a SKU twice
+func (s *productService) ImportProducts(ctx context.Context, format domain.ProductRecordFormat, r io.Reader, dryRun bool) (*domain.ProductImportReport, error) {
+	reader, err := domain.NewProductRecordReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ProductImportReport{DryRun: dryRun, Errors: make([]domain.ProductImportError, 0)}
+	categories := make(map[string]uuid.UUID)
+	seen := make(map[string]int)
+	for {
+		record, line, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		report.Records++
+		if err != nil {
+			if !errors.Is(err, domain.ErrInvalidProductRecord) {
+				return nil, fmt.Errorf("failed to read line %d: %w", line, err)
+			}
+			report.Fail(line, "", err)
+			continue
+		}
+
+		if first, ok := seen[record.SKU]; ok {
+			report.Fail(line, record.SKU, fmt.Errorf("%w: SKU %s is also on line %d", domain.ErrInvalidProductRecord, record.SKU, first))
+			continue
+		}
+		seen[record.SKU] = line
+
+		created, err := s.importRecord(ctx, record, categories, dryRun)
+		switch {
+		case err != nil:
+			report.Fail(line, record.SKU, err)
+		case created:
+			report.Created++
+		default:
+			report.Updated++
+		}
+	}
+
+	return report, nil
+}
+
+// importRecord creates the record's product, or updates it when its SKU exists, reporting
+// whether it was created. Categories caches the categories resolved so far.
+func (s *productService) importRecord(ctx context.Context, record *domain.ProductRecord, categories map[string]uuid.UUID, dryRun bool) (bool, error) {
+	categoryID, err := s.resolveCategory(ctx, record, categories)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := s.productRepo.GetBySKU(ctx, record.SKU)
+	if err != nil {
+		return false, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if existing == nil {
+		if categoryID == nil {
+			return false, fmt.Errorf("%w: category is required for a new product", domain.ErrInvalidProductRecord)
+		}
+		req, err := record.CreateRequest(*categoryID)
+		if err != nil {
+			return false, err
+		}
+		if !dryRun {
+			if _, err := s.CreateProduct(ctx, req); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	// GetBySKU also finds products by the SKUs of their variants
+	if existing.SKU != record.SKU {
+		return false, fmt.Errorf("SKU %s belongs to a variant of product %s", record.SKU, existing.SKU)
+	}
+	req, err := record.UpdateRequest(categoryID)
+	if err != nil {
+		return false, err
+	}
+	if dryRun {
+		if req.Stock != nil {
+			return false, checkStockNotByVariant(existing)
+		}
+		return false, nil
+	}
+	if _, err := s.UpdateProduct(ctx, existing.ID, req); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// resolveCategory finds the record's category by ID or name, returning nil when the record
+// names none
+func (s *productService) resolveCategory(ctx context.Context, record *domain.ProductRecord, categories map[string]uuid.UUID) (*uuid.UUID, error) {
+	key := "name:" + record.Category
+	if record.CategoryID != "" {
+		key = "id:" + record.CategoryID
+	} else if record.Category == "" {
+		return nil, nil
+	}
+	if id, ok := categories[key]; ok {
+		return &id, nil
+	}
+
+	var category *domain.Category
+	if record.CategoryID != "" {
+		id, err := uuid.Parse(record.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid category_id %q", domain.ErrInvalidProductRecord, record.CategoryID)
+		}
+		if category, err = s.categoryRepo.GetByID(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+	} else {
+		var err error
+		if category, err = s.categoryRepo.GetByName(ctx, record.Category); err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+	}
+	if category == nil {
+		return nil, fmt.Errorf("category %s not found", key[strings.Index(key, ":")+1:])
+	}
+
+	categories[key] = category.ID
+	return &category.ID, nil
+}
+
+// ExportProducts walks the catalogue in SKU order a batch at a time, flushing each batch to
+// w, so that neither the whole table nor the whole file is held in memory
+func (s *productService) ExportProducts(ctx context.Context, format domain.ProductRecordFormat, w io.Writer) error {
+	writer, err := domain.NewProductRecordWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	afterSKU := ""
+	for {
+		products, err := s.productRepo.ListAfterSKU(ctx, afterSKU, exportBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get products: %w", err)
+		}
+		for _, product := range products {
+			if err := writer.Write(domain.NewProductRecord(product)); err != nil {
+				return fmt.Errorf("failed to write product %s: %w", product.SKU, err)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write products: %w", err)
+		}
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		afterSKU = products[len(products)-1].SKU
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestProductService_ImportProducts(t *testing.T) {
+	ctx := context.Background()
+	setup := func() (*testutils.MockProductRepository, *domain.Category, *domain.Product, *productService) {
+		mockProductRepo := testutils.NewMockProductRepository()
+		mockCategoryRepo := testutils.NewMockCategoryRepository()
+		service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil).(*productService)
+
+		category := &domain.Category{ID: uuid.New(), Name: "Phones"}
+		mockCategoryRepo.Categories[category.ID] = category
+		existing := &domain.Product{ID: uuid.New(), Name: "Phone", SKU: "PH-1", Price: domain.NewMoney(50000, "USD"), Stock: 3, CategoryID: category.ID, IsActive: true}
+		mockProductRepo.Products[existing.ID] = existing
+		mockProductRepo.ProductsBySKU[existing.SKU] = existing
+		return mockProductRepo, category, existing, service
+	}
+	file := "sku,name,price,stock,category,is_active\n" +
+		"PH-2,Phone Pro,799.99,5,Phones,\n" +
+		"PH-1,,549.00,,,false\n" +
+		"PH-3,Phone Mini,-1,1,Phones,\n" +
+		"PH-4,Phone Max,999,1,Tablets,\n" +
+		"PH-2,Phone Pro,799.99,5,Phones,\n"
+
+	t.Run("Create and update by SKU, reporting failed rows", func(t *testing.T) {
+		mockProductRepo, category, existing, service := setup()
+
+		report, err := service.ImportProducts(ctx, domain.ProductFormatCSV, strings.NewReader(file), false)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if report.Records != 5 || report.Created != 1 || report.Updated != 1 || report.Failed != 3 {
+			t.Errorf("Expected 1 created, 1 updated and 3 failed of 5, got: %+v", report)
+		}
+		if len(report.Errors) != 3 || report.Errors[0].Line != 4 || report.Errors[1].Line != 5 || report.Errors[2].Line != 6 {
+			t.Errorf("Expected errors on lines 4, 5 and 6, got: %+v", report.Errors)
+		}
+
+		created := mockProductRepo.ProductsBySKU["PH-2"]
+		if created == nil || created.CategoryID != category.ID || created.Price != domain.NewMoney(79999, "USD") || !created.IsActive {
+			t.Errorf("Expected an active Phone Pro at 799.99 in Phones, got: %+v", created)
+		}
+		if existing.Name != "Phone" || existing.Price.Amount != 54900 || existing.IsActive || existing.Stock != 3 {
+			t.Errorf("Expected only the price and status of the existing product to change, got: %+v", existing)
+		}
+	})
+
+	t.Run("Dry run validates without writing", func(t *testing.T) {
+		mockProductRepo, _, existing, service := setup()
+
+		report, err := service.ImportProducts(ctx, domain.ProductFormatCSV, strings.NewReader(file), true)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !report.DryRun || report.Created != 1 || report.Updated != 1 || report.Failed != 3 {
+			t.Errorf("Expected the same counts as a real import, got: %+v", report)
+		}
+		if len(mockProductRepo.Products) != 1 || existing.Price.Amount != 50000 {
+			t.Errorf("Expected nothing written, got %d products", len(mockProductRepo.Products))
+		}
+	})
+
+	t.Run("Import JSON Lines with categories by ID", func(t *testing.T) {
+		mockProductRepo, category, _, service := setup()
+		lines := `{"sku":"PH-5","name":"Phone Lite","price":"199","category_id":"` + category.ID.String() + `","attributes":{"colour":"blue"}}` + "\n\n" +
+			`{"sku":"PH-6","name":"Phone Air","price":"299","unknown":true}` + "\n"
+
+		report, err := service.ImportProducts(ctx, domain.ProductFormatJSONL, strings.NewReader(lines), false)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if report.Created != 1 || report.Failed != 1 || report.Errors[0].Line != 3 {
+			t.Errorf("Expected one product created and line 3 failed, got: %+v", report)
+		}
+		if created := mockProductRepo.ProductsBySKU["PH-5"]; created == nil || created.Attributes["colour"] != "blue" {
+			t.Errorf("Expected Phone Lite with its attributes, got: %+v", created)
+		}
+	})
+
+	t.Run("Reject a file with unknown columns", func(t *testing.T) {
+		_, _, _, service := setup()
+
+		_, err := service.ImportProducts(ctx, domain.ProductFormatCSV, strings.NewReader("sku,colour\nPH-1,red\n"), false)
+
+		if err == nil {
+			t.Error("Expected an error for an unknown column, got nil")
+		}
+	})
+}
+
+func TestProductService_ExportProducts(t *testing.T) {
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockCategoryRepo := testutils.NewMockCategoryRepository()
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
+	ctx := context.Background()
+
+	category := domain.Category{ID: uuid.New(), Name: "Phones"}
+	mockCategoryRepo.Categories[category.ID] = &category
+	for _, sku := range []string{"B-2", "A-1", "C-3"} {
+		product := &domain.Product{ID: uuid.New(), Name: "Phone " + sku, SKU: sku, Price: domain.NewMoney(1999, "USD"), CategoryID: category.ID, Category: category, IsActive: true}
+		mockProductRepo.Products[product.ID] = product
+		mockProductRepo.ProductsBySKU[product.SKU] = product
+	}
+
+	var out bytes.Buffer
+	if err := service.ExportProducts(ctx, domain.ProductFormatJSONL, &out); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], `"sku":"A-1"`) || !strings.Contains(lines[0], `"price":"19.99"`) || !strings.Contains(lines[0], `"category":"Phones"`) {
+		t.Errorf("Expected three products in SKU order, got: %v", lines)
+	}
+
+	// The export reads back as an import of the same products
+	report, err := service.ImportProducts(ctx, domain.ProductFormatJSONL, &out, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if report.Updated != 3 || report.Failed != 0 {
+		t.Errorf("Expected the export to import cleanly, got: %+v", report)
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+func (s *productService) SchedulePrice(ctx context.Context, productID uuid.UUID, req *domain.CreateProductPriceRequest) (*domain.ProductPrice, error) {
+	product, err := s.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	price := &domain.ProductPrice{
+		ID:             uuid.New(),
+		ProductID:      productID,
+		Price:          req.Price,
+		CompareAtPrice: req.CompareAtPrice,
+		Label:          req.Label,
+		EffectiveFrom:  now,
+		EffectiveTo:    req.EffectiveTo,
+		CreatedAt:      now,
+	}
+	if req.EffectiveFrom != nil {
+		price.EffectiveFrom = *req.EffectiveFrom
+	}
+	if price.Price.Currency == "" {
+		price.Price.Currency = product.Price.Currency
+	}
+	if price.CompareAtPrice != nil && price.CompareAtPrice.Currency == "" {
+		compareAt := *price.CompareAtPrice
+		compareAt.Currency = product.Price.Currency
+		price.CompareAtPrice = &compareAt
+	}
+	if err := price.Validate(product.Price.Currency); err != nil {
+		return nil, err
+	}
+	if price.EffectiveTo != nil && !price.EffectiveTo.After(now) {
+		return nil, fmt.Errorf("%w: the price would end before it is scheduled", domain.ErrInvalidPrice)
+	}
+
+	if err := s.priceRepo.Create(ctx, price); err != nil {
+		return nil, fmt.Errorf("failed to schedule price: %w", err)
+	}
+
+	return price, nil
+}
+
+func (s *productService) GetPriceHistory(ctx context.Context, productID uuid.UUID, from, to *time.Time) ([]*domain.ProductPrice, error) {
+	if _, err := s.GetProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	if from != nil && to != nil && !to.After(*from) {
+		return nil, fmt.Errorf("%w: to must be after from", domain.ErrInvalidPrice)
+	}
+
+	prices, err := s.priceRepo.GetByProductID(ctx, productID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	return prices, nil
+}
+
+func (s *productService) GetEffectivePrice(ctx context.Context, productID uuid.UUID, at time.Time) (*domain.EffectivePrice, error) {
+	product, err := s.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := s.priceRepo.GetEffective(ctx, productID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	return domain.NewEffectivePrice(product, price, at), nil
+}
+
+// CancelPrice only removes prices that have not taken effect, so that the price history
+// stays a record of what products actually sold at
+func (s *productService) CancelPrice(ctx context.Context, productID, priceID uuid.UUID) error {
+	price, err := s.priceRepo.GetByID(ctx, priceID)
+	if err != nil {
+		return fmt.Errorf("failed to get price: %w", err)
+	}
+	if price == nil || price.ProductID != productID {
+		return fmt.Errorf("price not found")
+	}
+	if !price.EffectiveFrom.After(time.Now()) {
+		return fmt.Errorf("%w: it took effect at %s", domain.ErrPriceStarted, price.EffectiveFrom.Format(time.RFC3339))
+	}
+
+	if err := s.priceRepo.Delete(ctx, priceID); err != nil {
+		return fmt.Errorf("failed to cancel price: %w", err)
+	}
+
+	return nil
+}
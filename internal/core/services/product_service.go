@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"silbackendassessment/internal/core/domain"
@@ -14,21 +15,27 @@ import (
 type productService struct {
 	productRepo  ports.ProductRepository
 	categoryRepo ports.CategoryRepository
+	variantRepo  ports.ProductVariantRepository
+	priceRepo    ports.ProductPriceRepository
+	lowStock     ports.LowStockNotifier
 }
 
-// NewProductService creates a new product service
-func NewProductService(productRepo ports.ProductRepository, categoryRepo ports.CategoryRepository) ports.ProductService {
+// NewProductService creates a new product service. lowStock is told when a stock update
+// drops a product below its reorder point and may be nil.
+func NewProductService(productRepo ports.ProductRepository, categoryRepo ports.CategoryRepository, variantRepo ports.ProductVariantRepository, priceRepo ports.ProductPriceRepository, lowStock ports.LowStockNotifier) ports.ProductService {
 	return &productService{
 		productRepo:  productRepo,
 		categoryRepo: categoryRepo,
+		variantRepo:  variantRepo,
+		priceRepo:    priceRepo,
+		lowStock:     lowStock,
 	}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req *domain.CreateProductRequest) (*domain.Product, error) {
-	// Check if product with SKU already exists
-	existingProduct, err := s.productRepo.GetBySKU(ctx, req.SKU)
-	if err == nil && existingProduct != nil {
-		return nil, fmt.Errorf("product with SKU %s already exists", req.SKU)
+	// Check if a product or variant with SKU already exists
+	if err := s.checkSKUAvailable(ctx, req.SKU); err != nil {
+		return nil, err
 	}
 
 	// Validate category exists
@@ -40,23 +47,59 @@ func (s *productService) CreateProduct(ctx context.Context, req *domain.CreatePr
 		return nil, fmt.Errorf("category not found")
 	}
 
+	price := req.Price
+	if price.Currency == "" {
+		price.Currency = domain.DefaultCurrency
+	}
+	if price.IsNegative() {
+		return nil, fmt.Errorf("price cannot be negative")
+	}
+
+	attributes, err := req.Attributes.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	reorderPoint := domain.DefaultReorderPoint
+	if req.ReorderPoint != nil {
+		reorderPoint = *req.ReorderPoint
+	}
+	if reorderPoint < 0 || req.ReorderQuantity < 0 {
+		return nil, fmt.Errorf("reorder point and quantity cannot be negative")
+	}
+	if req.WeightGrams < 0 || req.LengthMM < 0 || req.WidthMM < 0 || req.HeightMM < 0 {
+		return nil, fmt.Errorf("weight and dimensions cannot be negative")
+	}
+
 	// Create new product
 	product := &domain.Product{
 		ID:          uuid.New(),
 		Name:        req.Name,
 		Description: req.Description,
 		SKU:         req.SKU,
-		Price:       req.Price,
+		Price:       price,
 		Stock:       req.Stock,
 		CategoryID:  req.CategoryID,
 		IsActive:    req.IsActive,
+		Attributes:  attributes,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+
+		ReorderPoint:    reorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+
+		WeightGrams: req.WeightGrams,
+		LengthMM:    req.LengthMM,
+		WidthMM:     req.WidthMM,
+		HeightMM:    req.HeightMM,
 	}
 
 	if err := s.productRepo.Create(ctx, product); err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
+	if err := s.priceRepo.Create(ctx, domain.NewListPrice(product.ID, product.Price, product.CreatedAt)); err != nil {
+		return nil, fmt.Errorf("failed to record price: %w", err)
+	}
 
 	return product, nil
 }
@@ -74,8 +117,8 @@ func (s *productService) GetProduct(ctx context.Context, id uuid.UUID) (*domain.
 	return product, nil
 }
 
-func (s *productService) GetProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
-	products, err := s.productRepo.GetAll(ctx, limit, offset)
+func (s *productService) GetProducts(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
+	products, err := s.productRepo.GetAll(ctx, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products: %w", err)
 	}
@@ -83,7 +126,7 @@ func (s *productService) GetProducts(ctx context.Context, limit, offset int) ([]
 	return products, nil
 }
 
-func (s *productService) GetProductsByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Product, error) {
+func (s *productService) GetProductsByCategory(ctx context.Context, categoryID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	// Validate category exists
 	category, err := s.categoryRepo.GetByID(ctx, categoryID)
 	if err != nil {
@@ -93,7 +136,7 @@ func (s *productService) GetProductsByCategory(ctx context.Context, categoryID u
 		return nil, fmt.Errorf("category not found")
 	}
 
-	products, err := s.productRepo.GetByCategoryID(ctx, categoryID, limit, offset)
+	products, err := s.productRepo.GetByCategoryID(ctx, categoryID, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products by category: %w", err)
 	}
@@ -101,8 +144,8 @@ func (s *productService) GetProductsByCategory(ctx context.Context, categoryID u
 	return products, nil
 }
 
-func (s *productService) GetActiveProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
-	products, err := s.productRepo.GetActiveProducts(ctx, limit, offset)
+func (s *productService) GetActiveProducts(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
+	products, err := s.productRepo.GetActiveProducts(ctx, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active products: %w", err)
 	}
@@ -110,15 +153,50 @@ func (s *productService) GetActiveProducts(ctx context.Context, limit, offset in
 	return products, nil
 }
 
-func (s *productService) SearchProducts(ctx context.Context, name string, limit, offset int) ([]*domain.Product, error) {
-	products, err := s.productRepo.SearchByName(ctx, name, limit, offset)
+// SearchProducts finds the products with words in their name, SKU or description
+// starting with every word of the query, best match first
+func (s *productService) SearchProducts(ctx context.Context, query string, limit, offset int) ([]*domain.ProductSearchResult, error) {
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, domain.ErrEmptySearch
+	}
+
+	results, err := s.productRepo.Search(ctx, terms, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search products: %w", err)
 	}
 
+	return results, nil
+}
+
+// QueryProducts returns the products matching every filter of the query
+func (s *productService) QueryProducts(ctx context.Context, query *domain.ProductQuery, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	products, err := s.productRepo.Query(ctx, query, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products: %w", err)
+	}
+
 	return products, nil
 }
 
+// GetProductFacets counts the products matching the query per category and attribute value
+func (s *productService) GetProductFacets(ctx context.Context, query *domain.ProductQuery) (*domain.ProductFacets, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	facets, err := s.productRepo.GetFacets(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product facets: %w", err)
+	}
+
+	return facets, nil
+}
+
 func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, req *domain.UpdateProductRequest) (*domain.Product, error) {
 	product, err := s.productRepo.GetByID(ctx, id)
 	if err != nil {
@@ -136,14 +214,29 @@ func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, req *d
 	if req.Description != nil {
 		product.Description = *req.Description
 	}
-	if req.SKU != nil {
+	if req.SKU != nil && *req.SKU != product.SKU {
+		if err := s.checkSKUAvailable(ctx, *req.SKU); err != nil {
+			return nil, err
+		}
 		product.SKU = *req.SKU
 	}
+	previousPrice := product.Price
 	if req.Price != nil {
+		if req.Price.IsNegative() {
+			return nil, fmt.Errorf("price cannot be negative")
+		}
 		product.Price = *req.Price
+		if product.Price.Currency == "" {
+			product.Price.Currency = domain.DefaultCurrency
+		}
 	}
 	if req.Stock != nil {
-		product.Stock = *req.Stock
+		if *req.Stock < 0 {
+			return nil, fmt.Errorf("stock cannot be negative")
+		}
+		if err := checkStockNotByVariant(product); err != nil {
+			return nil, err
+		}
 	}
 	if req.CategoryID != nil {
 		// Validate category exists
@@ -159,12 +252,63 @@ func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, req *d
 	if req.IsActive != nil {
 		product.IsActive = *req.IsActive
 	}
+	if req.Attributes != nil {
+		attributes, err := req.Attributes.Normalize()
+		if err != nil {
+			return nil, err
+		}
+		product.Attributes = attributes
+	}
+	if req.ReorderPoint != nil {
+		if *req.ReorderPoint < 0 {
+			return nil, fmt.Errorf("reorder point cannot be negative")
+		}
+		product.ReorderPoint = *req.ReorderPoint
+	}
+	if req.ReorderQuantity != nil {
+		if *req.ReorderQuantity < 0 {
+			return nil, fmt.Errorf("reorder quantity cannot be negative")
+		}
+		product.ReorderQuantity = *req.ReorderQuantity
+	}
+	if negative(req.WeightGrams) || negative(req.LengthMM) || negative(req.WidthMM) || negative(req.HeightMM) {
+		return nil, fmt.Errorf("weight and dimensions cannot be negative")
+	}
+	if req.WeightGrams != nil {
+		product.WeightGrams = *req.WeightGrams
+	}
+	if req.LengthMM != nil {
+		product.LengthMM = *req.LengthMM
+	}
+	if req.WidthMM != nil {
+		product.WidthMM = *req.WidthMM
+	}
+	if req.HeightMM != nil {
+		product.HeightMM = *req.HeightMM
+	}
 	product.UpdatedAt = time.Now()
 
 	if err := s.productRepo.Update(ctx, product); err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
+	// A new list price takes effect now; the price history keeps the old one
+	if product.Price != previousPrice {
+		if err := s.priceRepo.Create(ctx, domain.NewListPrice(product.ID, product.Price, product.UpdatedAt)); err != nil {
+			return nil, fmt.Errorf("failed to record price: %w", err)
+		}
+	}
+
+	// Stock changes go through the ledger rather than the plain update
+	if req.Stock != nil {
+		previousStock := product.Stock
+		if err := s.productRepo.UpdateStock(ctx, id, *req.Stock); err != nil {
+			return nil, fmt.Errorf("failed to update stock: %w", err)
+		}
+		product.Stock = *req.Stock
+		s.notifyLowStock(ctx, product, previousStock)
+	}
+
 	return product, nil
 }
 
@@ -181,11 +325,18 @@ func (s *productService) UpdateStock(ctx context.Context, id uuid.UUID, stock in
 	if stock < 0 {
 		return fmt.Errorf("stock cannot be negative")
 	}
+	if err := checkStockNotByVariant(product); err != nil {
+		return err
+	}
 
+	previousStock := product.Stock
 	if err := s.productRepo.UpdateStock(ctx, id, stock); err != nil {
 		return fmt.Errorf("failed to update stock: %w", err)
 	}
 
+	product.Stock = stock
+	s.notifyLowStock(ctx, product, previousStock)
+
 	return nil
 }
 
@@ -205,3 +356,248 @@ func (s *productService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 
 	return nil
 }
+
+// notifyLowStock alerts staff if the product's stock just dropped below its reorder point
+func (s *productService) notifyLowStock(ctx context.Context, product *domain.Product, previousStock int) {
+	if s.lowStock == nil {
+		return
+	}
+	if event := domain.NewLowStockEvent(product, previousStock); event != nil {
+		s.lowStock.NotifyLowStock(ctx, []*domain.LowStockEvent{event})
+	}
+}
+
+func (s *productService) CreateVariant(ctx context.Context, productID uuid.UUID, req *domain.CreateProductVariantRequest) (*domain.ProductVariant, error) {
+	product, err := s.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	sku := strings.TrimSpace(req.SKU)
+	if sku == "" {
+		return nil, fmt.Errorf("variant SKU is required")
+	}
+	if err := s.checkSKUAvailable(ctx, sku); err != nil {
+		return nil, err
+	}
+	options, err := variantOptions(product, uuid.Nil, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	price, err := variantPrice(product, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	if req.Stock < 0 {
+		return nil, fmt.Errorf("stock cannot be negative")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	variant := &domain.ProductVariant{
+		ID:        uuid.New(),
+		ProductID: productID,
+		SKU:       sku,
+		Options:   options,
+		Price:     price,
+		Stock:     req.Stock,
+		IsActive:  isActive,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.variantRepo.Create(ctx, variant); err != nil {
+		return nil, fmt.Errorf("failed to create variant: %w", err)
+	}
+
+	return variant, nil
+}
+
+func (s *productService) GetVariants(ctx context.Context, productID uuid.UUID) ([]*domain.ProductVariant, error) {
+	if _, err := s.GetProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	variants, err := s.variantRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants: %w", err)
+	}
+
+	return variants, nil
+}
+
+func (s *productService) UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, req *domain.UpdateProductVariantRequest) (*domain.ProductVariant, error) {
+	product, err := s.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := s.getVariant(ctx, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.SKU != nil {
+		sku := strings.TrimSpace(*req.SKU)
+		if sku == "" {
+			return nil, fmt.Errorf("variant SKU is required")
+		}
+		if sku != variant.SKU {
+			if err := s.checkSKUAvailable(ctx, sku); err != nil {
+				return nil, err
+			}
+			variant.SKU = sku
+		}
+	}
+	if req.Options != nil {
+		if variant.Options, err = variantOptions(product, variant.ID, req.Options); err != nil {
+			return nil, err
+		}
+	}
+	if req.Price != nil {
+		if variant.Price, err = variantPrice(product, req.Price); err != nil {
+			return nil, err
+		}
+	}
+	if req.Stock != nil && *req.Stock < 0 {
+		return nil, fmt.Errorf("stock cannot be negative")
+	}
+	if req.IsActive != nil {
+		variant.IsActive = *req.IsActive
+	}
+	variant.UpdatedAt = time.Now()
+
+	if err := s.variantRepo.Update(ctx, variant); err != nil {
+		return nil, fmt.Errorf("failed to update variant: %w", err)
+	}
+
+	// Stock changes go through the ledger, moving the product's stock with the variant's
+	if req.Stock != nil && *req.Stock != variant.Stock {
+		previousStock, previousVariantStock := product.Stock, variant.Stock
+		if err := s.variantRepo.UpdateStock(ctx, variant.ID, *req.Stock); err != nil {
+			return nil, fmt.Errorf("failed to update stock: %w", err)
+		}
+		updated := *product
+		updated.Stock = previousStock + *req.Stock - previousVariantStock
+		variant.Stock = *req.Stock
+		s.notifyLowStock(ctx, &updated, previousStock)
+	}
+
+	return variant, nil
+}
+
+func (s *productService) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	variant, err := s.getVariant(ctx, productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	// Removing a variant must not silently write off the product's stock
+	if variant.Stock > 0 {
+		return fmt.Errorf("variant %s still holds %d unit(s); set its stock to 0 first", variant.SKU, variant.Stock)
+	}
+
+	if err := s.variantRepo.Delete(ctx, variantID); err != nil {
+		return fmt.Errorf("failed to delete variant: %w", err)
+	}
+
+	return nil
+}
+
+// getVariant returns the product's variant with the ID
+func (s *productService) getVariant(ctx context.Context, productID, variantID uuid.UUID) (*domain.ProductVariant, error) {
+	variant, err := s.variantRepo.GetByID(ctx, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variant: %w", err)
+	}
+	if variant == nil || variant.ProductID != productID {
+		return nil, fmt.Errorf("variant not found")
+	}
+
+	return variant, nil
+}
+
+// checkSKUAvailable fails when a product or variant already uses the SKU
+func (s *productService) checkSKUAvailable(ctx context.Context, sku string) error {
+	existing, err := s.productRepo.GetBySKU(ctx, sku)
+	if err == nil && existing != nil {
+		return fmt.Errorf("product with SKU %s already exists", sku)
+	}
+	return nil
+}
+
+// checkStockNotByVariant rejects setting the stock of a product sold by variant directly,
+// since its stock is made up of its variants' stock
+func checkStockNotByVariant(product *domain.Product) error {
+	if len(product.Variants) > 0 {
+		return fmt.Errorf("product %s is sold by variant; update the stock of its variants instead", product.Name)
+	}
+	return nil
+}
+
+// negative reports whether an optional quantity is set and below zero
+func negative(n *int) bool {
+	return n != nil && *n < 0
+}
+
+// variantOptions trims the option names and values and checks no other variant of the
+// product (other than the one being updated) has the same options
+func variantOptions(product *domain.Product, variantID uuid.UUID, options domain.VariantOptions) (domain.VariantOptions, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("a variant needs at least one option")
+	}
+
+	trimmed := make(domain.VariantOptions, len(options))
+	for name, value := range options {
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "" || value == "" {
+			return nil, fmt.Errorf("variant option names and values cannot be empty")
+		}
+		trimmed[name] = value
+	}
+
+	for _, other := range product.Variants {
+		if other.ID != variantID && sameOptions(other.Options, trimmed) {
+			return nil, fmt.Errorf("product %s already has variant %s with these options", product.Name, other.SKU)
+		}
+	}
+
+	return trimmed, nil
+}
+
+// sameOptions reports whether two variants have the same option values
+func sameOptions(a, b domain.VariantOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if b[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// variantPrice validates a variant's price override, which must be in the product's currency
+// so variants can be ordered together
+func variantPrice(product *domain.Product, price *domain.Money) (*domain.Money, error) {
+	if price == nil {
+		return nil, nil
+	}
+
+	override := *price
+	if override.Currency == "" {
+		override.Currency = product.Price.Currency
+	}
+	if override.IsNegative() {
+		return nil, fmt.Errorf("price cannot be negative")
+	}
+	if override.Currency != product.Price.Currency {
+		return nil, fmt.Errorf("%w: variant price is in %s but product %s is priced in %s", domain.ErrCurrencyMismatch, override.Currency, product.Name, product.Price.Currency)
+	}
+
+	return &override, nil
+}
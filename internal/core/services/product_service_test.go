@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
 	"silbackendassessment/internal/testutils"
 
 	"github.com/google/uuid"
@@ -15,7 +16,7 @@ import (
 func TestProductService_CreateProduct(t *testing.T) {
 	mockProductRepo := testutils.NewMockProductRepository()
 	mockCategoryRepo := testutils.NewMockCategoryRepository()
-	service := NewProductService(mockProductRepo, mockCategoryRepo)
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
 	ctx := context.Background()
 
 	t.Run("Create product successfully", func(t *testing.T) {
@@ -34,7 +35,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 			Name:        "Test Product",
 			Description: "A test product",
 			SKU:         "TEST-001",
-			Price:       99.99,
+			Price:       domain.NewMoney(9999, "USD"),
 			Stock:       10,
 			CategoryID:  categoryID,
 		}
@@ -57,8 +58,8 @@ func TestProductService_CreateProduct(t *testing.T) {
 			t.Errorf("Expected SKU to be 'TEST-001', got: %s", product.SKU)
 		}
 
-		if product.Price != 99.99 {
-			t.Errorf("Expected Price to be 99.99, got: %f", product.Price)
+		if product.Price != domain.NewMoney(9999, "USD") {
+			t.Errorf("Expected Price to be 99.99 USD, got: %s", product.Price)
 		}
 
 		if product.Stock != 10 {
@@ -108,7 +109,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 			Name:        "New Product",
 			Description: "A new product",
 			SKU:         "EXISTING-001",
-			Price:       99.99,
+			Price:       domain.NewMoney(9999, "USD"),
 			Stock:       10,
 			CategoryID:  categoryID,
 		}
@@ -135,7 +136,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 			Name:        "Test Product",
 			Description: "A test product",
 			SKU:         "TEST-002",
-			Price:       99.99,
+			Price:       domain.NewMoney(9999, "USD"),
 			Stock:       10,
 			CategoryID:  categoryID,
 		}
@@ -172,7 +173,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 			Name:        "Test Product",
 			Description: "A test product",
 			SKU:         "TEST-003",
-			Price:       99.99,
+			Price:       domain.NewMoney(9999, "USD"),
 			Stock:       10,
 			CategoryID:  categoryID,
 		}
@@ -192,7 +193,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 func TestProductService_GetProduct(t *testing.T) {
 	mockProductRepo := testutils.NewMockProductRepository()
 	mockCategoryRepo := testutils.NewMockCategoryRepository()
-	service := NewProductService(mockProductRepo, mockCategoryRepo)
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
 	ctx := context.Background()
 
 	t.Run("Get existing product", func(t *testing.T) {
@@ -201,7 +202,7 @@ func TestProductService_GetProduct(t *testing.T) {
 			ID:        productID,
 			Name:      "Test Product",
 			SKU:       "TEST-001",
-			Price:     99.99,
+			Price:     domain.NewMoney(9999, "USD"),
 			Stock:     10,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -260,7 +261,7 @@ func TestProductService_GetProduct(t *testing.T) {
 func TestProductService_GetProducts(t *testing.T) {
 	mockProductRepo := testutils.NewMockProductRepository()
 	mockCategoryRepo := testutils.NewMockCategoryRepository()
-	service := NewProductService(mockProductRepo, mockCategoryRepo)
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
 	ctx := context.Background()
 
 	t.Run("Get products successfully", func(t *testing.T) {
@@ -269,7 +270,7 @@ func TestProductService_GetProducts(t *testing.T) {
 				ID:        uuid.New(),
 				Name:      "Product 1",
 				SKU:       "PROD-001",
-				Price:     99.99,
+				Price:     domain.NewMoney(9999, "USD"),
 				Stock:     10,
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
@@ -278,7 +279,7 @@ func TestProductService_GetProducts(t *testing.T) {
 				ID:        uuid.New(),
 				Name:      "Product 2",
 				SKU:       "PROD-002",
-				Price:     199.99,
+				Price:     domain.NewMoney(19999, "USD"),
 				Stock:     5,
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
@@ -286,21 +287,21 @@ func TestProductService_GetProducts(t *testing.T) {
 		}
 		mockProductRepo.AllProducts = products
 
-		result, err := service.GetProducts(ctx, 10, 0)
+		result, err := service.GetProducts(ctx, ports.PageRequest{Limit: 10})
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
 		}
 
-		if len(result) != 2 {
-			t.Errorf("Expected 2 products, got: %d", len(result))
+		if len(result.Items) != 2 {
+			t.Errorf("Expected 2 products, got: %d", len(result.Items))
 		}
 	})
 
 	t.Run("Repository error", func(t *testing.T) {
 		mockProductRepo.GetAllError = errors.New("database error")
 
-		products, err := service.GetProducts(ctx, 10, 0)
+		products, err := service.GetProducts(ctx, ports.PageRequest{Limit: 10})
 
 		if err == nil {
 			t.Error("Expected error from repository")
@@ -312,10 +313,48 @@ func TestProductService_GetProducts(t *testing.T) {
 	})
 }
 
+func TestProductService_QueryProducts(t *testing.T) {
+	mockProductRepo := testutils.NewMockProductRepository()
+	service := NewProductService(mockProductRepo, testutils.NewMockCategoryRepository(), testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
+	ctx := context.Background()
+
+	t.Run("Combined filters reach the repository", func(t *testing.T) {
+		categoryID := uuid.New()
+		minPrice, minStock := domain.NewMoney(1000, "USD"), 1
+		query := &domain.ProductQuery{
+			CategoryID: &categoryID,
+			MinPrice:   &minPrice,
+			MinStock:   &minStock,
+			Attributes: map[string][]string{"brand": {"Acme", "Globex"}},
+		}
+
+		if _, err := service.QueryProducts(ctx, query, ports.PageRequest{Limit: 10}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if mockProductRepo.LastQuery != query {
+			t.Errorf("Expected the query to be passed to the repository")
+		}
+	})
+
+	t.Run("Invalid price range", func(t *testing.T) {
+		mockProductRepo.LastQuery = nil
+		minPrice, maxPrice := domain.NewMoney(5000, "USD"), domain.NewMoney(1000, "USD")
+
+		_, err := service.GetProductFacets(ctx, &domain.ProductQuery{MinPrice: &minPrice, MaxPrice: &maxPrice})
+
+		if err == nil {
+			t.Error("Expected error for a minimum price above the maximum")
+		}
+		if mockProductRepo.LastQuery != nil {
+			t.Error("Expected the repository not to be queried")
+		}
+	})
+}
+
 func TestProductService_UpdateProduct(t *testing.T) {
 	mockProductRepo := testutils.NewMockProductRepository()
 	mockCategoryRepo := testutils.NewMockCategoryRepository()
-	service := NewProductService(mockProductRepo, mockCategoryRepo)
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
 	ctx := context.Background()
 
 	t.Run("Update product successfully", func(t *testing.T) {
@@ -324,7 +363,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 			ID:        productID,
 			Name:      "Original Product",
 			SKU:       "ORIG-001",
-			Price:     99.99,
+			Price:     domain.NewMoney(9999, "USD"),
 			Stock:     10,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -332,7 +371,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		mockProductRepo.Products[productID] = existingProduct
 
 		newName := "Updated Product"
-		newPrice := 149.99
+		newPrice := domain.NewMoney(14999, "USD")
 		req := &domain.UpdateProductRequest{
 			Name:  &newName,
 			Price: &newPrice,
@@ -352,8 +391,8 @@ func TestProductService_UpdateProduct(t *testing.T) {
 			t.Errorf("Expected Name to be 'Updated Product', got: %s", product.Name)
 		}
 
-		if product.Price != 149.99 {
-			t.Errorf("Expected Price to be 149.99, got: %f", product.Price)
+		if product.Price != domain.NewMoney(14999, "USD") {
+			t.Errorf("Expected Price to be 149.99 USD, got: %s", product.Price)
 		}
 
 		if product.SKU != "ORIG-001" {
@@ -389,7 +428,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 			ID:        productID,
 			Name:      "Original Product",
 			SKU:       "ORIG-001",
-			Price:     99.99,
+			Price:     domain.NewMoney(9999, "USD"),
 			Stock:     10,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -417,7 +456,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 func TestProductService_DeleteProduct(t *testing.T) {
 	mockProductRepo := testutils.NewMockProductRepository()
 	mockCategoryRepo := testutils.NewMockCategoryRepository()
-	service := NewProductService(mockProductRepo, mockCategoryRepo)
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
 	ctx := context.Background()
 
 	t.Run("Delete product successfully", func(t *testing.T) {
@@ -426,7 +465,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 			ID:        productID,
 			Name:      "Test Product",
 			SKU:       "TEST-001",
-			Price:     99.99,
+			Price:     domain.NewMoney(9999, "USD"),
 			Stock:     10,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -465,7 +504,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 			ID:        productID,
 			Name:      "Test Product",
 			SKU:       "TEST-001",
-			Price:     99.99,
+			Price:     domain.NewMoney(9999, "USD"),
 			Stock:     10,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -480,3 +519,302 @@ func TestProductService_DeleteProduct(t *testing.T) {
 		}
 	})
 }
+
+func TestProductService_UpdateStockLowStockAlert(t *testing.T) {
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockLowStock := testutils.NewMockLowStockNotifier()
+	service := NewProductService(mockProductRepo, testutils.NewMockCategoryRepository(), testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), mockLowStock)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Widget", SKU: "W-1", Stock: 12, ReorderPoint: 10, ReorderQuantity: 30, IsActive: true}
+
+	t.Run("Dropping below the reorder point alerts", func(t *testing.T) {
+		if err := service.UpdateStock(ctx, productID, 4); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(mockLowStock.Events) != 1 {
+			t.Fatalf("Expected 1 low stock event, got: %d", len(mockLowStock.Events))
+		}
+		event := mockLowStock.Events[0]
+		if event.ProductID != productID || event.PreviousStock != 12 || event.Stock != 4 {
+			t.Errorf("Unexpected low stock event: %+v", event)
+		}
+	})
+
+	t.Run("Staying below the reorder point does not alert again", func(t *testing.T) {
+		if err := service.UpdateStock(ctx, productID, 2); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(mockLowStock.Events) != 1 {
+			t.Errorf("Expected no new low stock event, got: %d", len(mockLowStock.Events))
+		}
+	})
+
+	t.Run("Restocking does not alert", func(t *testing.T) {
+		stock := 40
+		if _, err := service.UpdateProduct(ctx, productID, &domain.UpdateProductRequest{Stock: &stock}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(mockLowStock.Events) != 1 {
+			t.Errorf("Expected no new low stock event, got: %d", len(mockLowStock.Events))
+		}
+	})
+}
+
+func TestProductService_Variants(t *testing.T) {
+	mockProductRepo := testutils.NewMockProductRepository()
+	service := NewProductService(mockProductRepo, testutils.NewMockCategoryRepository(), testutils.NewMockProductVariantRepository(mockProductRepo), testutils.NewMockProductPriceRepository(), nil)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	product := &domain.Product{ID: productID, Name: "T-Shirt", SKU: "TS", Price: domain.NewMoney(1500, "USD"), IsActive: true}
+	mockProductRepo.Products[productID] = product
+	mockProductRepo.ProductsBySKU[product.SKU] = product
+
+	var medium *domain.ProductVariant
+
+	t.Run("Create variant adds its stock to the product", func(t *testing.T) {
+		var err error
+		medium, err = service.CreateVariant(ctx, productID, &domain.CreateProductVariantRequest{
+			SKU:     "TS-M-RED",
+			Options: domain.VariantOptions{"size": "M", " colour ": "red"},
+			Stock:   8,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if medium.Stock != 8 || product.Stock != 8 {
+			t.Errorf("Expected variant and product stock of 8, got: %d and %d", medium.Stock, product.Stock)
+		}
+		if medium.Options["colour"] != "red" {
+			t.Errorf("Expected trimmed option names, got: %v", medium.Options)
+		}
+		movement := mockProductRepo.Movements[len(mockProductRepo.Movements)-1]
+		if movement.VariantID == nil || *movement.VariantID != medium.ID || movement.Reason != domain.StockMovementReasonInitial {
+			t.Errorf("Expected an initial movement for the variant, got: %+v", movement)
+		}
+	})
+
+	t.Run("First variant takes over the product's stock", func(t *testing.T) {
+		stocked := &domain.Product{ID: uuid.New(), Name: "Mug", SKU: "MUG", Price: domain.NewMoney(900, "USD"), Stock: 5, IsActive: true}
+		mockProductRepo.Products[stocked.ID] = stocked
+		mockProductRepo.ProductsBySKU[stocked.SKU] = stocked
+
+		variant, err := service.CreateVariant(ctx, stocked.ID, &domain.CreateProductVariantRequest{SKU: "MUG-BLUE", Options: domain.VariantOptions{"colour": "blue"}, Stock: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if variant.Stock != 7 || stocked.Stock != 7 {
+			t.Errorf("Expected variant and product stock of 7, got: %d and %d", variant.Stock, stocked.Stock)
+		}
+
+		var variantLedger, productLedger int
+		for _, movement := range mockProductRepo.Movements {
+			if movement.ProductID != stocked.ID {
+				continue
+			}
+			productLedger += movement.Delta
+			if movement.VariantID != nil && *movement.VariantID == variant.ID {
+				variantLedger += movement.Delta
+			}
+		}
+		if variantLedger != 7 || productLedger != 2 {
+			t.Errorf("Expected ledger deltas of 7 for the variant and 2 for the product, got: %d and %d", variantLedger, productLedger)
+		}
+
+		second, err := service.CreateVariant(ctx, stocked.ID, &domain.CreateProductVariantRequest{SKU: "MUG-RED", Options: domain.VariantOptions{"colour": "red"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if second.Stock != 0 || stocked.Stock != 7 {
+			t.Errorf("Expected the second variant to start empty, got: %d and product stock %d", second.Stock, stocked.Stock)
+		}
+	})
+
+	t.Run("Duplicate SKU", func(t *testing.T) {
+		_, err := service.CreateVariant(ctx, productID, &domain.CreateProductVariantRequest{SKU: "TS-M-RED", Options: domain.VariantOptions{"size": "L"}})
+		if err == nil {
+			t.Error("Expected error for duplicate variant SKU")
+		}
+
+		_, err = service.CreateProduct(ctx, &domain.CreateProductRequest{Name: "Other", SKU: "TS-M-RED", Price: domain.NewMoney(100, "USD")})
+		if err == nil {
+			t.Error("Expected error creating a product with a variant's SKU")
+		}
+	})
+
+	t.Run("Duplicate options", func(t *testing.T) {
+		_, err := service.CreateVariant(ctx, productID, &domain.CreateProductVariantRequest{SKU: "TS-M-RED-2", Options: domain.VariantOptions{"colour": "red", "size": "M"}})
+		if err == nil {
+			t.Error("Expected error for a variant with the same options")
+		}
+	})
+
+	t.Run("Price override must use the product currency", func(t *testing.T) {
+		price := domain.NewMoney(1800, "EUR")
+		_, err := service.CreateVariant(ctx, productID, &domain.CreateProductVariantRequest{SKU: "TS-XL", Options: domain.VariantOptions{"size": "XL"}, Price: &price})
+		if !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("Expected ErrCurrencyMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("Product stock is set through its variants", func(t *testing.T) {
+		if err := service.UpdateStock(ctx, productID, 20); err == nil {
+			t.Error("Expected error setting the stock of a product sold by variant")
+		}
+
+		stock := 3
+		updated, err := service.UpdateVariant(ctx, productID, medium.ID, &domain.UpdateProductVariantRequest{Stock: &stock})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if updated.Stock != 3 || product.Stock != 3 {
+			t.Errorf("Expected variant and product stock of 3, got: %d and %d", updated.Stock, product.Stock)
+		}
+	})
+
+	t.Run("Variant with stock cannot be deleted", func(t *testing.T) {
+		if err := service.DeleteVariant(ctx, productID, medium.ID); err == nil {
+			t.Error("Expected error deleting a variant holding stock")
+		}
+		if err := service.DeleteVariant(ctx, uuid.New(), medium.ID); err == nil {
+			t.Error("Expected error deleting a variant through another product")
+		}
+	})
+}
+
+func TestProductService_Prices(t *testing.T) {
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockPriceRepo := testutils.NewMockProductPriceRepository()
+	mockCategoryRepo := testutils.NewMockCategoryRepository()
+	service := NewProductService(mockProductRepo, mockCategoryRepo, testutils.NewMockProductVariantRepository(mockProductRepo), mockPriceRepo, nil)
+	ctx := context.Background()
+
+	categoryID := uuid.New()
+	mockCategoryRepo.Categories[categoryID] = &domain.Category{ID: categoryID, Name: "Clothing"}
+	product, err := service.CreateProduct(ctx, &domain.CreateProductRequest{Name: "Jacket", SKU: "JKT", Price: domain.NewMoney(10000, "USD"), CategoryID: categoryID})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	t.Run("Creating and repricing a product records list prices", func(t *testing.T) {
+		price := domain.NewMoney(12000, "USD")
+		if _, err := service.UpdateProduct(ctx, product.ID, &domain.UpdateProductRequest{Price: &price}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		history, err := service.GetPriceHistory(ctx, product.ID, nil, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(history) != 2 || history[0].Price.Amount != 10000 || history[1].Price.Amount != 12000 {
+			t.Errorf("Expected list prices of 100.00 and 120.00, got: %+v", history)
+		}
+	})
+
+	var sale *domain.ProductPrice
+	start := time.Now().Add(24 * time.Hour)
+	end := start.Add(72 * time.Hour)
+
+	t.Run("Schedule a sale", func(t *testing.T) {
+		compareAt := domain.NewMoney(12000, "")
+		sale, err = service.SchedulePrice(ctx, product.ID, &domain.CreateProductPriceRequest{
+			Price:          domain.NewMoney(9000, ""),
+			CompareAtPrice: &compareAt,
+			Label:          "Black Friday",
+			EffectiveFrom:  &start,
+			EffectiveTo:    &end,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if sale.Price.Currency != "USD" || sale.CompareAtPrice.Currency != "USD" {
+			t.Errorf("Expected prices in the product currency, got: %s and %s", sale.Price, sale.CompareAtPrice)
+		}
+
+		current, err := service.GetEffectivePrice(ctx, product.ID, time.Now())
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if current.Price.Amount != 12000 || current.PriceID == nil {
+			t.Errorf("Expected the list price before the sale, got: %+v", current)
+		}
+
+		during, err := service.GetEffectivePrice(ctx, product.ID, start.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if during.Price.Amount != 9000 || during.CompareAtPrice == nil || during.CompareAtPrice.Amount != 12000 || during.Label != "Black Friday" {
+			t.Errorf("Expected the sale price during the sale, got: %+v", during)
+		}
+	})
+
+	t.Run("Invalid prices", func(t *testing.T) {
+		compareAt := domain.NewMoney(8000, "USD")
+		_, err := service.SchedulePrice(ctx, product.ID, &domain.CreateProductPriceRequest{Price: domain.NewMoney(9000, "USD"), CompareAtPrice: &compareAt})
+		if !errors.Is(err, domain.ErrInvalidPrice) {
+			t.Errorf("Expected ErrInvalidPrice for a compare-at price below the price, got: %v", err)
+		}
+
+		_, err = service.SchedulePrice(ctx, product.ID, &domain.CreateProductPriceRequest{Price: domain.NewMoney(9000, "EUR")})
+		if !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("Expected ErrCurrencyMismatch, got: %v", err)
+		}
+
+		past := time.Now().Add(-time.Hour)
+		earlier := past.Add(-time.Hour)
+		_, err = service.SchedulePrice(ctx, product.ID, &domain.CreateProductPriceRequest{Price: domain.NewMoney(9000, "USD"), EffectiveFrom: &earlier, EffectiveTo: &past})
+		if !errors.Is(err, domain.ErrInvalidPrice) {
+			t.Errorf("Expected ErrInvalidPrice for a price that has already ended, got: %v", err)
+		}
+	})
+
+	t.Run("Price history by window", func(t *testing.T) {
+		from := start.Add(time.Hour)
+		history, err := service.GetPriceHistory(ctx, product.ID, &from, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(history) != 3 {
+			t.Errorf("Expected the list prices and the sale, got %d prices", len(history))
+		}
+
+		after := end.Add(time.Hour)
+		history, err = service.GetPriceHistory(ctx, product.ID, &after, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		for _, price := range history {
+			if price.ID == sale.ID {
+				t.Error("Expected the sale to be left out after it ends")
+			}
+		}
+
+		if _, err := service.GetPriceHistory(ctx, product.ID, &after, &from); !errors.Is(err, domain.ErrInvalidPrice) {
+			t.Errorf("Expected ErrInvalidPrice for a backwards window, got: %v", err)
+		}
+	})
+
+	t.Run("Cancel a price", func(t *testing.T) {
+		history, _ := service.GetPriceHistory(ctx, product.ID, nil, nil)
+		if err := service.CancelPrice(ctx, product.ID, history[0].ID); !errors.Is(err, domain.ErrPriceStarted) {
+			t.Errorf("Expected ErrPriceStarted cancelling a price in effect, got: %v", err)
+		}
+
+		if err := service.CancelPrice(ctx, uuid.New(), sale.ID); err == nil {
+			t.Error("Expected error cancelling another product's price")
+		}
+
+		if err := service.CancelPrice(ctx, product.ID, sale.ID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, ok := mockPriceRepo.Prices[sale.ID]; ok {
+			t.Error("Expected the sale to be removed")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type promotionService struct {
+	promotionRepo ports.PromotionRepository
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(promotionRepo ports.PromotionRepository) ports.PromotionService {
+	return &promotionService{
+		promotionRepo: promotionRepo,
+	}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, req *domain.CreatePromotionRequest) (*domain.Promotion, error) {
+	promotion := &domain.Promotion{
+		ID:               uuid.New(),
+		Name:             req.Name,
+		Description:      req.Description,
+		Type:             req.Type,
+		PercentOff:       req.PercentOff,
+		AmountOff:        req.AmountOff,
+		BuyQuantity:      req.BuyQuantity,
+		GetQuantity:      req.GetQuantity,
+		MinSubtotal:      req.MinSubtotal,
+		ProductIDs:       nonNilIDs(req.ProductIDs),
+		CategoryIDs:      nonNilIDs(req.CategoryIDs),
+		CustomerIDs:      nonNilIDs(req.CustomerIDs),
+		Stackable:        req.Stackable,
+		Priority:         req.Priority,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		StartsAt:         req.StartsAt,
+		ExpiresAt:        req.ExpiresAt,
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	if code := domain.NormalizeCouponCode(req.Code); code != "" {
+		promotion.Code = &code
+	}
+	if err := promotion.Validate(); err != nil {
+		return nil, err
+	}
+
+	if promotion.Code != nil {
+		existing, err := s.promotionRepo.GetByCode(ctx, *promotion.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check coupon code: %w", err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("promotion with code %s already exists", *promotion.Code)
+		}
+	}
+
+	if err := s.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	promotion, err := s.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	if promotion == nil {
+		return nil, fmt.Errorf("promotion not found")
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) GetPromotions(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Promotion], error) {
+	promotions, err := s.promotionRepo.GetAll(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotions: %w", err)
+	}
+
+	return promotions, nil
+}
+
+func (s *promotionService) UpdatePromotion(ctx context.Context, id uuid.UUID, req *domain.UpdatePromotionRequest) (*domain.Promotion, error) {
+	promotion, err := s.GetPromotion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		promotion.Name = *req.Name
+	}
+	if req.Description != nil {
+		promotion.Description = *req.Description
+	}
+	if req.MinSubtotal != nil {
+		promotion.MinSubtotal = req.MinSubtotal
+	}
+	if req.ProductIDs != nil {
+		promotion.ProductIDs = nonNilIDs(*req.ProductIDs)
+	}
+	if req.CategoryIDs != nil {
+		promotion.CategoryIDs = nonNilIDs(*req.CategoryIDs)
+	}
+	if req.CustomerIDs != nil {
+		promotion.CustomerIDs = nonNilIDs(*req.CustomerIDs)
+	}
+	if req.Stackable != nil {
+		promotion.Stackable = *req.Stackable
+	}
+	if req.Priority != nil {
+		promotion.Priority = *req.Priority
+	}
+	if req.UsageLimit != nil {
+		promotion.UsageLimit = req.UsageLimit
+	}
+	if req.PerCustomerLimit != nil {
+		promotion.PerCustomerLimit = req.PerCustomerLimit
+	}
+	if req.StartsAt != nil {
+		promotion.StartsAt = req.StartsAt
+	}
+	if req.ExpiresAt != nil {
+		promotion.ExpiresAt = req.ExpiresAt
+	}
+	if req.IsActive != nil {
+		promotion.IsActive = *req.IsActive
+	}
+	if err := promotion.Validate(); err != nil {
+		return nil, err
+	}
+	promotion.UpdatedAt = time.Now()
+
+	if err := s.promotionRepo.Update(ctx, promotion); err != nil {
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// nonNilIDs returns ids, or an empty list in place of nil, which the NOT NULL array
+// columns would otherwise reject
+func nonNilIDs(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
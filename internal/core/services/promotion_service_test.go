@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+)
+
+func TestPromotionService(t *testing.T) {
+	ctx := context.Background()
+
+	mockPromotionRepo := testutils.NewMockPromotionRepository()
+	service := NewPromotionService(mockPromotionRepo)
+
+	var coupon *domain.Promotion
+
+	t.Run("Create coupon", func(t *testing.T) {
+		var err error
+		coupon, err = service.CreatePromotion(ctx, &domain.CreatePromotionRequest{Name: "Welcome", Code: " welcome10 ", Type: domain.PromotionTypePercentage, PercentOff: 10})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if coupon.Code == nil || *coupon.Code != "WELCOME10" || !coupon.IsActive {
+			t.Errorf("Expected an active coupon with code WELCOME10, got: %+v", coupon)
+		}
+		if coupon.ProductIDs == nil || coupon.CategoryIDs == nil || coupon.CustomerIDs == nil {
+			t.Error("Expected empty rather than nil eligibility lists")
+		}
+	})
+
+	t.Run("Duplicate code", func(t *testing.T) {
+		_, err := service.CreatePromotion(ctx, &domain.CreatePromotionRequest{Name: "Welcome again", Code: "WELCOME10", Type: domain.PromotionTypePercentage, PercentOff: 5})
+		if err == nil {
+			t.Error("Expected error for duplicate coupon code")
+		}
+	})
+
+	t.Run("Invalid rule", func(t *testing.T) {
+		_, err := service.CreatePromotion(ctx, &domain.CreatePromotionRequest{Name: "Free stuff", Type: domain.PromotionTypeBuyXGetY, BuyQuantity: 2})
+		if !errors.Is(err, domain.ErrInvalidPromotion) {
+			t.Errorf("Expected ErrInvalidPromotion, got: %v", err)
+		}
+	})
+
+	t.Run("Deactivate", func(t *testing.T) {
+		inactive := false
+		updated, err := service.UpdatePromotion(ctx, coupon.ID, &domain.UpdatePromotionRequest{IsActive: &inactive})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if updated.IsActive {
+			t.Error("Expected the promotion to be inactive")
+		}
+	})
+
+	t.Run("Update rejects invalid limits", func(t *testing.T) {
+		limit := 0
+		_, err := service.UpdatePromotion(ctx, coupon.ID, &domain.UpdatePromotionRequest{UsageLimit: &limit})
+		if !errors.Is(err, domain.ErrInvalidPromotion) {
+			t.Errorf("Expected ErrInvalidPromotion, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type purchaseOrderService struct {
+	purchaseOrderRepo ports.PurchaseOrderRepository
+	supplierRepo      ports.SupplierRepository
+	productRepo       ports.ProductRepository
+	warehouseRepo     ports.WarehouseRepository
+	txManager         ports.TxManager
+}
+
+// NewPurchaseOrderService creates a new purchase order service
+func NewPurchaseOrderService(
+	purchaseOrderRepo ports.PurchaseOrderRepository,
+	supplierRepo ports.SupplierRepository,
+	productRepo ports.ProductRepository,
+	warehouseRepo ports.WarehouseRepository,
+	txManager ports.TxManager,
+) ports.PurchaseOrderService {
+	return &purchaseOrderService{
+		purchaseOrderRepo: purchaseOrderRepo,
+		supplierRepo:      supplierRepo,
+		productRepo:       productRepo,
+		warehouseRepo:     warehouseRepo,
+		txManager:         txManager,
+	}
+}
+
+func (s *purchaseOrderService) CreatePurchaseOrder(ctx context.Context, req *domain.CreatePurchaseOrderRequest) (*domain.PurchaseOrder, error) {
+	supplier, err := s.supplierRepo.GetByID(ctx, req.SupplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+	if supplier == nil {
+		return nil, fmt.Errorf("supplier not found")
+	}
+	if !supplier.IsActive {
+		return nil, fmt.Errorf("%w: supplier %s is not active", domain.ErrInvalidPurchaseOrder, supplier.Name)
+	}
+	if err := s.validateWarehouse(ctx, req.WarehouseID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	po := &domain.PurchaseOrder{
+		ID:           uuid.New(),
+		SupplierID:   req.SupplierID,
+		WarehouseID:  req.WarehouseID,
+		Status:       domain.PurchaseOrderStatusDraft,
+		ExpectedDate: req.ExpectedDate,
+		Notes:        req.Notes,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if po.Lines, err = s.buildLines(ctx, po.ID, req.Lines); err != nil {
+		return nil, err
+	}
+
+	if err := s.purchaseOrderRepo.Create(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to create purchase order: %w", err)
+	}
+	po.Supplier = supplier
+
+	return po, nil
+}
+
+func (s *purchaseOrderService) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	po, err := s.purchaseOrderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	if po == nil {
+		return nil, fmt.Errorf("purchase order not found")
+	}
+
+	return po, nil
+}
+
+func (s *purchaseOrderService) GetPurchaseOrders(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.PurchaseOrder], error) {
+	pos, err := s.purchaseOrderRepo.GetAll(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase orders: %w", err)
+	}
+
+	return pos, nil
+}
+
+func (s *purchaseOrderService) GetPurchaseOrdersBySupplier(ctx context.Context, supplierID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.PurchaseOrder], error) {
+	supplier, err := s.supplierRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+	if supplier == nil {
+		return nil, fmt.Errorf("supplier not found")
+	}
+
+	pos, err := s.purchaseOrderRepo.GetBySupplierID(ctx, supplierID, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase orders: %w", err)
+	}
+
+	return pos, nil
+}
+
+func (s *purchaseOrderService) UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req *domain.UpdatePurchaseOrderRequest) (*domain.PurchaseOrder, error) {
+	var po *domain.PurchaseOrder
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if po, err = s.GetPurchaseOrder(ctx, id); err != nil {
+			return err
+		}
+		if po.Status == domain.PurchaseOrderStatusReceived {
+			return fmt.Errorf("%w: purchase order has already been received", domain.ErrInvalidStatusTransition)
+		}
+
+		// Update fields if provided
+		if req.WarehouseID != nil {
+			if po.Status != domain.PurchaseOrderStatusDraft && po.Status != domain.PurchaseOrderStatusSent {
+				return fmt.Errorf("%w: cannot change the warehouse of a %s purchase order", domain.ErrInvalidStatusTransition, po.Status)
+			}
+			if err := s.validateWarehouse(ctx, req.WarehouseID); err != nil {
+				return err
+			}
+			po.WarehouseID = req.WarehouseID
+		}
+		if req.ExpectedDate != nil {
+			po.ExpectedDate = req.ExpectedDate
+		}
+		if req.Notes != nil {
+			po.Notes = *req.Notes
+		}
+		po.UpdatedAt = time.Now()
+
+		if err := s.purchaseOrderRepo.Update(ctx, po); err != nil {
+			return fmt.Errorf("failed to update purchase order: %w", err)
+		}
+
+		if req.Lines == nil {
+			return nil
+		}
+		// What was ordered is fixed once the supplier has the order
+		if po.Status != domain.PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: lines can only be changed while the purchase order is a draft", domain.ErrInvalidStatusTransition)
+		}
+		if po.Lines, err = s.buildLines(ctx, po.ID, req.Lines); err != nil {
+			return err
+		}
+		if err := s.purchaseOrderRepo.ReplaceLines(ctx, po); err != nil {
+			return fmt.Errorf("failed to update purchase order lines: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func (s *purchaseOrderService) SendPurchaseOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	po, err := s.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := po.Status.ValidateTransition(domain.PurchaseOrderStatusSent); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	po.Status = domain.PurchaseOrderStatusSent
+	po.SentAt = &now
+	po.UpdatedAt = now
+
+	if err := s.purchaseOrderRepo.Update(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	return po, nil
+}
+
+// ReceivePurchaseOrder records stock arriving against a sent purchase order, adding it to
+// the products' stock at the purchase order's warehouse
+func (s *purchaseOrderService) ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req *domain.ReceivePurchaseOrderRequest) (*domain.PurchaseOrder, error) {
+	var po *domain.PurchaseOrder
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if po, err = s.GetPurchaseOrder(ctx, id); err != nil {
+			return err
+		}
+		if po.Status != domain.PurchaseOrderStatusSent && po.Status != domain.PurchaseOrderStatusPartiallyReceived {
+			return fmt.Errorf("%w: cannot receive a %s purchase order", domain.ErrInvalidStatusTransition, po.Status)
+		}
+
+		// Work out what is still to arrive per line
+		outstanding := make(map[uuid.UUID]int, len(po.Lines))
+		linesByID := make(map[uuid.UUID]domain.PurchaseOrderLine, len(po.Lines))
+		for _, line := range po.Lines {
+			outstanding[line.ID] = line.Outstanding()
+			linesByID[line.ID] = line
+		}
+
+		lines := req.Lines
+		if len(lines) == 0 {
+			for _, line := range po.Lines {
+				if qty := line.Outstanding(); qty > 0 {
+					lines = append(lines, domain.ReceivePurchaseOrderLineRequest{LineID: line.ID, Quantity: qty})
+				}
+			}
+		}
+
+		for _, lineReq := range lines {
+			left, ok := outstanding[lineReq.LineID]
+			if !ok {
+				return fmt.Errorf("%w: line %s does not belong to purchase order", domain.ErrInvalidPurchaseOrder, lineReq.LineID)
+			}
+			if lineReq.Quantity <= 0 {
+				return fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidPurchaseOrder)
+			}
+			if lineReq.Quantity > left {
+				return fmt.Errorf("%w: line %s has %d unit(s) outstanding, received %d", domain.ErrInvalidPurchaseOrder, lineReq.LineID, left, lineReq.Quantity)
+			}
+			outstanding[lineReq.LineID] = left - lineReq.Quantity
+
+			if err := s.purchaseOrderRepo.ReceiveLine(ctx, lineReq.LineID, lineReq.Quantity); err != nil {
+				if errors.Is(err, domain.ErrInvalidPurchaseOrder) {
+					return fmt.Errorf("%w: line %s was received concurrently", err, lineReq.LineID)
+				}
+				return fmt.Errorf("failed to receive purchase order line: %w", err)
+			}
+
+			line := linesByID[lineReq.LineID]
+			movement := domain.NewStockMovement(line.ProductID, lineReq.Quantity, domain.StockMovementReasonPurchase, nil).ForVariant(line.VariantID)
+			movement.Note = fmt.Sprintf("purchase order %s", po.ID)
+			if po.WarehouseID != nil {
+				movement.InWarehouse(*po.WarehouseID)
+			}
+			if err := s.productRepo.AdjustStock(ctx, movement); err != nil {
+				return fmt.Errorf("failed to restock product %s: %w", line.ProductID, err)
+			}
+		}
+
+		if po, err = s.GetPurchaseOrder(ctx, id); err != nil {
+			return err
+		}
+		next := po.ReceiptStatus()
+		if next == po.Status {
+			return nil
+		}
+		if err := po.Status.ValidateTransition(next); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		po.Status = next
+		if next == domain.PurchaseOrderStatusReceived {
+			po.ReceivedAt = &now
+		}
+		po.UpdatedAt = now
+
+		if err := s.purchaseOrderRepo.Update(ctx, po); err != nil {
+			return fmt.Errorf("failed to update purchase order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// validateWarehouse checks that stock can be received into the warehouse; nil means the default warehouse
+func (s *purchaseOrderService) validateWarehouse(ctx context.Context, warehouseID *uuid.UUID) error {
+	if warehouseID == nil {
+		return nil
+	}
+	warehouse, err := s.warehouseRepo.GetByID(ctx, *warehouseID)
+	if err != nil {
+		return fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	if warehouse == nil {
+		return fmt.Errorf("warehouse not found")
+	}
+	if !warehouse.IsActive {
+		return fmt.Errorf("%w: warehouse %s is not active", domain.ErrInvalidPurchaseOrder, warehouse.Code)
+	}
+	return nil
+}
+
+// buildLines validates the requested lines and turns them into lines of the purchase order
+func (s *purchaseOrderService) buildLines(ctx context.Context, poID uuid.UUID, reqs []domain.CreatePurchaseOrderLineRequest) ([]domain.PurchaseOrderLine, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("%w: at least one line is required", domain.ErrInvalidPurchaseOrder)
+	}
+
+	now := time.Now()
+	seen := make(map[uuid.UUID]bool, len(reqs))
+	lines := make([]domain.PurchaseOrderLine, 0, len(reqs))
+	for _, lineReq := range reqs {
+		product, err := s.productRepo.GetByID(ctx, lineReq.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product not found: %s", lineReq.ProductID)
+		}
+		// Products with variants are stocked through them, so each line restocks one variant
+		variant, err := product.ResolveVariant(lineReq.VariantID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPurchaseOrder, err)
+		}
+		stocked := product.ID
+		if variant != nil {
+			stocked = variant.ID
+		}
+		if seen[stocked] {
+			return nil, fmt.Errorf("%w: product %s appears on more than one line", domain.ErrInvalidPurchaseOrder, product.Name)
+		}
+		seen[stocked] = true
+		if lineReq.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidPurchaseOrder)
+		}
+
+		unitCost := lineReq.UnitCost
+		if unitCost.Currency == "" {
+			unitCost.Currency = domain.DefaultCurrency
+		}
+		if unitCost.IsNegative() {
+			return nil, fmt.Errorf("%w: unit cost cannot be negative", domain.ErrInvalidPurchaseOrder)
+		}
+
+		lines = append(lines, domain.PurchaseOrderLine{
+			ID:              uuid.New(),
+			PurchaseOrderID: poID,
+			ProductID:       product.ID,
+			VariantID:       lineReq.VariantID,
+			QuantityOrdered: lineReq.Quantity,
+			UnitCost:        unitCost,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	}
+
+	// All lines of a purchase order must share a currency
+	if _, err := (&domain.PurchaseOrder{Lines: lines}).TotalCost(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPurchaseOrder, err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestPurchaseOrderService(t *testing.T) {
+	ctx := context.Background()
+
+	mockPurchaseOrderRepo := testutils.NewMockPurchaseOrderRepository()
+	mockSupplierRepo := testutils.NewMockSupplierRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockWarehouseRepo := testutils.NewMockWarehouseRepository()
+	service := NewPurchaseOrderService(mockPurchaseOrderRepo, mockSupplierRepo, mockProductRepo, mockWarehouseRepo, testutils.NewMockTxManager())
+
+	supplierID := uuid.New()
+	mockSupplierRepo.Suppliers[supplierID] = &domain.Supplier{ID: supplierID, Name: "Acme Ltd", IsActive: true}
+	warehouse := &domain.Warehouse{ID: uuid.New(), Code: "MBA", IsActive: true}
+	mockWarehouseRepo.Warehouses[warehouse.ID] = warehouse
+
+	widgetID, gadgetID := uuid.New(), uuid.New()
+	mockProductRepo.Products[widgetID] = &domain.Product{ID: widgetID, Name: "Widget", Stock: 2, IsActive: true}
+	mockProductRepo.Products[gadgetID] = &domain.Product{ID: gadgetID, Name: "Gadget", Stock: 0, IsActive: true}
+
+	newRequest := func() *domain.CreatePurchaseOrderRequest {
+		return &domain.CreatePurchaseOrderRequest{
+			SupplierID:  supplierID,
+			WarehouseID: &warehouse.ID,
+			Lines: []domain.CreatePurchaseOrderLineRequest{
+				{ProductID: widgetID, Quantity: 10, UnitCost: domain.NewMoney(250, "KES")},
+				{ProductID: gadgetID, Quantity: 5, UnitCost: domain.NewMoney(1000, "KES")},
+			},
+		}
+	}
+
+	t.Run("Create draft purchase order", func(t *testing.T) {
+		po, err := service.CreatePurchaseOrder(ctx, newRequest())
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if po.Status != domain.PurchaseOrderStatusDraft || len(po.Lines) != 2 {
+			t.Errorf("Expected a draft with 2 lines, got: %s with %d lines", po.Status, len(po.Lines))
+		}
+		if total, _ := po.TotalCost(); total != domain.NewMoney(7500, "KES") {
+			t.Errorf("Expected total cost of 7500 KES, got: %v", total)
+		}
+	})
+
+	t.Run("Mixed currencies are rejected", func(t *testing.T) {
+		req := newRequest()
+		req.Lines[1].UnitCost = domain.NewMoney(10, "USD")
+
+		_, err := service.CreatePurchaseOrder(ctx, req)
+
+		if !errors.Is(err, domain.ErrInvalidPurchaseOrder) {
+			t.Errorf("Expected ErrInvalidPurchaseOrder, got: %v", err)
+		}
+	})
+
+	t.Run("Drafts cannot be received", func(t *testing.T) {
+		po, _ := service.CreatePurchaseOrder(ctx, newRequest())
+
+		_, err := service.ReceivePurchaseOrder(ctx, po.ID, &domain.ReceivePurchaseOrderRequest{})
+
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+	})
+
+	t.Run("Receiving adds stock at the purchase order's warehouse", func(t *testing.T) {
+		po, _ := service.CreatePurchaseOrder(ctx, newRequest())
+		if _, err := service.SendPurchaseOrder(ctx, po.ID); err != nil {
+			t.Fatalf("Expected no error sending, got: %v", err)
+		}
+
+		// Only part of the widgets arrive
+		po, err := service.ReceivePurchaseOrder(ctx, po.ID, &domain.ReceivePurchaseOrderRequest{
+			Lines: []domain.ReceivePurchaseOrderLineRequest{{LineID: po.Lines[0].ID, Quantity: 4}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if po.Status != domain.PurchaseOrderStatusPartiallyReceived {
+			t.Errorf("Expected status partially_received, got: %s", po.Status)
+		}
+		if stock := mockProductRepo.Products[widgetID].Stock; stock != 6 {
+			t.Errorf("Expected widget stock of 6, got: %d", stock)
+		}
+		movement := mockProductRepo.Movements[len(mockProductRepo.Movements)-1]
+		if movement.Reason != domain.StockMovementReasonPurchase || movement.WarehouseID == nil || *movement.WarehouseID != warehouse.ID {
+			t.Errorf("Expected a purchase movement at %s, got: %+v", warehouse.Code, movement)
+		}
+
+		// Receiving more than is outstanding fails
+		_, err = service.ReceivePurchaseOrder(ctx, po.ID, &domain.ReceivePurchaseOrderRequest{
+			Lines: []domain.ReceivePurchaseOrderLineRequest{{LineID: po.Lines[0].ID, Quantity: 7}},
+		})
+		if !errors.Is(err, domain.ErrInvalidPurchaseOrder) {
+			t.Errorf("Expected ErrInvalidPurchaseOrder, got: %v", err)
+		}
+
+		// The rest arrives
+		po, err = service.ReceivePurchaseOrder(ctx, po.ID, &domain.ReceivePurchaseOrderRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if po.Status != domain.PurchaseOrderStatusReceived || po.ReceivedAt == nil {
+			t.Errorf("Expected status received with a receipt time, got: %s", po.Status)
+		}
+		if widget, gadget := mockProductRepo.Products[widgetID].Stock, mockProductRepo.Products[gadgetID].Stock; widget != 12 || gadget != 5 {
+			t.Errorf("Expected stock of 12 widgets and 5 gadgets, got: %d and %d", widget, gadget)
+		}
+	})
+
+	t.Run("Products with variants are received into a variant", func(t *testing.T) {
+		shirtID := uuid.New()
+		small := &domain.ProductVariant{ID: uuid.New(), ProductID: shirtID, SKU: "SH-S", Stock: 1, IsActive: true}
+		mockProductRepo.Products[shirtID] = &domain.Product{ID: shirtID, Name: "Shirt", Stock: 1, IsActive: true, Variants: []*domain.ProductVariant{small}}
+
+		req := newRequest()
+		req.Lines = []domain.CreatePurchaseOrderLineRequest{{ProductID: shirtID, Quantity: 3, UnitCost: domain.NewMoney(400, "KES")}}
+		if _, err := service.CreatePurchaseOrder(ctx, req); !errors.Is(err, domain.ErrInvalidPurchaseOrder) {
+			t.Errorf("Expected ErrInvalidPurchaseOrder without a variant, got: %v", err)
+		}
+
+		req.Lines[0].VariantID = &small.ID
+		po, err := service.CreatePurchaseOrder(ctx, req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := service.SendPurchaseOrder(ctx, po.ID); err != nil {
+			t.Fatalf("Expected no error sending, got: %v", err)
+		}
+		if _, err := service.ReceivePurchaseOrder(ctx, po.ID, &domain.ReceivePurchaseOrderRequest{}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if product, variant := mockProductRepo.Products[shirtID].Stock, small.Stock; product != 4 || variant != 4 {
+			t.Errorf("Expected the shirt and its variant to have 4 in stock, got: %d and %d", product, variant)
+		}
+	})
+
+	t.Run("Lines are fixed once sent", func(t *testing.T) {
+		po, _ := service.CreatePurchaseOrder(ctx, newRequest())
+		if _, err := service.SendPurchaseOrder(ctx, po.ID); err != nil {
+			t.Fatalf("Expected no error sending, got: %v", err)
+		}
+
+		_, err := service.UpdatePurchaseOrder(ctx, po.ID, &domain.UpdatePurchaseOrderRequest{Lines: newRequest().Lines[:1]})
+
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+	})
+}
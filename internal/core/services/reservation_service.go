@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type reservationService struct {
+	reservationRepo ports.ReservationRepository
+	productRepo     ports.ProductRepository
+	customerRepo    ports.CustomerRepository
+	ttl             time.Duration
+	maxTTL          time.Duration
+}
+
+// NewReservationService creates a new stock reservation service. Reservations
+// requested without an expiry are held for ttl, or DefaultReservationTTL when ttl is zero.
+// Requested expiries may not exceed maxTTL, or DefaultMaxReservationTTL when maxTTL is zero.
+func NewReservationService(
+	reservationRepo ports.ReservationRepository,
+	productRepo ports.ProductRepository,
+	customerRepo ports.CustomerRepository,
+	ttl time.Duration,
+	maxTTL time.Duration,
+) ports.ReservationService {
+	if ttl <= 0 {
+		ttl = domain.DefaultReservationTTL
+	}
+	if maxTTL <= 0 {
+		maxTTL = domain.DefaultMaxReservationTTL
+	}
+	if maxTTL < ttl {
+		maxTTL = ttl
+	}
+	return &reservationService{
+		reservationRepo: reservationRepo,
+		productRepo:     productRepo,
+		customerRepo:    customerRepo,
+		ttl:             ttl,
+		maxTTL:          maxTTL,
+	}
+}
+
+func (s *reservationService) CreateReservation(ctx context.Context, req *domain.CreateReservationRequest) (*domain.StockReservation, error) {
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	if req.TTLSeconds < 0 {
+		return nil, fmt.Errorf("ttl cannot be negative")
+	}
+	// Compared in seconds so that huge values cannot overflow the duration
+	if int64(req.TTLSeconds) > int64(s.maxTTL/time.Second) {
+		return nil, fmt.Errorf("ttl cannot exceed %d seconds", int64(s.maxTTL/time.Second))
+	}
+
+	customer, err := s.customerRepo.GetByID(ctx, req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	if customer == nil {
+		return nil, fmt.Errorf("customer not found")
+	}
+
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if !product.IsActive {
+		return nil, fmt.Errorf("product is not active: %s", product.Name)
+	}
+	// Reservations hold product stock, which cannot be turned into an order for a variant
+	if len(product.Variants) > 0 {
+		return nil, fmt.Errorf("%w: product %s is sold by variant and cannot be reserved", domain.ErrVariantRequired, product.Name)
+	}
+
+	ttl := s.ttl
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+
+	now := time.Now()
+	reservation := &domain.StockReservation{
+		ID:         uuid.New(),
+		ProductID:  req.ProductID,
+		CustomerID: req.CustomerID,
+		Quantity:   req.Quantity,
+		Status:     domain.ReservationStatusActive,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// The repository checks availability under a row lock so concurrent holds cannot oversell
+	if err := s.reservationRepo.Create(ctx, reservation); err != nil {
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			return nil, fmt.Errorf("insufficient stock for product %s: %w", product.Name, err)
+		}
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func (s *reservationService) GetReservation(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error) {
+	reservation, err := s.reservationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if reservation == nil {
+		return nil, fmt.Errorf("reservation not found")
+	}
+
+	return reservation, nil
+}
+
+// ReleaseReservation gives the held stock back before the reservation expires
+func (s *reservationService) ReleaseReservation(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error) {
+	reservation, err := s.GetReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := reservation.Status.ValidateTransition(domain.ReservationStatusReleased); err != nil {
+		return nil, err
+	}
+
+	if err := s.reservationRepo.Release(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+
+	reservation.Status = domain.ReservationStatusReleased
+	reservation.UpdatedAt = time.Now()
+	return reservation, nil
+}
+
+// ReleaseExpired marks every reservation past its expiry as expired and returns how many were released
+func (s *reservationService) ReleaseExpired(ctx context.Context) (int, error) {
+	released, err := s.reservationRepo.ExpireDue(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired reservations: %w", err)
+	}
+	return released, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestReservationService(t *testing.T) {
+	ctx := context.Background()
+
+	mockReservationRepo := testutils.NewMockReservationRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	service := NewReservationService(mockReservationRepo, mockProductRepo, mockCustomerRepo, 0, 0)
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "John", Email: "john@example.com"}
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Product 1", Stock: 5, IsActive: true}
+
+	t.Run("Create reservation with default expiry", func(t *testing.T) {
+		before := time.Now()
+
+		reservation, err := service.CreateReservation(ctx, &domain.CreateReservationRequest{ProductID: productID, CustomerID: customerID, Quantity: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if reservation.Status != domain.ReservationStatusActive {
+			t.Errorf("Expected status active, got: %s", reservation.Status)
+		}
+		if reservation.ExpiresAt.Before(before.Add(domain.DefaultReservationTTL)) {
+			t.Errorf("Expected reservation to be held for %s, expires at %s", domain.DefaultReservationTTL, reservation.ExpiresAt)
+		}
+		if mockProductRepo.Products[productID].Stock != 5 {
+			t.Errorf("Expected on-hand stock to be untouched, got: %d", mockProductRepo.Products[productID].Stock)
+		}
+	})
+
+	t.Run("Expiry cannot exceed the maximum", func(t *testing.T) {
+		created := len(mockReservationRepo.Reservations)
+		maxSeconds := int(domain.DefaultMaxReservationTTL / time.Second)
+
+		if _, err := service.CreateReservation(ctx, &domain.CreateReservationRequest{ProductID: productID, CustomerID: customerID, Quantity: 1, TTLSeconds: maxSeconds + 1}); err == nil {
+			t.Error("Expected error for a ttl above the maximum")
+		}
+		if _, err := service.CreateReservation(ctx, &domain.CreateReservationRequest{ProductID: productID, CustomerID: customerID, Quantity: 1, TTLSeconds: math.MaxInt}); err == nil {
+			t.Error("Expected error for a ttl that would overflow")
+		}
+		if len(mockReservationRepo.Reservations) != created {
+			t.Errorf("Expected no reservation to be created, got: %d", len(mockReservationRepo.Reservations)-created)
+		}
+
+		reservation, err := service.CreateReservation(ctx, &domain.CreateReservationRequest{ProductID: productID, CustomerID: customerID, Quantity: 1, TTLSeconds: maxSeconds})
+		if err != nil {
+			t.Fatalf("Expected no error at the maximum, got: %v", err)
+		}
+		if reservation.ExpiresAt.After(time.Now().Add(domain.DefaultMaxReservationTTL)) {
+			t.Errorf("Expected reservation to expire within %s, expires at %s", domain.DefaultMaxReservationTTL, reservation.ExpiresAt)
+		}
+		if _, err := service.ReleaseReservation(ctx, reservation.ID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("Insufficient available stock", func(t *testing.T) {
+		mockReservationRepo.CreateError = domain.ErrInsufficientStock
+		defer func() { mockReservationRepo.CreateError = nil }()
+
+		_, err := service.CreateReservation(ctx, &domain.CreateReservationRequest{ProductID: productID, CustomerID: customerID, Quantity: 9})
+
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock, got: %v", err)
+		}
+	})
+
+	t.Run("Release reservation", func(t *testing.T) {
+		reservation, err := service.CreateReservation(ctx, &domain.CreateReservationRequest{ProductID: productID, CustomerID: customerID, Quantity: 1, TTLSeconds: 60})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		released, err := service.ReleaseReservation(ctx, reservation.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if released.Status != domain.ReservationStatusReleased {
+			t.Errorf("Expected status released, got: %s", released.Status)
+		}
+
+		// A released reservation cannot be released again
+		if _, err := service.ReleaseReservation(ctx, reservation.ID); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+	})
+
+	t.Run("Sweeper releases expired reservations", func(t *testing.T) {
+		expired := &domain.StockReservation{ID: uuid.New(), ProductID: productID, CustomerID: customerID, Quantity: 1, Status: domain.ReservationStatusActive, ExpiresAt: time.Now().Add(-time.Second)}
+		mockReservationRepo.Reservations[expired.ID] = expired
+
+		NewReservationSweeper(service, time.Minute).Sweep(ctx)
+
+		if expired.Status != domain.ReservationStatusExpired {
+			t.Errorf("Expected status expired, got: %s", expired.Status)
+		}
+	})
+}
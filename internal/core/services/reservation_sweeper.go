@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"silbackendassessment/internal/core/ports"
+)
+
+// DefaultReservationSweepInterval is how often expired reservations are released when no interval is configured
+const DefaultReservationSweepInterval = time.Minute
+
+// ReservationSweeper periodically releases expired stock reservations
+type ReservationSweeper struct {
+	reservationService ports.ReservationService
+	interval           time.Duration
+}
+
+// NewReservationSweeper creates a sweeper that runs every interval, or DefaultReservationSweepInterval when interval is zero
+func NewReservationSweeper(reservationService ports.ReservationService, interval time.Duration) *ReservationSweeper {
+	if interval <= 0 {
+		interval = DefaultReservationSweepInterval
+	}
+	return &ReservationSweeper{
+		reservationService: reservationService,
+		interval:           interval,
+	}
+}
+
+// Run sweeps until ctx is cancelled. Failures are logged and retried on the next tick.
+func (s *ReservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep releases expired reservations once
+func (s *ReservationSweeper) Sweep(ctx context.Context) {
+	released, err := s.reservationService.ReleaseExpired(ctx)
+	if err != nil {
+		log.Printf("Reservation sweep failed: %v", err)
+		return
+	}
+	if released > 0 {
+		log.Printf("Released %d expired stock reservation(s)", released)
+	}
+}
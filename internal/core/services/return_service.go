@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type returnService struct {
+	returnRepo          ports.ReturnRepository
+	refundRepo          ports.RefundRepository
+	orderRepo           ports.OrderRepository
+	orderItemRepo       ports.OrderItemRepository
+	productRepo         ports.ProductRepository
+	customerRepo        ports.CustomerRepository
+	notificationService ports.NotificationService
+	txManager           ports.TxManager
+}
+
+// NewReturnService creates a new return service
+func NewReturnService(
+	returnRepo ports.ReturnRepository,
+	refundRepo ports.RefundRepository,
+	orderRepo ports.OrderRepository,
+	orderItemRepo ports.OrderItemRepository,
+	productRepo ports.ProductRepository,
+	customerRepo ports.CustomerRepository,
+	notificationService ports.NotificationService,
+	txManager ports.TxManager,
+) ports.ReturnService {
+	return &returnService{
+		returnRepo:          returnRepo,
+		refundRepo:          refundRepo,
+		orderRepo:           orderRepo,
+		orderItemRepo:       orderItemRepo,
+		productRepo:         productRepo,
+		customerRepo:        customerRepo,
+		notificationService: notificationService,
+		txManager:           txManager,
+	}
+}
+
+func (s *returnService) RequestReturn(ctx context.Context, orderID uuid.UUID, req *domain.CreateReturnRequest) (*domain.ReturnRequest, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", domain.ErrInvalidReturn)
+	}
+
+	var ret *domain.ReturnRequest
+	var order *domain.Order
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return fmt.Errorf("order not found")
+		}
+
+		// Only delivered goods can be sent back
+		if order.Status != domain.OrderStatusDelivered {
+			return fmt.Errorf("%w: cannot return items of order with status %s", domain.ErrInvalidStatusTransition, order.Status)
+		}
+
+		orderItems, err := s.orderItemRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		existing, err := s.returnRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get returns: %w", err)
+		}
+
+		// Work out what can still be returned per order item
+		returned := domain.ReturnedQuantities(existing)
+		returnable := make(map[uuid.UUID]int, len(orderItems))
+		for _, item := range orderItems {
+			returnable[item.ID] = item.Quantity - returned[item.ID]
+		}
+
+		now := time.Now()
+		ret = &domain.ReturnRequest{
+			ID:         uuid.New(),
+			OrderID:    orderID,
+			CustomerID: order.CustomerID,
+			Status:     domain.ReturnStatusRequested,
+			Reason:     req.Reason,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+
+		for _, itemReq := range req.Items {
+			left, ok := returnable[itemReq.OrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: order item %s does not belong to order", domain.ErrInvalidReturn, itemReq.OrderItemID)
+			}
+			if itemReq.Quantity <= 0 {
+				return fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidReturn)
+			}
+			if itemReq.Quantity > left {
+				return fmt.Errorf("%w: order item %s has %d unit(s) left to return, requested %d", domain.ErrInvalidReturn, itemReq.OrderItemID, left, itemReq.Quantity)
+			}
+			returnable[itemReq.OrderItemID] = left - itemReq.Quantity
+
+			ret.Items = append(ret.Items, domain.ReturnItem{
+				ID:              uuid.New(),
+				ReturnRequestID: ret.ID,
+				OrderItemID:     itemReq.OrderItemID,
+				Quantity:        itemReq.Quantity,
+				CreatedAt:       now,
+			})
+		}
+
+		if err := s.returnRepo.Create(ctx, ret); err != nil {
+			return fmt.Errorf("failed to create return: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, ret, fmt.Sprintf("Return Request Received - %s", order.OrderNumber),
+		fmt.Sprintf("We have received your request to return items from order %s. We will let you know once it has been reviewed.", order.OrderNumber))
+
+	return ret, nil
+}
+
+func (s *returnService) GetReturn(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error) {
+	ret, err := s.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("return not found")
+	}
+
+	return ret, nil
+}
+
+func (s *returnService) GetReturnsByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.ReturnRequest, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	returns, err := s.returnRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+
+	return returns, nil
+}
+
+func (s *returnService) ApproveReturn(ctx context.Context, id uuid.UUID, req *domain.ReviewReturnRequest) (*domain.ReturnRequest, error) {
+	ret, err := s.review(ctx, id, domain.ReturnStatusApproved, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, ret, "Return Approved",
+		"Your return request has been approved. Please send the items back; your refund will be issued once we receive them.")
+
+	return ret, nil
+}
+
+func (s *returnService) RejectReturn(ctx context.Context, id uuid.UUID, req *domain.ReviewReturnRequest) (*domain.ReturnRequest, error) {
+	ret, err := s.review(ctx, id, domain.ReturnStatusRejected, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	body := "Unfortunately your return request has been rejected."
+	if req.Note != "" {
+		body += " Reason: " + req.Note
+	}
+	s.notify(ctx, ret, "Return Rejected", body)
+
+	return ret, nil
+}
+
+func (s *returnService) ReceiveReturn(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error) {
+	var ret *domain.ReturnRequest
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = s.returnRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get return: %w", err)
+		}
+		if ret == nil {
+			return fmt.Errorf("return not found")
+		}
+
+		if err := ret.Status.ValidateTransition(domain.ReturnStatusReceived); err != nil {
+			return err
+		}
+
+		orderItems, err := s.orderItemRepo.GetByOrderID(ctx, ret.OrderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		itemsByID := make(map[uuid.UUID]*domain.OrderItem, len(orderItems))
+		for _, item := range orderItems {
+			itemsByID[item.ID] = item
+		}
+
+		// Put the goods back on the shelf and refund what was paid for them
+		var amount domain.Money
+		for _, returnItem := range ret.Items {
+			orderItem, ok := itemsByID[returnItem.OrderItemID]
+			if !ok {
+				return fmt.Errorf("order item not found: %s", returnItem.OrderItemID)
+			}
+
+			if err := s.productRepo.AdjustStock(ctx, domain.NewStockMovement(orderItem.ProductID, returnItem.Quantity, domain.StockMovementReasonReturn, &ret.OrderID).ForVariant(orderItem.VariantID)); err != nil {
+				return fmt.Errorf("failed to restock product: %w", err)
+			}
+
+			amount, err = amount.Add(orderItem.PaidFor(returnItem.Quantity))
+			if err != nil {
+				return fmt.Errorf("failed to calculate refund: %w", err)
+			}
+		}
+
+		now := time.Now()
+		refund := &domain.Refund{
+			ID:              uuid.New(),
+			ReturnRequestID: ret.ID,
+			OrderID:         ret.OrderID,
+			Amount:          amount,
+			CreatedAt:       now,
+		}
+		if err := s.refundRepo.Create(ctx, refund); err != nil {
+			return fmt.Errorf("failed to create refund: %w", err)
+		}
+
+		ret.Status = domain.ReturnStatusReceived
+		ret.ReceivedAt = &now
+		ret.UpdatedAt = now
+		ret.Refund = refund
+
+		if err := s.returnRepo.Update(ctx, ret); err != nil {
+			return fmt.Errorf("failed to update return: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, ret, "Refund Issued",
+		fmt.Sprintf("We have received your returned items. A refund of %s has been issued.", ret.Refund.Amount))
+
+	return ret, nil
+}
+
+// review records a staff decision on a requested return
+func (s *returnService) review(ctx context.Context, id uuid.UUID, status domain.ReturnStatus, note string) (*domain.ReturnRequest, error) {
+	var ret *domain.ReturnRequest
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = s.returnRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get return: %w", err)
+		}
+		if ret == nil {
+			return fmt.Errorf("return not found")
+		}
+
+		if err := ret.Status.ValidateTransition(status); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ret.Status = status
+		ret.ReviewNote = note
+		ret.ReviewedBy = domain.ActorFromContext(ctx)
+		ret.ReviewedAt = &now
+		ret.UpdatedAt = now
+
+		if err := s.returnRepo.Update(ctx, ret); err != nil {
+			return fmt.Errorf("failed to update return: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// notify emails the customer about their return. Delivery failures are logged
+// rather than returned so they never undo a committed return.
+func (s *returnService) notify(ctx context.Context, ret *domain.ReturnRequest, subject, body string) {
+	if s.notificationService == nil {
+		return
+	}
+
+	customer, err := s.customerRepo.GetByID(ctx, ret.CustomerID)
+	if err != nil || customer == nil {
+		log.Printf("return %s: could not look up customer %s for notification: %v", ret.ID, ret.CustomerID, err)
+		return
+	}
+
+	message := fmt.Sprintf("Dear %s,\n\n%s\n\nReturn reference: %s", customer.FirstName, body, ret.ID)
+	if err := s.notificationService.SendEmail(ctx, customer.Email, subject, message); err != nil {
+		log.Printf("return %s: failed to send notification: %v", ret.ID, err)
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestReturnService(t *testing.T) {
+	ctx := domain.ContextWithActor(context.Background(), "user:admin")
+
+	mockReturnRepo := testutils.NewMockReturnRepository()
+	mockRefundRepo := testutils.NewMockRefundRepository()
+	mockOrderRepo := testutils.NewMockOrderRepository()
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockCustomerRepo := testutils.NewMockCustomerRepository()
+	mockNotifications := testutils.NewMockNotificationService()
+	service := NewReturnService(mockReturnRepo, mockRefundRepo, mockOrderRepo, mockOrderItemRepo, mockProductRepo, mockCustomerRepo, mockNotifications, testutils.NewMockTxManager())
+
+	customerID := uuid.New()
+	mockCustomerRepo.Customers[customerID] = &domain.Customer{ID: customerID, FirstName: "John", Email: "john@example.com"}
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Product 1", Stock: 5}
+
+	newOrder := func(status domain.OrderStatus) (uuid.UUID, *domain.OrderItem) {
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{ID: orderID, CustomerID: customerID, OrderNumber: "ORD-001", Status: status}
+		item := &domain.OrderItem{ID: uuid.New(), OrderID: orderID, ProductID: productID, Quantity: 3, UnitPrice: domain.NewMoney(2500, "USD"), TotalPrice: domain.NewMoney(7500, "USD")}
+		mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{item}
+		return orderID, item
+	}
+
+	t.Run("Return requires a delivered order", func(t *testing.T) {
+		orderID, item := newOrder(domain.OrderStatusShipped)
+
+		_, err := service.RequestReturn(ctx, orderID, &domain.CreateReturnRequest{
+			Reason: "Damaged",
+			Items:  []domain.CreateReturnItemRequest{{OrderItemID: item.ID, Quantity: 1}},
+		})
+
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+	})
+
+	t.Run("Cannot return more than was ordered", func(t *testing.T) {
+		orderID, item := newOrder(domain.OrderStatusDelivered)
+
+		_, err := service.RequestReturn(ctx, orderID, &domain.CreateReturnRequest{
+			Reason: "Damaged",
+			Items:  []domain.CreateReturnItemRequest{{OrderItemID: item.ID, Quantity: 4}},
+		})
+
+		if !errors.Is(err, domain.ErrInvalidReturn) {
+			t.Errorf("Expected ErrInvalidReturn, got: %v", err)
+		}
+	})
+
+	t.Run("Approved return is restocked and refunded on receipt", func(t *testing.T) {
+		orderID, item := newOrder(domain.OrderStatusDelivered)
+		mockNotifications.SentEmails = nil
+
+		ret, err := service.RequestReturn(ctx, orderID, &domain.CreateReturnRequest{
+			Reason: "Wrong size",
+			Items:  []domain.CreateReturnItemRequest{{OrderItemID: item.ID, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		// Receiving before approval is not allowed
+		if _, err := service.ReceiveReturn(ctx, ret.ID); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+
+		ret, err = service.ApproveReturn(ctx, ret.ID, &domain.ReviewReturnRequest{Note: "ok"})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if ret.ReviewedBy != "user:admin" {
+			t.Errorf("Expected reviewer to be recorded, got: %s", ret.ReviewedBy)
+		}
+
+		ret, err = service.ReceiveReturn(ctx, ret.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if ret.Status != domain.ReturnStatusReceived {
+			t.Errorf("Expected status to be 'received', got: %s", ret.Status)
+		}
+		if stock := mockProductRepo.Products[productID].Stock; stock != 7 {
+			t.Errorf("Expected stock to be restored to 7, got: %d", stock)
+		}
+		if len(mockRefundRepo.Refunds) != 1 || mockRefundRepo.Refunds[0].Amount != domain.NewMoney(5000, "USD") {
+			t.Errorf("Expected a single refund of 50.00 USD, got: %+v", mockRefundRepo.Refunds)
+		}
+		if len(mockNotifications.SentEmails) != 3 {
+			t.Errorf("Expected 3 notifications, got: %d", len(mockNotifications.SentEmails))
+		}
+	})
+
+	t.Run("Rejected return releases its quantities", func(t *testing.T) {
+		orderID, item := newOrder(domain.OrderStatusDelivered)
+		req := &domain.CreateReturnRequest{
+			Reason: "Changed my mind",
+			Items:  []domain.CreateReturnItemRequest{{OrderItemID: item.ID, Quantity: 3}},
+		}
+
+		ret, err := service.RequestReturn(ctx, orderID, req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := service.RejectReturn(ctx, ret.ID, &domain.ReviewReturnRequest{Note: "Outside return window"}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if _, err := service.RequestReturn(ctx, orderID, req); err != nil {
+			t.Errorf("Expected items of a rejected return to be returnable again, got: %v", err)
+		}
+	})
+}
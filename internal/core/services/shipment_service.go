@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type shipmentService struct {
+	shipmentRepo  ports.ShipmentRepository
+	orderRepo     ports.OrderRepository
+	orderItemRepo ports.OrderItemRepository
+	orderService  ports.OrderService
+	txManager     ports.TxManager
+}
+
+// NewShipmentService creates a new shipment service
+func NewShipmentService(
+	shipmentRepo ports.ShipmentRepository,
+	orderRepo ports.OrderRepository,
+	orderItemRepo ports.OrderItemRepository,
+	orderService ports.OrderService,
+	txManager ports.TxManager,
+) ports.ShipmentService {
+	return &shipmentService{
+		shipmentRepo:  shipmentRepo,
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		orderService:  orderService,
+		txManager:     txManager,
+	}
+}
+
+func (s *shipmentService) CreateShipment(ctx context.Context, orderID uuid.UUID, req *domain.CreateShipmentRequest) (*domain.Shipment, error) {
+	if req.Carrier == "" {
+		return nil, fmt.Errorf("%w: carrier is required", domain.ErrInvalidShipment)
+	}
+
+	var shipment *domain.Shipment
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return fmt.Errorf("order not found")
+		}
+
+		// Parcels can only be prepared once the order is being fulfilled
+		if order.Status != domain.OrderStatusProcessing && order.Status != domain.OrderStatusPartiallyShipped {
+			return fmt.Errorf("%w: cannot create shipment for order with status %s", domain.ErrInvalidStatusTransition, order.Status)
+		}
+
+		orderItems, err := s.orderItemRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		existing, err := s.shipmentRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get shipments: %w", err)
+		}
+
+		// Work out what is still left to ship per order item
+		allocated := domain.AllocatedQuantities(existing)
+		remaining := make(map[uuid.UUID]int, len(orderItems))
+		for _, item := range orderItems {
+			remaining[item.ID] = item.Quantity - allocated[item.ID]
+		}
+
+		now := time.Now()
+		shipment = &domain.Shipment{
+			ID:             uuid.New(),
+			OrderID:        orderID,
+			Carrier:        req.Carrier,
+			TrackingNumber: req.TrackingNumber,
+			Status:         domain.ShipmentStatusPending,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		items := req.Items
+		if len(items) == 0 {
+			for _, item := range orderItems {
+				if qty := remaining[item.ID]; qty > 0 {
+					items = append(items, domain.CreateShipmentItemRequest{OrderItemID: item.ID, Quantity: qty})
+				}
+			}
+			if len(items) == 0 {
+				return fmt.Errorf("%w: all order items have already been allocated to shipments", domain.ErrInvalidShipment)
+			}
+		}
+
+		for _, itemReq := range items {
+			left, ok := remaining[itemReq.OrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: order item %s does not belong to order", domain.ErrInvalidShipment, itemReq.OrderItemID)
+			}
+			if itemReq.Quantity <= 0 {
+				return fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidShipment)
+			}
+			if itemReq.Quantity > left {
+				return fmt.Errorf("%w: order item %s has %d unit(s) left to ship, requested %d", domain.ErrInvalidShipment, itemReq.OrderItemID, left, itemReq.Quantity)
+			}
+			remaining[itemReq.OrderItemID] = left - itemReq.Quantity
+
+			shipment.Items = append(shipment.Items, domain.ShipmentItem{
+				ID:          uuid.New(),
+				ShipmentID:  shipment.ID,
+				OrderItemID: itemReq.OrderItemID,
+				Quantity:    itemReq.Quantity,
+				CreatedAt:   now,
+			})
+		}
+
+		if err := s.shipmentRepo.Create(ctx, shipment); err != nil {
+			return fmt.Errorf("failed to create shipment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+func (s *shipmentService) GetShipment(ctx context.Context, id uuid.UUID) (*domain.Shipment, error) {
+	shipment, err := s.shipmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if shipment == nil {
+		return nil, fmt.Errorf("shipment not found")
+	}
+
+	return shipment, nil
+}
+
+func (s *shipmentService) GetShipmentsByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.Shipment, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("order not found")
+	}
+
+	shipments, err := s.shipmentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments: %w", err)
+	}
+
+	return shipments, nil
+}
+
+func (s *shipmentService) UpdateShipment(ctx context.Context, id uuid.UUID, req *domain.UpdateShipmentRequest) (*domain.Shipment, error) {
+	var shipment *domain.Shipment
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		shipment, err = s.shipmentRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get shipment: %w", err)
+		}
+		if shipment == nil {
+			return fmt.Errorf("shipment not found")
+		}
+
+		if req.Carrier != nil {
+			shipment.Carrier = *req.Carrier
+		}
+		if req.TrackingNumber != nil {
+			shipment.TrackingNumber = *req.TrackingNumber
+		}
+
+		statusChanged := req.Status != nil && *req.Status != shipment.Status
+		if statusChanged {
+			if err := shipment.Status.ValidateTransition(*req.Status); err != nil {
+				return err
+			}
+
+			now := time.Now()
+			shipment.Status = *req.Status
+			switch shipment.Status {
+			case domain.ShipmentStatusShipped:
+				shipment.ShippedAt = &now
+			case domain.ShipmentStatusDelivered:
+				shipment.DeliveredAt = &now
+			}
+		}
+		shipment.UpdatedAt = time.Now()
+
+		if err := s.shipmentRepo.Update(ctx, shipment); err != nil {
+			return fmt.Errorf("failed to update shipment: %w", err)
+		}
+
+		if statusChanged {
+			return s.syncOrderStatus(ctx, shipment.OrderID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// syncOrderStatus moves the order to the status implied by its shipments
+func (s *shipmentService) syncOrderStatus(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return fmt.Errorf("order not found")
+	}
+
+	orderItems, err := s.orderItemRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order items: %w", err)
+	}
+	shipments, err := s.shipmentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipments: %w", err)
+	}
+
+	derived, ok := domain.DeriveOrderStatus(orderItems, shipments)
+	if !ok || derived == order.Status {
+		return nil
+	}
+
+	const reason = "derived from shipments"
+	current := order.Status
+
+	// An order delivered in one step still passes through shipped
+	if derived == domain.OrderStatusDelivered && current != domain.OrderStatusShipped {
+		if err := s.orderService.UpdateOrderStatus(ctx, orderID, domain.OrderStatusShipped, reason); err != nil {
+			return err
+		}
+		current = domain.OrderStatusShipped
+	}
+
+	if !current.CanTransitionTo(derived) {
+		return nil
+	}
+	return s.orderService.UpdateOrderStatus(ctx, orderID, derived, reason)
+}
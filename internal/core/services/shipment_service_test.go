@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestShipmentService_CreateShipment(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(status domain.OrderStatus) (ports.ShipmentService, uuid.UUID, *domain.OrderItem, *domain.OrderItem) {
+		mockOrderRepo := testutils.NewMockOrderRepository()
+		mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+		orderService := NewOrderService(mockOrderRepo, mockOrderItemRepo, testutils.NewMockOrderStatusHistoryRepository(), testutils.NewMockCustomerRepository(), testutils.NewMockProductRepository(), testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(nil), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+		service := NewShipmentService(testutils.NewMockShipmentRepository(), mockOrderRepo, mockOrderItemRepo, orderService, testutils.NewMockTxManager())
+
+		orderID := uuid.New()
+		mockOrderRepo.Orders[orderID] = &domain.Order{ID: orderID, OrderNumber: "ORD-001", Status: status}
+		itemA := &domain.OrderItem{ID: uuid.New(), OrderID: orderID, Quantity: 3}
+		itemB := &domain.OrderItem{ID: uuid.New(), OrderID: orderID, Quantity: 1}
+		mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{itemA, itemB}
+		return service, orderID, itemA, itemB
+	}
+
+	t.Run("Ship all remaining items", func(t *testing.T) {
+		service, orderID, _, _ := setup(domain.OrderStatusProcessing)
+
+		shipment, err := service.CreateShipment(ctx, orderID, &domain.CreateShipmentRequest{Carrier: "DHL", TrackingNumber: "TRK-1"})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if shipment.Status != domain.ShipmentStatusPending {
+			t.Errorf("Expected pending shipment, got: %s", shipment.Status)
+		}
+		if len(shipment.Items) != 2 {
+			t.Errorf("Expected 2 shipment items, got: %d", len(shipment.Items))
+		}
+	})
+
+	t.Run("Quantities beyond what is left are rejected", func(t *testing.T) {
+		service, orderID, itemA, _ := setup(domain.OrderStatusProcessing)
+
+		_, err := service.CreateShipment(ctx, orderID, &domain.CreateShipmentRequest{
+			Carrier: "DHL",
+			Items:   []domain.CreateShipmentItemRequest{{OrderItemID: itemA.ID, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		_, err = service.CreateShipment(ctx, orderID, &domain.CreateShipmentRequest{
+			Carrier: "DHL",
+			Items:   []domain.CreateShipmentItemRequest{{OrderItemID: itemA.ID, Quantity: 2}},
+		})
+		if !errors.Is(err, domain.ErrInvalidShipment) {
+			t.Errorf("Expected ErrInvalidShipment, got: %v", err)
+		}
+	})
+
+	t.Run("Order not yet processing is rejected", func(t *testing.T) {
+		service, orderID, _, _ := setup(domain.OrderStatusConfirmed)
+
+		_, err := service.CreateShipment(ctx, orderID, &domain.CreateShipmentRequest{Carrier: "DHL"})
+
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+	})
+}
+
+func TestShipmentService_UpdateShipment(t *testing.T) {
+	ctx := context.Background()
+	mockOrderRepo := testutils.NewMockOrderRepository()
+	mockOrderItemRepo := testutils.NewMockOrderItemRepository()
+	mockHistoryRepo := testutils.NewMockOrderStatusHistoryRepository()
+	orderService := NewOrderService(mockOrderRepo, mockOrderItemRepo, mockHistoryRepo, testutils.NewMockCustomerRepository(), testutils.NewMockProductRepository(), testutils.NewMockProductPriceRepository(), testutils.NewMockPromotionRepository(), testutils.NewMockOrderDiscountRepository(), testutils.NewMockTaxRateRepository(), testutils.NewMockShippingMethodRepository(), testutils.NewMockReservationRepository(), testutils.NewMockStockLevelRepository(nil), testutils.NewMockTxManager(), testutils.NewMockOrderNumberGenerator(), nil, domain.AllocationStrategyNearest)
+	service := NewShipmentService(testutils.NewMockShipmentRepository(), mockOrderRepo, mockOrderItemRepo, orderService, testutils.NewMockTxManager())
+
+	orderID := uuid.New()
+	mockOrderRepo.Orders[orderID] = &domain.Order{ID: orderID, OrderNumber: "ORD-001", Status: domain.OrderStatusProcessing}
+	itemA := &domain.OrderItem{ID: uuid.New(), OrderID: orderID, Quantity: 3}
+	itemB := &domain.OrderItem{ID: uuid.New(), OrderID: orderID, Quantity: 1}
+	mockOrderItemRepo.AllOrderItems = []*domain.OrderItem{itemA, itemB}
+
+	first, err := service.CreateShipment(ctx, orderID, &domain.CreateShipmentRequest{
+		Carrier: "DHL",
+		Items:   []domain.CreateShipmentItemRequest{{OrderItemID: itemA.ID, Quantity: 3}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	second, err := service.CreateShipment(ctx, orderID, &domain.CreateShipmentRequest{Carrier: "UPS"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	shipped := domain.ShipmentStatusShipped
+	delivered := domain.ShipmentStatusDelivered
+
+	t.Run("First parcel shipped marks order partially shipped", func(t *testing.T) {
+		shipment, err := service.UpdateShipment(ctx, first.ID, &domain.UpdateShipmentRequest{Status: &shipped})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if shipment.ShippedAt == nil {
+			t.Error("Expected shipped_at to be set")
+		}
+		if status := mockOrderRepo.Orders[orderID].Status; status != domain.OrderStatusPartiallyShipped {
+			t.Errorf("Expected order to be partially shipped, got: %s", status)
+		}
+	})
+
+	t.Run("Last parcel shipped marks order shipped", func(t *testing.T) {
+		if _, err := service.UpdateShipment(ctx, second.ID, &domain.UpdateShipmentRequest{Status: &shipped}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if status := mockOrderRepo.Orders[orderID].Status; status != domain.OrderStatusShipped {
+			t.Errorf("Expected order to be shipped, got: %s", status)
+		}
+	})
+
+	t.Run("All parcels delivered marks order delivered", func(t *testing.T) {
+		for _, id := range []uuid.UUID{first.ID, second.ID} {
+			if _, err := service.UpdateShipment(ctx, id, &domain.UpdateShipmentRequest{Status: &delivered}); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		}
+		if status := mockOrderRepo.Orders[orderID].Status; status != domain.OrderStatusDelivered {
+			t.Errorf("Expected order to be delivered, got: %s", status)
+		}
+		if mockOrderRepo.Orders[orderID].DeliveredDate == nil {
+			t.Error("Expected delivered date to be set")
+		}
+	})
+
+	t.Run("Invalid shipment transition is rejected", func(t *testing.T) {
+		cancelled := domain.ShipmentStatusCancelled
+		_, err := service.UpdateShipment(ctx, first.ID, &domain.UpdateShipmentRequest{Status: &cancelled})
+
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("Expected ErrInvalidStatusTransition, got: %v", err)
+		}
+	})
+}
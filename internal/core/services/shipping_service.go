@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type shippingService struct {
+	shippingMethodRepo ports.ShippingMethodRepository
+}
+
+// NewShippingService creates a new shipping service
+func NewShippingService(shippingMethodRepo ports.ShippingMethodRepository) ports.ShippingService {
+	return &shippingService{
+		shippingMethodRepo: shippingMethodRepo,
+	}
+}
+
+func (s *shippingService) CreateShippingMethod(ctx context.Context, req *domain.CreateShippingMethodRequest) (*domain.ShippingMethod, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	zones := req.Zones
+	if zones == nil {
+		zones = []domain.ShippingZone{}
+	}
+
+	method := &domain.ShippingMethod{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+		Amount:      req.Amount,
+		PerKgAmount: req.PerKgAmount,
+		FreeOver:    req.FreeOver,
+		Zones:       zones,
+		IsActive:    isActive,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	method.Normalize()
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.shippingMethodRepo.Create(ctx, method); err != nil {
+		return nil, fmt.Errorf("failed to create shipping method: %w", err)
+	}
+
+	return method, nil
+}
+
+func (s *shippingService) GetShippingMethod(ctx context.Context, id uuid.UUID) (*domain.ShippingMethod, error) {
+	method, err := s.shippingMethodRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping method: %w", err)
+	}
+	if method == nil {
+		return nil, fmt.Errorf("shipping method not found")
+	}
+
+	return method, nil
+}
+
+func (s *shippingService) GetShippingMethods(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.ShippingMethod], error) {
+	methods, err := s.shippingMethodRepo.GetAll(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping methods: %w", err)
+	}
+
+	return methods, nil
+}
+
+func (s *shippingService) UpdateShippingMethod(ctx context.Context, id uuid.UUID, req *domain.UpdateShippingMethodRequest) (*domain.ShippingMethod, error) {
+	method, err := s.GetShippingMethod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		method.Name = *req.Name
+	}
+	if req.Description != nil {
+		method.Description = *req.Description
+	}
+	if req.Type != nil {
+		method.Type = *req.Type
+	}
+	if req.Amount != nil {
+		method.Amount = *req.Amount
+	}
+	if req.PerKgAmount != nil {
+		method.PerKgAmount = req.PerKgAmount
+	}
+	if req.FreeOver != nil {
+		method.FreeOver = req.FreeOver
+	}
+	if req.Zones != nil {
+		method.Zones = *req.Zones
+		if method.Zones == nil {
+			method.Zones = []domain.ShippingZone{}
+		}
+	}
+	if req.IsActive != nil {
+		method.IsActive = *req.IsActive
+	}
+	method.Normalize()
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	method.UpdatedAt = time.Now()
+
+	if err := s.shippingMethodRepo.Update(ctx, method); err != nil {
+		return nil, fmt.Errorf("failed to update shipping method: %w", err)
+	}
+
+	return method, nil
+}
+
+// DeleteShippingMethod deletes a shipping method; orders shipped by it keep its name and
+// what it charged them
+func (s *shippingService) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetShippingMethod(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.shippingMethodRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete shipping method: %w", err)
+	}
+
+	return nil
+}
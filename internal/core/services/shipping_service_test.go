@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestShippingService(t *testing.T) {
+	ctx := context.Background()
+
+	mockShippingMethodRepo := testutils.NewMockShippingMethodRepository()
+	service := NewShippingService(mockShippingMethodRepo)
+
+	var courier *domain.ShippingMethod
+
+	t.Run("Create weight based method", func(t *testing.T) {
+		perKg := domain.Money{Amount: 5000}
+		var err error
+		courier, err = service.CreateShippingMethod(ctx, &domain.CreateShippingMethodRequest{
+			Name:        "Courier",
+			Type:        domain.ShippingWeightBased,
+			Amount:      domain.NewMoney(20000, "KES"),
+			PerKgAmount: &perKg,
+			Zones:       []domain.ShippingZone{{Country: " ke", City: " Nairobi "}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !courier.IsActive || courier.PerKgAmount.Currency != "KES" {
+			t.Errorf("Expected an active method charging per kilogram in KES, got: %+v", courier)
+		}
+		if courier.Zones[0].Country != "KE" || courier.Zones[0].City != "Nairobi" {
+			t.Errorf("Expected the zone to be normalized, got: %+v", courier.Zones[0])
+		}
+	})
+
+	t.Run("Create without zones", func(t *testing.T) {
+		method, err := service.CreateShippingMethod(ctx, &domain.CreateShippingMethodRequest{Name: "Standard", Type: domain.ShippingFlatRate, Amount: domain.Money{Amount: 500}})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if method.Zones == nil || method.Amount.Currency != domain.DefaultCurrency {
+			t.Errorf("Expected empty zones and the default currency, got: %+v", method)
+		}
+	})
+
+	t.Run("Invalid method", func(t *testing.T) {
+		_, err := service.CreateShippingMethod(ctx, &domain.CreateShippingMethodRequest{Name: "Free over", Type: domain.ShippingFreeOverThreshold, Amount: domain.NewMoney(500, "USD")})
+		if !errors.Is(err, domain.ErrInvalidShippingMethod) {
+			t.Errorf("Expected ErrInvalidShippingMethod, got: %v", err)
+		}
+	})
+
+	t.Run("Deactivate", func(t *testing.T) {
+		inactive := false
+		updated, err := service.UpdateShippingMethod(ctx, courier.ID, &domain.UpdateShippingMethodRequest{IsActive: &inactive})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if updated.IsActive {
+			t.Error("Expected the method to be inactive")
+		}
+	})
+
+	t.Run("Update rejects a mismatched currency", func(t *testing.T) {
+		amount := domain.NewMoney(500, "USD")
+		_, err := service.UpdateShippingMethod(ctx, courier.ID, &domain.UpdateShippingMethodRequest{Amount: &amount})
+		if !errors.Is(err, domain.ErrInvalidShippingMethod) {
+			t.Errorf("Expected ErrInvalidShippingMethod, got: %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := service.DeleteShippingMethod(ctx, courier.ID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if err := service.DeleteShippingMethod(ctx, uuid.New()); err == nil {
+			t.Error("Expected error for unknown shipping method")
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type supplierService struct {
+	supplierRepo ports.SupplierRepository
+}
+
+// NewSupplierService creates a new supplier service
+func NewSupplierService(supplierRepo ports.SupplierRepository) ports.SupplierService {
+	return &supplierService{
+		supplierRepo: supplierRepo,
+	}
+}
+
+func (s *supplierService) CreateSupplier(ctx context.Context, req *domain.CreateSupplierRequest) (*domain.Supplier, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("supplier name is required")
+	}
+
+	// Check if supplier with name already exists
+	existing, err := s.supplierRepo.GetByName(ctx, name)
+	if err == nil && existing != nil {
+		return nil, fmt.Errorf("supplier with name %s already exists", name)
+	}
+
+	supplier := &domain.Supplier{
+		ID:        uuid.New(),
+		Name:      name,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Address:   req.Address,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.supplierRepo.Create(ctx, supplier); err != nil {
+		return nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+func (s *supplierService) GetSupplier(ctx context.Context, id uuid.UUID) (*domain.Supplier, error) {
+	supplier, err := s.supplierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+	if supplier == nil {
+		return nil, fmt.Errorf("supplier not found")
+	}
+
+	return supplier, nil
+}
+
+func (s *supplierService) GetSuppliers(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Supplier], error) {
+	suppliers, err := s.supplierRepo.GetAll(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppliers: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+func (s *supplierService) UpdateSupplier(ctx context.Context, id uuid.UUID, req *domain.UpdateSupplierRequest) (*domain.Supplier, error) {
+	supplier, err := s.GetSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("supplier name is required")
+		}
+		if name != supplier.Name {
+			existing, err := s.supplierRepo.GetByName(ctx, name)
+			if err == nil && existing != nil {
+				return nil, fmt.Errorf("supplier with name %s already exists", name)
+			}
+		}
+		supplier.Name = name
+	}
+	if req.Email != nil {
+		supplier.Email = *req.Email
+	}
+	if req.Phone != nil {
+		supplier.Phone = *req.Phone
+	}
+	if req.Address != nil {
+		supplier.Address = *req.Address
+	}
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+
+	supplier.UpdatedAt = time.Now()
+
+	if err := s.supplierRepo.Update(ctx, supplier); err != nil {
+		return nil, fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	return supplier, nil
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+)
+
+func TestSupplierService(t *testing.T) {
+	ctx := context.Background()
+
+	mockSupplierRepo := testutils.NewMockSupplierRepository()
+	service := NewSupplierService(mockSupplierRepo)
+
+	var acme *domain.Supplier
+
+	t.Run("Create supplier", func(t *testing.T) {
+		var err error
+		acme, err = service.CreateSupplier(ctx, &domain.CreateSupplierRequest{Name: " Acme Ltd ", Email: "orders@acme.example"})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if acme.Name != "Acme Ltd" || !acme.IsActive {
+			t.Errorf("Expected an active supplier named Acme Ltd, got: %+v", acme)
+		}
+	})
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		_, err := service.CreateSupplier(ctx, &domain.CreateSupplierRequest{Name: "Acme Ltd"})
+		if err == nil {
+			t.Error("Expected error for duplicate supplier name")
+		}
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		_, err := service.CreateSupplier(ctx, &domain.CreateSupplierRequest{Name: "  "})
+		if err == nil {
+			t.Error("Expected error for missing supplier name")
+		}
+	})
+
+	t.Run("Update supplier", func(t *testing.T) {
+		phone := "+254700000000"
+		isActive := false
+		supplier, err := service.UpdateSupplier(ctx, acme.ID, &domain.UpdateSupplierRequest{Phone: &phone, IsActive: &isActive})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if supplier.Phone != phone || supplier.IsActive {
+			t.Errorf("Expected phone to be set and supplier deactivated, got: %+v", supplier)
+		}
+	})
+}
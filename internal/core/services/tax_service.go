@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type taxService struct {
+	taxRateRepo  ports.TaxRateRepository
+	categoryRepo ports.CategoryRepository
+}
+
+// NewTaxService creates a new tax service
+func NewTaxService(taxRateRepo ports.TaxRateRepository, categoryRepo ports.CategoryRepository) ports.TaxService {
+	return &taxService{
+		taxRateRepo:  taxRateRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *taxService) CreateTaxRate(ctx context.Context, req *domain.CreateTaxRateRequest) (*domain.TaxRate, error) {
+	rate := &domain.TaxRate{
+		ID:               uuid.New(),
+		Country:          domain.NormalizeCountry(req.Country),
+		CategoryID:       req.CategoryID,
+		Name:             req.Name,
+		Rate:             req.Rate,
+		PricesIncludeTax: req.PricesIncludeTax,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+
+	if rate.CategoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, *rate.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		if category == nil {
+			return nil, fmt.Errorf("%w: category not found", domain.ErrInvalidTaxRate)
+		}
+	}
+
+	// A country has one standard rate and one rate per category
+	existing, err := s.taxRateRepo.GetByCountry(ctx, rate.Country)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tax rates: %w", err)
+	}
+	for _, other := range existing {
+		if sameCategory(other.CategoryID, rate.CategoryID) {
+			return nil, fmt.Errorf("%w: %s already has this rate, update it instead", domain.ErrInvalidTaxRate, rate.Country)
+		}
+	}
+
+	if err := s.taxRateRepo.Create(ctx, rate); err != nil {
+		return nil, fmt.Errorf("failed to create tax rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+func (s *taxService) GetTaxRate(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error) {
+	rate, err := s.taxRateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rate: %w", err)
+	}
+	if rate == nil {
+		return nil, fmt.Errorf("tax rate not found")
+	}
+
+	return rate, nil
+}
+
+func (s *taxService) GetTaxRates(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.TaxRate], error) {
+	rates, err := s.taxRateRepo.GetAll(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (s *taxService) UpdateTaxRate(ctx context.Context, id uuid.UUID, req *domain.UpdateTaxRateRequest) (*domain.TaxRate, error) {
+	rate, err := s.GetTaxRate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rate.Name = *req.Name
+	}
+	if req.Rate != nil {
+		rate.Rate = *req.Rate
+	}
+	if req.PricesIncludeTax != nil {
+		rate.PricesIncludeTax = *req.PricesIncludeTax
+	}
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+	rate.UpdatedAt = time.Now()
+
+	if err := s.taxRateRepo.Update(ctx, rate); err != nil {
+		return nil, fmt.Errorf("failed to update tax rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+func (s *taxService) DeleteTaxRate(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetTaxRate(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.taxRateRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete tax rate: %w", err)
+	}
+
+	return nil
+}
+
+// sameCategory reports whether two rates cover the same category, nil being the standard rate
+func sameCategory(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestTaxService(t *testing.T) {
+	ctx := context.Background()
+
+	mockTaxRateRepo := testutils.NewMockTaxRateRepository()
+	mockCategoryRepo := testutils.NewMockCategoryRepository()
+	service := NewTaxService(mockTaxRateRepo, mockCategoryRepo)
+
+	foodID := uuid.New()
+	mockCategoryRepo.Categories[foodID] = &domain.Category{ID: foodID, Name: "Food"}
+
+	var vat *domain.TaxRate
+
+	t.Run("Create standard rate", func(t *testing.T) {
+		var err error
+		vat, err = service.CreateTaxRate(ctx, &domain.CreateTaxRateRequest{Country: " ke ", Name: "VAT", Rate: 1600, PricesIncludeTax: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if vat.Country != "KE" || vat.CategoryID != nil {
+			t.Errorf("Expected the standard rate of KE, got: %+v", vat)
+		}
+	})
+
+	t.Run("Duplicate standard rate", func(t *testing.T) {
+		_, err := service.CreateTaxRate(ctx, &domain.CreateTaxRateRequest{Country: "KE", Name: "VAT again", Rate: 1600})
+		if !errors.Is(err, domain.ErrInvalidTaxRate) {
+			t.Errorf("Expected ErrInvalidTaxRate, got: %v", err)
+		}
+	})
+
+	t.Run("Create category rate", func(t *testing.T) {
+		rate, err := service.CreateTaxRate(ctx, &domain.CreateTaxRateRequest{Country: "KE", CategoryID: &foodID, Name: "Zero-rated food", Rate: 0})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if rate.CategoryID == nil || *rate.CategoryID != foodID {
+			t.Errorf("Expected a rate for the food category, got: %+v", rate)
+		}
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		unknown := uuid.New()
+		_, err := service.CreateTaxRate(ctx, &domain.CreateTaxRateRequest{Country: "KE", CategoryID: &unknown, Name: "Nothing", Rate: 0})
+		if err == nil {
+			t.Error("Expected error for unknown category")
+		}
+	})
+
+	t.Run("Update rate", func(t *testing.T) {
+		rate := 1800
+		updated, err := service.UpdateTaxRate(ctx, vat.ID, &domain.UpdateTaxRateRequest{Rate: &rate})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if updated.Rate != 1800 {
+			t.Errorf("Expected a rate of 1800, got: %d", updated.Rate)
+		}
+
+		tooHigh := 10001
+		if _, err := service.UpdateTaxRate(ctx, vat.ID, &domain.UpdateTaxRateRequest{Rate: &tooHigh}); !errors.Is(err, domain.ErrInvalidTaxRate) {
+			t.Errorf("Expected ErrInvalidTaxRate, got: %v", err)
+		}
+	})
+
+	t.Run("Delete rate", func(t *testing.T) {
+		if err := service.DeleteTaxRate(ctx, vat.ID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := service.GetTaxRate(ctx, vat.ID); err == nil {
+			t.Error("Expected error for deleted tax rate")
+		}
+	})
+}
@@ -57,8 +57,8 @@ func (s *userService) GetUser(ctx context.Context, id uuid.UUID) (*domain.User,
 	return user, nil
 }
 
-func (s *userService) GetUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
-	users, err := s.userRepo.GetAll(ctx, limit, offset)
+func (s *userService) GetUsers(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.User], error) {
+	users, err := s.userRepo.GetAll(ctx, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
@@ -66,6 +66,22 @@ func (s *userService) GetUsers(ctx context.Context, limit, offset int) ([]*domai
 	return users, nil
 }
 
+// SearchUsers finds the users with words in their name or email starting with
+// every word of the query, best match first
+func (s *userService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.UserSearchResult, error) {
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, domain.ErrEmptySearch
+	}
+
+	results, err := s.userRepo.Search(ctx, terms, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return results, nil
+}
+
 func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req *domain.UpdateUserRequest) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
 	"silbackendassessment/internal/testutils"
 
 	"github.com/google/uuid"
@@ -194,21 +195,21 @@ func TestUserService_GetUsers(t *testing.T) {
 		}
 		mockRepo.AllUsers = users
 
-		result, err := service.GetUsers(ctx, 10, 0)
+		result, err := service.GetUsers(ctx, ports.PageRequest{Limit: 10})
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
 		}
 
-		if len(result) != 2 {
-			t.Errorf("Expected 2 users, got: %d", len(result))
+		if len(result.Items) != 2 {
+			t.Errorf("Expected 2 users, got: %d", len(result.Items))
 		}
 	})
 
 	t.Run("Repository error", func(t *testing.T) {
 		mockRepo.GetAllError = errors.New("database error")
 
-		users, err := service.GetUsers(ctx, 10, 0)
+		users, err := service.GetUsers(ctx, ports.PageRequest{Limit: 10})
 
 		if err == nil {
 			t.Error("Expected error from repository")
@@ -220,6 +221,85 @@ func TestUserService_GetUsers(t *testing.T) {
 	})
 }
 
+func TestUserService_GetUsersByCursor(t *testing.T) {
+	mockRepo := testutils.NewMockUserRepository()
+	service := NewUserService(mockRepo)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		mockRepo.AllUsers = append(mockRepo.AllUsers, &domain.User{
+			ID:        uuid.New(),
+			Name:      "User",
+			Email:     uuid.NewString() + "@example.com",
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+
+	first, err := service.GetUsers(ctx, ports.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(first.Items) != 2 || first.TotalCount != 5 || !first.HasNextPage() {
+		t.Fatalf("Expected 2 of 5 users and a next page, got: %d of %d", len(first.Items), first.TotalCount)
+	}
+
+	second, err := service.GetUsers(ctx, ports.PageRequest{Limit: 2, After: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(second.Items) != 2 || second.Items[0].ID != mockRepo.AllUsers[2].ID {
+		t.Errorf("Expected the second page to continue after the cursor")
+	}
+
+	last, err := service.GetUsers(ctx, ports.PageRequest{Limit: 2, After: second.NextCursor})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(last.Items) != 1 || last.HasNextPage() {
+		t.Errorf("Expected one user on the last page, got: %d", len(last.Items))
+	}
+}
+
+func TestUserService_SearchUsers(t *testing.T) {
+	mockRepo := testutils.NewMockUserRepository()
+	service := NewUserService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.AllUsers = []*domain.User{
+		{ID: uuid.New(), Name: "Alice Admin", Email: "alice@example.com"},
+		{ID: uuid.New(), Name: "Bob Builder", Email: "bob@example.com"},
+	}
+
+	t.Run("Search by email prefix", func(t *testing.T) {
+		results, err := service.SearchUsers(ctx, "bob@", 10, 0)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if len(results) != 1 || results[0].User.Name != "Bob Builder" {
+			t.Errorf("Expected Bob to match, got: %v", results)
+		}
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		_, err := service.SearchUsers(ctx, "", 10, 0)
+
+		if !errors.Is(err, domain.ErrEmptySearch) {
+			t.Errorf("Expected ErrEmptySearch, got: %v", err)
+		}
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo.GetAllError = errors.New("database error")
+
+		if _, err := service.SearchUsers(ctx, "alice", 10, 0); err == nil {
+			t.Error("Expected error from repository")
+		}
+	})
+}
+
 func TestUserService_UpdateUser(t *testing.T) {
 	mockRepo := testutils.NewMockUserRepository()
 	service := NewUserService(mockRepo)
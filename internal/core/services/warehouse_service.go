@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+type warehouseService struct {
+	warehouseRepo  ports.WarehouseRepository
+	stockLevelRepo ports.StockLevelRepository
+	productRepo    ports.ProductRepository
+}
+
+// NewWarehouseService creates a new warehouse service
+func NewWarehouseService(
+	warehouseRepo ports.WarehouseRepository,
+	stockLevelRepo ports.StockLevelRepository,
+	productRepo ports.ProductRepository,
+) ports.WarehouseService {
+	return &warehouseService{
+		warehouseRepo:  warehouseRepo,
+		stockLevelRepo: stockLevelRepo,
+		productRepo:    productRepo,
+	}
+}
+
+func (s *warehouseService) CreateWarehouse(ctx context.Context, req *domain.CreateWarehouseRequest) (*domain.Warehouse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return nil, fmt.Errorf("warehouse code is required")
+	}
+	if req.Name == "" || req.City == "" || req.Country == "" {
+		return nil, fmt.Errorf("warehouse name, city and country are required")
+	}
+
+	// Check if warehouse with code already exists
+	existing, err := s.warehouseRepo.GetByCode(ctx, code)
+	if err == nil && existing != nil {
+		return nil, fmt.Errorf("warehouse with code %s already exists", code)
+	}
+
+	warehouse := &domain.Warehouse{
+		ID:        uuid.New(),
+		Code:      code,
+		Name:      req.Name,
+		City:      req.City,
+		Country:   req.Country,
+		IsDefault: req.IsDefault,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.warehouseRepo.Create(ctx, warehouse); err != nil {
+		return nil, fmt.Errorf("failed to create warehouse: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+func (s *warehouseService) GetWarehouse(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error) {
+	warehouse, err := s.warehouseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	if warehouse == nil {
+		return nil, fmt.Errorf("warehouse not found")
+	}
+
+	return warehouse, nil
+}
+
+func (s *warehouseService) GetWarehouses(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Warehouse], error) {
+	warehouses, err := s.warehouseRepo.GetAll(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warehouses: %w", err)
+	}
+
+	return warehouses, nil
+}
+
+func (s *warehouseService) UpdateWarehouse(ctx context.Context, id uuid.UUID, req *domain.UpdateWarehouseRequest) (*domain.Warehouse, error) {
+	warehouse, err := s.GetWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Name != nil {
+		warehouse.Name = *req.Name
+	}
+	if req.City != nil {
+		warehouse.City = *req.City
+	}
+	if req.Country != nil {
+		warehouse.Country = *req.Country
+	}
+	if req.IsDefault != nil {
+		// Another warehouse must be made default instead, so stock without a location always has a home
+		if warehouse.IsDefault && !*req.IsDefault {
+			return nil, fmt.Errorf("mark another warehouse as default instead of unsetting %s", warehouse.Code)
+		}
+		warehouse.IsDefault = *req.IsDefault
+	}
+	if req.IsActive != nil {
+		warehouse.IsActive = *req.IsActive
+	}
+
+	warehouse.UpdatedAt = time.Now()
+
+	if err := s.warehouseRepo.Update(ctx, warehouse); err != nil {
+		return nil, fmt.Errorf("failed to update warehouse: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+func (s *warehouseService) GetWarehouseStock(ctx context.Context, id uuid.UUID, limit, offset int) ([]*domain.StockLevel, error) {
+	if _, err := s.GetWarehouse(ctx, id); err != nil {
+		return nil, err
+	}
+
+	levels, err := s.stockLevelRepo.GetByWarehouseID(ctx, id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock levels: %w", err)
+	}
+
+	return levels, nil
+}
+
+func (s *warehouseService) GetStockLevels(ctx context.Context, productID uuid.UUID) ([]*domain.StockLevel, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	levels, err := s.stockLevelRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock levels: %w", err)
+	}
+
+	return levels, nil
+}
+
+// SetStockLevel sets the product's quantity at a warehouse and returns the product's stock levels
+func (s *warehouseService) SetStockLevel(ctx context.Context, productID, warehouseID uuid.UUID, req *domain.SetStockLevelRequest) ([]*domain.StockLevel, error) {
+	if req.Quantity < 0 {
+		return nil, fmt.Errorf("quantity cannot be negative")
+	}
+
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if err := checkStockNotByVariant(product); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetWarehouse(ctx, warehouseID); err != nil {
+		return nil, err
+	}
+
+	if err := s.stockLevelRepo.Set(ctx, productID, warehouseID, req.Quantity); err != nil {
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			return nil, fmt.Errorf("cannot lower stock of product %s below what is reserved: %w", product.Name, err)
+		}
+		return nil, fmt.Errorf("failed to set stock level: %w", err)
+	}
+
+	return s.GetStockLevels(ctx, productID)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silbackendassessment/internal/core/domain"
+	"silbackendassessment/internal/testutils"
+
+	"github.com/google/uuid"
+)
+
+func TestWarehouseService(t *testing.T) {
+	ctx := context.Background()
+
+	mockWarehouseRepo := testutils.NewMockWarehouseRepository()
+	mockProductRepo := testutils.NewMockProductRepository()
+	mockStockLevelRepo := testutils.NewMockStockLevelRepository(mockProductRepo)
+	service := NewWarehouseService(mockWarehouseRepo, mockStockLevelRepo, mockProductRepo)
+
+	productID := uuid.New()
+	mockProductRepo.Products[productID] = &domain.Product{ID: productID, Name: "Product 1", Stock: 0, IsActive: true}
+
+	var nairobi, mombasa *domain.Warehouse
+
+	t.Run("Create warehouses", func(t *testing.T) {
+		var err error
+		nairobi, err = service.CreateWarehouse(ctx, &domain.CreateWarehouseRequest{Code: "nbo", Name: "Nairobi", City: "Nairobi", Country: "Kenya", IsDefault: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if nairobi.Code != "NBO" {
+			t.Errorf("Expected code to be normalised to NBO, got: %s", nairobi.Code)
+		}
+
+		mombasa, err = service.CreateWarehouse(ctx, &domain.CreateWarehouseRequest{Code: "MBA", Name: "Mombasa", City: "Mombasa", Country: "Kenya", IsDefault: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if nairobi.IsDefault || !mombasa.IsDefault {
+			t.Errorf("Expected the new default warehouse to replace the old one")
+		}
+	})
+
+	t.Run("Duplicate code", func(t *testing.T) {
+		_, err := service.CreateWarehouse(ctx, &domain.CreateWarehouseRequest{Code: "NBO", Name: "Nairobi 2", City: "Nairobi", Country: "Kenya"})
+		if err == nil {
+			t.Error("Expected error for duplicate warehouse code")
+		}
+	})
+
+	t.Run("Default warehouse cannot be unset directly", func(t *testing.T) {
+		isDefault := false
+		_, err := service.UpdateWarehouse(ctx, mombasa.ID, &domain.UpdateWarehouseRequest{IsDefault: &isDefault})
+		if err == nil {
+			t.Error("Expected error unsetting the default warehouse")
+		}
+	})
+
+	t.Run("Set stock level records an adjustment at the warehouse", func(t *testing.T) {
+		levels, err := service.SetStockLevel(ctx, productID, nairobi.ID, &domain.SetStockLevelRequest{Quantity: 7})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(levels) != 1 || levels[0].Quantity != 7 {
+			t.Errorf("Expected a single stock level of 7, got: %v", levels)
+		}
+		if mockProductRepo.Products[productID].Stock != 7 {
+			t.Errorf("Expected aggregate stock of 7, got: %d", mockProductRepo.Products[productID].Stock)
+		}
+		movement := mockProductRepo.Movements[len(mockProductRepo.Movements)-1]
+		if movement.Reason != domain.StockMovementReasonAdjustment || movement.WarehouseID == nil || *movement.WarehouseID != nairobi.ID {
+			t.Errorf("Expected an adjustment at Nairobi, got: %+v", movement)
+		}
+	})
+
+	t.Run("Products with variants are stocked through them", func(t *testing.T) {
+		shirtID := uuid.New()
+		mockProductRepo.Products[shirtID] = &domain.Product{ID: shirtID, Name: "Shirt", IsActive: true, Variants: []*domain.ProductVariant{{ID: uuid.New(), ProductID: shirtID, SKU: "SH-S", IsActive: true}}}
+
+		if _, err := service.SetStockLevel(ctx, shirtID, nairobi.ID, &domain.SetStockLevelRequest{Quantity: 5}); err == nil {
+			t.Error("Expected error setting the stock of a product sold by variant")
+		}
+	})
+
+	t.Run("Lowering below reserved stock fails", func(t *testing.T) {
+		mockStockLevelRepo.SetError = domain.ErrInsufficientStock
+		defer func() { mockStockLevelRepo.SetError = nil }()
+
+		_, err := service.SetStockLevel(ctx, productID, nairobi.ID, &domain.SetStockLevelRequest{Quantity: 0})
+
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock, got: %v", err)
+		}
+	})
+}
@@ -3,6 +3,8 @@ package testutils
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"silbackendassessment/internal/core/domain"
@@ -20,6 +22,13 @@ var (
 	ErrCategoryNotFound        = errors.New("category not found")
 	ErrOrderNotFound           = errors.New("order not found")
 	ErrOrderItemNotFound       = errors.New("order item not found")
+	ErrShipmentNotFound        = errors.New("shipment not found")
+	ErrReturnNotFound          = errors.New("return not found")
+	ErrReservationNotFound     = errors.New("reservation not found")
+	ErrWarehouseNotFound       = errors.New("warehouse not found")
+	ErrSupplierNotFound        = errors.New("supplier not found")
+	ErrPurchaseOrderNotFound   = errors.New("purchase order not found")
+	ErrVariantNotFound         = errors.New("variant not found")
 	ErrInvalidNotificationType = errors.New("invalid notification type")
 )
 
@@ -53,12 +62,12 @@ func (m *MockUserService) GetUser(ctx context.Context, id uuid.UUID) (*domain.Us
 	return nil, ErrUserNotFound
 }
 
-func (m *MockUserService) GetUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+func (m *MockUserService) GetUsers(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.User], error) {
 	users := make([]*domain.User, 0, len(m.Users))
 	for _, user := range m.Users {
 		users = append(users, user)
 	}
-	return users, nil
+	return pageOf(users, page, userIDOf), nil
 }
 
 func (m *MockUserService) UpdateUser(ctx context.Context, id uuid.UUID, req *domain.UpdateUserRequest) (*domain.User, error) {
@@ -86,14 +95,14 @@ func (m *MockUserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (m *MockUserService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, error) {
-	users := make([]*domain.User, 0)
+func (m *MockUserService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.UserSearchResult, error) {
+	results := make([]*domain.UserSearchResult, 0)
 	for _, user := range m.Users {
-		if user.Name == query || user.Email == query {
-			users = append(users, user)
+		if matchesSearch(domain.SearchTerms(query), user.Name, user.Email) {
+			results = append(results, &domain.UserSearchResult{User: user, Rank: 1, Highlight: user.Name})
 		}
 	}
-	return users, nil
+	return results, nil
 }
 
 // MockCustomerService implements ports.CustomerService for testing
@@ -133,12 +142,12 @@ func (m *MockCustomerService) GetCustomer(ctx context.Context, id uuid.UUID) (*d
 	return nil, ErrCustomerNotFound
 }
 
-func (m *MockCustomerService) GetCustomers(ctx context.Context, limit, offset int) ([]*domain.Customer, error) {
+func (m *MockCustomerService) GetCustomers(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Customer], error) {
 	customers := make([]*domain.Customer, 0, len(m.Customers))
 	for _, customer := range m.Customers {
 		customers = append(customers, customer)
 	}
-	return customers, nil
+	return pageOf(customers, page, customerIDOf), nil
 }
 
 func (m *MockCustomerService) UpdateCustomer(ctx context.Context, id uuid.UUID, req *domain.UpdateCustomerRequest) (*domain.Customer, error) {
@@ -184,14 +193,127 @@ func (m *MockCustomerService) DeleteCustomer(ctx context.Context, id uuid.UUID)
 	return nil
 }
 
-func (m *MockCustomerService) SearchCustomers(ctx context.Context, query string, limit, offset int) ([]*domain.Customer, error) {
-	customers := make([]*domain.Customer, 0)
+func (m *MockCustomerService) SearchCustomers(ctx context.Context, query string, limit, offset int) ([]*domain.CustomerSearchResult, error) {
+	results := make([]*domain.CustomerSearchResult, 0)
 	for _, customer := range m.Customers {
-		if customer.FirstName == query || customer.LastName == query || customer.Email == query {
-			customers = append(customers, customer)
+		if matchesSearch(domain.SearchTerms(query), customer.FirstName, customer.LastName, customer.Email, customer.Phone) {
+			results = append(results, &domain.CustomerSearchResult{Customer: customer, Rank: 1, Highlight: customer.FirstName})
 		}
 	}
-	return customers, nil
+	return results, nil
+}
+
+// matchesSearch reports whether every term starts a word of one of the fields, as the
+// full-text search does
+func matchesSearch(terms []string, fields ...string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	for _, term := range terms {
+		found := false
+		for _, field := range fields {
+			for _, word := range domain.SearchTerms(field) {
+				if strings.HasPrefix(word, term) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// pageOf returns the page of items a page request selects, taking the items in the
+// order given. A cursor continues after the item it was taken from.
+func pageOf[T any](items []T, page ports.PageRequest, idOf func(T) uuid.UUID) *ports.Page[T] {
+	cursorOf := func(item T) ports.Cursor {
+		return ports.NewCursor(page.Order(), idOf(item))
+	}
+	start := page.Offset
+	if page.After != nil {
+		start = len(items)
+		for i, item := range items {
+			if cursorOf(item).String() == page.After.String() {
+				start = i + 1
+				break
+			}
+		}
+	}
+	start = min(start, len(items))
+	end := min(start+page.Size(), len(items))
+
+	result := &ports.Page[T]{Items: items[start:end], TotalCount: len(items)}
+	for _, item := range result.Items {
+		result.Cursors = append(result.Cursors, cursorOf(item))
+	}
+	if end < len(items) {
+		cursor := cursorOf(items[end-1])
+		result.NextCursor = &cursor
+	}
+	return result
+}
+
+func userIDOf(u *domain.User) uuid.UUID {
+	return u.ID
+}
+
+func customerIDOf(c *domain.Customer) uuid.UUID {
+	return c.ID
+}
+
+func categoryIDOf(c *domain.Category) uuid.UUID {
+	return c.ID
+}
+
+func productIDOf(p *domain.Product) uuid.UUID {
+	return p.ID
+}
+
+func orderIDOf(o *domain.Order) uuid.UUID {
+	return o.ID
+}
+
+func warehouseIDOf(w *domain.Warehouse) uuid.UUID {
+	return w.ID
+}
+
+func supplierIDOf(s *domain.Supplier) uuid.UUID {
+	return s.ID
+}
+
+func purchaseOrderIDOf(p *domain.PurchaseOrder) uuid.UUID {
+	return p.ID
+}
+
+func stockMovementIDOf(s *domain.StockMovement) uuid.UUID {
+	return s.ID
+}
+
+func promotionIDOf(p *domain.Promotion) uuid.UUID {
+	return p.ID
+}
+
+func taxRateIDOf(r *domain.TaxRate) uuid.UUID {
+	return r.ID
+}
+
+func shippingMethodIDOf(m *domain.ShippingMethod) uuid.UUID {
+	return m.ID
+}
+
+// MockLowStockNotifier implements ports.LowStockNotifier for testing
+type MockLowStockNotifier struct {
+	Events []*domain.LowStockEvent
+}
+
+func NewMockLowStockNotifier() *MockLowStockNotifier {
+	return &MockLowStockNotifier{}
+}
+
+func (m *MockLowStockNotifier) NotifyLowStock(ctx context.Context, events []*domain.LowStockEvent) {
+	m.Events = append(m.Events, events...)
 }
 
 // MockNotificationService implements ports.NotificationService for testing
@@ -282,7 +404,7 @@ func (m *MockNotificationService) SendOrderConfirmationEmail(ctx context.Context
 	return m.SendEmail(ctx, customerEmail, "Order Confirmation", "Your order has been confirmed")
 }
 
-func (m *MockNotificationService) SendOrderConfirmationSMS(ctx context.Context, phoneNumber, customerName, orderNumber string, totalAmount float64) error {
+func (m *MockNotificationService) SendOrderConfirmationSMS(ctx context.Context, phoneNumber, customerName, orderNumber string, totalAmount domain.Money) error {
 	return m.SendSMS(ctx, phoneNumber, "Your order has been confirmed")
 }
 
@@ -398,11 +520,24 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return nil, ErrUserNotFound
 }
 
-func (m *MockUserRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+func (m *MockUserRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.User], error) {
+	if m.GetAllError != nil {
+		return nil, m.GetAllError
+	}
+	return pageOf(m.AllUsers, page, userIDOf), nil
+}
+
+func (m *MockUserRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.UserSearchResult, error) {
 	if m.GetAllError != nil {
 		return nil, m.GetAllError
 	}
-	return m.AllUsers, nil
+	results := make([]*domain.UserSearchResult, 0)
+	for _, user := range m.AllUsers {
+		if matchesSearch(terms, user.Name, user.Email) {
+			results = append(results, &domain.UserSearchResult{User: user, Rank: 1, Highlight: user.Name})
+		}
+	}
+	return results, nil
 }
 
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
@@ -476,11 +611,24 @@ func (m *MockCustomerRepository) GetByEmail(ctx context.Context, email string) (
 	return nil, ErrCustomerNotFound
 }
 
-func (m *MockCustomerRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Customer, error) {
+func (m *MockCustomerRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Customer], error) {
+	if m.GetAllError != nil {
+		return nil, m.GetAllError
+	}
+	return pageOf(m.AllCustomers, page, customerIDOf), nil
+}
+
+func (m *MockCustomerRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.CustomerSearchResult, error) {
 	if m.GetAllError != nil {
 		return nil, m.GetAllError
 	}
-	return m.AllCustomers, nil
+	results := make([]*domain.CustomerSearchResult, 0)
+	for _, customer := range m.AllCustomers {
+		if matchesSearch(terms, customer.FirstName, customer.LastName, customer.Email, customer.Phone) {
+			results = append(results, &domain.CustomerSearchResult{Customer: customer, Rank: 1, Highlight: customer.FirstName})
+		}
+	}
+	return results, nil
 }
 
 func (m *MockCustomerRepository) Update(ctx context.Context, customer *domain.Customer) error {
@@ -509,6 +657,10 @@ type MockProductRepository struct {
 	Products      map[uuid.UUID]*domain.Product
 	ProductsBySKU map[string]*domain.Product
 	AllProducts   []*domain.Product
+	Movements     []*domain.StockMovement
+	Reserved      map[uuid.UUID]int
+	LastQuery     *domain.ProductQuery
+	Facets        *domain.ProductFacets
 	CreateError   error
 	GetByIDError  error
 	GetBySKUError error
@@ -522,6 +674,8 @@ func NewMockProductRepository() *MockProductRepository {
 		Products:      make(map[uuid.UUID]*domain.Product),
 		ProductsBySKU: make(map[string]*domain.Product),
 		AllProducts:   make([]*domain.Product, 0),
+		Movements:     make([]*domain.StockMovement, 0),
+		Reserved:      make(map[uuid.UUID]int),
 	}
 }
 
@@ -531,6 +685,9 @@ func (m *MockProductRepository) Create(ctx context.Context, product *domain.Prod
 	}
 	m.Products[product.ID] = product
 	m.ProductsBySKU[product.SKU] = product
+	if product.Stock != 0 {
+		m.recordMovement(ctx, domain.NewStockMovement(product.ID, product.Stock, domain.StockMovementReasonInitial, nil))
+	}
 	return nil
 }
 
@@ -551,20 +708,30 @@ func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*doma
 	if product, exists := m.ProductsBySKU[sku]; exists {
 		return product, nil
 	}
-	return nil, ErrProductNotFound
+	for _, product := range m.Products {
+		for _, variant := range product.Variants {
+			if variant.SKU == sku {
+				return product, nil
+			}
+		}
+	}
+	return nil, nil
 }
 
-func (m *MockProductRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+func (m *MockProductRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	if m.GetAllError != nil {
 		return nil, m.GetAllError
 	}
-	return m.AllProducts, nil
+	return pageOf(m.AllProducts, page, productIDOf), nil
 }
 
 func (m *MockProductRepository) Update(ctx context.Context, product *domain.Product) error {
 	if m.UpdateError != nil {
 		return m.UpdateError
 	}
+	if existing, exists := m.Products[product.ID]; exists {
+		product.Stock = existing.Stock
+	}
 	m.Products[product.ID] = product
 	m.ProductsBySKU[product.SKU] = product
 	return nil
@@ -582,19 +749,73 @@ func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return ErrProductNotFound
 }
 
-func (m *MockProductRepository) GetByCategoryID(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Product, error) {
+func (m *MockProductRepository) GetByCategoryID(ctx context.Context, categoryID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	// Simple implementation for testing
-	return m.AllProducts, nil
+	return pageOf(m.AllProducts, page, productIDOf), nil
 }
 
-func (m *MockProductRepository) GetActiveProducts(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+func (m *MockProductRepository) GetActiveProducts(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
 	// Simple implementation for testing
-	return m.AllProducts, nil
+	return pageOf(m.AllProducts, page, productIDOf), nil
 }
 
-func (m *MockProductRepository) SearchByName(ctx context.Context, name string, limit, offset int) ([]*domain.Product, error) {
-	// Simple implementation for testing
-	return m.AllProducts, nil
+func (m *MockProductRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*domain.ProductSearchResult, error) {
+	if m.GetAllError != nil {
+		return nil, m.GetAllError
+	}
+	results := make([]*domain.ProductSearchResult, 0)
+	for _, product := range m.AllProducts {
+		if matchesSearch(terms, product.Name, product.SKU, product.Description) {
+			results = append(results, &domain.ProductSearchResult{Product: product, Rank: 1, Highlight: product.Name})
+		}
+	}
+	return results, nil
+}
+
+func (m *MockProductRepository) Query(ctx context.Context, query *domain.ProductQuery, page ports.PageRequest) (*ports.Page[*domain.Product], error) {
+	if m.GetAllError != nil {
+		return nil, m.GetAllError
+	}
+	m.LastQuery = query
+	return pageOf(m.AllProducts, page, productIDOf), nil
+}
+
+func (m *MockProductRepository) GetFacets(ctx context.Context, query *domain.ProductQuery) (*domain.ProductFacets, error) {
+	if m.GetAllError != nil {
+		return nil, m.GetAllError
+	}
+	m.LastQuery = query
+	if m.Facets != nil {
+		return m.Facets, nil
+	}
+	return &domain.ProductFacets{Total: len(m.AllProducts)}, nil
+}
+
+func (m *MockProductRepository) GetLowStock(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+	var products []*domain.Product
+	for _, product := range m.AllProducts {
+		if product.IsActive && product.IsLowStock() {
+			products = append(products, product)
+		}
+	}
+	return products, nil
+}
+
+func (m *MockProductRepository) ListAfterSKU(ctx context.Context, afterSKU string, limit int) ([]*domain.Product, error) {
+	if m.GetAllError != nil {
+		return nil, m.GetAllError
+	}
+	var products []*domain.Product
+	for _, product := range m.Products {
+		if product.SKU > afterSKU {
+			products = append(products, product)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].SKU < products[j].SKU })
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	return products, nil
 }
 
 func (m *MockProductRepository) UpdateStock(ctx context.Context, id uuid.UUID, stock int) error {
@@ -602,21 +823,106 @@ func (m *MockProductRepository) UpdateStock(ctx context.Context, id uuid.UUID, s
 		return m.UpdateError
 	}
 	if product, exists := m.Products[id]; exists {
+		if stock != product.Stock {
+			m.recordMovement(ctx, domain.NewStockMovement(id, stock-product.Stock, domain.StockMovementReasonAdjustment, nil))
+		}
 		product.Stock = stock
 		return nil
 	}
 	return ErrProductNotFound
 }
 
+func (m *MockProductRepository) AdjustStock(ctx context.Context, movement *domain.StockMovement) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	product, exists := m.Products[movement.ProductID]
+	if !exists {
+		return ErrProductNotFound
+	}
+	if movement.Delta < 0 && product.Stock+movement.Delta < m.Reserved[movement.ProductID] {
+		return domain.ErrInsufficientStock
+	}
+	var variant *domain.ProductVariant
+	if movement.VariantID != nil {
+		for _, v := range product.Variants {
+			if v.ID == *movement.VariantID {
+				variant = v
+			}
+		}
+		if variant == nil || variant.Stock+movement.Delta < 0 {
+			return domain.ErrInsufficientStock
+		}
+		variant.Stock += movement.Delta
+	}
+	product.Stock += movement.Delta
+	m.recordMovement(ctx, movement)
+	return nil
+}
+
+func (m *MockProductRepository) recordMovement(ctx context.Context, movement *domain.StockMovement) {
+	if movement.Actor == "" {
+		movement.Actor = domain.ActorFromContext(ctx)
+	}
+	m.Movements = append(m.Movements, movement)
+}
+
+// MockProductPriceRepository implements ports.ProductPriceRepository for testing
+type MockProductPriceRepository struct {
+	Prices      map[uuid.UUID]*domain.ProductPrice
+	CreateError error
+}
+
+func NewMockProductPriceRepository() *MockProductPriceRepository {
+	return &MockProductPriceRepository{
+		Prices: make(map[uuid.UUID]*domain.ProductPrice),
+	}
+}
+
+func (m *MockProductPriceRepository) Create(ctx context.Context, price *domain.ProductPrice) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Prices[price.ID] = price
+	return nil
+}
+
+func (m *MockProductPriceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductPrice, error) {
+	return m.Prices[id], nil
+}
+
+func (m *MockProductPriceRepository) GetByProductID(ctx context.Context, productID uuid.UUID, from, to *time.Time) ([]*domain.ProductPrice, error) {
+	prices := make([]*domain.ProductPrice, 0)
+	for _, price := range m.Prices {
+		if price.ProductID == productID && price.Overlaps(from, to) {
+			prices = append(prices, price)
+		}
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].EffectiveFrom.Before(prices[j].EffectiveFrom) })
+	return prices, nil
+}
+
+func (m *MockProductPriceRepository) GetEffective(ctx context.Context, productID uuid.UUID, at time.Time) (*domain.ProductPrice, error) {
+	prices, _ := m.GetByProductID(ctx, productID, nil, nil)
+	return domain.EffectivePriceAt(prices, at), nil
+}
+
+func (m *MockProductPriceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(m.Prices, id)
+	return nil
+}
+
 // MockCategoryRepository implements ports.CategoryRepository for testing
 type MockCategoryRepository struct {
 	Categories    map[uuid.UUID]*domain.Category
 	AllCategories []*domain.Category
+	Redirects     []*domain.CategorySlugRedirect
 	CreateError   error
 	GetByIDError  error
 	GetAllError   error
 	UpdateError   error
 	DeleteError   error
+	RedirectError error
 }
 
 func NewMockCategoryRepository() *MockCategoryRepository {
@@ -630,10 +936,21 @@ func (m *MockCategoryRepository) Create(ctx context.Context, category *domain.Ca
 	if m.CreateError != nil {
 		return m.CreateError
 	}
+	category.Path = domain.CategoryPath(m.parentPath(category.ParentID), category.ID)
 	m.Categories[category.ID] = category
 	return nil
 }
 
+func (m *MockCategoryRepository) parentPath(parentID *uuid.UUID) string {
+	if parentID == nil {
+		return ""
+	}
+	if parent, exists := m.Categories[*parentID]; exists {
+		return parent.Path
+	}
+	return ""
+}
+
 func (m *MockCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
 	if m.GetByIDError != nil {
 		return nil, m.GetByIDError
@@ -644,11 +961,11 @@ func (m *MockCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 	return nil, ErrCategoryNotFound
 }
 
-func (m *MockCategoryRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Category, error) {
+func (m *MockCategoryRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Category], error) {
 	if m.GetAllError != nil {
 		return nil, m.GetAllError
 	}
-	return m.AllCategories, nil
+	return pageOf(m.AllCategories, page, categoryIDOf), nil
 }
 
 func (m *MockCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
@@ -690,6 +1007,121 @@ func (m *MockCategoryRepository) GetRootCategories(ctx context.Context) ([]*doma
 	return m.AllCategories, nil
 }
 
+func (m *MockCategoryRepository) GetAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Category, error) {
+	category, exists := m.Categories[id]
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+	ancestors := make([]*domain.Category, 0)
+	for _, ancestorID := range category.AncestorIDs() {
+		if ancestor, exists := m.Categories[ancestorID]; exists {
+			ancestors = append(ancestors, ancestor)
+		}
+	}
+	return ancestors, nil
+}
+
+func (m *MockCategoryRepository) GetDescendants(ctx context.Context, id uuid.UUID) ([]*domain.Category, error) {
+	root, exists := m.Categories[id]
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+	descendants := make([]*domain.Category, 0)
+	for _, category := range m.Categories {
+		if category.ID != id && strings.HasPrefix(category.Path, root.Path) {
+			descendants = append(descendants, category)
+		}
+	}
+	sort.Slice(descendants, func(i, j int) bool {
+		if descendants[i].Position != descendants[j].Position {
+			return descendants[i].Position < descendants[j].Position
+		}
+		return descendants[i].Name < descendants[j].Name
+	})
+	return descendants, nil
+}
+
+func (m *MockCategoryRepository) Move(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	category, exists := m.Categories[id]
+	if !exists {
+		return ErrCategoryNotFound
+	}
+	var parent *domain.Category
+	if parentID != nil {
+		parent = m.Categories[*parentID]
+	}
+	if err := category.CanMoveUnder(parent); err != nil {
+		return err
+	}
+
+	oldPath := category.Path
+	newPath := domain.CategoryPath(m.parentPath(parentID), id)
+	category.ParentID = parentID
+	if oldPath == "" {
+		m.rebuildPaths(category, newPath)
+		return nil
+	}
+	for _, c := range m.Categories {
+		if strings.HasPrefix(c.Path, oldPath) {
+			c.Path = newPath + strings.TrimPrefix(c.Path, oldPath)
+		}
+	}
+	return nil
+}
+
+// rebuildPaths gives the category the path and its descendants paths below it, following
+// their parent links
+func (m *MockCategoryRepository) rebuildPaths(category *domain.Category, path string) {
+	category.Path = path
+	for _, c := range m.Categories {
+		if c.ParentID != nil && *c.ParentID == category.ID && c.ID != category.ID {
+			m.rebuildPaths(c, domain.CategoryPath(path, c.ID))
+		}
+	}
+}
+
+func (m *MockCategoryRepository) GetBySlug(ctx context.Context, parentID *uuid.UUID, slug string) (*domain.Category, error) {
+	for _, category := range m.Categories {
+		if sameParent(category.ParentID, parentID) && category.Slug == slug {
+			return category, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCategoryRepository) GetBySlugRedirect(ctx context.Context, parentID *uuid.UUID, slug string) (*domain.Category, error) {
+	for _, redirect := range m.Redirects {
+		if sameParent(redirect.ParentID, parentID) && redirect.Slug == slug {
+			return m.Categories[redirect.CategoryID], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCategoryRepository) AddSlugRedirect(ctx context.Context, redirect *domain.CategorySlugRedirect) error {
+	if m.RedirectError != nil {
+		return m.RedirectError
+	}
+	redirects := m.Redirects[:0]
+	for _, r := range m.Redirects {
+		if !sameParent(r.ParentID, redirect.ParentID) || r.Slug != redirect.Slug {
+			redirects = append(redirects, r)
+		}
+	}
+	m.Redirects = append(redirects, redirect)
+	return nil
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // MockOrderRepository implements ports.OrderRepository for testing
 type MockOrderRepository struct {
 	Orders       map[uuid.UUID]*domain.Order
@@ -726,21 +1158,21 @@ func (m *MockOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 	return nil, ErrOrderNotFound
 }
 
-func (m *MockOrderRepository) GetAll(ctx context.Context, limit, offset int) ([]*domain.Order, error) {
+func (m *MockOrderRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	if m.GetAllError != nil {
 		return nil, m.GetAllError
 	}
-	return m.AllOrders, nil
+	return pageOf(m.AllOrders, page, orderIDOf), nil
 }
 
-func (m *MockOrderRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID, limit, offset int) ([]*domain.Order, error) {
+func (m *MockOrderRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	// Simple implementation for testing
-	return m.AllOrders, nil
+	return pageOf(m.AllOrders, page, orderIDOf), nil
 }
 
-func (m *MockOrderRepository) GetByStatus(ctx context.Context, status domain.OrderStatus, limit, offset int) ([]*domain.Order, error) {
+func (m *MockOrderRepository) GetByStatus(ctx context.Context, status domain.OrderStatus, page ports.PageRequest) (*ports.Page[*domain.Order], error) {
 	// Simple implementation for testing
-	return m.AllOrders, nil
+	return pageOf(m.AllOrders, page, orderIDOf), nil
 }
 
 func (m *MockOrderRepository) GetByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
@@ -867,3 +1299,961 @@ func (m *MockOrderItemRepository) DeleteByOrderID(ctx context.Context, orderID u
 	}
 	return nil
 }
+
+// MockOrderStatusHistoryRepository implements ports.OrderStatusHistoryRepository for testing
+type MockOrderStatusHistoryRepository struct {
+	Entries     []*domain.OrderStatusHistory
+	CreateError error
+}
+
+func NewMockOrderStatusHistoryRepository() *MockOrderStatusHistoryRepository {
+	return &MockOrderStatusHistoryRepository{
+		Entries: make([]*domain.OrderStatusHistory, 0),
+	}
+}
+
+func (m *MockOrderStatusHistoryRepository) Create(ctx context.Context, entry *domain.OrderStatusHistory) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Entries = append(m.Entries, entry)
+	return nil
+}
+
+func (m *MockOrderStatusHistoryRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderStatusHistory, error) {
+	var entries []*domain.OrderStatusHistory
+	for _, entry := range m.Entries {
+		if entry.OrderID == orderID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// MockOrderDiscountRepository implements ports.OrderDiscountRepository for testing
+type MockOrderDiscountRepository struct {
+	Discounts   []*domain.OrderDiscount
+	CreateError error
+}
+
+func NewMockOrderDiscountRepository() *MockOrderDiscountRepository {
+	return &MockOrderDiscountRepository{
+		Discounts: make([]*domain.OrderDiscount, 0),
+	}
+}
+
+func (m *MockOrderDiscountRepository) Create(ctx context.Context, discount *domain.OrderDiscount) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Discounts = append(m.Discounts, discount)
+	return nil
+}
+
+func (m *MockOrderDiscountRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.OrderDiscount, error) {
+	var discounts []*domain.OrderDiscount
+	for _, discount := range m.Discounts {
+		if discount.OrderID == orderID {
+			discounts = append(discounts, discount)
+		}
+	}
+	return discounts, nil
+}
+
+func (m *MockOrderDiscountRepository) Update(ctx context.Context, discount *domain.OrderDiscount) error {
+	for i, existing := range m.Discounts {
+		if existing.ID == discount.ID {
+			m.Discounts[i] = discount
+		}
+	}
+	return nil
+}
+
+func (m *MockOrderDiscountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, discount := range m.Discounts {
+		if discount.ID == id {
+			m.Discounts = append(m.Discounts[:i], m.Discounts[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// MockPromotionRepository implements ports.PromotionRepository for testing. CustomerUses
+// counts the orders each customer used a promotion on, keyed by promotion and customer ID.
+type MockPromotionRepository struct {
+	Promotions   map[uuid.UUID]*domain.Promotion
+	CustomerUses map[[2]uuid.UUID]int
+	CreateError  error
+}
+
+func NewMockPromotionRepository() *MockPromotionRepository {
+	return &MockPromotionRepository{
+		Promotions:   make(map[uuid.UUID]*domain.Promotion),
+		CustomerUses: make(map[[2]uuid.UUID]int),
+	}
+}
+
+func (m *MockPromotionRepository) Create(ctx context.Context, promotion *domain.Promotion) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Promotions[promotion.ID] = promotion
+	return nil
+}
+
+func (m *MockPromotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	return m.Promotions[id], nil
+}
+
+func (m *MockPromotionRepository) GetByCode(ctx context.Context, code string) (*domain.Promotion, error) {
+	for _, promotion := range m.Promotions {
+		if promotion.Code != nil && *promotion.Code == code {
+			return promotion, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPromotionRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Promotion], error) {
+	promotions := make([]*domain.Promotion, 0, len(m.Promotions))
+	for _, promotion := range m.Promotions {
+		promotions = append(promotions, promotion)
+	}
+	return pageOf(promotions, page, promotionIDOf), nil
+}
+
+func (m *MockPromotionRepository) GetAutomatic(ctx context.Context, at time.Time) ([]*domain.Promotion, error) {
+	var promotions []*domain.Promotion
+	for _, promotion := range m.Promotions {
+		if !promotion.IsCoupon() && promotion.Available(at) == nil {
+			promotions = append(promotions, promotion)
+		}
+	}
+	return promotions, nil
+}
+
+func (m *MockPromotionRepository) Update(ctx context.Context, promotion *domain.Promotion) error {
+	m.Promotions[promotion.ID] = promotion
+	return nil
+}
+
+func (m *MockPromotionRepository) Redeem(ctx context.Context, id uuid.UUID) error {
+	promotion, ok := m.Promotions[id]
+	if !ok || (promotion.UsageLimit != nil && promotion.TimesUsed >= *promotion.UsageLimit) {
+		return domain.ErrPromotionUnavailable
+	}
+	promotion.TimesUsed++
+	return nil
+}
+
+func (m *MockPromotionRepository) Release(ctx context.Context, id uuid.UUID) error {
+	if promotion, ok := m.Promotions[id]; ok && promotion.TimesUsed > 0 {
+		promotion.TimesUsed--
+	}
+	return nil
+}
+
+func (m *MockPromotionRepository) CountCustomerUses(ctx context.Context, id, customerID uuid.UUID) (int, error) {
+	return m.CustomerUses[[2]uuid.UUID{id, customerID}], nil
+}
+
+// MockTaxRateRepository implements ports.TaxRateRepository for testing
+type MockTaxRateRepository struct {
+	Rates       map[uuid.UUID]*domain.TaxRate
+	CreateError error
+}
+
+func NewMockTaxRateRepository() *MockTaxRateRepository {
+	return &MockTaxRateRepository{
+		Rates: make(map[uuid.UUID]*domain.TaxRate),
+	}
+}
+
+func (m *MockTaxRateRepository) Create(ctx context.Context, rate *domain.TaxRate) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Rates[rate.ID] = rate
+	return nil
+}
+
+func (m *MockTaxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error) {
+	return m.Rates[id], nil
+}
+
+func (m *MockTaxRateRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.TaxRate], error) {
+	rates := make([]*domain.TaxRate, 0, len(m.Rates))
+	for _, rate := range m.Rates {
+		rates = append(rates, rate)
+	}
+	return pageOf(rates, page, taxRateIDOf), nil
+}
+
+func (m *MockTaxRateRepository) GetByCountry(ctx context.Context, country string) ([]*domain.TaxRate, error) {
+	var rates []*domain.TaxRate
+	for _, rate := range m.Rates {
+		if rate.Country == country {
+			rates = append(rates, rate)
+		}
+	}
+	return rates, nil
+}
+
+func (m *MockTaxRateRepository) Update(ctx context.Context, rate *domain.TaxRate) error {
+	m.Rates[rate.ID] = rate
+	return nil
+}
+
+func (m *MockTaxRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(m.Rates, id)
+	return nil
+}
+
+// MockShippingMethodRepository implements ports.ShippingMethodRepository for testing
+type MockShippingMethodRepository struct {
+	Methods     map[uuid.UUID]*domain.ShippingMethod
+	CreateError error
+}
+
+func NewMockShippingMethodRepository() *MockShippingMethodRepository {
+	return &MockShippingMethodRepository{
+		Methods: make(map[uuid.UUID]*domain.ShippingMethod),
+	}
+}
+
+func (m *MockShippingMethodRepository) Create(ctx context.Context, method *domain.ShippingMethod) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Methods[method.ID] = method
+	return nil
+}
+
+func (m *MockShippingMethodRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ShippingMethod, error) {
+	return m.Methods[id], nil
+}
+
+func (m *MockShippingMethodRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.ShippingMethod], error) {
+	methods := make([]*domain.ShippingMethod, 0, len(m.Methods))
+	for _, method := range m.Methods {
+		methods = append(methods, method)
+	}
+	return pageOf(methods, page, shippingMethodIDOf), nil
+}
+
+func (m *MockShippingMethodRepository) GetActive(ctx context.Context) ([]*domain.ShippingMethod, error) {
+	var methods []*domain.ShippingMethod
+	for _, method := range m.Methods {
+		if method.IsActive {
+			methods = append(methods, method)
+		}
+	}
+	return methods, nil
+}
+
+func (m *MockShippingMethodRepository) Update(ctx context.Context, method *domain.ShippingMethod) error {
+	m.Methods[method.ID] = method
+	return nil
+}
+
+func (m *MockShippingMethodRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(m.Methods, id)
+	return nil
+}
+
+// MockTxManager implements ports.TxManager for testing
+type MockTxManager struct {
+	Calls       int
+	CommitCount int
+}
+
+func NewMockTxManager() *MockTxManager {
+	return &MockTxManager{}
+}
+
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	m.CommitCount++
+	return nil
+}
+
+// MockOrderNumberGenerator implements ports.OrderNumberGenerator for testing
+type MockOrderNumberGenerator struct {
+	Format        domain.OrderNumberFormat
+	Seq           int64
+	GenerateError error
+}
+
+func NewMockOrderNumberGenerator() *MockOrderNumberGenerator {
+	return &MockOrderNumberGenerator{
+		Format: domain.OrderNumberFormat{Prefix: "ORD", DateLayout: "20060102"},
+	}
+}
+
+func (m *MockOrderNumberGenerator) Next(ctx context.Context) (string, error) {
+	if m.GenerateError != nil {
+		return "", m.GenerateError
+	}
+	m.Seq++
+	return m.Format.Format(m.Seq, time.Now()), nil
+}
+
+func (m *MockOrderNumberGenerator) Validate(orderNumber string) bool {
+	return m.Format.Validate(orderNumber)
+}
+
+// MockShipmentRepository implements ports.ShipmentRepository for testing
+type MockShipmentRepository struct {
+	Shipments   map[uuid.UUID]*domain.Shipment
+	CreateError error
+	UpdateError error
+}
+
+func NewMockShipmentRepository() *MockShipmentRepository {
+	return &MockShipmentRepository{
+		Shipments: make(map[uuid.UUID]*domain.Shipment),
+	}
+}
+
+func (m *MockShipmentRepository) Create(ctx context.Context, shipment *domain.Shipment) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Shipments[shipment.ID] = shipment
+	return nil
+}
+
+func (m *MockShipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Shipment, error) {
+	if shipment, exists := m.Shipments[id]; exists {
+		return shipment, nil
+	}
+	return nil, ErrShipmentNotFound
+}
+
+func (m *MockShipmentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.Shipment, error) {
+	var shipments []*domain.Shipment
+	for _, shipment := range m.Shipments {
+		if shipment.OrderID == orderID {
+			shipments = append(shipments, shipment)
+		}
+	}
+	return shipments, nil
+}
+
+func (m *MockShipmentRepository) Update(ctx context.Context, shipment *domain.Shipment) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	m.Shipments[shipment.ID] = shipment
+	return nil
+}
+
+// MockReturnRepository implements ports.ReturnRepository for testing
+type MockReturnRepository struct {
+	Returns     map[uuid.UUID]*domain.ReturnRequest
+	CreateError error
+	UpdateError error
+}
+
+func NewMockReturnRepository() *MockReturnRepository {
+	return &MockReturnRepository{
+		Returns: make(map[uuid.UUID]*domain.ReturnRequest),
+	}
+}
+
+func (m *MockReturnRepository) Create(ctx context.Context, ret *domain.ReturnRequest) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Returns[ret.ID] = ret
+	return nil
+}
+
+func (m *MockReturnRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ReturnRequest, error) {
+	if ret, exists := m.Returns[id]; exists {
+		return ret, nil
+	}
+	return nil, ErrReturnNotFound
+}
+
+func (m *MockReturnRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.ReturnRequest, error) {
+	var returns []*domain.ReturnRequest
+	for _, ret := range m.Returns {
+		if ret.OrderID == orderID {
+			returns = append(returns, ret)
+		}
+	}
+	return returns, nil
+}
+
+func (m *MockReturnRepository) Update(ctx context.Context, ret *domain.ReturnRequest) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	m.Returns[ret.ID] = ret
+	return nil
+}
+
+// MockRefundRepository implements ports.RefundRepository for testing
+type MockRefundRepository struct {
+	Refunds     []*domain.Refund
+	CreateError error
+}
+
+func NewMockRefundRepository() *MockRefundRepository {
+	return &MockRefundRepository{
+		Refunds: make([]*domain.Refund, 0),
+	}
+}
+
+func (m *MockRefundRepository) Create(ctx context.Context, refund *domain.Refund) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Refunds = append(m.Refunds, refund)
+	return nil
+}
+
+func (m *MockRefundRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*domain.Refund, error) {
+	var refunds []*domain.Refund
+	for _, refund := range m.Refunds {
+		if refund.OrderID == orderID {
+			refunds = append(refunds, refund)
+		}
+	}
+	return refunds, nil
+}
+
+// MockStockMovementRepository implements ports.StockMovementRepository for testing
+type MockStockMovementRepository struct {
+	Movements     []*domain.StockMovement
+	Discrepancies []*domain.StockDiscrepancy
+	Synced        []uuid.UUID
+	GetError      error
+	SyncError     error
+}
+
+func NewMockStockMovementRepository() *MockStockMovementRepository {
+	return &MockStockMovementRepository{
+		Movements:     make([]*domain.StockMovement, 0),
+		Discrepancies: make([]*domain.StockDiscrepancy, 0),
+	}
+}
+
+func (m *MockStockMovementRepository) GetByProductID(ctx context.Context, productID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.StockMovement], error) {
+	if m.GetError != nil {
+		return nil, m.GetError
+	}
+	var movements []*domain.StockMovement
+	for _, movement := range m.Movements {
+		if movement.ProductID == productID {
+			movements = append(movements, movement)
+		}
+	}
+	return pageOf(movements, page, stockMovementIDOf), nil
+}
+
+func (m *MockStockMovementRepository) GetDiscrepancies(ctx context.Context) ([]*domain.StockDiscrepancy, error) {
+	if m.GetError != nil {
+		return nil, m.GetError
+	}
+	return m.Discrepancies, nil
+}
+
+func (m *MockStockMovementRepository) SyncStock(ctx context.Context, productID uuid.UUID) error {
+	if m.SyncError != nil {
+		return m.SyncError
+	}
+	m.Synced = append(m.Synced, productID)
+	return nil
+}
+
+// MockReservationRepository implements ports.ReservationRepository for testing
+type MockReservationRepository struct {
+	Reservations map[uuid.UUID]*domain.StockReservation
+	CreateError  error
+	UpdateError  error
+}
+
+func NewMockReservationRepository() *MockReservationRepository {
+	return &MockReservationRepository{
+		Reservations: make(map[uuid.UUID]*domain.StockReservation),
+	}
+}
+
+func (m *MockReservationRepository) Create(ctx context.Context, reservation *domain.StockReservation) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Reservations[reservation.ID] = reservation
+	return nil
+}
+
+func (m *MockReservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.StockReservation, error) {
+	if reservation, exists := m.Reservations[id]; exists {
+		return reservation, nil
+	}
+	return nil, ErrReservationNotFound
+}
+
+func (m *MockReservationRepository) Convert(ctx context.Context, id, orderID uuid.UUID) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	reservation, exists := m.Reservations[id]
+	if !exists || !reservation.IsHeld(time.Now()) {
+		return domain.ErrReservationUnavailable
+	}
+	reservation.Status = domain.ReservationStatusConverted
+	reservation.OrderID = &orderID
+	return nil
+}
+
+func (m *MockReservationRepository) Release(ctx context.Context, id uuid.UUID) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	if reservation, exists := m.Reservations[id]; exists && reservation.Status == domain.ReservationStatusActive {
+		reservation.Status = domain.ReservationStatusReleased
+	}
+	return nil
+}
+
+func (m *MockReservationRepository) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	if m.UpdateError != nil {
+		return 0, m.UpdateError
+	}
+	expired := 0
+	for _, reservation := range m.Reservations {
+		if reservation.Status == domain.ReservationStatusActive && !now.Before(reservation.ExpiresAt) {
+			reservation.Status = domain.ReservationStatusExpired
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// MockWarehouseRepository implements ports.WarehouseRepository for testing
+type MockWarehouseRepository struct {
+	Warehouses  map[uuid.UUID]*domain.Warehouse
+	CreateError error
+	UpdateError error
+}
+
+func NewMockWarehouseRepository() *MockWarehouseRepository {
+	return &MockWarehouseRepository{
+		Warehouses: make(map[uuid.UUID]*domain.Warehouse),
+	}
+}
+
+func (m *MockWarehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.clearDefault(warehouse)
+	m.Warehouses[warehouse.ID] = warehouse
+	return nil
+}
+
+func (m *MockWarehouseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error) {
+	if warehouse, exists := m.Warehouses[id]; exists {
+		return warehouse, nil
+	}
+	return nil, ErrWarehouseNotFound
+}
+
+func (m *MockWarehouseRepository) GetByCode(ctx context.Context, code string) (*domain.Warehouse, error) {
+	for _, warehouse := range m.Warehouses {
+		if warehouse.Code == code {
+			return warehouse, nil
+		}
+	}
+	return nil, ErrWarehouseNotFound
+}
+
+func (m *MockWarehouseRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Warehouse], error) {
+	warehouses := make([]*domain.Warehouse, 0, len(m.Warehouses))
+	for _, warehouse := range m.Warehouses {
+		warehouses = append(warehouses, warehouse)
+	}
+	return pageOf(warehouses, page, warehouseIDOf), nil
+}
+
+func (m *MockWarehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	m.clearDefault(warehouse)
+	m.Warehouses[warehouse.ID] = warehouse
+	return nil
+}
+
+func (m *MockWarehouseRepository) clearDefault(warehouse *domain.Warehouse) {
+	if !warehouse.IsDefault {
+		return
+	}
+	for id, other := range m.Warehouses {
+		if id != warehouse.ID {
+			other.IsDefault = false
+		}
+	}
+}
+
+// MockProductVariantRepository implements ports.ProductVariantRepository for testing.
+// Variants are kept on their product in Products, whose stock moves with theirs.
+type MockProductVariantRepository struct {
+	Products    *MockProductRepository
+	CreateError error
+	UpdateError error
+}
+
+func NewMockProductVariantRepository(products *MockProductRepository) *MockProductVariantRepository {
+	return &MockProductVariantRepository{
+		Products: products,
+	}
+}
+
+func (m *MockProductVariantRepository) Create(ctx context.Context, variant *domain.ProductVariant) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	product, exists := m.Products.Products[variant.ProductID]
+	if !exists {
+		return ErrProductNotFound
+	}
+	stock := variant.Stock
+	variant.Stock = 0
+	product.Variants = append(product.Variants, variant)
+	// The first variant takes over the stock the product held without variants
+	if held := product.Stock; len(product.Variants) == 1 && held > 0 {
+		if err := m.Products.AdjustStock(ctx, domain.NewStockMovement(variant.ProductID, held, domain.StockMovementReasonAdjustment, nil).ForVariant(&variant.ID)); err != nil {
+			return err
+		}
+		if err := m.Products.AdjustStock(ctx, domain.NewStockMovement(variant.ProductID, -held, domain.StockMovementReasonAdjustment, nil)); err != nil {
+			return err
+		}
+	}
+	if stock == 0 {
+		return nil
+	}
+	return m.Products.AdjustStock(ctx, domain.NewStockMovement(variant.ProductID, stock, domain.StockMovementReasonInitial, nil).ForVariant(&variant.ID))
+}
+
+func (m *MockProductVariantRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductVariant, error) {
+	for _, product := range m.Products.Products {
+		for _, variant := range product.Variants {
+			if variant.ID == id {
+				return variant, nil
+			}
+		}
+	}
+	return nil, ErrVariantNotFound
+}
+
+func (m *MockProductVariantRepository) GetBySKU(ctx context.Context, sku string) (*domain.ProductVariant, error) {
+	for _, product := range m.Products.Products {
+		for _, variant := range product.Variants {
+			if variant.SKU == sku {
+				return variant, nil
+			}
+		}
+	}
+	return nil, ErrVariantNotFound
+}
+
+func (m *MockProductVariantRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*domain.ProductVariant, error) {
+	if product, exists := m.Products.Products[productID]; exists {
+		return product.Variants, nil
+	}
+	return nil, nil
+}
+
+func (m *MockProductVariantRepository) Update(ctx context.Context, variant *domain.ProductVariant) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	if _, err := m.GetByID(ctx, variant.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *MockProductVariantRepository) UpdateStock(ctx context.Context, id uuid.UUID, stock int) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	variant, err := m.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if stock == variant.Stock {
+		return nil
+	}
+	return m.Products.AdjustStock(ctx, domain.NewStockMovement(variant.ProductID, stock-variant.Stock, domain.StockMovementReasonAdjustment, nil).ForVariant(&id))
+}
+
+func (m *MockProductVariantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for _, product := range m.Products.Products {
+		for i, variant := range product.Variants {
+			if variant.ID == id {
+				product.Variants = append(product.Variants[:i], product.Variants[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return ErrVariantNotFound
+}
+
+// MockStockLevelRepository implements ports.StockLevelRepository for testing.
+// Products without explicit Levels hold all their stock at DefaultWarehouse, and
+// order allocations are derived from the movements recorded by Products.
+type MockStockLevelRepository struct {
+	Levels           map[uuid.UUID][]*domain.StockLevel
+	Products         *MockProductRepository
+	DefaultWarehouse *domain.Warehouse
+	GetError         error
+	SetError         error
+}
+
+func NewMockStockLevelRepository(products *MockProductRepository) *MockStockLevelRepository {
+	return &MockStockLevelRepository{
+		Levels:   make(map[uuid.UUID][]*domain.StockLevel),
+		Products: products,
+		DefaultWarehouse: &domain.Warehouse{
+			ID:        uuid.New(),
+			Code:      "MAIN",
+			Name:      "Main Warehouse",
+			IsDefault: true,
+			IsActive:  true,
+		},
+	}
+}
+
+func (m *MockStockLevelRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*domain.StockLevel, error) {
+	if m.GetError != nil {
+		return nil, m.GetError
+	}
+	if levels, exists := m.Levels[productID]; exists {
+		return levels, nil
+	}
+	if m.Products == nil {
+		return nil, nil
+	}
+	product, exists := m.Products.Products[productID]
+	if !exists || product.Stock <= 0 {
+		return nil, nil
+	}
+	return []*domain.StockLevel{{
+		ProductID:   productID,
+		WarehouseID: m.DefaultWarehouse.ID,
+		Quantity:    product.Stock,
+		Warehouse:   m.DefaultWarehouse,
+	}}, nil
+}
+
+func (m *MockStockLevelRepository) GetByWarehouseID(ctx context.Context, warehouseID uuid.UUID, limit, offset int) ([]*domain.StockLevel, error) {
+	if m.GetError != nil {
+		return nil, m.GetError
+	}
+	var levels []*domain.StockLevel
+	for _, productLevels := range m.Levels {
+		for _, level := range productLevels {
+			if level.WarehouseID == warehouseID {
+				levels = append(levels, level)
+			}
+		}
+	}
+	return levels, nil
+}
+
+func (m *MockStockLevelRepository) Set(ctx context.Context, productID, warehouseID uuid.UUID, quantity int) error {
+	if m.SetError != nil {
+		return m.SetError
+	}
+	var level *domain.StockLevel
+	for _, existing := range m.Levels[productID] {
+		if existing.WarehouseID == warehouseID {
+			level = existing
+		}
+	}
+	if level == nil {
+		level = &domain.StockLevel{ID: uuid.New(), ProductID: productID, WarehouseID: warehouseID}
+		m.Levels[productID] = append(m.Levels[productID], level)
+	}
+	if delta := quantity - level.Quantity; delta != 0 && m.Products != nil {
+		movement := domain.NewStockMovement(productID, delta, domain.StockMovementReasonAdjustment, nil)
+		if err := m.Products.AdjustStock(ctx, movement.InWarehouse(warehouseID)); err != nil {
+			return err
+		}
+	}
+	level.Quantity = quantity
+	return nil
+}
+
+func (m *MockStockLevelRepository) GetOrderAllocations(ctx context.Context, orderID uuid.UUID) ([]*domain.StockAllocation, error) {
+	if m.GetError != nil {
+		return nil, m.GetError
+	}
+	if m.Products == nil {
+		return nil, nil
+	}
+	type key struct{ productID, warehouseID uuid.UUID }
+	held := make(map[key]int)
+	var order []key
+	for _, movement := range m.Products.Movements {
+		if movement.OrderID == nil || *movement.OrderID != orderID || movement.WarehouseID == nil {
+			continue
+		}
+		switch movement.Reason {
+		case domain.StockMovementReasonSale, domain.StockMovementReasonOrderEdit, domain.StockMovementReasonCancel:
+			k := key{movement.ProductID, *movement.WarehouseID}
+			if _, seen := held[k]; !seen {
+				order = append(order, k)
+			}
+			held[k] -= movement.Delta
+		}
+	}
+
+	var allocations []*domain.StockAllocation
+	for _, k := range order {
+		if held[k] > 0 {
+			allocations = append(allocations, &domain.StockAllocation{ProductID: k.productID, WarehouseID: k.warehouseID, Quantity: held[k]})
+		}
+	}
+	return allocations, nil
+}
+
+// MockSupplierRepository implements ports.SupplierRepository for testing
+type MockSupplierRepository struct {
+	Suppliers   map[uuid.UUID]*domain.Supplier
+	CreateError error
+	UpdateError error
+}
+
+func NewMockSupplierRepository() *MockSupplierRepository {
+	return &MockSupplierRepository{
+		Suppliers: make(map[uuid.UUID]*domain.Supplier),
+	}
+}
+
+func (m *MockSupplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Suppliers[supplier.ID] = supplier
+	return nil
+}
+
+func (m *MockSupplierRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error) {
+	if supplier, exists := m.Suppliers[id]; exists {
+		return supplier, nil
+	}
+	return nil, ErrSupplierNotFound
+}
+
+func (m *MockSupplierRepository) GetByName(ctx context.Context, name string) (*domain.Supplier, error) {
+	for _, supplier := range m.Suppliers {
+		if supplier.Name == name {
+			return supplier, nil
+		}
+	}
+	return nil, ErrSupplierNotFound
+}
+
+func (m *MockSupplierRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.Supplier], error) {
+	suppliers := make([]*domain.Supplier, 0, len(m.Suppliers))
+	for _, supplier := range m.Suppliers {
+		suppliers = append(suppliers, supplier)
+	}
+	return pageOf(suppliers, page, supplierIDOf), nil
+}
+
+func (m *MockSupplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	m.Suppliers[supplier.ID] = supplier
+	return nil
+}
+
+// MockPurchaseOrderRepository implements ports.PurchaseOrderRepository for testing
+type MockPurchaseOrderRepository struct {
+	PurchaseOrders map[uuid.UUID]*domain.PurchaseOrder
+	CreateError    error
+	UpdateError    error
+}
+
+func NewMockPurchaseOrderRepository() *MockPurchaseOrderRepository {
+	return &MockPurchaseOrderRepository{
+		PurchaseOrders: make(map[uuid.UUID]*domain.PurchaseOrder),
+	}
+}
+
+func (m *MockPurchaseOrderRepository) Create(ctx context.Context, po *domain.PurchaseOrder) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.PurchaseOrders[po.ID] = po
+	return nil
+}
+
+func (m *MockPurchaseOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	if po, exists := m.PurchaseOrders[id]; exists {
+		return po, nil
+	}
+	return nil, ErrPurchaseOrderNotFound
+}
+
+func (m *MockPurchaseOrderRepository) GetAll(ctx context.Context, page ports.PageRequest) (*ports.Page[*domain.PurchaseOrder], error) {
+	pos := make([]*domain.PurchaseOrder, 0, len(m.PurchaseOrders))
+	for _, po := range m.PurchaseOrders {
+		pos = append(pos, po)
+	}
+	return pageOf(pos, page, purchaseOrderIDOf), nil
+}
+
+func (m *MockPurchaseOrderRepository) GetBySupplierID(ctx context.Context, supplierID uuid.UUID, page ports.PageRequest) (*ports.Page[*domain.PurchaseOrder], error) {
+	var pos []*domain.PurchaseOrder
+	for _, po := range m.PurchaseOrders {
+		if po.SupplierID == supplierID {
+			pos = append(pos, po)
+		}
+	}
+	return pageOf(pos, page, purchaseOrderIDOf), nil
+}
+
+func (m *MockPurchaseOrderRepository) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	m.PurchaseOrders[po.ID] = po
+	return nil
+}
+
+func (m *MockPurchaseOrderRepository) ReplaceLines(ctx context.Context, po *domain.PurchaseOrder) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	m.PurchaseOrders[po.ID] = po
+	return nil
+}
+
+func (m *MockPurchaseOrderRepository) ReceiveLine(ctx context.Context, lineID uuid.UUID, quantity int) error {
+	for _, po := range m.PurchaseOrders {
+		for i := range po.Lines {
+			if po.Lines[i].ID != lineID {
+				continue
+			}
+			if po.Lines[i].QuantityReceived+quantity > po.Lines[i].QuantityOrdered {
+				return domain.ErrInvalidPurchaseOrder
+			}
+			po.Lines[i].QuantityReceived += quantity
+			return nil
+		}
+	}
+	return domain.ErrInvalidPurchaseOrder
+}
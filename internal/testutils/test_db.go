@@ -51,9 +51,29 @@ func (tdb *TestDB) Close() error {
 // Cleanup truncates all tables to ensure clean state between tests
 func (tdb *TestDB) Cleanup() error {
 	tables := []string{
+		"purchase_order_lines",
+		"purchase_orders",
+		"suppliers",
+		"stock_reservations",
+		"stock_movements",
+		"stock_levels",
+		"warehouses",
+		"refunds",
+		"return_items",
+		"return_requests",
+		"shipment_items",
+		"shipments",
+		"order_status_history",
+		"order_discounts",
+		"promotions",
+		"tax_rates",
 		"order_items",
 		"orders",
+		"shipping_methods",
+		"product_prices",
+		"product_variants",
 		"products",
+		"category_slug_redirects",
 		"categories",
 		"customers",
 		"users",
@@ -73,12 +93,20 @@ func (tdb *TestDB) Cleanup() error {
 func (tdb *TestDB) SetupTestSchema() error {
 	// Create tables in dependency order
 	schema := []string{
+		`DO $$ BEGIN
+			CREATE TYPE money_amount AS (amount BIGINT, currency CHAR(3));
+		EXCEPTION WHEN duplicate_object THEN NULL;
+		END $$`,
 		`CREATE TABLE IF NOT EXISTS users (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			name VARCHAR(255) NOT NULL,
 			email VARCHAR(255) UNIQUE NOT NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(email, '')), 'B')
+			) STORED
 		)`,
 		`CREATE TABLE IF NOT EXISTS customers (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -92,25 +120,89 @@ func (tdb *TestDB) SetupTestSchema() error {
 			zip_code VARCHAR(20),
 			country VARCHAR(100),
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(email, '')), 'B') ||
+				setweight(to_tsvector('simple', coalesce(phone, '') || ' ' || regexp_replace(coalesce(phone, ''), '\D', '', 'g')), 'B')
+			) STORED
 		)`,
 		`CREATE TABLE IF NOT EXISTS categories (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			name VARCHAR(255) NOT NULL,
 			description TEXT,
 			parent_id UUID REFERENCES categories(id),
+			path TEXT NOT NULL DEFAULT '',
+			slug VARCHAR(100) NOT NULL DEFAULT '',
+			position INTEGER NOT NULL DEFAULT 0,
+			image_url TEXT NOT NULL DEFAULT '',
+			meta_title VARCHAR(70) NOT NULL DEFAULT '',
+			meta_description VARCHAR(320) NOT NULL DEFAULT '',
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
+		`CREATE TABLE IF NOT EXISTS category_slug_redirects (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+			parent_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+			slug VARCHAR(100) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
 		`CREATE TABLE IF NOT EXISTS products (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			name VARCHAR(255) NOT NULL,
 			description TEXT,
 			sku VARCHAR(100) UNIQUE NOT NULL,
-			price DECIMAL(10,2) NOT NULL,
+			price money_amount NOT NULL,
 			stock INTEGER NOT NULL DEFAULT 0,
+			reorder_point INTEGER NOT NULL DEFAULT 10,
+			reorder_quantity INTEGER NOT NULL DEFAULT 0,
 			category_id UUID REFERENCES categories(id),
 			is_active BOOLEAN DEFAULT true,
+			attributes JSONB NOT NULL DEFAULT '{}',
+			weight_grams INTEGER NOT NULL DEFAULT 0,
+			length_mm INTEGER NOT NULL DEFAULT 0,
+			width_mm INTEGER NOT NULL DEFAULT 0,
+			height_mm INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(sku, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+			) STORED
+		)`,
+		`CREATE TABLE IF NOT EXISTS product_variants (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+			sku VARCHAR(100) UNIQUE NOT NULL,
+			options JSONB NOT NULL DEFAULT '{}',
+			price money_amount,
+			stock INTEGER NOT NULL DEFAULT 0,
+			is_active BOOLEAN DEFAULT true,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS product_prices (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+			price money_amount NOT NULL,
+			compare_at_price money_amount,
+			label VARCHAR(100) NOT NULL DEFAULT '',
+			effective_from TIMESTAMP NOT NULL,
+			effective_to TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS shipping_methods (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			name VARCHAR(255) NOT NULL,
+			description TEXT,
+			type VARCHAR(30) NOT NULL,
+			amount money_amount NOT NULL,
+			per_kg_amount money_amount,
+			free_over money_amount,
+			zones JSONB NOT NULL DEFAULT '[]',
+			is_active BOOLEAN NOT NULL DEFAULT true,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -119,8 +211,17 @@ func (tdb *TestDB) SetupTestSchema() error {
 			customer_id UUID REFERENCES customers(id),
 			order_number VARCHAR(50) UNIQUE NOT NULL,
 			status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
-			total_amount DECIMAL(10,2) NOT NULL,
+			total_amount money_amount NOT NULL,
+			discount_total money_amount NOT NULL,
+			subtotal money_amount NOT NULL,
+			tax_total money_amount NOT NULL,
+			shipping_total money_amount NOT NULL,
+			grand_total money_amount NOT NULL,
 			shipping_address TEXT NOT NULL,
+			shipping_country VARCHAR(100) NOT NULL DEFAULT '',
+			shipping_city VARCHAR(100) NOT NULL DEFAULT '',
+			shipping_method_id UUID REFERENCES shipping_methods(id) ON DELETE SET NULL,
+			shipping_method_name VARCHAR(255) NOT NULL DEFAULT '',
 			billing_address TEXT NOT NULL,
 			notes TEXT,
 			order_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -133,9 +234,188 @@ func (tdb *TestDB) SetupTestSchema() error {
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			order_id UUID REFERENCES orders(id),
 			product_id UUID REFERENCES products(id),
+			variant_id UUID REFERENCES product_variants(id),
+			quantity INTEGER NOT NULL,
+			unit_price money_amount NOT NULL,
+			discount_amount money_amount NOT NULL,
+			total_price money_amount NOT NULL,
+			tax_rate INTEGER NOT NULL DEFAULT 0,
+			tax_inclusive BOOLEAN NOT NULL DEFAULT false,
+			tax_amount money_amount NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS tax_rates (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			country VARCHAR(100) NOT NULL,
+			category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			rate INTEGER NOT NULL,
+			prices_include_tax BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS promotions (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			name VARCHAR(255) NOT NULL,
+			description TEXT,
+			code VARCHAR(32) UNIQUE,
+			type VARCHAR(20) NOT NULL,
+			percent_off INTEGER NOT NULL DEFAULT 0,
+			amount_off money_amount,
+			buy_quantity INTEGER NOT NULL DEFAULT 0,
+			get_quantity INTEGER NOT NULL DEFAULT 0,
+			min_subtotal money_amount,
+			product_ids UUID[] NOT NULL DEFAULT '{}',
+			category_ids UUID[] NOT NULL DEFAULT '{}',
+			customer_ids UUID[] NOT NULL DEFAULT '{}',
+			stackable BOOLEAN NOT NULL DEFAULT false,
+			priority INTEGER NOT NULL DEFAULT 0,
+			usage_limit INTEGER,
+			per_customer_limit INTEGER,
+			times_used INTEGER NOT NULL DEFAULT 0,
+			starts_at TIMESTAMP,
+			expires_at TIMESTAMP,
+			is_active BOOLEAN NOT NULL DEFAULT true,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS order_discounts (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+			promotion_id UUID REFERENCES promotions(id),
+			code VARCHAR(32) NOT NULL DEFAULT '',
+			name VARCHAR(255) NOT NULL,
+			amount money_amount NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS order_status_history (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+			from_status VARCHAR(50),
+			to_status VARCHAR(50) NOT NULL,
+			actor VARCHAR(255) NOT NULL,
+			reason TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS shipments (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+			carrier VARCHAR(100) NOT NULL,
+			tracking_number VARCHAR(255),
+			status VARCHAR(50) NOT NULL DEFAULT 'pending',
+			shipped_at TIMESTAMP,
+			delivered_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS shipment_items (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			shipment_id UUID REFERENCES shipments(id) ON DELETE CASCADE,
+			order_item_id UUID REFERENCES order_items(id) ON DELETE CASCADE,
+			quantity INTEGER NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS return_requests (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+			customer_id UUID REFERENCES customers(id),
+			status VARCHAR(50) NOT NULL DEFAULT 'requested',
+			reason TEXT,
+			review_note TEXT,
+			reviewed_by VARCHAR(255),
+			reviewed_at TIMESTAMP,
+			received_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS return_items (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			return_request_id UUID REFERENCES return_requests(id) ON DELETE CASCADE,
+			order_item_id UUID REFERENCES order_items(id) ON DELETE CASCADE,
+			quantity INTEGER NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS refunds (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			return_request_id UUID UNIQUE REFERENCES return_requests(id) ON DELETE CASCADE,
+			order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+			amount money_amount NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS warehouses (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			code VARCHAR(50) UNIQUE NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			city VARCHAR(255) NOT NULL,
+			country VARCHAR(255) NOT NULL,
+			is_default BOOLEAN DEFAULT false,
+			is_active BOOLEAN DEFAULT true,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS stock_levels (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+			warehouse_id UUID REFERENCES warehouses(id),
+			quantity INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (product_id, warehouse_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS stock_movements (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+			delta INTEGER NOT NULL,
+			reason VARCHAR(50) NOT NULL,
+			order_id UUID,
+			warehouse_id UUID REFERENCES warehouses(id),
+			variant_id UUID REFERENCES product_variants(id) ON DELETE SET NULL,
+			actor VARCHAR(255) NOT NULL,
+			note TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS stock_reservations (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+			customer_id UUID REFERENCES customers(id) ON DELETE CASCADE,
 			quantity INTEGER NOT NULL,
-			unit_price DECIMAL(10,2) NOT NULL,
-			total_price DECIMAL(10,2) NOT NULL,
+			status VARCHAR(50) NOT NULL DEFAULT 'active',
+			expires_at TIMESTAMP NOT NULL,
+			order_id UUID REFERENCES orders(id) ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS suppliers (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			name VARCHAR(255) UNIQUE NOT NULL,
+			email VARCHAR(255),
+			phone VARCHAR(50),
+			address TEXT,
+			is_active BOOLEAN DEFAULT true,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS purchase_orders (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			supplier_id UUID REFERENCES suppliers(id),
+			warehouse_id UUID REFERENCES warehouses(id),
+			status VARCHAR(50) NOT NULL DEFAULT 'draft',
+			expected_date TIMESTAMP,
+			notes TEXT,
+			sent_at TIMESTAMP,
+			received_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS purchase_order_lines (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			purchase_order_id UUID REFERENCES purchase_orders(id) ON DELETE CASCADE,
+			product_id UUID REFERENCES products(id),
+			variant_id UUID REFERENCES product_variants(id),
+			quantity_ordered INTEGER NOT NULL,
+			quantity_received INTEGER NOT NULL DEFAULT 0,
+			unit_cost money_amount NOT NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,